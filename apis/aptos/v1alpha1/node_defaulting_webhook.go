@@ -52,6 +52,10 @@ func (r *Node) Default() {
 		r.Spec.MetricsPort = DefaultMetricsPort
 	}
 
+	if r.Spec.Metrics.Port == 0 {
+		r.Spec.Metrics.Port = r.Spec.MetricsPort
+	}
+
 	if r.Spec.APIPort == 0 {
 		r.Spec.APIPort = DefaultAPIPort
 	}
@@ -64,4 +68,6 @@ func (r *Node) Default() {
 		}
 	}
 
+	r.Spec.Disruption.Default()
+
 }
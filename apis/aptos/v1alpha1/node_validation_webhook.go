@@ -1,6 +1,7 @@
 package v1alpha1
 
 import (
+	"github.com/kotalco/kotal/apis/shared"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -9,7 +10,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
-// +kubebuilder:webhook:verbs=create;update,path=/validate-aptos-kotal-io-v1alpha1-node,mutating=false,failurePolicy=fail,groups=aptos.kotal.io,resources=nodes,versions=v1alpha1,name=validate-aptos-v1alpha1-node.kb.io,sideEffects=None,admissionReviewVersions=v1
+// +kubebuilder:webhook:verbs=create;update;delete,path=/validate-aptos-kotal-io-v1alpha1-node,mutating=false,failurePolicy=fail,groups=aptos.kotal.io,resources=nodes,versions=v1alpha1,name=validate-aptos-v1alpha1-node.kb.io,sideEffects=None,admissionReviewVersions=v1
 
 var _ webhook.Validator = &Node{}
 
@@ -17,13 +18,13 @@ var _ webhook.Validator = &Node{}
 func (r *Node) validate() field.ErrorList {
 	var nodeErrors field.ErrorList
 
-	if r.Spec.NodePrivateKeySecretName != "" && r.Spec.PeerId == "" {
+	if r.Spec.NodePrivateKeySecretName.Name != "" && r.Spec.PeerId == "" {
 		err := field.Invalid(field.NewPath("spec").Child("peerId"), r.Spec.PeerId, "must provide peerId if nodePrivateKeySecretName is provided")
 		nodeErrors = append(nodeErrors, err)
 	}
 
-	if r.Spec.PeerId != "" && r.Spec.NodePrivateKeySecretName == "" {
-		err := field.Invalid(field.NewPath("spec").Child("nodePrivateKeySecretName"), r.Spec.NodePrivateKeySecretName, "must provide nodePrivateKeySecretName if peerId is provided")
+	if r.Spec.PeerId != "" && r.Spec.NodePrivateKeySecretName.Name == "" {
+		err := field.Invalid(field.NewPath("spec").Child("nodePrivateKeySecretName"), r.Spec.NodePrivateKeySecretName.Name, "must provide nodePrivateKeySecretName if peerId is provided")
 		nodeErrors = append(nodeErrors, err)
 	}
 
@@ -38,12 +39,20 @@ func (r *Node) ValidateCreate() (admission.Warnings, error) {
 
 	allErrors = append(allErrors, r.validate()...)
 	allErrors = append(allErrors, r.Spec.Resources.ValidateCreate()...)
+	allErrors = append(allErrors, r.Spec.Access.Validate()...)
+	allErrors = append(allErrors, r.Spec.PodExtras.Validate()...)
+	allErrors = append(allErrors, r.Spec.Deletion.Validate()...)
+	allErrors = append(allErrors, shared.ValidateReplicas(r.Spec.Replicas, r.Spec.Validator)...)
+	allErrors = append(allErrors, r.Spec.SecretStore.Validate()...)
+	allErrors = append(allErrors, r.Spec.SecretStore.ValidateNodeKey(r.Spec.NodePrivateKeySecretName, r.Spec.Replicas)...)
+
+	warnings := r.Spec.ExtraArgs.Warnings(r, nodeArgs)
 
 	if len(allErrors) == 0 {
-		return nil, nil
+		return warnings, nil
 	}
 
-	return nil, apierrors.NewInvalid(schema.GroupKind{}, r.Name, allErrors)
+	return warnings, apierrors.NewInvalid(schema.GroupKind{}, r.Name, allErrors)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
@@ -55,22 +64,31 @@ func (r *Node) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
 
 	allErrors = append(allErrors, r.validate()...)
 	allErrors = append(allErrors, r.Spec.Resources.ValidateUpdate(&oldNode.Spec.Resources)...)
+	allErrors = append(allErrors, r.Spec.Access.Validate()...)
+	allErrors = append(allErrors, r.Spec.PodExtras.Validate()...)
+	allErrors = append(allErrors, r.Spec.Deletion.Validate()...)
+	allErrors = append(allErrors, shared.ValidateReplicas(r.Spec.Replicas, r.Spec.Validator)...)
+	allErrors = append(allErrors, r.Spec.DataResync.ValidateUpdate(&oldNode.Spec.DataResync, r.Spec.Validator)...)
+	allErrors = append(allErrors, r.Spec.SecretStore.Validate()...)
+	allErrors = append(allErrors, r.Spec.SecretStore.ValidateNodeKey(r.Spec.NodePrivateKeySecretName, r.Spec.Replicas)...)
 
 	if r.Spec.Network != oldNode.Spec.Network {
 		err := field.Invalid(field.NewPath("spec").Child("network"), r.Spec.Network, "field is immutable")
 		allErrors = append(allErrors, err)
 	}
 
+	warnings := r.Spec.ExtraArgs.Warnings(r, nodeArgs)
+
 	if len(allErrors) == 0 {
-		return nil, nil
+		return warnings, nil
 	}
 
-	return nil, apierrors.NewInvalid(schema.GroupKind{}, r.Name, allErrors)
+	return warnings, apierrors.NewInvalid(schema.GroupKind{}, r.Name, allErrors)
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
 func (r *Node) ValidateDelete() (admission.Warnings, error) {
 	nodelog.Info("validate delete", "name", r.Name)
 
-	return nil, nil
+	return nil, shared.ValidateDeletionProtection(r)
 }
@@ -25,7 +25,7 @@ var _ = Describe("Aptos node validation", func() {
 				},
 				Spec: NodeSpec{
 					Network:                  Devnet,
-					NodePrivateKeySecretName: "my-private-key",
+					NodePrivateKeySecretName: shared.SecretKeySelector{Name: "my-private-key"},
 				},
 			},
 			Errors: field.ErrorList{
@@ -100,7 +100,7 @@ var _ = Describe("Aptos node validation", func() {
 				},
 				Spec: NodeSpec{
 					Network:                  Devnet,
-					NodePrivateKeySecretName: "my-private-key",
+					NodePrivateKeySecretName: shared.SecretKeySelector{Name: "my-private-key"},
 					PeerId:                   "76c8ca8bb75d1abd853fc17b70cc72cb78a63425fa85be96743825d93cc57d6f",
 				},
 			},
@@ -110,7 +110,7 @@ var _ = Describe("Aptos node validation", func() {
 				},
 				Spec: NodeSpec{
 					Network:                  Devnet,
-					NodePrivateKeySecretName: "my-private-key",
+					NodePrivateKeySecretName: shared.SecretKeySelector{Name: "my-private-key"},
 				},
 			},
 			Errors: field.ErrorList{
@@ -130,7 +130,7 @@ var _ = Describe("Aptos node validation", func() {
 				},
 				Spec: NodeSpec{
 					Network:                  Devnet,
-					NodePrivateKeySecretName: "my-private-key",
+					NodePrivateKeySecretName: shared.SecretKeySelector{Name: "my-private-key"},
 					PeerId:                   "76c8ca8bb75d1abd853fc17b70cc72cb78a63425fa85be96743825d93cc57d6f",
 				},
 			},
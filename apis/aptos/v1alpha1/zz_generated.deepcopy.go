@@ -16,7 +16,7 @@ func (in *Node) DeepCopyInto(out *Node) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Node.
@@ -79,11 +79,17 @@ func (in *NodeSpec) DeepCopyInto(out *NodeSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.RemoveArgs != nil {
+		in, out := &in.RemoveArgs, &out.RemoveArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Replicas != nil {
 		in, out := &in.Replicas, &out.Replicas
 		*out = new(uint)
 		**out = **in
 	}
+	out.NodePrivateKeySecretName = in.NodePrivateKeySecretName
 	if in.SeedPeers != nil {
 		in, out := &in.SeedPeers, &out.SeedPeers
 		*out = make([]Peer, len(*in))
@@ -91,6 +97,18 @@ func (in *NodeSpec) DeepCopyInto(out *NodeSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	in.Access.DeepCopyInto(&out.Access)
+	in.Disruption.DeepCopyInto(&out.Disruption)
+	out.Metrics = in.Metrics
+	if in.SecretStore != nil {
+		in, out := &in.SecretStore, &out.SecretStore
+		*out = new(shared.SecretStore)
+		**out = **in
+	}
+	in.Shutdown.DeepCopyInto(&out.Shutdown)
+	out.DataResync = in.DataResync
+	out.Deletion = in.Deletion
+	in.PodExtras.DeepCopyInto(&out.PodExtras)
 	in.Resources.DeepCopyInto(&out.Resources)
 }
 
@@ -107,6 +125,12 @@ func (in *NodeSpec) DeepCopy() *NodeSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeStatus) DeepCopyInto(out *NodeStatus) {
 	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = make([]shared.ReplicaStatus, len(*in))
+		copy(*out, *in)
+	}
+	in.ResyncStatus.DeepCopyInto(&out.ResyncStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeStatus.
@@ -5,10 +5,20 @@ const (
 	DefaultMainnetRPCPort uint = 8332
 	// DefaultTestnetRPCPort is the default JSON-RPC port for testnet
 	DefaultTestnetRPCPort uint = 18332
+	// DefaultRegtestRPCPort is the default JSON-RPC port for regtest
+	DefaultRegtestRPCPort uint = 18443
+	// DefaultSignetRPCPort is the default JSON-RPC port for signet
+	DefaultSignetRPCPort uint = 38332
 	// DefaultMainnetP2PPort is the default p2p port for mainnet
 	DefaultMainnetP2PPort uint = 8333
 	// DefaultTestnetP2PPort is the default p2p port for testnet
 	DefaultTestnetP2PPort uint = 18333
+	// DefaultRegtestP2PPort is the default p2p port for regtest
+	DefaultRegtestP2PPort uint = 18444
+	// DefaultSignetP2PPort is the default p2p port for signet
+	DefaultSignetP2PPort uint = 38333
+	// DefaultBlockGenerationInterval is the default seconds between generated regtest blocks
+	DefaultBlockGenerationInterval uint = 60
 	// DefaltReplicas is the default replicas
 	DefaltReplicas uint = 1
 	// DefaultListen is the default connection to outside strategy
@@ -17,11 +27,15 @@ const (
 	DefaultDBCacheSize uint = 450
 	// DefaultMaxConnections is the default maximum connections to peers
 	DefaultMaxConnections uint = 125
+	// DefaultMetricsPort is the default prometheus exporter port
+	DefaultMetricsPort uint = 9332
 )
 
 const (
 	// DefaultBitcoinCoreImage is the default Bitcoin core client image
 	DefaultBitcoinCoreImage = "lncm/bitcoind:v26.0"
+	// DefaultBitcoinExporterImage is the default Bitcoin prometheus exporter image
+	DefaultBitcoinExporterImage = "jvstein/bitcoin-prometheus-exporter:v0.7.0"
 )
 
 // Resources
@@ -39,3 +53,57 @@ const (
 	// DefaultNodeStorageRequest is the Storage requested by Bitcoin node
 	DefaultNodeStorageRequest = "100Gi"
 )
+
+// Lightning Network node
+const (
+	// DefaultLightningClient is the default Lightning Network node client
+	DefaultLightningClient = LNDClient
+	// DefaultLNDImage is the default lnd client image
+	DefaultLNDImage = "lightninglabs/lnd:v0.17.4-beta"
+	// DefaultLightningP2PPort is the default Lightning Network p2p port
+	DefaultLightningP2PPort uint = 9735
+	// DefaultLightningGRPCPort is the default Lightning Network node gRPC port
+	DefaultLightningGRPCPort uint = 10009
+	// DefaultLightningRESTPort is the default Lightning Network node REST port
+	DefaultLightningRESTPort uint = 8080
+
+	// DefaultLightningNodeCPURequest is the cpu requested by Lightning Network node
+	DefaultLightningNodeCPURequest = "1"
+	// DefaultLightningNodeCPULimit is the cpu limit for Lightning Network node
+	DefaultLightningNodeCPULimit = "2"
+
+	// DefaultLightningNodeMemoryRequest is the memory requested by Lightning Network node
+	DefaultLightningNodeMemoryRequest = "1Gi"
+	// DefaultLightningNodeMemoryLimit is the memory limit for Lightning Network node
+	DefaultLightningNodeMemoryLimit = "2Gi"
+
+	// DefaultLightningNodeStorageRequest is the Storage requested by Lightning Network node
+	DefaultLightningNodeStorageRequest = "10Gi"
+)
+
+// Electrum server
+const (
+	// DefaultElectrumClient is the default Electrum server client
+	DefaultElectrumClient = ElectrsClient
+	// DefaultElectrsImage is the default electrs client image
+	DefaultElectrsImage = "getumbrel/electrs:v0.10.2"
+	// DefaultFulcrumImage is the default Fulcrum client image
+	DefaultFulcrumImage = "cculianu/fulcrum:v1.9.8"
+	// DefaultElectrumPort is the default Electrum protocol TCP port
+	DefaultElectrumPort uint = 50001
+	// DefaultElectrumTLSPort is the default Electrum protocol SSL port
+	DefaultElectrumTLSPort uint = 50002
+
+	// DefaultElectrumServerCPURequest is the cpu requested by Electrum server
+	DefaultElectrumServerCPURequest = "2"
+	// DefaultElectrumServerCPULimit is the cpu limit for Electrum server
+	DefaultElectrumServerCPULimit = "4"
+
+	// DefaultElectrumServerMemoryRequest is the memory requested by Electrum server
+	DefaultElectrumServerMemoryRequest = "4Gi"
+	// DefaultElectrumServerMemoryLimit is the memory limit for Electrum server
+	DefaultElectrumServerMemoryLimit = "8Gi"
+
+	// DefaultElectrumServerStorageRequest is the Storage requested by Electrum server
+	DefaultElectrumServerStorageRequest = "200Gi"
+)
@@ -0,0 +1,102 @@
+package v1alpha1
+
+import (
+	"github.com/kotalco/kotal/apis/shared"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ElectrumClient is Electrum protocol server client
+type ElectrumClient string
+
+// Electrum protocol server clients
+const (
+	// ElectrsClient is Rust Electrum server
+	ElectrsClient ElectrumClient = "electrs"
+	// FulcrumClient is Fulcrum Electrum server
+	FulcrumClient ElectrumClient = "fulcrum"
+)
+
+// ElectrumServerSpec defines the desired state of ElectrumServer
+type ElectrumServerSpec struct {
+	// Image is Electrum server client image
+	Image string `json:"image,omitempty"`
+	// ExtraArgs is extra arguments to pass down to the cli
+	ExtraArgs shared.ExtraArgs `json:"extraArgs,omitempty"`
+	// RemoveArgs is names of generated cli arguments to remove
+	// +listType=set
+	RemoveArgs []string `json:"removeArgs,omitempty"`
+	// Replicas is number of replicas
+	// +kubebuilder:validation:Minimum=0
+	Replicas *uint `json:"replicas,omitempty"`
+	// Client is Electrum server client
+	// +kubebuilder:validation:Enum=electrs;fulcrum
+	Client ElectrumClient `json:"client,omitempty"`
+	// BitcoinNode is Bitcoin node name in the same namespace
+	// its network, p2p port and the first rpc user credentials are used
+	BitcoinNode string `json:"bitcoinNode"`
+	// Port is Electrum protocol TCP server port
+	Port uint `json:"port,omitempty"`
+	// TLS enables Electrum protocol SSL server
+	TLS bool `json:"tls,omitempty"`
+	// TLSPort is Electrum protocol SSL server port
+	TLSPort uint `json:"tlsPort,omitempty"`
+	// CertSecretName is k8s secret name that holds tls.key and tls.crt
+	// self signed certificate is generated if it's not provided
+	CertSecretName string `json:"certSecretName,omitempty"`
+	// Access is network access settings of server endpoints
+	Access shared.Access `json:"access,omitempty"`
+	// Disruption is pod disruption budget settings
+	Disruption shared.Disruption `json:"disruption,omitempty"`
+	// Shutdown is graceful shutdown settings
+	Shutdown shared.Shutdown `json:"shutdown,omitempty"`
+	// Suspend scales server to zero replicas, keeping its index, services and secrets
+	Suspend bool `json:"suspend,omitempty"`
+	// DataResync is server index resync settings
+	shared.DataResync `json:",inline"`
+	// Deletion is deletion settings of server index and generated secrets
+	shared.Deletion `json:",inline"`
+	// PodExtras is extra environment variables, volumes and containers injected into server pods
+	shared.PodExtras `json:",inline"`
+	// Resources is server compute and storage resources
+	shared.Resources `json:"resources,omitempty"`
+}
+
+// ElectrumServerStatus defines the observed state of ElectrumServer
+type ElectrumServerStatus struct {
+	Client string `json:"client,omitempty"`
+	// IndexedHeight is the height of the best block indexed by the server
+	IndexedHeight uint `json:"indexedHeight,omitempty"`
+	// Mode is reconciliation mode
+	Mode shared.Mode `json:"mode,omitempty"`
+	// ResyncStatus is server index resync status
+	shared.ResyncStatus `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// ElectrumServer is the Schema for the electrumservers API
+// +kubebuilder:printcolumn:name="Client",type=string,JSONPath=".spec.client"
+// +kubebuilder:printcolumn:name="Bitcoin Node",type=string,JSONPath=".spec.bitcoinNode"
+// +kubebuilder:printcolumn:name="Indexed Height",type=integer,JSONPath=".status.indexedHeight"
+// +kubebuilder:printcolumn:name="Mode",type=string,JSONPath=".status.mode"
+type ElectrumServer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ElectrumServerSpec   `json:"spec,omitempty"`
+	Status ElectrumServerStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ElectrumServerList contains a list of ElectrumServer
+type ElectrumServerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ElectrumServer `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ElectrumServer{}, &ElectrumServerList{})
+}
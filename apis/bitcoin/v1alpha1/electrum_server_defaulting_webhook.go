@@ -0,0 +1,68 @@
+package v1alpha1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// +kubebuilder:webhook:path=/mutate-bitcoin-kotal-io-v1alpha1-electrumserver,mutating=true,failurePolicy=fail,groups=bitcoin.kotal.io,resources=electrumservers,verbs=create;update,versions=v1alpha1,name=mutate-bitcoin-v1alpha1-electrumserver.kb.io,sideEffects=None,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &ElectrumServer{}
+
+func (r *ElectrumServer) DefaultNodeResources() {
+	if r.Spec.Resources.CPU == "" {
+		r.Spec.Resources.CPU = DefaultElectrumServerCPURequest
+	}
+
+	if r.Spec.Resources.CPULimit == "" {
+		r.Spec.Resources.CPULimit = DefaultElectrumServerCPULimit
+	}
+
+	if r.Spec.Resources.Memory == "" {
+		r.Spec.Resources.Memory = DefaultElectrumServerMemoryRequest
+	}
+
+	if r.Spec.Resources.MemoryLimit == "" {
+		r.Spec.Resources.MemoryLimit = DefaultElectrumServerMemoryLimit
+	}
+
+	if r.Spec.Resources.Storage == "" {
+		r.Spec.Resources.Storage = DefaultElectrumServerStorageRequest
+	}
+}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type
+func (r *ElectrumServer) Default() {
+	electrumserverlog.Info("default", "name", r.Name)
+
+	r.DefaultNodeResources()
+
+	if r.Spec.Client == "" {
+		r.Spec.Client = DefaultElectrumClient
+	}
+
+	if r.Spec.Image == "" {
+		switch r.Spec.Client {
+		case ElectrsClient:
+			r.Spec.Image = DefaultElectrsImage
+		case FulcrumClient:
+			r.Spec.Image = DefaultFulcrumImage
+		}
+	}
+
+	if r.Spec.Replicas == nil {
+		// constants are not addressable
+		replicas := DefaltReplicas
+		r.Spec.Replicas = &replicas
+	}
+
+	if r.Spec.Port == 0 {
+		r.Spec.Port = DefaultElectrumPort
+	}
+
+	if r.Spec.TLSPort == 0 {
+		r.Spec.TLSPort = DefaultElectrumTLSPort
+	}
+
+	r.Spec.Disruption.Default()
+
+}
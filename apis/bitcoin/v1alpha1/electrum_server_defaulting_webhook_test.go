@@ -0,0 +1,45 @@
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Bitcoin Electrum server defaulting", func() {
+	It("Should default Electrum server", func() {
+		server := ElectrumServer{
+			ObjectMeta: metav1.ObjectMeta{},
+			Spec: ElectrumServerSpec{
+				BitcoinNode: "bitcoin-node",
+			},
+		}
+
+		server.Default()
+
+		Expect(server.Spec.Client).To(Equal(ElectrsClient))
+		Expect(server.Spec.Image).To(Equal(DefaultElectrsImage))
+		Expect(server.Spec.Port).To(Equal(DefaultElectrumPort))
+		Expect(server.Spec.TLSPort).To(Equal(DefaultElectrumTLSPort))
+		Expect(*server.Spec.Replicas).To(Equal(DefaltReplicas))
+		Expect(server.Spec.CPU).To(Equal(DefaultElectrumServerCPURequest))
+		Expect(server.Spec.CPULimit).To(Equal(DefaultElectrumServerCPULimit))
+		Expect(server.Spec.Memory).To(Equal(DefaultElectrumServerMemoryRequest))
+		Expect(server.Spec.MemoryLimit).To(Equal(DefaultElectrumServerMemoryLimit))
+		Expect(server.Spec.Storage).To(Equal(DefaultElectrumServerStorageRequest))
+	})
+
+	It("Should default Fulcrum server image", func() {
+		server := ElectrumServer{
+			ObjectMeta: metav1.ObjectMeta{},
+			Spec: ElectrumServerSpec{
+				Client:      FulcrumClient,
+				BitcoinNode: "bitcoin-node",
+			},
+		}
+
+		server.Default()
+
+		Expect(server.Spec.Image).To(Equal(DefaultFulcrumImage))
+	})
+})
@@ -0,0 +1,109 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	"github.com/kotalco/kotal/apis/shared"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// +kubebuilder:webhook:verbs=create;update;delete,path=/validate-bitcoin-kotal-io-v1alpha1-electrumserver,mutating=false,failurePolicy=fail,groups=bitcoin.kotal.io,resources=electrumservers,versions=v1alpha1,name=validate-bitcoin-v1alpha1-electrumserver.kb.io,sideEffects=None,admissionReviewVersions=v1
+
+var _ webhook.Validator = &ElectrumServer{}
+
+// validate is common create and update validation rules
+func (r *ElectrumServer) validate() field.ErrorList {
+	var serverErrors field.ErrorList
+
+	path := field.NewPath("spec")
+
+	if r.Spec.BitcoinNode == "" {
+		err := field.Required(path.Child("bitcoinNode"), "must provide Bitcoin node name")
+		serverErrors = append(serverErrors, err)
+	}
+
+	// ssl server is supported by fulcrum only
+	if r.Spec.TLS && r.Spec.Client != FulcrumClient {
+		err := field.Invalid(path.Child("tls"), r.Spec.TLS, fmt.Sprintf("not supported by %s client", r.Spec.Client))
+		serverErrors = append(serverErrors, err)
+	}
+
+	if r.Spec.CertSecretName != "" && !r.Spec.TLS {
+		err := field.Invalid(path.Child("certSecretName"), r.Spec.CertSecretName, "can't be set without enabling tls")
+		serverErrors = append(serverErrors, err)
+	}
+
+	if r.Spec.TLS && r.Spec.TLSPort == r.Spec.Port {
+		err := field.Invalid(path.Child("tlsPort"), r.Spec.TLSPort, "must be different from port")
+		serverErrors = append(serverErrors, err)
+	}
+
+	return serverErrors
+}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *ElectrumServer) ValidateCreate() (admission.Warnings, error) {
+	var allErrors field.ErrorList
+
+	electrumserverlog.Info("validate create", "name", r.Name)
+
+	allErrors = append(allErrors, r.validate()...)
+	allErrors = append(allErrors, r.Spec.Resources.ValidateCreate()...)
+	allErrors = append(allErrors, r.Spec.Access.Validate()...)
+	allErrors = append(allErrors, r.Spec.PodExtras.Validate()...)
+	allErrors = append(allErrors, r.Spec.Deletion.Validate()...)
+
+	warnings := r.Spec.ExtraArgs.Warnings(r, electrumServerArgs)
+
+	if len(allErrors) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(schema.GroupKind{}, r.Name, allErrors)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *ElectrumServer) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	var allErrors field.ErrorList
+	oldServer := old.(*ElectrumServer)
+
+	electrumserverlog.Info("validate update", "name", r.Name)
+
+	allErrors = append(allErrors, r.validate()...)
+	allErrors = append(allErrors, r.Spec.Resources.ValidateUpdate(&oldServer.Spec.Resources)...)
+	allErrors = append(allErrors, r.Spec.Access.Validate()...)
+	allErrors = append(allErrors, r.Spec.PodExtras.Validate()...)
+	allErrors = append(allErrors, r.Spec.Deletion.Validate()...)
+	allErrors = append(allErrors, r.Spec.DataResync.ValidateUpdate(&oldServer.Spec.DataResync, false)...)
+
+	// index format is client specific
+	if r.Spec.Client != oldServer.Spec.Client {
+		err := field.Invalid(field.NewPath("spec").Child("client"), r.Spec.Client, "field is immutable")
+		allErrors = append(allErrors, err)
+	}
+
+	if r.Spec.BitcoinNode != oldServer.Spec.BitcoinNode {
+		err := field.Invalid(field.NewPath("spec").Child("bitcoinNode"), r.Spec.BitcoinNode, "field is immutable")
+		allErrors = append(allErrors, err)
+	}
+
+	warnings := r.Spec.ExtraArgs.Warnings(r, electrumServerArgs)
+
+	if len(allErrors) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(schema.GroupKind{}, r.Name, allErrors)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *ElectrumServer) ValidateDelete() (admission.Warnings, error) {
+	electrumserverlog.Info("validate delete", "name", r.Name)
+
+	return nil, shared.ValidateDeletionProtection(r)
+}
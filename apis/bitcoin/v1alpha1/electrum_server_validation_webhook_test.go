@@ -0,0 +1,203 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	"github.com/kotalco/kotal/apis/shared"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var _ = Describe("Bitcoin Electrum server validation", func() {
+	createCases := []struct {
+		Title  string
+		Server *ElectrumServer
+		Errors field.ErrorList
+	}{
+		{
+			Title: "missing bitcoin node",
+			Server: &ElectrumServer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-server",
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:   field.ErrorTypeRequired,
+					Field:  "spec.bitcoinNode",
+					Detail: "must provide Bitcoin node name",
+				},
+			},
+		},
+		{
+			Title: "electrs server with tls enabled",
+			Server: &ElectrumServer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-server",
+				},
+				Spec: ElectrumServerSpec{
+					Client:      ElectrsClient,
+					BitcoinNode: "bitcoin-node",
+					TLS:         true,
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.tls",
+					BadValue: true,
+					Detail:   "not supported by electrs client",
+				},
+			},
+		},
+		{
+			Title: "certificate secret without tls",
+			Server: &ElectrumServer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-server",
+				},
+				Spec: ElectrumServerSpec{
+					Client:         FulcrumClient,
+					BitcoinNode:    "bitcoin-node",
+					CertSecretName: "my-cert",
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.certSecretName",
+					BadValue: "my-cert",
+					Detail:   "can't be set without enabling tls",
+				},
+			},
+		},
+		{
+			Title: "tls port conflicting with tcp port",
+			Server: &ElectrumServer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-server",
+				},
+				Spec: ElectrumServerSpec{
+					Client:      FulcrumClient,
+					BitcoinNode: "bitcoin-node",
+					TLS:         true,
+					Port:        50001,
+					TLSPort:     50001,
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.tlsPort",
+					BadValue: uint(50001),
+					Detail:   "must be different from port",
+				},
+			},
+		},
+	}
+
+	updateCases := []struct {
+		Title     string
+		OldServer *ElectrumServer
+		NewServer *ElectrumServer
+		Errors    field.ErrorList
+	}{
+		{
+			Title: "updated client",
+			OldServer: &ElectrumServer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-server",
+				},
+				Spec: ElectrumServerSpec{
+					Client:      ElectrsClient,
+					BitcoinNode: "bitcoin-node",
+				},
+			},
+			NewServer: &ElectrumServer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-server",
+				},
+				Spec: ElectrumServerSpec{
+					Client:      FulcrumClient,
+					BitcoinNode: "bitcoin-node",
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.client",
+					BadValue: FulcrumClient,
+					Detail:   "field is immutable",
+				},
+			},
+		},
+		{
+			Title: "updated bitcoin node",
+			OldServer: &ElectrumServer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-server",
+				},
+				Spec: ElectrumServerSpec{
+					BitcoinNode: "bitcoin-node",
+				},
+			},
+			NewServer: &ElectrumServer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-server",
+				},
+				Spec: ElectrumServerSpec{
+					BitcoinNode: "other-bitcoin-node",
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.bitcoinNode",
+					BadValue: "other-bitcoin-node",
+					Detail:   "field is immutable",
+				},
+			},
+		},
+	}
+
+	Context("While creating Electrum server", func() {
+		for _, c := range createCases {
+			func() {
+				cc := c
+				It(fmt.Sprintf("Should validate %s", cc.Title), func() {
+					cc.Server.Default()
+					_, err := cc.Server.ValidateCreate()
+
+					errStatus := err.(*errors.StatusError)
+
+					causes := shared.ErrorsToCauses(cc.Errors)
+
+					Expect(errStatus.ErrStatus.Details.Causes).To(ContainElements(causes))
+				})
+			}()
+		}
+	})
+
+	Context("While updating Electrum server", func() {
+		for _, c := range updateCases {
+			func() {
+				cc := c
+				It(fmt.Sprintf("Should validate %s", cc.Title), func() {
+					cc.OldServer.Default()
+					cc.NewServer.Default()
+					_, err := cc.NewServer.ValidateUpdate(cc.OldServer)
+
+					errStatus := err.(*errors.StatusError)
+
+					causes := shared.ErrorsToCauses(cc.Errors)
+
+					Expect(errStatus.ErrStatus.Details.Causes).To(ContainElements(causes))
+				})
+			}()
+		}
+	})
+
+})
@@ -0,0 +1,28 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// log is for logging in this package.
+var electrumserverlog = logf.Log.WithName("electrumserver-resource")
+
+// electrumServerArgs generates electrum server cli arguments checked against extra arguments by validation webhook
+var electrumServerArgs sharedAPI.ArgsGenerator
+
+// SetupWebhookWithManager sets up the webook with a given controller manager
+// args is electrum server cli arguments generator, webhook registration fails without it
+func (r *ElectrumServer) SetupWebhookWithManager(mgr ctrl.Manager, args sharedAPI.ArgsGenerator) error {
+	if args == nil {
+		return fmt.Errorf("no cli arguments generator is registered for %T", r)
+	}
+	electrumServerArgs = args
+
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
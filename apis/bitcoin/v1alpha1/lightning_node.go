@@ -0,0 +1,109 @@
+package v1alpha1
+
+import (
+	"github.com/kotalco/kotal/apis/shared"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// LightningClient is Lightning Network node client
+type LightningClient string
+
+// Lightning Network node clients
+const (
+	// LNDClient is Lightning Labs Lightning Network Daemon
+	LNDClient LightningClient = "lnd"
+)
+
+// LightningNodeSpec defines the desired state of LightningNode
+type LightningNodeSpec struct {
+	// Image is Lightning Network node client image
+	Image string `json:"image,omitempty"`
+	// ExtraArgs is extra arguments to pass down to the cli
+	ExtraArgs shared.ExtraArgs `json:"extraArgs,omitempty"`
+	// RemoveArgs is names of generated cli arguments to remove
+	// +listType=set
+	RemoveArgs []string `json:"removeArgs,omitempty"`
+	// Replicas is number of replicas
+	// it must be 0 or 1, wallet can't be shared by replicas
+	// +kubebuilder:validation:Minimum=0
+	Replicas *uint `json:"replicas,omitempty"`
+	// Client is Lightning Network node client
+	// +kubebuilder:validation:Enum=lnd
+	Client LightningClient `json:"client,omitempty"`
+	// BitcoinNode is Bitcoin node name in the same namespace
+	// its network, the first rpc user credentials and ZeroMQ notifications endpoints are used
+	BitcoinNode string `json:"bitcoinNode"`
+	// Alias is node alias announced to the network
+	Alias string `json:"alias,omitempty"`
+	// P2PPort is p2p communications port
+	P2PPort uint `json:"p2pPort,omitempty"`
+	// GRPCPort is gRPC API server port
+	GRPCPort uint `json:"grpcPort,omitempty"`
+	// RESTPort is REST API server port
+	RESTPort uint `json:"restPort,omitempty"`
+	// Access is network access settings of node endpoints
+	Access shared.Access `json:"access,omitempty"`
+	// Disruption is pod disruption budget settings
+	Disruption shared.Disruption `json:"disruption,omitempty"`
+	// Shutdown is graceful shutdown settings
+	Shutdown shared.Shutdown `json:"shutdown,omitempty"`
+	// Suspend scales node to zero replicas, keeping its data, services and secrets
+	Suspend bool `json:"suspend,omitempty"`
+	// DataResync is node data resync settings
+	shared.DataResync `json:",inline"`
+	// Deletion is deletion settings of node data and generated secrets, except wallet secret which is always kept
+	shared.Deletion `json:",inline"`
+	// PodExtras is extra environment variables, volumes and containers injected into node pods
+	shared.PodExtras `json:",inline"`
+	// Resources is node compute and storage resources
+	shared.Resources `json:"resources,omitempty"`
+}
+
+// LightningNodeStatus defines the observed state of LightningNode
+type LightningNodeStatus struct {
+	Client string `json:"client,omitempty"`
+	// IdentityPubkey is node public key
+	IdentityPubkey string `json:"identityPubkey,omitempty"`
+	// SyncedToChain is true if node is synced to Bitcoin node chain, it's reported for lnd nodes
+	SyncedToChain bool `json:"syncedToChain,omitempty"`
+	// BlockHeight is node best block height, it's reported for lnd nodes
+	BlockHeight uint `json:"blockHeight,omitempty"`
+	// ActiveChannels is active channels count, it's reported for lnd nodes
+	ActiveChannels uint `json:"activeChannels,omitempty"`
+	// PendingChannels is pending channels count, it's reported for lnd nodes
+	PendingChannels uint `json:"pendingChannels,omitempty"`
+	// Mode is reconciliation mode
+	Mode shared.Mode `json:"mode,omitempty"`
+	// ResyncStatus is node data resync status
+	shared.ResyncStatus `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// LightningNode is the Schema for the lightningnodes API
+// +kubebuilder:printcolumn:name="Client",type=string,JSONPath=".spec.client"
+// +kubebuilder:printcolumn:name="Bitcoin Node",type=string,JSONPath=".spec.bitcoinNode"
+// +kubebuilder:printcolumn:name="Synced",type=boolean,JSONPath=".status.syncedToChain"
+// +kubebuilder:printcolumn:name="Channels",type=integer,JSONPath=".status.activeChannels"
+// +kubebuilder:printcolumn:name="Mode",type=string,JSONPath=".status.mode"
+type LightningNode struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   LightningNodeSpec   `json:"spec,omitempty"`
+	Status LightningNodeStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// LightningNodeList contains a list of LightningNode
+type LightningNodeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []LightningNode `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&LightningNode{}, &LightningNodeList{})
+}
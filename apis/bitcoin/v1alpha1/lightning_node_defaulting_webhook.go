@@ -0,0 +1,70 @@
+package v1alpha1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// +kubebuilder:webhook:path=/mutate-bitcoin-kotal-io-v1alpha1-lightningnode,mutating=true,failurePolicy=fail,groups=bitcoin.kotal.io,resources=lightningnodes,verbs=create;update,versions=v1alpha1,name=mutate-bitcoin-v1alpha1-lightningnode.kb.io,sideEffects=None,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &LightningNode{}
+
+func (r *LightningNode) DefaultNodeResources() {
+	if r.Spec.Resources.CPU == "" {
+		r.Spec.Resources.CPU = DefaultLightningNodeCPURequest
+	}
+
+	if r.Spec.Resources.CPULimit == "" {
+		r.Spec.Resources.CPULimit = DefaultLightningNodeCPULimit
+	}
+
+	if r.Spec.Resources.Memory == "" {
+		r.Spec.Resources.Memory = DefaultLightningNodeMemoryRequest
+	}
+
+	if r.Spec.Resources.MemoryLimit == "" {
+		r.Spec.Resources.MemoryLimit = DefaultLightningNodeMemoryLimit
+	}
+
+	if r.Spec.Resources.Storage == "" {
+		r.Spec.Resources.Storage = DefaultLightningNodeStorageRequest
+	}
+}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type
+func (r *LightningNode) Default() {
+	lightningnodelog.Info("default", "name", r.Name)
+
+	r.DefaultNodeResources()
+
+	if r.Spec.Client == "" {
+		r.Spec.Client = DefaultLightningClient
+	}
+
+	if r.Spec.Image == "" {
+		switch r.Spec.Client {
+		case LNDClient:
+			r.Spec.Image = DefaultLNDImage
+		}
+	}
+
+	if r.Spec.Replicas == nil {
+		// constants are not addressable
+		replicas := DefaltReplicas
+		r.Spec.Replicas = &replicas
+	}
+
+	if r.Spec.P2PPort == 0 {
+		r.Spec.P2PPort = DefaultLightningP2PPort
+	}
+
+	if r.Spec.GRPCPort == 0 {
+		r.Spec.GRPCPort = DefaultLightningGRPCPort
+	}
+
+	if r.Spec.RESTPort == 0 {
+		r.Spec.RESTPort = DefaultLightningRESTPort
+	}
+
+	r.Spec.Disruption.Default()
+
+}
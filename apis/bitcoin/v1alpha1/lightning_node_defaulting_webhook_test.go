@@ -0,0 +1,32 @@
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Bitcoin lightning node defaulting", func() {
+	It("Should default lightning node", func() {
+		node := LightningNode{
+			ObjectMeta: metav1.ObjectMeta{},
+			Spec: LightningNodeSpec{
+				BitcoinNode: "bitcoin-node",
+			},
+		}
+
+		node.Default()
+
+		Expect(node.Spec.Client).To(Equal(LNDClient))
+		Expect(node.Spec.Image).To(Equal(DefaultLNDImage))
+		Expect(node.Spec.P2PPort).To(Equal(DefaultLightningP2PPort))
+		Expect(node.Spec.GRPCPort).To(Equal(DefaultLightningGRPCPort))
+		Expect(node.Spec.RESTPort).To(Equal(DefaultLightningRESTPort))
+		Expect(*node.Spec.Replicas).To(Equal(DefaltReplicas))
+		Expect(node.Spec.CPU).To(Equal(DefaultLightningNodeCPURequest))
+		Expect(node.Spec.CPULimit).To(Equal(DefaultLightningNodeCPULimit))
+		Expect(node.Spec.Memory).To(Equal(DefaultLightningNodeMemoryRequest))
+		Expect(node.Spec.MemoryLimit).To(Equal(DefaultLightningNodeMemoryLimit))
+		Expect(node.Spec.Storage).To(Equal(DefaultLightningNodeStorageRequest))
+	})
+})
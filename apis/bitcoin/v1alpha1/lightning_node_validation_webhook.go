@@ -0,0 +1,114 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	"github.com/kotalco/kotal/apis/shared"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// +kubebuilder:webhook:verbs=create;update;delete,path=/validate-bitcoin-kotal-io-v1alpha1-lightningnode,mutating=false,failurePolicy=fail,groups=bitcoin.kotal.io,resources=lightningnodes,versions=v1alpha1,name=validate-bitcoin-v1alpha1-lightningnode.kb.io,sideEffects=None,admissionReviewVersions=v1
+
+var _ webhook.Validator = &LightningNode{}
+
+// validate is common create and update validation rules
+func (r *LightningNode) validate() field.ErrorList {
+	var nodeErrors field.ErrorList
+
+	path := field.NewPath("spec")
+
+	if r.Spec.BitcoinNode == "" {
+		err := field.Required(path.Child("bitcoinNode"), "must provide Bitcoin node name")
+		nodeErrors = append(nodeErrors, err)
+	}
+
+	ports := []struct {
+		name string
+		port uint
+	}{
+		{"p2pPort", r.Spec.P2PPort},
+		{"grpcPort", r.Spec.GRPCPort},
+		{"restPort", r.Spec.RESTPort},
+	}
+
+	used := map[uint]string{}
+	for _, p := range ports {
+		if name, found := used[p.port]; found {
+			err := field.Invalid(path.Child(p.name), p.port, fmt.Sprintf("must be different from %s", name))
+			nodeErrors = append(nodeErrors, err)
+			continue
+		}
+		used[p.port] = p.name
+	}
+
+	return nodeErrors
+}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *LightningNode) ValidateCreate() (admission.Warnings, error) {
+	var allErrors field.ErrorList
+
+	lightningnodelog.Info("validate create", "name", r.Name)
+
+	allErrors = append(allErrors, r.validate()...)
+	allErrors = append(allErrors, r.Spec.Resources.ValidateCreate()...)
+	allErrors = append(allErrors, r.Spec.Access.Validate()...)
+	allErrors = append(allErrors, r.Spec.PodExtras.Validate()...)
+	allErrors = append(allErrors, r.Spec.Deletion.Validate()...)
+	allErrors = append(allErrors, shared.ValidateReplicas(r.Spec.Replicas, true)...)
+
+	warnings := r.Spec.ExtraArgs.Warnings(r, lightningNodeArgs)
+
+	if len(allErrors) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(schema.GroupKind{}, r.Name, allErrors)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *LightningNode) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	var allErrors field.ErrorList
+	oldNode := old.(*LightningNode)
+
+	lightningnodelog.Info("validate update", "name", r.Name)
+
+	allErrors = append(allErrors, r.validate()...)
+	allErrors = append(allErrors, r.Spec.Resources.ValidateUpdate(&oldNode.Spec.Resources)...)
+	allErrors = append(allErrors, r.Spec.Access.Validate()...)
+	allErrors = append(allErrors, r.Spec.PodExtras.Validate()...)
+	allErrors = append(allErrors, r.Spec.Deletion.Validate()...)
+	allErrors = append(allErrors, shared.ValidateReplicas(r.Spec.Replicas, true)...)
+	// node data holds channels state, resyncing it from scratch loses channels funds
+	allErrors = append(allErrors, r.Spec.DataResync.ValidateUpdate(&oldNode.Spec.DataResync, true)...)
+
+	if r.Spec.Client != oldNode.Spec.Client {
+		err := field.Invalid(field.NewPath("spec").Child("client"), r.Spec.Client, "field is immutable")
+		allErrors = append(allErrors, err)
+	}
+
+	if r.Spec.BitcoinNode != oldNode.Spec.BitcoinNode {
+		err := field.Invalid(field.NewPath("spec").Child("bitcoinNode"), r.Spec.BitcoinNode, "field is immutable")
+		allErrors = append(allErrors, err)
+	}
+
+	warnings := r.Spec.ExtraArgs.Warnings(r, lightningNodeArgs)
+
+	if len(allErrors) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(schema.GroupKind{}, r.Name, allErrors)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *LightningNode) ValidateDelete() (admission.Warnings, error) {
+	lightningnodelog.Info("validate delete", "name", r.Name)
+
+	return nil, shared.ValidateDeletionProtection(r)
+}
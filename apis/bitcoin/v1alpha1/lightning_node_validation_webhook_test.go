@@ -0,0 +1,210 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	"github.com/kotalco/kotal/apis/shared"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var _ = Describe("Bitcoin lightning node validation", func() {
+	createCases := []struct {
+		Title  string
+		Node   *LightningNode
+		Errors field.ErrorList
+	}{
+		{
+			Title: "missing bitcoin node",
+			Node: &LightningNode{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-node",
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:   field.ErrorTypeRequired,
+					Field:  "spec.bitcoinNode",
+					Detail: "must provide Bitcoin node name",
+				},
+			},
+		},
+		{
+			Title: "gRPC port conflicting with p2p port",
+			Node: &LightningNode{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-node",
+				},
+				Spec: LightningNodeSpec{
+					BitcoinNode: "bitcoin-node",
+					P2PPort:     9735,
+					GRPCPort:    9735,
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.grpcPort",
+					BadValue: uint(9735),
+					Detail:   "must be different from p2pPort",
+				},
+			},
+		},
+		{
+			Title: "REST port conflicting with gRPC port",
+			Node: &LightningNode{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-node",
+				},
+				Spec: LightningNodeSpec{
+					BitcoinNode: "bitcoin-node",
+					GRPCPort:    8888,
+					RESTPort:    8888,
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.restPort",
+					BadValue: uint(8888),
+					Detail:   "must be different from grpcPort",
+				},
+			},
+		},
+	}
+
+	updateCases := []struct {
+		Title   string
+		OldNode *LightningNode
+		NewNode *LightningNode
+		Errors  field.ErrorList
+	}{
+		{
+			Title: "updated client",
+			OldNode: &LightningNode{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-node",
+				},
+				Spec: LightningNodeSpec{
+					Client:      LNDClient,
+					BitcoinNode: "bitcoin-node",
+				},
+			},
+			NewNode: &LightningNode{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-node",
+				},
+				Spec: LightningNodeSpec{
+					Client:      LightningClient("eclair"),
+					BitcoinNode: "bitcoin-node",
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.client",
+					BadValue: LightningClient("eclair"),
+					Detail:   "field is immutable",
+				},
+			},
+		},
+		{
+			Title: "updated bitcoin node",
+			OldNode: &LightningNode{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-node",
+				},
+				Spec: LightningNodeSpec{
+					BitcoinNode: "bitcoin-node",
+				},
+			},
+			NewNode: &LightningNode{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-node",
+				},
+				Spec: LightningNodeSpec{
+					BitcoinNode: "other-bitcoin-node",
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.bitcoinNode",
+					BadValue: "other-bitcoin-node",
+					Detail:   "field is immutable",
+				},
+			},
+		},
+		{
+			Title: "resynced data without force",
+			OldNode: &LightningNode{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-node",
+				},
+				Spec: LightningNodeSpec{
+					BitcoinNode: "bitcoin-node",
+				},
+			},
+			NewNode: &LightningNode{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-node",
+				},
+				Spec: LightningNodeSpec{
+					BitcoinNode: "bitcoin-node",
+					DataResync: shared.DataResync{
+						Resync: 1,
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.resync",
+					BadValue: uint(1),
+					Detail:   "validator data can't be resynced unless forceResync is true",
+				},
+			},
+		},
+	}
+
+	Context("While creating lightning node", func() {
+		for _, c := range createCases {
+			func() {
+				cc := c
+				It(fmt.Sprintf("Should validate %s", cc.Title), func() {
+					cc.Node.Default()
+					_, err := cc.Node.ValidateCreate()
+
+					errStatus := err.(*errors.StatusError)
+
+					causes := shared.ErrorsToCauses(cc.Errors)
+
+					Expect(errStatus.ErrStatus.Details.Causes).To(ContainElements(causes))
+				})
+			}()
+		}
+	})
+
+	Context("While updating lightning node", func() {
+		for _, c := range updateCases {
+			func() {
+				cc := c
+				It(fmt.Sprintf("Should validate %s", cc.Title), func() {
+					cc.OldNode.Default()
+					cc.NewNode.Default()
+					_, err := cc.NewNode.ValidateUpdate(cc.OldNode)
+
+					errStatus := err.(*errors.StatusError)
+
+					causes := shared.ErrorsToCauses(cc.Errors)
+
+					Expect(errStatus.ErrStatus.Details.Causes).To(ContainElements(causes))
+				})
+			}()
+		}
+	})
+
+})
@@ -0,0 +1,28 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// log is for logging in this package.
+var lightningnodelog = logf.Log.WithName("lightningnode-resource")
+
+// lightningNodeArgs generates lightning node cli arguments checked against extra arguments by validation webhook
+var lightningNodeArgs sharedAPI.ArgsGenerator
+
+// SetupWebhookWithManager sets up the webook with a given controller manager
+// args is lightning node cli arguments generator, webhook registration fails without it
+func (r *LightningNode) SetupWebhookWithManager(mgr ctrl.Manager, args sharedAPI.ArgsGenerator) error {
+	if args == nil {
+		return fmt.Errorf("no cli arguments generator is registered for %T", r)
+	}
+	lightningNodeArgs = args
+
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
@@ -12,14 +12,90 @@ type BitcoinNetwork string
 const (
 	Mainnet BitcoinNetwork = "mainnet"
 	Testnet BitcoinNetwork = "testnet"
+	Regtest BitcoinNetwork = "regtest"
+	Signet  BitcoinNetwork = "signet"
 )
 
+// BlockGeneration is regtest blocks generation settings
+type BlockGeneration struct {
+	// Address is the address receiving generated blocks rewards
+	Address string `json:"address"`
+	// Interval is seconds between generated blocks
+	// +kubebuilder:validation:Minimum=1
+	Interval uint `json:"interval,omitempty"`
+}
+
+// Bitcoin node conditions
+const (
+	// ConditionRPCAuthReady is true if rpc users salted credentials are generated
+	ConditionRPCAuthReady = "RPCAuthReady"
+)
+
+// Bitcoin node conditions reasons
+const (
+	// ReasonRPCAuthGenerated is the reason of generated rpc users salted credentials
+	ReasonRPCAuthGenerated = "RPCAuthGenerated"
+	// ReasonRPCPasswordNotFound is the reason of missing rpc user password secret
+	ReasonRPCPasswordNotFound = "RPCPasswordNotFound"
+)
+
+// ZMQ is ZeroMQ notifications settings, notification is disabled if its port is 0
+type ZMQ struct {
+	// RawBlockPort is port publishing raw blocks
+	RawBlockPort uint `json:"rawBlockPort,omitempty"`
+	// RawTxPort is port publishing raw transactions
+	RawTxPort uint `json:"rawTxPort,omitempty"`
+	// HashBlockPort is port publishing blocks hashes
+	HashBlockPort uint `json:"hashBlockPort,omitempty"`
+	// SequencePort is port publishing blocks and mempool transactions sequence
+	SequencePort uint `json:"sequencePort,omitempty"`
+}
+
+// AssumeUTXO is UTXO snapshot source, node is usable once snapshot is loaded while chain history is validated in the background
+type AssumeUTXO struct {
+	// Path is UTXO snapshot file path relative to node data directory
+	Path string `json:"path,omitempty"`
+	// URL is UTXO snapshot file url, it's downloaded into node data directory
+	URL string `json:"url,omitempty"`
+	// ConfigMapName is config map holding small UTXO snapshot file at kotal-utxo-snapshot.dat key, used by regtest and signet nodes
+	ConfigMapName string `json:"configMapName,omitempty"`
+}
+
+// AssumeUTXOPhase is UTXO snapshot loading phase
+type AssumeUTXOPhase string
+
+// UTXO snapshot loading phases
+const (
+	// AssumeUTXOLoading is loading UTXO snapshot by the node
+	AssumeUTXOLoading AssumeUTXOPhase = "Loading"
+	// AssumeUTXOValidating is validating chain history up to UTXO snapshot base block in the background
+	AssumeUTXOValidating AssumeUTXOPhase = "Validating"
+	// AssumeUTXOValidated is validated chain history up to UTXO snapshot base block
+	AssumeUTXOValidated AssumeUTXOPhase = "Validated"
+)
+
+// AssumeUTXOStatus is UTXO snapshot loading and background validation status
+type AssumeUTXOStatus struct {
+	// Phase is UTXO snapshot loading phase
+	Phase AssumeUTXOPhase `json:"phase,omitempty"`
+	// LoadTime is when UTXO snapshot loading was last requested
+	LoadTime *metav1.Time `json:"loadTime,omitempty"`
+	// SnapshotBlockHash is UTXO snapshot base block hash
+	SnapshotBlockHash string `json:"snapshotBlockHash,omitempty"`
+	// SnapshotHeight is UTXO snapshot base block height
+	SnapshotHeight uint `json:"snapshotHeight,omitempty"`
+	// ValidatedHeight is background validated blocks height
+	ValidatedHeight uint `json:"validatedHeight,omitempty"`
+	// ValidationProgress is background validation progress percentage
+	ValidationProgress string `json:"validationProgress,omitempty"`
+}
+
 // RPCUsers is JSON-RPC users credentials
 type RPCUser struct {
 	// Username is JSON-RPC username
 	Username string `json:"username"`
 	// PasswordSecretName is k8s secret name holding JSON-RPC user password
-	PasswordSecretName string `json:"passwordSecretName"`
+	PasswordSecretName shared.SecretKeySelector `json:"passwordSecretName"`
 }
 
 // NodeSpec defines the desired state of Node
@@ -28,12 +104,22 @@ type NodeSpec struct {
 	Image string `json:"image,omitempty"`
 	// ExtraArgs is extra arguments to pass down to the cli
 	ExtraArgs shared.ExtraArgs `json:"extraArgs,omitempty"`
+	// RemoveArgs is names of generated cli arguments to remove
+	// +listType=set
+	RemoveArgs []string `json:"removeArgs,omitempty"`
 	// Replicas is number of replicas
-	// +kubebuilder:validation:Enum=0;1
+	// +kubebuilder:validation:Minimum=0
 	Replicas *uint `json:"replicas,omitempty"`
 	// Network is Bitcoin network to join and sync
-	// +kubebuilder:validation:Enum=mainnet;testnet
+	// +kubebuilder:validation:Enum=mainnet;testnet;regtest;signet
 	Network BitcoinNetwork `json:"network"`
+	// SignetChallenge is custom signet blocks challenge script in hex
+	SignetChallenge string `json:"signetChallenge,omitempty"`
+	// SignetSeedNodes is custom signet seed nodes
+	// +listType=set
+	SignetSeedNodes []string `json:"signetSeedNodes,omitempty"`
+	// BlockGeneration generates regtest blocks on an interval by the operator
+	BlockGeneration *BlockGeneration `json:"blockGeneration,omitempty"`
 	// Listen accepts connections from outside
 	Listen *bool `json:"listen,omitempty"`
 	// P2PPort is p2p communications port
@@ -46,19 +132,30 @@ type NodeSpec struct {
 	RPCPort uint `json:"rpcPort,omitempty"`
 	// RPCUsers is JSON-RPC users credentials
 	RPCUsers []RPCUser `json:"rpcUsers,omitempty"`
+	// ConnectionSecret publishes secret holding JSON-RPC url and the first rpc user credentials
+	ConnectionSecret bool `json:"connectionSecret,omitempty"`
 	// RPCWhitelist is a list of whitelisted rpc method
 	// +listType=set
 	RPCWhitelist []string `json:"rpcWhitelist,omitempty"`
+	// REST enables REST interface served on JSON-RPC server port
+	REST bool `json:"rest,omitempty"`
+	// ZMQ is ZeroMQ notifications settings
+	ZMQ ZMQ `json:"zmq,omitempty"`
 	// Wallet load wallet and enables wallet RPC calls
 	Wallet bool `json:"wallet,omitempty"`
 	// TransactionIndex maintains a full tx index
 	TransactionIndex bool `json:"txIndex,omitempty"`
+	// BlockFilterIndex maintains compact block filters index used by the getblockfilter RPC
+	BlockFilterIndex bool `json:"blockFilterIndex,omitempty"`
 	// CoinStatsIndex maintains coinstats index used by the gettxoutsetinfo RPC
 	CoinStatsIndex bool `json:"coinStatsIndex,omitempty"`
 	// ReIndex rebuild chain state and block index
 	ReIndex bool `json:"reIndex,omitempty"`
 	// Pruning allows pruneblockchain RPC to delete specific blocks
 	Pruning bool `json:"pruning,omitempty"`
+	// PruneTargetMiB prunes old blocks automatically to keep blocks files under target size in MiB
+	// +kubebuilder:validation:Minimum=550
+	PruneTargetMiB uint `json:"pruneTargetMiB,omitempty"`
 	// BlocksOnly rejects transactions from network peers
 	// https://bitcointalk.org/index.php?topic=1377345.0
 	BlocksOnly bool `json:"blocksOnly,omitempty"`
@@ -66,6 +163,24 @@ type NodeSpec struct {
 	// +kubebuilder:validation:Minimum=4
 	// +kubebuilder:validation:Maximum=16384
 	DBCacheSize uint `json:"dbCacheSize,omitempty"`
+	// AssumeUTXO is UTXO snapshot loaded by the operator once JSON-RPC server is up
+	AssumeUTXO *AssumeUTXO `json:"assumeUTXO,omitempty"`
+	// Access is network access settings of node endpoints
+	Access shared.Access `json:"access,omitempty"`
+	// Disruption is pod disruption budget settings
+	Disruption shared.Disruption `json:"disruption,omitempty"`
+	// Metrics is Prometheus metrics settings
+	Metrics shared.Metrics `json:"metrics,omitempty"`
+	// Shutdown is graceful shutdown settings
+	Shutdown shared.Shutdown `json:"shutdown,omitempty"`
+	// Suspend scales node to zero replicas, keeping its data, services and secrets
+	Suspend bool `json:"suspend,omitempty"`
+	// DataResync is node data resync settings
+	shared.DataResync `json:",inline"`
+	// Deletion is deletion settings of node data and generated secrets
+	shared.Deletion `json:",inline"`
+	// PodExtras is extra environment variables, volumes and containers injected into node pods
+	shared.PodExtras `json:",inline"`
 	// Resources is node compute and storage resources
 	shared.Resources `json:"resources,omitempty"`
 }
@@ -73,6 +188,18 @@ type NodeSpec struct {
 // NodeStatus defines the observed state of Node
 type NodeStatus struct {
 	Client string `json:"client,omitempty"`
+	// Mode is reconciliation mode
+	Mode shared.Mode `json:"mode,omitempty"`
+	// ResyncStatus is node data resync status
+	shared.ResyncStatus `json:",inline"`
+	// AssumeUTXO is UTXO snapshot loading and background validation status
+	AssumeUTXO *AssumeUTXOStatus `json:"assumeUTXO,omitempty"`
+	// LastBlockGenerationTime is when regtest block was last generated by the operator
+	LastBlockGenerationTime *metav1.Time `json:"lastBlockGenerationTime,omitempty"`
+	// Conditions is node conditions
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -81,6 +208,7 @@ type NodeStatus struct {
 // Node is the Schema for the nodes API
 // +kubebuilder:printcolumn:name="Network",type=string,JSONPath=".spec.network"
 // +kubebuilder:printcolumn:name="Client",type=string,JSONPath=".status.client"
+// +kubebuilder:printcolumn:name="Mode",type=string,JSONPath=".status.mode"
 type Node struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
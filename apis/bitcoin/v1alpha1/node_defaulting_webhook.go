@@ -41,23 +41,35 @@ func (r *Node) Default() {
 	}
 
 	if r.Spec.RPCPort == 0 {
-		if r.Spec.Network == Mainnet {
+		switch r.Spec.Network {
+		case Mainnet:
 			r.Spec.RPCPort = DefaultMainnetRPCPort
-		}
-		if r.Spec.Network == Testnet {
+		case Testnet:
 			r.Spec.RPCPort = DefaultTestnetRPCPort
+		case Regtest:
+			r.Spec.RPCPort = DefaultRegtestRPCPort
+		case Signet:
+			r.Spec.RPCPort = DefaultSignetRPCPort
 		}
 	}
 
 	if r.Spec.P2PPort == 0 {
-		if r.Spec.Network == Mainnet {
+		switch r.Spec.Network {
+		case Mainnet:
 			r.Spec.P2PPort = DefaultMainnetP2PPort
-		}
-		if r.Spec.Network == Testnet {
+		case Testnet:
 			r.Spec.P2PPort = DefaultTestnetP2PPort
+		case Regtest:
+			r.Spec.P2PPort = DefaultRegtestP2PPort
+		case Signet:
+			r.Spec.P2PPort = DefaultSignetP2PPort
 		}
 	}
 
+	if r.Spec.BlockGeneration != nil && r.Spec.BlockGeneration.Interval == 0 {
+		r.Spec.BlockGeneration.Interval = DefaultBlockGenerationInterval
+	}
+
 	if r.Spec.Replicas == nil {
 		// constants are not addressable
 		replicas := DefaltReplicas
@@ -70,6 +82,10 @@ func (r *Node) Default() {
 		r.Spec.Listen = &defaultListen
 	}
 
+	if r.Spec.Metrics.Port == 0 {
+		r.Spec.Metrics.Port = DefaultMetricsPort
+	}
+
 	if r.Spec.DBCacheSize == 0 {
 		r.Spec.DBCacheSize = DefaultDBCacheSize
 	}
@@ -80,4 +96,6 @@ func (r *Node) Default() {
 		r.Spec.MaxConnections = &maxConnections
 	}
 
+	r.Spec.Disruption.Default()
+
 }
@@ -22,6 +22,7 @@ var _ = Describe("Bitcoin node defaulting", func() {
 		Expect(*node.Spec.Listen).To(Equal(DefaultListen))
 		Expect(*node.Spec.MaxConnections).To(Equal(DefaultMaxConnections))
 		Expect(node.Spec.DBCacheSize).To(Equal(DefaultDBCacheSize))
+		Expect(node.Spec.Metrics.Port).To(Equal(DefaultMetricsPort))
 		Expect(node.Spec.RPCPort).To(Equal(DefaultMainnetRPCPort))
 		Expect(*node.Spec.Replicas).To(Equal(DefaltReplicas))
 		Expect(node.Spec.CPU).To(Equal(DefaultNodeCPURequest))
@@ -31,4 +32,36 @@ var _ = Describe("Bitcoin node defaulting", func() {
 		Expect(node.Spec.Storage).To(Equal(DefaultNodeStorageRequest))
 
 	})
+
+	It("Should default Bitcoin regtest node", func() {
+		node := Node{
+			ObjectMeta: metav1.ObjectMeta{},
+			Spec: NodeSpec{
+				Network: Regtest,
+				BlockGeneration: &BlockGeneration{
+					Address: "bcrt1qjxs5y6zvmq5d5r9p0mnvlhcd8sf2ajkgyfuw0z",
+				},
+			},
+		}
+
+		node.Default()
+
+		Expect(node.Spec.P2PPort).To(Equal(DefaultRegtestP2PPort))
+		Expect(node.Spec.RPCPort).To(Equal(DefaultRegtestRPCPort))
+		Expect(node.Spec.BlockGeneration.Interval).To(Equal(DefaultBlockGenerationInterval))
+	})
+
+	It("Should default Bitcoin signet node", func() {
+		node := Node{
+			ObjectMeta: metav1.ObjectMeta{},
+			Spec: NodeSpec{
+				Network: Signet,
+			},
+		}
+
+		node.Default()
+
+		Expect(node.Spec.P2PPort).To(Equal(DefaultSignetP2PPort))
+		Expect(node.Spec.RPCPort).To(Equal(DefaultSignetRPCPort))
+	})
 })
@@ -1,6 +1,11 @@
 package v1alpha1
 
 import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/kotalco/kotal/apis/shared"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -9,10 +14,13 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
-// +kubebuilder:webhook:verbs=create;update,path=/validate-bitcoin-kotal-io-v1alpha1-node,mutating=false,failurePolicy=fail,groups=bitcoin.kotal.io,resources=nodes,versions=v1alpha1,name=validate-bitcoin-v1alpha1-node.kb.io,sideEffects=None,admissionReviewVersions=v1
+// +kubebuilder:webhook:verbs=create;update;delete,path=/validate-bitcoin-kotal-io-v1alpha1-node,mutating=false,failurePolicy=fail,groups=bitcoin.kotal.io,resources=nodes,versions=v1alpha1,name=validate-bitcoin-v1alpha1-node.kb.io,sideEffects=None,admissionReviewVersions=v1
 
 var _ webhook.Validator = &Node{}
 
+// rpcUsernameRegexp matches rpc usernames, they're used as secret data keys
+var rpcUsernameRegexp = regexp.MustCompile(`^[-._a-zA-Z0-9]+$`)
+
 // Validate is common create and update validation rules
 func (n *Node) validate() field.ErrorList {
 	var nodeErrors field.ErrorList
@@ -23,9 +31,174 @@ func (n *Node) validate() field.ErrorList {
 		nodeErrors = append(nodeErrors, err)
 	}
 
+	// compact block filters index requires all blocks
+	if n.Spec.BlockFilterIndex && n.Spec.Pruning {
+		err := field.Invalid(field.NewPath("spec").Child("pruning"), n.Spec.Pruning, "must be false if block filter index is enabled")
+		nodeErrors = append(nodeErrors, err)
+	}
+
+	// automatic pruning to target size
+	if n.Spec.PruneTargetMiB != 0 && (n.Spec.TransactionIndex || n.Spec.BlockFilterIndex) {
+		err := field.Invalid(field.NewPath("spec").Child("pruneTargetMiB"), n.Spec.PruneTargetMiB, "must be 0 if transaction index or block filter index is enabled")
+		nodeErrors = append(nodeErrors, err)
+	}
+
+	if n.Spec.PruneTargetMiB != 0 && n.Spec.Pruning {
+		err := field.Invalid(field.NewPath("spec").Child("pruning"), n.Spec.Pruning, "must be false if prune target is set")
+		nodeErrors = append(nodeErrors, err)
+	}
+
+	if n.Spec.AssumeUTXO != nil {
+		nodeErrors = append(nodeErrors, n.validateAssumeUTXO()...)
+	}
+
+	// REST interface is served by JSON-RPC server
+	if n.Spec.REST && !n.Spec.RPC {
+		err := field.Invalid(field.NewPath("spec").Child("rest"), n.Spec.REST, "must be false if rpc is disabled")
+		nodeErrors = append(nodeErrors, err)
+	}
+
+	nodeErrors = append(nodeErrors, n.validateZMQPorts()...)
+
+	usernames := map[string]bool{}
+	for i, user := range n.Spec.RPCUsers {
+		path := field.NewPath("spec").Child("rpcUsers").Index(i).Child("username")
+		if !rpcUsernameRegexp.MatchString(user.Username) {
+			err := field.Invalid(path, user.Username, "must consist of alphanumeric characters, '-', '_' or '.'")
+			nodeErrors = append(nodeErrors, err)
+		} else if usernames[user.Username] {
+			nodeErrors = append(nodeErrors, field.Duplicate(path, user.Username))
+		}
+		usernames[user.Username] = true
+	}
+
+	// metrics exporter queries the node using JSON-RPC
+	if n.Spec.Metrics.Enabled && !n.Spec.RPC {
+		err := field.Invalid(field.NewPath("spec").Child("metrics").Child("enabled"), n.Spec.Metrics.Enabled, "must be false if rpc is disabled")
+		nodeErrors = append(nodeErrors, err)
+	}
+
+	if n.Spec.Metrics.Enabled && len(n.Spec.RPCUsers) == 0 {
+		err := field.Invalid(field.NewPath("spec").Child("rpcUsers"), n.Spec.RPCUsers, "must have at least one user if metrics is enabled")
+		nodeErrors = append(nodeErrors, err)
+	}
+
+	// connection secret holds the first rpc user credentials
+	if n.Spec.ConnectionSecret && (!n.Spec.RPC || len(n.Spec.RPCUsers) == 0) {
+		err := field.Invalid(field.NewPath("spec").Child("connectionSecret"), n.Spec.ConnectionSecret, "must be false if rpc is disabled or there are no rpc users")
+		nodeErrors = append(nodeErrors, err)
+	}
+
+	if n.Spec.Network != Signet {
+		if n.Spec.SignetChallenge != "" {
+			err := field.Invalid(field.NewPath("spec").Child("signetChallenge"), n.Spec.SignetChallenge, "must be empty if network is not signet")
+			nodeErrors = append(nodeErrors, err)
+		}
+		if len(n.Spec.SignetSeedNodes) != 0 {
+			err := field.Invalid(field.NewPath("spec").Child("signetSeedNodes"), n.Spec.SignetSeedNodes, "must be empty if network is not signet")
+			nodeErrors = append(nodeErrors, err)
+		}
+	}
+
+	// custom signet seed nodes serve custom signet blocks
+	if len(n.Spec.SignetSeedNodes) != 0 && n.Spec.SignetChallenge == "" {
+		err := field.Invalid(field.NewPath("spec").Child("signetSeedNodes"), n.Spec.SignetSeedNodes, "must be empty if signet challenge is not set")
+		nodeErrors = append(nodeErrors, err)
+	}
+
+	if n.Spec.BlockGeneration != nil {
+		path := field.NewPath("spec").Child("blockGeneration")
+
+		if n.Spec.Network != Regtest {
+			err := field.Invalid(path, n.Spec.Network, "must be regtest network to generate blocks")
+			nodeErrors = append(nodeErrors, err)
+		}
+
+		// blocks are generated by calling node JSON-RPC server
+		if !n.Spec.RPC || len(n.Spec.RPCUsers) == 0 {
+			err := field.Invalid(path, n.Spec.RPCUsers, "must enable rpc and have at least one rpc user to generate blocks")
+			nodeErrors = append(nodeErrors, err)
+		}
+
+		if n.Spec.BlockGeneration.Address == "" {
+			err := field.Required(path.Child("address"), "must provide address to generate blocks to")
+			nodeErrors = append(nodeErrors, err)
+		}
+	}
+
 	return nodeErrors
 }
 
+// validateAssumeUTXO validates UTXO snapshot source
+func (n *Node) validateAssumeUTXO() (nodeErrors field.ErrorList) {
+	path := field.NewPath("spec").Child("assumeUTXO")
+	source := n.Spec.AssumeUTXO
+
+	sources := 0
+	for _, value := range []string{source.Path, source.URL, source.ConfigMapName} {
+		if value != "" {
+			sources++
+		}
+	}
+
+	if sources != 1 {
+		err := field.Invalid(path, sources, "must set exactly one of path, url or configMapName")
+		nodeErrors = append(nodeErrors, err)
+	}
+
+	if strings.HasPrefix(source.Path, "/") || strings.Contains(source.Path, "..") {
+		err := field.Invalid(path.Child("path"), source.Path, "must be relative path inside node data directory")
+		nodeErrors = append(nodeErrors, err)
+	}
+
+	// UTXO snapshot is loaded by calling node JSON-RPC server
+	if !n.Spec.RPC || len(n.Spec.RPCUsers) == 0 {
+		err := field.Invalid(path, n.Spec.RPCUsers, "must enable rpc and have at least one rpc user to load UTXO snapshot")
+		nodeErrors = append(nodeErrors, err)
+	}
+
+	return
+}
+
+// validateZMQPorts validates ZeroMQ notifications ports don't conflict with each other or node ports
+func (n *Node) validateZMQPorts() (nodeErrors field.ErrorList) {
+	path := field.NewPath("spec").Child("zmq")
+
+	used := map[uint]string{
+		n.Spec.P2PPort: "p2pPort",
+	}
+	if n.Spec.RPC {
+		used[n.Spec.RPCPort] = "rpcPort"
+	}
+	if n.Spec.Metrics.Enabled {
+		used[n.Spec.Metrics.Port] = "metrics.port"
+	}
+
+	zmqPorts := []struct {
+		name string
+		port uint
+	}{
+		{"rawBlockPort", n.Spec.ZMQ.RawBlockPort},
+		{"rawTxPort", n.Spec.ZMQ.RawTxPort},
+		{"hashBlockPort", n.Spec.ZMQ.HashBlockPort},
+		{"sequencePort", n.Spec.ZMQ.SequencePort},
+	}
+
+	for _, zmq := range zmqPorts {
+		if zmq.port == 0 {
+			continue
+		}
+		if name, found := used[zmq.port]; found {
+			err := field.Invalid(path.Child(zmq.name), zmq.port, fmt.Sprintf("must be different from %s", name))
+			nodeErrors = append(nodeErrors, err)
+			continue
+		}
+		used[zmq.port] = "zmq." + zmq.name
+	}
+
+	return
+}
+
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (r *Node) ValidateCreate() (admission.Warnings, error) {
 	var allErrors field.ErrorList
@@ -34,12 +207,17 @@ func (r *Node) ValidateCreate() (admission.Warnings, error) {
 
 	allErrors = append(allErrors, r.validate()...)
 	allErrors = append(allErrors, r.Spec.Resources.ValidateCreate()...)
+	allErrors = append(allErrors, r.Spec.Access.Validate()...)
+	allErrors = append(allErrors, r.Spec.PodExtras.Validate()...)
+	allErrors = append(allErrors, r.Spec.Deletion.Validate()...)
+
+	warnings := r.Spec.ExtraArgs.Warnings(r, nodeArgs)
 
 	if len(allErrors) == 0 {
-		return nil, nil
+		return warnings, nil
 	}
 
-	return nil, apierrors.NewInvalid(schema.GroupKind{}, r.Name, allErrors)
+	return warnings, apierrors.NewInvalid(schema.GroupKind{}, r.Name, allErrors)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
@@ -51,22 +229,28 @@ func (r *Node) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
 
 	allErrors = append(allErrors, r.validate()...)
 	allErrors = append(allErrors, r.Spec.Resources.ValidateUpdate(&oldNode.Spec.Resources)...)
+	allErrors = append(allErrors, r.Spec.Access.Validate()...)
+	allErrors = append(allErrors, r.Spec.PodExtras.Validate()...)
+	allErrors = append(allErrors, r.Spec.Deletion.Validate()...)
+	allErrors = append(allErrors, r.Spec.DataResync.ValidateUpdate(&oldNode.Spec.DataResync, false)...)
 
 	if r.Spec.Network != oldNode.Spec.Network {
 		err := field.Invalid(field.NewPath("spec").Child("network"), r.Spec.Network, "field is immutable")
 		allErrors = append(allErrors, err)
 	}
 
+	warnings := r.Spec.ExtraArgs.Warnings(r, nodeArgs)
+
 	if len(allErrors) == 0 {
-		return nil, nil
+		return warnings, nil
 	}
 
-	return nil, apierrors.NewInvalid(schema.GroupKind{}, r.Name, allErrors)
+	return warnings, apierrors.NewInvalid(schema.GroupKind{}, r.Name, allErrors)
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
 func (r *Node) ValidateDelete() (admission.Warnings, error) {
 	nodelog.Info("validate delete", "name", r.Name)
 
-	return nil, nil
+	return nil, shared.ValidateDeletionProtection(r)
 }
@@ -39,6 +39,365 @@ var _ = Describe("Bitcoin node validation", func() {
 				},
 			},
 		},
+		{
+			Title: "metrics without rpc",
+			Node: &Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-node",
+				},
+				Spec: NodeSpec{
+					Network: "mainnet",
+					Metrics: shared.Metrics{
+						Enabled: true,
+					},
+					RPCUsers: []RPCUser{
+						{
+							Username:           "kotal",
+							PasswordSecretName: shared.SecretKeySelector{Name: "kotal-password"},
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.metrics.enabled",
+					BadValue: true,
+					Detail:   "must be false if rpc is disabled",
+				},
+			},
+		},
+		{
+			Title: "invalid and duplicate rpc usernames",
+			Node: &Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-node",
+				},
+				Spec: NodeSpec{
+					Network: "mainnet",
+					RPC:     true,
+					RPCUsers: []RPCUser{
+						{
+							Username:           "kotal",
+							PasswordSecretName: shared.SecretKeySelector{Name: "kotal-password"},
+						},
+						{
+							Username:           "kotal:admin",
+							PasswordSecretName: shared.SecretKeySelector{Name: "admin-password"},
+						},
+						{
+							Username:           "kotal",
+							PasswordSecretName: shared.SecretKeySelector{Name: "kotal-password"},
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.rpcUsers[1].username",
+					BadValue: "kotal:admin",
+					Detail:   "must consist of alphanumeric characters, '-', '_' or '.'",
+				},
+				{
+					Type:     field.ErrorTypeDuplicate,
+					Field:    "spec.rpcUsers[2].username",
+					BadValue: "kotal",
+				},
+			},
+		},
+		{
+			Title: "block filter index with pruning",
+			Node: &Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-node",
+				},
+				Spec: NodeSpec{
+					Network:          "mainnet",
+					Pruning:          true,
+					BlockFilterIndex: true,
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.pruning",
+					BadValue: true,
+					Detail:   "must be false if block filter index is enabled",
+				},
+			},
+		},
+		{
+			Title: "rest without rpc",
+			Node: &Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-node",
+				},
+				Spec: NodeSpec{
+					Network: "mainnet",
+					REST:    true,
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.rest",
+					BadValue: true,
+					Detail:   "must be false if rpc is disabled",
+				},
+			},
+		},
+		{
+			Title: "conflicting zmq ports",
+			Node: &Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-node",
+				},
+				Spec: NodeSpec{
+					Network: "mainnet",
+					RPC:     true,
+					ZMQ: ZMQ{
+						RawBlockPort: 28332,
+						RawTxPort:    28332,
+						SequencePort: 8332,
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.zmq.rawTxPort",
+					BadValue: uint(28332),
+					Detail:   "must be different from zmq.rawBlockPort",
+				},
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.zmq.sequencePort",
+					BadValue: uint(8332),
+					Detail:   "must be different from rpcPort",
+				},
+			},
+		},
+		{
+			Title: "prune target with transaction index",
+			Node: &Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-node",
+				},
+				Spec: NodeSpec{
+					Network:          "mainnet",
+					PruneTargetMiB:   1024,
+					TransactionIndex: true,
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.pruneTargetMiB",
+					BadValue: uint(1024),
+					Detail:   "must be 0 if transaction index or block filter index is enabled",
+				},
+			},
+		},
+		{
+			Title: "assume utxo with multiple sources",
+			Node: &Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-node",
+				},
+				Spec: NodeSpec{
+					Network: "mainnet",
+					RPC:     true,
+					RPCUsers: []RPCUser{
+						{
+							Username:           "kotal",
+							PasswordSecretName: shared.SecretKeySelector{Name: "kotal-password"},
+						},
+					},
+					AssumeUTXO: &AssumeUTXO{
+						Path: "utxo-840000.dat",
+						URL:  "https://snapshots.example/utxo-840000.dat",
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.assumeUTXO",
+					BadValue: 2,
+					Detail:   "must set exactly one of path, url or configMapName",
+				},
+			},
+		},
+		{
+			Title: "assume utxo path outside data directory",
+			Node: &Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-node",
+				},
+				Spec: NodeSpec{
+					Network: "mainnet",
+					RPC:     true,
+					RPCUsers: []RPCUser{
+						{
+							Username:           "kotal",
+							PasswordSecretName: shared.SecretKeySelector{Name: "kotal-password"},
+						},
+					},
+					AssumeUTXO: &AssumeUTXO{
+						Path: "../utxo-840000.dat",
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.assumeUTXO.path",
+					BadValue: "../utxo-840000.dat",
+					Detail:   "must be relative path inside node data directory",
+				},
+			},
+		},
+		{
+			Title: "assume utxo without rpc",
+			Node: &Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-node",
+				},
+				Spec: NodeSpec{
+					Network: "mainnet",
+					AssumeUTXO: &AssumeUTXO{
+						URL: "https://snapshots.example/utxo-840000.dat",
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.assumeUTXO",
+					BadValue: []RPCUser(nil),
+					Detail:   "must enable rpc and have at least one rpc user to load UTXO snapshot",
+				},
+			},
+		},
+		{
+			Title: "connection secret without rpc",
+			Node: &Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-node",
+				},
+				Spec: NodeSpec{
+					Network:          "mainnet",
+					ConnectionSecret: true,
+					RPCUsers: []RPCUser{
+						{
+							Username:           "kotal",
+							PasswordSecretName: shared.SecretKeySelector{Name: "kotal-password"},
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.connectionSecret",
+					BadValue: true,
+					Detail:   "must be false if rpc is disabled or there are no rpc users",
+				},
+			},
+		},
+		{
+			Title: "signet challenge on mainnet",
+			Node: &Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-node",
+				},
+				Spec: NodeSpec{
+					Network:         "mainnet",
+					SignetChallenge: "51",
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.signetChallenge",
+					BadValue: "51",
+					Detail:   "must be empty if network is not signet",
+				},
+			},
+		},
+		{
+			Title: "signet seed nodes without signet challenge",
+			Node: &Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-node",
+				},
+				Spec: NodeSpec{
+					Network:         "signet",
+					SignetSeedNodes: []string{"seed.signet.example:38333"},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.signetSeedNodes",
+					BadValue: []string{"seed.signet.example:38333"},
+					Detail:   "must be empty if signet challenge is not set",
+				},
+			},
+		},
+		{
+			Title: "block generation on testnet",
+			Node: &Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-node",
+				},
+				Spec: NodeSpec{
+					Network: "testnet",
+					RPC:     true,
+					RPCUsers: []RPCUser{
+						{
+							Username:           "kotal",
+							PasswordSecretName: shared.SecretKeySelector{Name: "kotal-password"},
+						},
+					},
+					BlockGeneration: &BlockGeneration{
+						Address: "bcrt1qjxs5y6zvmq5d5r9p0mnvlhcd8sf2ajkgyfuw0z",
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.blockGeneration",
+					BadValue: Testnet,
+					Detail:   "must be regtest network to generate blocks",
+				},
+			},
+		},
+		{
+			Title: "block generation without rpc users",
+			Node: &Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-node",
+				},
+				Spec: NodeSpec{
+					Network: "regtest",
+					RPC:     true,
+					BlockGeneration: &BlockGeneration{
+						Address: "bcrt1qjxs5y6zvmq5d5r9p0mnvlhcd8sf2ajkgyfuw0z",
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.blockGeneration",
+					BadValue: []RPCUser(nil),
+					Detail:   "must enable rpc and have at least one rpc user to generate blocks",
+				},
+			},
+		},
 	}
 
 	updateCases := []struct {
@@ -7,16 +7,294 @@ package v1alpha1
 
 import (
 	"github.com/kotalco/kotal/apis/shared"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AssumeUTXO) DeepCopyInto(out *AssumeUTXO) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AssumeUTXO.
+func (in *AssumeUTXO) DeepCopy() *AssumeUTXO {
+	if in == nil {
+		return nil
+	}
+	out := new(AssumeUTXO)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AssumeUTXOStatus) DeepCopyInto(out *AssumeUTXOStatus) {
+	*out = *in
+	if in.LoadTime != nil {
+		in, out := &in.LoadTime, &out.LoadTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AssumeUTXOStatus.
+func (in *AssumeUTXOStatus) DeepCopy() *AssumeUTXOStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AssumeUTXOStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlockGeneration) DeepCopyInto(out *BlockGeneration) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlockGeneration.
+func (in *BlockGeneration) DeepCopy() *BlockGeneration {
+	if in == nil {
+		return nil
+	}
+	out := new(BlockGeneration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElectrumServer) DeepCopyInto(out *ElectrumServer) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElectrumServer.
+func (in *ElectrumServer) DeepCopy() *ElectrumServer {
+	if in == nil {
+		return nil
+	}
+	out := new(ElectrumServer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElectrumServer) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElectrumServerList) DeepCopyInto(out *ElectrumServerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ElectrumServer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElectrumServerList.
+func (in *ElectrumServerList) DeepCopy() *ElectrumServerList {
+	if in == nil {
+		return nil
+	}
+	out := new(ElectrumServerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElectrumServerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElectrumServerSpec) DeepCopyInto(out *ElectrumServerSpec) {
+	*out = *in
+	if in.ExtraArgs != nil {
+		in, out := &in.ExtraArgs, &out.ExtraArgs
+		*out = make(shared.ExtraArgs, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.RemoveArgs != nil {
+		in, out := &in.RemoveArgs, &out.RemoveArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(uint)
+		**out = **in
+	}
+	in.Access.DeepCopyInto(&out.Access)
+	in.Disruption.DeepCopyInto(&out.Disruption)
+	in.Shutdown.DeepCopyInto(&out.Shutdown)
+	out.DataResync = in.DataResync
+	out.Deletion = in.Deletion
+	in.PodExtras.DeepCopyInto(&out.PodExtras)
+	in.Resources.DeepCopyInto(&out.Resources)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElectrumServerSpec.
+func (in *ElectrumServerSpec) DeepCopy() *ElectrumServerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElectrumServerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElectrumServerStatus) DeepCopyInto(out *ElectrumServerStatus) {
+	*out = *in
+	in.ResyncStatus.DeepCopyInto(&out.ResyncStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElectrumServerStatus.
+func (in *ElectrumServerStatus) DeepCopy() *ElectrumServerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ElectrumServerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LightningNode) DeepCopyInto(out *LightningNode) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LightningNode.
+func (in *LightningNode) DeepCopy() *LightningNode {
+	if in == nil {
+		return nil
+	}
+	out := new(LightningNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LightningNode) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LightningNodeList) DeepCopyInto(out *LightningNodeList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]LightningNode, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LightningNodeList.
+func (in *LightningNodeList) DeepCopy() *LightningNodeList {
+	if in == nil {
+		return nil
+	}
+	out := new(LightningNodeList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LightningNodeList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LightningNodeSpec) DeepCopyInto(out *LightningNodeSpec) {
+	*out = *in
+	if in.ExtraArgs != nil {
+		in, out := &in.ExtraArgs, &out.ExtraArgs
+		*out = make(shared.ExtraArgs, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.RemoveArgs != nil {
+		in, out := &in.RemoveArgs, &out.RemoveArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(uint)
+		**out = **in
+	}
+	in.Access.DeepCopyInto(&out.Access)
+	in.Disruption.DeepCopyInto(&out.Disruption)
+	in.Shutdown.DeepCopyInto(&out.Shutdown)
+	out.DataResync = in.DataResync
+	out.Deletion = in.Deletion
+	in.PodExtras.DeepCopyInto(&out.PodExtras)
+	in.Resources.DeepCopyInto(&out.Resources)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LightningNodeSpec.
+func (in *LightningNodeSpec) DeepCopy() *LightningNodeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LightningNodeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LightningNodeStatus) DeepCopyInto(out *LightningNodeStatus) {
+	*out = *in
+	in.ResyncStatus.DeepCopyInto(&out.ResyncStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LightningNodeStatus.
+func (in *LightningNodeStatus) DeepCopy() *LightningNodeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LightningNodeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Node) DeepCopyInto(out *Node) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Node.
@@ -79,11 +357,26 @@ func (in *NodeSpec) DeepCopyInto(out *NodeSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.RemoveArgs != nil {
+		in, out := &in.RemoveArgs, &out.RemoveArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Replicas != nil {
 		in, out := &in.Replicas, &out.Replicas
 		*out = new(uint)
 		**out = **in
 	}
+	if in.SignetSeedNodes != nil {
+		in, out := &in.SignetSeedNodes, &out.SignetSeedNodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.BlockGeneration != nil {
+		in, out := &in.BlockGeneration, &out.BlockGeneration
+		*out = new(BlockGeneration)
+		**out = **in
+	}
 	if in.Listen != nil {
 		in, out := &in.Listen, &out.Listen
 		*out = new(bool)
@@ -104,6 +397,19 @@ func (in *NodeSpec) DeepCopyInto(out *NodeSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	out.ZMQ = in.ZMQ
+	if in.AssumeUTXO != nil {
+		in, out := &in.AssumeUTXO, &out.AssumeUTXO
+		*out = new(AssumeUTXO)
+		**out = **in
+	}
+	in.Access.DeepCopyInto(&out.Access)
+	in.Disruption.DeepCopyInto(&out.Disruption)
+	out.Metrics = in.Metrics
+	in.Shutdown.DeepCopyInto(&out.Shutdown)
+	out.DataResync = in.DataResync
+	out.Deletion = in.Deletion
+	in.PodExtras.DeepCopyInto(&out.PodExtras)
 	in.Resources.DeepCopyInto(&out.Resources)
 }
 
@@ -120,6 +426,23 @@ func (in *NodeSpec) DeepCopy() *NodeSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeStatus) DeepCopyInto(out *NodeStatus) {
 	*out = *in
+	in.ResyncStatus.DeepCopyInto(&out.ResyncStatus)
+	if in.AssumeUTXO != nil {
+		in, out := &in.AssumeUTXO, &out.AssumeUTXO
+		*out = new(AssumeUTXOStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastBlockGenerationTime != nil {
+		in, out := &in.LastBlockGenerationTime, &out.LastBlockGenerationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeStatus.
@@ -135,6 +458,7 @@ func (in *NodeStatus) DeepCopy() *NodeStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RPCUser) DeepCopyInto(out *RPCUser) {
 	*out = *in
+	out.PasswordSecretName = in.PasswordSecretName
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RPCUser.
@@ -146,3 +470,18 @@ func (in *RPCUser) DeepCopy() *RPCUser {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZMQ) DeepCopyInto(out *ZMQ) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZMQ.
+func (in *ZMQ) DeepCopy() *ZMQ {
+	if in == nil {
+		return nil
+	}
+	out := new(ZMQ)
+	in.DeepCopyInto(out)
+	return out
+}
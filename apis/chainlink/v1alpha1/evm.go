@@ -0,0 +1,69 @@
+package v1alpha1
+
+import "strings"
+
+// GasEstimatorMode is the algorithm used to estimate gas price
+// +kubebuilder:validation:Enum=BlockHistory;FixedPrice;L2Suggested;SuggestedPrice
+type GasEstimatorMode string
+
+const (
+	// BlockHistoryGasEstimator estimates gas price from recent blocks
+	BlockHistoryGasEstimator GasEstimatorMode = "BlockHistory"
+	// FixedPriceGasEstimator uses fixed default gas price
+	FixedPriceGasEstimator GasEstimatorMode = "FixedPrice"
+	// L2SuggestedGasEstimator uses gas price suggested by L2 node
+	L2SuggestedGasEstimator GasEstimatorMode = "L2Suggested"
+	// SuggestedPriceGasEstimator uses gas price suggested by node
+	SuggestedPriceGasEstimator GasEstimatorMode = "SuggestedPrice"
+)
+
+// EVMNode is EVM chain node the Chainlink node connects to
+type EVMNode struct {
+	// Name is node name, unique within the chain
+	Name string `json:"name"`
+	// WSURL is node websocket endpoint, it's required for primary nodes
+	WSURL string `json:"wsURL,omitempty"`
+	// HTTPURL is node http endpoint
+	HTTPURL string `json:"httpURL"`
+	// SendOnly limits the node to sending transactions
+	SendOnly bool `json:"sendOnly,omitempty"`
+}
+
+// EVMChain is EVM chain served by the Chainlink node
+type EVMChain struct {
+	// ChainID is EVM chain id
+	// +kubebuilder:validation:Minimum=1
+	ChainID uint `json:"chainId"`
+	// LinkContractAddress is link contract address on the chain
+	LinkContractAddress string `json:"linkContractAddress"`
+	// Nodes is chain nodes
+	// +kubebuilder:validation:MinItems=1
+	Nodes []EVMNode `json:"nodes"`
+	// FinalityDepth is number of blocks after which a block is considered final
+	FinalityDepth uint `json:"finalityDepth,omitempty"`
+	// GasEstimatorMode is the algorithm used to estimate gas price
+	GasEstimatorMode GasEstimatorMode `json:"gasEstimatorMode,omitempty"`
+}
+
+// Chains returns EVM chains served by the node
+// ethereumChainId, ethereumWsEndpoint, ethereumHttpEndpoints and linkContractAddress
+// are a shorthand for a single chain, listed before evmChains
+func (s *NodeSpec) Chains() []EVMChain {
+	chains := []EVMChain{}
+
+	if s.EthereumChainId != 0 {
+		chains = append(chains, EVMChain{
+			ChainID:             s.EthereumChainId,
+			LinkContractAddress: s.LinkContractAddress,
+			Nodes: []EVMNode{
+				{
+					Name:    "node",
+					WSURL:   s.EthereumWSEndpoint,
+					HTTPURL: strings.Join(s.EthereumHTTPEndpoints, ","),
+				},
+			},
+		})
+	}
+
+	return append(chains, s.EVMChains...)
+}
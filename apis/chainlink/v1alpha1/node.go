@@ -10,7 +10,7 @@ type APICredentials struct {
 	// Email is user email
 	Email string `json:"email"`
 	// PasswordSecretName is the k8s secret name that holds password
-	PasswordSecretName string `json:"passwordSecretName"`
+	PasswordSecretName shared.SecretKeySelector `json:"passwordSecretName"`
 }
 
 // NodeSpec defines the desired state of Node
@@ -19,22 +19,28 @@ type NodeSpec struct {
 	Image string `json:"image,omitempty"`
 	// ExtraArgs is extra arguments to pass down to the cli
 	ExtraArgs shared.ExtraArgs `json:"extraArgs,omitempty"`
+	// RemoveArgs is names of generated cli arguments to remove
+	// +listType=set
+	RemoveArgs []string `json:"removeArgs,omitempty"`
 	// Replicas is number of replicas
-	// +kubebuilder:validation:Enum=0;1
+	// +kubebuilder:validation:Minimum=0
 	Replicas *uint `json:"replicas,omitempty"`
 	// EthereumChainId is ethereum chain id
-	EthereumChainId uint `json:"ethereumChainId"`
+	// it's a shorthand for a single EVM chain, use evmChains for multiple chains
+	EthereumChainId uint `json:"ethereumChainId,omitempty"`
 	// EthereumWSEndpoint is ethereum websocket endpoint
-	EthereumWSEndpoint string `json:"ethereumWsEndpoint"`
+	EthereumWSEndpoint string `json:"ethereumWsEndpoint,omitempty"`
 	// EthereumHTTPEndpoints is ethereum http endpoints
 	// +listType=set
 	EthereumHTTPEndpoints []string `json:"ethereumHttpEndpoints,omitempty"`
 	// LinkContractAddress is link contract address
-	LinkContractAddress string `json:"linkContractAddress"`
+	LinkContractAddress string `json:"linkContractAddress,omitempty"`
+	// EVMChains is EVM chains served by the node
+	EVMChains []EVMChain `json:"evmChains,omitempty"`
 	// DatabaseURL is postgres database connection URL
 	DatabaseURL string `json:"databaseURL"`
 	// KeystorePasswordSecretName is k8s secret name that holds keystore password
-	KeystorePasswordSecretName string `json:"keystorePasswordSecretName"`
+	KeystorePasswordSecretName shared.SecretKeySelector `json:"keystorePasswordSecretName"`
 	// APICredentials is api credentials
 	APICredentials APICredentials `json:"apiCredentials"`
 	// CORSDomains is the domains from which to accept cross origin requests
@@ -55,6 +61,22 @@ type NodeSpec struct {
 	// Logging is logging verboisty level
 	// +kubebuilder:validation:Enum=debug;info;warn;error;panic
 	Logging shared.VerbosityLevel `json:"logging,omitempty"`
+	// Access is network access settings of node endpoints
+	Access shared.Access `json:"access,omitempty"`
+	// Disruption is pod disruption budget settings
+	Disruption shared.Disruption `json:"disruption,omitempty"`
+	// Metrics is Prometheus metrics settings
+	Metrics shared.Metrics `json:"metrics,omitempty"`
+	// Shutdown is graceful shutdown settings
+	Shutdown shared.Shutdown `json:"shutdown,omitempty"`
+	// Suspend scales node to zero replicas, keeping its data, services and secrets
+	Suspend bool `json:"suspend,omitempty"`
+	// DataResync is node data resync settings
+	shared.DataResync `json:",inline"`
+	// Deletion is deletion settings of node data and generated secrets
+	shared.Deletion `json:",inline"`
+	// PodExtras is extra environment variables, volumes and containers injected into node pods
+	shared.PodExtras `json:",inline"`
 	// Resources is node compute and storage resources
 	shared.Resources `json:"resources,omitempty"`
 }
@@ -62,6 +84,10 @@ type NodeSpec struct {
 // NodeStatus defines the observed state of Node
 type NodeStatus struct {
 	Client string `json:"client,omitempty"`
+	// Mode is reconciliation mode
+	Mode shared.Mode `json:"mode,omitempty"`
+	// ResyncStatus is node data resync status
+	shared.ResyncStatus `json:",inline"`
 }
 
 // +kubebuilder:object:root=true
@@ -71,6 +97,7 @@ type NodeStatus struct {
 // +kubebuilder:printcolumn:name="Client",type=string,JSONPath=".status.client"
 // +kubebuilder:printcolumn:name="EthereumChainId",type=number,JSONPath=".spec.ethereumChainId"
 // +kubebuilder:printcolumn:name="LinkContractAddress",type=string,JSONPath=".spec.linkContractAddress",priority=10
+// +kubebuilder:printcolumn:name="Mode",type=string,JSONPath=".status.mode"
 type Node struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
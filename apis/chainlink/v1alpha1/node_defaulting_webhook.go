@@ -63,4 +63,6 @@ func (r *Node) Default() {
 		r.Spec.CORSDomains = DefaultCorsDomains
 	}
 
+	r.Spec.Disruption.Default()
+
 }
@@ -3,6 +3,7 @@ package v1alpha1
 import (
 	"fmt"
 
+	"github.com/kotalco/kotal/apis/shared"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -11,23 +12,106 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
-// +kubebuilder:webhook:verbs=create;update,path=/validate-chainlink-kotal-io-v1alpha1-node,mutating=false,failurePolicy=fail,groups=chainlink.kotal.io,resources=nodes,versions=v1alpha1,name=validate-chainlink-v1alpha1-node.kb.io,sideEffects=None,admissionReviewVersions=v1
+// +kubebuilder:webhook:verbs=create;update;delete,path=/validate-chainlink-kotal-io-v1alpha1-node,mutating=false,failurePolicy=fail,groups=chainlink.kotal.io,resources=nodes,versions=v1alpha1,name=validate-chainlink-v1alpha1-node.kb.io,sideEffects=None,admissionReviewVersions=v1
 
 var _ webhook.Validator = &Node{}
 
+// validate is common create and update validation rules
+func (r *Node) validate() field.ErrorList {
+	var nodeErrors field.ErrorList
+
+	// metrics are served by the API server
+	if r.Spec.Metrics.Enabled && !r.Spec.API {
+		err := field.Invalid(field.NewPath("spec").Child("metrics").Child("enabled"), r.Spec.Metrics.Enabled, "must be false if api is disabled")
+		nodeErrors = append(nodeErrors, err)
+	}
+
+	nodeErrors = append(nodeErrors, r.validateEVMChains()...)
+
+	return nodeErrors
+}
+
+// validateEVMChains validates EVM chains and their nodes
+func (r *Node) validateEVMChains() field.ErrorList {
+	var nodeErrors field.ErrorList
+
+	spec := field.NewPath("spec")
+
+	if r.Spec.EthereumChainId == 0 && len(r.Spec.EVMChains) == 0 {
+		err := field.Required(spec.Child("evmChains"), "must specify evmChains or ethereumChainId")
+		nodeErrors = append(nodeErrors, err)
+	}
+
+	// shorthand single chain
+	if r.Spec.EthereumChainId != 0 {
+		if r.Spec.EthereumWSEndpoint == "" {
+			nodeErrors = append(nodeErrors, field.Required(spec.Child("ethereumWsEndpoint"), "must be provided if ethereumChainId is provided"))
+		}
+		if r.Spec.LinkContractAddress == "" {
+			nodeErrors = append(nodeErrors, field.Required(spec.Child("linkContractAddress"), "must be provided if ethereumChainId is provided"))
+		}
+	}
+
+	chainIDs := map[uint]bool{}
+	if r.Spec.EthereumChainId != 0 {
+		chainIDs[r.Spec.EthereumChainId] = true
+	}
+
+	for i, chain := range r.Spec.EVMChains {
+		path := spec.Child("evmChains").Index(i)
+
+		if chainIDs[chain.ChainID] {
+			nodeErrors = append(nodeErrors, field.Duplicate(path.Child("chainId"), chain.ChainID))
+		}
+		chainIDs[chain.ChainID] = true
+
+		names := map[string]bool{}
+		primary := false
+		for j, node := range chain.Nodes {
+			nodePath := path.Child("nodes").Index(j)
+
+			if names[node.Name] {
+				nodeErrors = append(nodeErrors, field.Duplicate(nodePath.Child("name"), node.Name))
+			}
+			names[node.Name] = true
+
+			if node.SendOnly {
+				continue
+			}
+
+			primary = true
+			if node.WSURL == "" {
+				nodeErrors = append(nodeErrors, field.Required(nodePath.Child("wsURL"), "must be provided for primary nodes"))
+			}
+		}
+
+		if !primary {
+			nodeErrors = append(nodeErrors, field.Required(path.Child("nodes"), "at least one primary node is required"))
+		}
+	}
+
+	return nodeErrors
+}
+
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (r *Node) ValidateCreate() (admission.Warnings, error) {
 	var allErrors field.ErrorList
 
 	nodelog.Info("validate create", "name", r.Name)
 
+	allErrors = append(allErrors, r.validate()...)
 	allErrors = append(allErrors, r.Spec.Resources.ValidateCreate()...)
+	allErrors = append(allErrors, r.Spec.Access.Validate()...)
+	allErrors = append(allErrors, r.Spec.PodExtras.Validate()...)
+	allErrors = append(allErrors, r.Spec.Deletion.Validate()...)
+
+	warnings := r.Spec.ExtraArgs.Warnings(r, nodeArgs)
 
 	if len(allErrors) == 0 {
-		return nil, nil
+		return warnings, nil
 	}
 
-	return nil, apierrors.NewInvalid(schema.GroupKind{}, r.Name, allErrors)
+	return warnings, apierrors.NewInvalid(schema.GroupKind{}, r.Name, allErrors)
 
 }
 
@@ -38,27 +122,47 @@ func (r *Node) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
 
 	nodelog.Info("validate update", "name", r.Name)
 
+	allErrors = append(allErrors, r.validate()...)
 	allErrors = append(allErrors, r.Spec.Resources.ValidateUpdate(&oldNode.Spec.Resources)...)
+	allErrors = append(allErrors, r.Spec.Access.Validate()...)
+	allErrors = append(allErrors, r.Spec.PodExtras.Validate()...)
+	allErrors = append(allErrors, r.Spec.Deletion.Validate()...)
+	allErrors = append(allErrors, r.Spec.DataResync.ValidateUpdate(&oldNode.Spec.DataResync, false)...)
 
-	if oldNode.Spec.EthereumChainId != r.Spec.EthereumChainId {
+	// shorthand chain can be cleared, to be moved into evmChains
+	if r.Spec.EthereumChainId != 0 && oldNode.Spec.EthereumChainId != r.Spec.EthereumChainId {
 		err := field.Invalid(field.NewPath("spec").Child("ethereumChainId"), fmt.Sprintf("%d", r.Spec.EthereumChainId), "field is immutable")
 		allErrors = append(allErrors, err)
 	}
 
-	if oldNode.Spec.LinkContractAddress != r.Spec.LinkContractAddress {
+	if r.Spec.LinkContractAddress != "" && oldNode.Spec.LinkContractAddress != r.Spec.LinkContractAddress {
 		err := field.Invalid(field.NewPath("spec").Child("linkContractAddress"), r.Spec.LinkContractAddress, "field is immutable")
 		allErrors = append(allErrors, err)
 	}
 
+	// LINK contract address of a chain can't be changed, chains can be added or removed
+	oldLinkContracts := map[uint]string{}
+	for _, chain := range oldNode.Spec.Chains() {
+		oldLinkContracts[chain.ChainID] = chain.LinkContractAddress
+	}
+	for i, chain := range r.Spec.EVMChains {
+		if address, ok := oldLinkContracts[chain.ChainID]; ok && address != chain.LinkContractAddress {
+			err := field.Invalid(field.NewPath("spec").Child("evmChains").Index(i).Child("linkContractAddress"), chain.LinkContractAddress, "field is immutable")
+			allErrors = append(allErrors, err)
+		}
+	}
+
+	warnings := r.Spec.ExtraArgs.Warnings(r, nodeArgs)
+
 	if len(allErrors) == 0 {
-		return nil, nil
+		return warnings, nil
 	}
 
-	return nil, apierrors.NewInvalid(schema.GroupKind{}, r.Name, allErrors)
+	return warnings, apierrors.NewInvalid(schema.GroupKind{}, r.Name, allErrors)
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
 func (r *Node) ValidateDelete() (admission.Warnings, error) {
 	nodelog.Info("validate delete", "name", r.Name)
-	return nil, nil
+	return nil, shared.ValidateDeletionProtection(r)
 }
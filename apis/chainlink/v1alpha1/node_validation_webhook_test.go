@@ -16,7 +16,133 @@ var _ = Describe("Chainlink node validation", func() {
 		Title  string
 		Node   *Node
 		Errors field.ErrorList
-	}{}
+	}{
+		{
+			Title: "node without evm chains",
+			Node: &Node{
+				ObjectMeta: v1.ObjectMeta{
+					Name: "my-node",
+				},
+				Spec: NodeSpec{},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeRequired,
+					Field:    "spec.evmChains",
+					BadValue: "",
+					Detail:   "must specify evmChains or ethereumChainId",
+				},
+			},
+		},
+		{
+			Title: "node with duplicate chain ids",
+			Node: &Node{
+				ObjectMeta: v1.ObjectMeta{
+					Name: "my-node",
+				},
+				Spec: NodeSpec{
+					EthereumChainId:     1,
+					EthereumWSEndpoint:  "wss://my-eth-node:8546",
+					LinkContractAddress: "0x514910771af9ca656af840dff83e8264ecf986ca",
+					EVMChains: []EVMChain{
+						{
+							ChainID:             137,
+							LinkContractAddress: "0xb0897686c545045afc77cf20ec7a532e3120e0f1",
+							Nodes: []EVMNode{
+								{Name: "polygon", WSURL: "wss://my-polygon-node:8546", HTTPURL: "https://my-polygon-node:8545"},
+							},
+						},
+						{
+							ChainID:             1,
+							LinkContractAddress: "0x514910771af9ca656af840dff83e8264ecf986ca",
+							Nodes: []EVMNode{
+								{Name: "ethereum", WSURL: "wss://my-eth-node:8546", HTTPURL: "https://my-eth-node:8545"},
+							},
+						},
+						{
+							ChainID:             137,
+							LinkContractAddress: "0xb0897686c545045afc77cf20ec7a532e3120e0f1",
+							Nodes: []EVMNode{
+								{Name: "polygon", WSURL: "wss://my-polygon-node:8546", HTTPURL: "https://my-polygon-node:8545"},
+							},
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeDuplicate,
+					Field:    "spec.evmChains[1].chainId",
+					BadValue: uint(1),
+				},
+				{
+					Type:     field.ErrorTypeDuplicate,
+					Field:    "spec.evmChains[2].chainId",
+					BadValue: uint(137),
+				},
+			},
+		},
+		{
+			Title: "chain with invalid nodes",
+			Node: &Node{
+				ObjectMeta: v1.ObjectMeta{
+					Name: "my-node",
+				},
+				Spec: NodeSpec{
+					EVMChains: []EVMChain{
+						{
+							ChainID:             137,
+							LinkContractAddress: "0xb0897686c545045afc77cf20ec7a532e3120e0f1",
+							Nodes: []EVMNode{
+								{Name: "polygon", HTTPURL: "https://my-polygon-node:8545", SendOnly: true},
+								{Name: "polygon", HTTPURL: "https://my-polygon-node2:8545", SendOnly: true},
+							},
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeDuplicate,
+					Field:    "spec.evmChains[0].nodes[1].name",
+					BadValue: "polygon",
+				},
+				{
+					Type:     field.ErrorTypeRequired,
+					Field:    "spec.evmChains[0].nodes",
+					BadValue: "",
+					Detail:   "at least one primary node is required",
+				},
+			},
+		},
+		{
+			Title: "primary node without websocket endpoint",
+			Node: &Node{
+				ObjectMeta: v1.ObjectMeta{
+					Name: "my-node",
+				},
+				Spec: NodeSpec{
+					EVMChains: []EVMChain{
+						{
+							ChainID:             137,
+							LinkContractAddress: "0xb0897686c545045afc77cf20ec7a532e3120e0f1",
+							Nodes: []EVMNode{
+								{Name: "polygon", HTTPURL: "https://my-polygon-node:8545"},
+							},
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeRequired,
+					Field:    "spec.evmChains[0].nodes[0].wsURL",
+					BadValue: "",
+					Detail:   "must be provided for primary nodes",
+				},
+			},
+		},
+	}
 
 	updateCases := []struct {
 		Title   string
@@ -78,6 +204,49 @@ var _ = Describe("Chainlink node validation", func() {
 				},
 			},
 		},
+		{
+			Title: "updating evm chain LINK contract address",
+			OldNode: &Node{
+				ObjectMeta: v1.ObjectMeta{
+					Name: "my-node",
+				},
+				Spec: NodeSpec{
+					EVMChains: []EVMChain{
+						{
+							ChainID:             137,
+							LinkContractAddress: "0xb0897686c545045afc77cf20ec7a532e3120e0f1",
+							Nodes: []EVMNode{
+								{Name: "polygon", WSURL: "wss://my-polygon-node:8546", HTTPURL: "https://my-polygon-node:8545"},
+							},
+						},
+					},
+				},
+			},
+			NewNode: &Node{
+				ObjectMeta: v1.ObjectMeta{
+					Name: "my-node",
+				},
+				Spec: NodeSpec{
+					EVMChains: []EVMChain{
+						{
+							ChainID:             137,
+							LinkContractAddress: "0x326c977e6efc84e512bb9c30f76e30c160ed06fb",
+							Nodes: []EVMNode{
+								{Name: "polygon", WSURL: "wss://my-polygon-node:8546", HTTPURL: "https://my-polygon-node:8545"},
+							},
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.evmChains[0].linkContractAddress",
+					BadValue: "0x326c977e6efc84e512bb9c30f76e30c160ed06fb",
+					Detail:   "field is immutable",
+				},
+			},
+		},
 	}
 
 	Context("While creating node", func() {
@@ -13,6 +13,7 @@ import (
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *APICredentials) DeepCopyInto(out *APICredentials) {
 	*out = *in
+	out.PasswordSecretName = in.PasswordSecretName
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APICredentials.
@@ -25,13 +26,48 @@ func (in *APICredentials) DeepCopy() *APICredentials {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EVMChain) DeepCopyInto(out *EVMChain) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]EVMNode, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EVMChain.
+func (in *EVMChain) DeepCopy() *EVMChain {
+	if in == nil {
+		return nil
+	}
+	out := new(EVMChain)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EVMNode) DeepCopyInto(out *EVMNode) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EVMNode.
+func (in *EVMNode) DeepCopy() *EVMNode {
+	if in == nil {
+		return nil
+	}
+	out := new(EVMNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Node) DeepCopyInto(out *Node) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Node.
@@ -94,6 +130,11 @@ func (in *NodeSpec) DeepCopyInto(out *NodeSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.RemoveArgs != nil {
+		in, out := &in.RemoveArgs, &out.RemoveArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Replicas != nil {
 		in, out := &in.Replicas, &out.Replicas
 		*out = new(uint)
@@ -104,12 +145,27 @@ func (in *NodeSpec) DeepCopyInto(out *NodeSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.EVMChains != nil {
+		in, out := &in.EVMChains, &out.EVMChains
+		*out = make([]EVMChain, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	out.KeystorePasswordSecretName = in.KeystorePasswordSecretName
 	out.APICredentials = in.APICredentials
 	if in.CORSDomains != nil {
 		in, out := &in.CORSDomains, &out.CORSDomains
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	in.Access.DeepCopyInto(&out.Access)
+	in.Disruption.DeepCopyInto(&out.Disruption)
+	out.Metrics = in.Metrics
+	in.Shutdown.DeepCopyInto(&out.Shutdown)
+	out.DataResync = in.DataResync
+	out.Deletion = in.Deletion
+	in.PodExtras.DeepCopyInto(&out.PodExtras)
 	in.Resources.DeepCopyInto(&out.Resources)
 }
 
@@ -126,6 +182,7 @@ func (in *NodeSpec) DeepCopy() *NodeSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeStatus) DeepCopyInto(out *NodeStatus) {
 	*out = *in
+	in.ResyncStatus.DeepCopyInto(&out.ResyncStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeStatus.
@@ -38,6 +38,8 @@ const (
 	DefaultWSPort uint = 8546
 	// DefaultGraphQLPort is the default graphQL port
 	DefaultGraphQLPort uint = 8547
+	// DefaultMetricsPort is the default prometheus metrics port
+	DefaultMetricsPort uint = 6060
 )
 
 // Genesis block defaults
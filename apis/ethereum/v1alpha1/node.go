@@ -13,6 +13,12 @@ type NodeStatus struct {
 	Network string `json:"network,omitempty"`
 	// EnodeURL is the node URL
 	EnodeURL string `json:"enodeURL,omitempty"`
+	// Replicas is node replicas P2P identities
+	Replicas []shared.ReplicaStatus `json:"replicas,omitempty"`
+	// Mode is reconciliation mode
+	Mode shared.Mode `json:"mode,omitempty"`
+	// ResyncStatus is node data resync status
+	shared.ResyncStatus `json:",inline"`
 }
 
 // +kubebuilder:object:root=true
@@ -23,6 +29,7 @@ type NodeStatus struct {
 // +kubebuilder:printcolumn:name="Consensus",type=string,JSONPath=".status.consensus"
 // +kubebuilder:printcolumn:name="Network",type=string,JSONPath=".status.network"
 // +kubebuilder:printcolumn:name="enodeURL",type=string,JSONPath=".status.enodeURL",priority=10
+// +kubebuilder:printcolumn:name="Mode",type=string,JSONPath=".status.mode"
 type Node struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -48,9 +55,12 @@ type NodeSpec struct {
 
 	// ExtraArgs is extra arguments to pass down to the cli
 	ExtraArgs shared.ExtraArgs `json:"extraArgs,omitempty"`
+	// RemoveArgs is names of generated cli arguments to remove
+	// +listType=set
+	RemoveArgs []string `json:"removeArgs,omitempty"`
 
 	// Replicas is number of replicas
-	// +kubebuilder:validation:Enum=0;1
+	// +kubebuilder:validation:Minimum=0
 	Replicas *uint `json:"replicas,omitempty"`
 
 	// Genesis is genesis block configuration
@@ -70,7 +80,7 @@ type NodeSpec struct {
 	Bootnodes []Enode `json:"bootnodes,omitempty"`
 
 	// NodePrivateKeySecretName is the secret name holding node private key
-	NodePrivateKeySecretName string `json:"nodePrivateKeySecretName,omitempty"`
+	NodePrivateKeySecretName shared.SecretKeySelector `json:"nodePrivateKeySecretName,omitempty"`
 
 	// StaticNodes is a set of ethereum nodes to maintain connection to
 	// +listType=set
@@ -107,7 +117,7 @@ type NodeSpec struct {
 	EnginePort uint `json:"enginePort,omitempty"`
 
 	// JWTSecretName is kubernetes secret name holding JWT secret
-	JWTSecretName string `json:"jwtSecretName,omitempty"`
+	JWTSecretName shared.SecretKeySelector `json:"jwtSecretName,omitempty"`
 
 	// RPC is whether HTTP-RPC server is enabled or not
 	RPC bool `json:"rpc,omitempty"`
@@ -135,6 +145,24 @@ type NodeSpec struct {
 	// GraphQLPort is the GraphQL server listening port
 	GraphQLPort uint `json:"graphqlPort,omitempty"`
 
+	// Access is network access settings of node endpoints
+	Access shared.Access `json:"access,omitempty"`
+	// Disruption is pod disruption budget settings
+	Disruption shared.Disruption `json:"disruption,omitempty"`
+	// Metrics is Prometheus metrics settings
+	Metrics shared.Metrics `json:"metrics,omitempty"`
+	// SecretStore is external secret store keys are loaded from instead of Kubernetes secrets
+	SecretStore *shared.SecretStore `json:"secretStore,omitempty"`
+	// Shutdown is graceful shutdown settings
+	Shutdown shared.Shutdown `json:"shutdown,omitempty"`
+	// Suspend scales node to zero replicas, keeping its data, services and secrets
+	Suspend bool `json:"suspend,omitempty"`
+	// DataResync is node data resync settings
+	shared.DataResync `json:",inline"`
+	// Deletion is deletion settings of node data and generated secrets
+	shared.Deletion `json:",inline"`
+	// PodExtras is extra environment variables, volumes and containers injected into node pods
+	shared.PodExtras `json:",inline"`
 	// Resources is node compute and storage resources
 	shared.Resources `json:"resources,omitempty"`
 }
@@ -259,9 +287,9 @@ const (
 // ImportedAccount is account derived from private key
 type ImportedAccount struct {
 	// PrivateKeySecretName is the secret name holding account private key
-	PrivateKeySecretName string `json:"privateKeySecretName"`
+	PrivateKeySecretName shared.SecretKeySelector `json:"privateKeySecretName"`
 	// PasswordSecretName is the secret holding password used to encrypt account private key
-	PasswordSecretName string `json:"passwordSecretName"`
+	PasswordSecretName shared.SecretKeySelector `json:"passwordSecretName"`
 }
 
 func init() {
@@ -94,10 +94,16 @@ func (n *Node) Default() {
 		n.Spec.GraphQLPort = DefaultGraphQLPort
 	}
 
+	if n.Spec.Metrics.Port == 0 {
+		n.Spec.Metrics.Port = DefaultMetricsPort
+	}
+
 	if n.Spec.Logging == "" {
 		n.Spec.Logging = DefaultLogging
 	}
 
+	n.Spec.Disruption.Default()
+
 }
 
 // DefaultNodeResources defaults node cpu, memory and storage resources
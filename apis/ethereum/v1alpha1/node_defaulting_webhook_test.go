@@ -235,6 +235,7 @@ var _ = Describe("Ethereum defaulting", func() {
 		Expect(node.Spec.WSPort).To(Equal(DefaultWSPort))
 		Expect(node.Spec.WSAPI).To(Equal(DefaultAPIs))
 		Expect(node.Spec.GraphQLPort).To(Equal(DefaultGraphQLPort))
+		Expect(node.Spec.Metrics.Port).To(Equal(DefaultMetricsPort))
 		Expect(node.Spec.Resources.CPU).To(Equal(DefaultPrivateNetworkNodeCPURequest))
 		Expect(node.Spec.Resources.CPULimit).To(Equal(DefaultPrivateNetworkNodeCPULimit))
 		Expect(node.Spec.Resources.Memory).To(Equal(DefaultPrivateNetworkNodeMemoryRequest))
@@ -3,6 +3,7 @@ package v1alpha1
 import (
 	"fmt"
 
+	"github.com/kotalco/kotal/apis/shared"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -11,7 +12,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
-// +kubebuilder:webhook:verbs=create;update,path=/validate-ethereum-kotal-io-v1alpha1-node,mutating=false,failurePolicy=fail,groups=ethereum.kotal.io,resources=nodes,versions=v1alpha1,name=validate-ethereum-v1alpha1-node.kb.io,sideEffects=None,admissionReviewVersions=v1
+// +kubebuilder:webhook:verbs=create;update;delete,path=/validate-ethereum-kotal-io-v1alpha1-node,mutating=false,failurePolicy=fail,groups=ethereum.kotal.io,resources=nodes,versions=v1alpha1,name=validate-ethereum-v1alpha1-node.kb.io,sideEffects=None,admissionReviewVersions=v1
 
 var _ webhook.Validator = &Node{}
 
@@ -47,7 +48,7 @@ func (n *Node) validate() field.ErrorList {
 	}
 
 	// validate jwtSecretName is provided if engine is enabled
-	if n.Spec.Engine && n.Spec.JWTSecretName == "" {
+	if n.Spec.Engine && n.Spec.JWTSecretName.Name == "" {
 		err := field.Invalid(path.Child("jwtSecretName"), "", "must provide jwtSecretName if engine is true")
 		nodeErrors = append(nodeErrors, err)
 	}
@@ -131,6 +132,12 @@ func (n *Node) validate() field.ErrorList {
 		nodeErrors = append(nodeErrors, err)
 	}
 
+	// validate nethermind account keystore can't be generated from keys loaded from secret store
+	if n.Spec.Client == NethermindClient && n.Spec.Import != nil && n.Spec.SecretStore != nil {
+		err := field.Invalid(path.Child("import"), n.Spec.Import.PrivateKeySecretName.Name, "not supported by nethermind client if keys are loaded from secret store")
+		nodeErrors = append(nodeErrors, err)
+	}
+
 	return nodeErrors
 }
 
@@ -142,17 +149,24 @@ func (n *Node) ValidateCreate() (admission.Warnings, error) {
 
 	allErrors = append(allErrors, n.validate()...)
 	allErrors = append(allErrors, n.Spec.Resources.ValidateCreate()...)
+	allErrors = append(allErrors, n.Spec.Access.Validate()...)
+	allErrors = append(allErrors, n.Spec.PodExtras.Validate()...)
+	allErrors = append(allErrors, n.Spec.Deletion.Validate()...)
+	allErrors = append(allErrors, n.Spec.SecretStore.Validate()...)
+	allErrors = append(allErrors, n.Spec.SecretStore.ValidateNodeKey(n.Spec.NodePrivateKeySecretName, n.Spec.Replicas)...)
 
 	// validate genesis block
 	if n.Spec.Genesis != nil {
 		allErrors = append(allErrors, n.Spec.Genesis.ValidateCreate()...)
 	}
 
+	warnings := n.Spec.ExtraArgs.Warnings(n, nodeArgs)
+
 	if len(allErrors) == 0 {
-		return nil, nil
+		return warnings, nil
 	}
 
-	return nil, apierrors.NewInvalid(schema.GroupKind{}, n.Name, allErrors)
+	return warnings, apierrors.NewInvalid(schema.GroupKind{}, n.Name, allErrors)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
@@ -179,17 +193,25 @@ func (n *Node) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
 
 	allErrors = append(allErrors, n.validate()...)
 	allErrors = append(allErrors, n.Spec.Resources.ValidateUpdate(&oldNode.Spec.Resources)...)
+	allErrors = append(allErrors, n.Spec.Access.Validate()...)
+	allErrors = append(allErrors, n.Spec.PodExtras.Validate()...)
+	allErrors = append(allErrors, n.Spec.Deletion.Validate()...)
+	allErrors = append(allErrors, n.Spec.DataResync.ValidateUpdate(&oldNode.Spec.DataResync, false)...)
+	allErrors = append(allErrors, n.Spec.SecretStore.Validate()...)
+	allErrors = append(allErrors, n.Spec.SecretStore.ValidateNodeKey(n.Spec.NodePrivateKeySecretName, n.Spec.Replicas)...)
+
+	warnings := n.Spec.ExtraArgs.Warnings(n, nodeArgs)
 
 	if len(allErrors) == 0 {
-		return nil, nil
+		return warnings, nil
 	}
 
-	return nil, apierrors.NewInvalid(schema.GroupKind{}, n.Name, allErrors)
+	return warnings, apierrors.NewInvalid(schema.GroupKind{}, n.Name, allErrors)
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
 func (n *Node) ValidateDelete() (admission.Warnings, error) {
 	nodelog.Info("validate delete", "name", n.Name)
 
-	return nil, nil
+	return nil, shared.ValidateDeletionProtection(n)
 }
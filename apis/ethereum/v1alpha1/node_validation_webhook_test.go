@@ -8,6 +8,7 @@ import (
 	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
@@ -176,8 +177,8 @@ var _ = Describe("Ethereum node validation", func() {
 					Miner:    true,
 					Coinbase: coinbase,
 					Import: &ImportedAccount{
-						PrivateKeySecretName: "my-account-privatekey",
-						PasswordSecretName:   "my-account-password",
+						PrivateKeySecretName: shared.SecretKeySelector{Name: "my-account-privatekey"},
+						PasswordSecretName:   shared.SecretKeySelector{Name: "my-account-password"},
 					},
 				},
 			},
@@ -231,8 +232,8 @@ var _ = Describe("Ethereum node validation", func() {
 					Miner:    true,
 					Coinbase: coinbase,
 					Import: &ImportedAccount{
-						PrivateKeySecretName: "my-account-privatekey",
-						PasswordSecretName:   "my-account-password",
+						PrivateKeySecretName: shared.SecretKeySelector{Name: "my-account-privatekey"},
+						PasswordSecretName:   shared.SecretKeySelector{Name: "my-account-password"},
 					},
 				},
 			},
@@ -262,8 +263,8 @@ var _ = Describe("Ethereum node validation", func() {
 					Miner:    true,
 					Coinbase: coinbase,
 					Import: &ImportedAccount{
-						PrivateKeySecretName: "my-account-privatekey",
-						PasswordSecretName:   "my-account-password",
+						PrivateKeySecretName: shared.SecretKeySelector{Name: "my-account-privatekey"},
+						PasswordSecretName:   shared.SecretKeySelector{Name: "my-account-password"},
 					},
 				},
 			},
@@ -293,8 +294,8 @@ var _ = Describe("Ethereum node validation", func() {
 					Miner:    true,
 					Coinbase: coinbase,
 					Import: &ImportedAccount{
-						PrivateKeySecretName: "my-account-privatekey",
-						PasswordSecretName:   "my-account-password",
+						PrivateKeySecretName: shared.SecretKeySelector{Name: "my-account-privatekey"},
+						PasswordSecretName:   shared.SecretKeySelector{Name: "my-account-password"},
 					},
 				},
 			},
@@ -745,4 +746,26 @@ var _ = Describe("Ethereum node validation", func() {
 		}
 	})
 
+	It("Should warn about extra arguments shadowing arguments generated by injected generator", func() {
+		nodeArgs = func(obj runtime.Object) []string {
+			return []string{"--syncmode", string(obj.(*Node).Spec.SyncMode)}
+		}
+		defer func() { nodeArgs = nil }()
+
+		node := &Node{
+			Spec: NodeSpec{
+				Network: "mainnet",
+				Client:  GethClient,
+				ExtraArgs: shared.ExtraArgs{
+					"--syncmode": "full",
+				},
+			},
+		}
+		node.Default()
+
+		warnings, err := node.ValidateCreate()
+		Expect(err).To(BeNil())
+		Expect(warnings).To(ConsistOf("spec.extraArgs[--syncmode]: overrides argument managed by spec"))
+	})
+
 })
@@ -159,6 +159,8 @@ func (in *IBFT2) DeepCopy() *IBFT2 {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ImportedAccount) DeepCopyInto(out *ImportedAccount) {
 	*out = *in
+	out.PrivateKeySecretName = in.PrivateKeySecretName
+	out.PasswordSecretName = in.PasswordSecretName
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImportedAccount.
@@ -177,7 +179,7 @@ func (in *Node) DeepCopyInto(out *Node) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Node.
@@ -240,6 +242,11 @@ func (in *NodeSpec) DeepCopyInto(out *NodeSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.RemoveArgs != nil {
+		in, out := &in.RemoveArgs, &out.RemoveArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Replicas != nil {
 		in, out := &in.Replicas, &out.Replicas
 		*out = new(uint)
@@ -260,6 +267,7 @@ func (in *NodeSpec) DeepCopyInto(out *NodeSpec) {
 		*out = make([]Enode, len(*in))
 		copy(*out, *in)
 	}
+	out.NodePrivateKeySecretName = in.NodePrivateKeySecretName
 	if in.StaticNodes != nil {
 		in, out := &in.StaticNodes, &out.StaticNodes
 		*out = make([]Enode, len(*in))
@@ -275,6 +283,7 @@ func (in *NodeSpec) DeepCopyInto(out *NodeSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	out.JWTSecretName = in.JWTSecretName
 	if in.RPCAPI != nil {
 		in, out := &in.RPCAPI, &out.RPCAPI
 		*out = make([]API, len(*in))
@@ -285,6 +294,18 @@ func (in *NodeSpec) DeepCopyInto(out *NodeSpec) {
 		*out = make([]API, len(*in))
 		copy(*out, *in)
 	}
+	in.Access.DeepCopyInto(&out.Access)
+	in.Disruption.DeepCopyInto(&out.Disruption)
+	out.Metrics = in.Metrics
+	if in.SecretStore != nil {
+		in, out := &in.SecretStore, &out.SecretStore
+		*out = new(shared.SecretStore)
+		**out = **in
+	}
+	in.Shutdown.DeepCopyInto(&out.Shutdown)
+	out.DataResync = in.DataResync
+	out.Deletion = in.Deletion
+	in.PodExtras.DeepCopyInto(&out.PodExtras)
 	in.Resources.DeepCopyInto(&out.Resources)
 }
 
@@ -301,6 +322,12 @@ func (in *NodeSpec) DeepCopy() *NodeSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeStatus) DeepCopyInto(out *NodeStatus) {
 	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = make([]shared.ReplicaStatus, len(*in))
+		copy(*out, *in)
+	}
+	in.ResyncStatus.DeepCopyInto(&out.ResyncStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeStatus.
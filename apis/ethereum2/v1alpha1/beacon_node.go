@@ -11,8 +11,11 @@ type BeaconNodeSpec struct {
 	Image string `json:"image,omitempty"`
 	// ExtraArgs is extra arguments to pass down to the cli
 	ExtraArgs shared.ExtraArgs `json:"extraArgs,omitempty"`
+	// RemoveArgs is names of generated cli arguments to remove
+	// +listType=set
+	RemoveArgs []string `json:"removeArgs,omitempty"`
 	// Replicas is number of replicas
-	// +kubebuilder:validation:Enum=0;1
+	// +kubebuilder:validation:Minimum=0
 	Replicas *uint `json:"replicas,omitempty"`
 
 	// Network is the network to join
@@ -22,7 +25,7 @@ type BeaconNodeSpec struct {
 	// ExecutionEngineEndpoint is Ethereum Execution engine node endpoint
 	ExecutionEngineEndpoint string `json:"executionEngineEndpoint"`
 	// JWTSecretName is kubernetes secret name holding JWT secret
-	JWTSecretName string `json:"jwtSecretName"`
+	JWTSecretName shared.SecretKeySelector `json:"jwtSecretName"`
 	// FeeRecipient is ethereum address collecting transaction fees
 	FeeRecipient shared.EthereumAddress `json:"feeRecipient,omitempty"`
 
@@ -61,19 +64,43 @@ type BeaconNodeSpec struct {
 	// P2PPort is p2p and discovery port
 	P2PPort uint `json:"p2pPort,omitempty"`
 
+	// Access is network access settings of node endpoints
+	Access shared.Access `json:"access,omitempty"`
+	// Disruption is pod disruption budget settings
+	Disruption shared.Disruption `json:"disruption,omitempty"`
+	// Metrics is Prometheus metrics settings
+	Metrics shared.Metrics `json:"metrics,omitempty"`
+	// SecretStore is external secret store keys are loaded from instead of Kubernetes secrets
+	SecretStore *shared.SecretStore `json:"secretStore,omitempty"`
+	// Shutdown is graceful shutdown settings
+	Shutdown shared.Shutdown `json:"shutdown,omitempty"`
+	// Suspend scales node to zero replicas, keeping its data, services and secrets
+	Suspend bool `json:"suspend,omitempty"`
+	// DataResync is node data resync settings
+	shared.DataResync `json:",inline"`
+	// Deletion is deletion settings of node data and generated secrets
+	shared.Deletion `json:",inline"`
+	// PodExtras is extra environment variables, volumes and containers injected into node pods
+	shared.PodExtras `json:",inline"`
 	// Resources is node compute and storage resources
 	shared.Resources `json:"resources,omitempty"`
 }
 
 // BeaconNodeStatus defines the observed state of BeaconNode
 type BeaconNodeStatus struct {
+	// Mode is reconciliation mode
+	Mode shared.Mode `json:"mode,omitempty"`
+	// ResyncStatus is node data resync status
+	shared.ResyncStatus `json:",inline"`
 }
 
 // +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
 
 // BeaconNode is the Schema for the beaconnodes API
 // +kubebuilder:printcolumn:name="Client",type=string,JSONPath=".spec.client"
 // +kubebuilder:printcolumn:name="Network",type=string,JSONPath=".spec.network"
+// +kubebuilder:printcolumn:name="Mode",type=string,JSONPath=".status.mode"
 type BeaconNode struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
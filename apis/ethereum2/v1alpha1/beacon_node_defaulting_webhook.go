@@ -53,6 +53,10 @@ func (r *BeaconNode) Default() {
 		r.Spec.GRPCPort = DefaultGRPCPort
 	}
 
+	if r.Spec.Metrics.Port == 0 {
+		r.Spec.Metrics.Port = DefaultMetricsPort
+	}
+
 	if len(r.Spec.CORSDomains) == 0 {
 		r.Spec.CORSDomains = DefaultOrigins
 	}
@@ -66,6 +70,8 @@ func (r *BeaconNode) Default() {
 
 	r.DefaultNodeResources()
 
+	r.Spec.Disruption.Default()
+
 }
 
 // DefaultNodeResources defaults Ethereum 2.0 node cpu, memory and storage resources
@@ -82,6 +82,7 @@ var _ = Describe("Ethereum 2.0 beacon node defaulting", func() {
 		Expect(node.Spec.Image).To(Equal(DefaultPrysmBeaconNodeImage))
 		Expect(*node.Spec.Replicas).To(Equal(DefaltReplicas))
 		Expect(node.Spec.GRPCPort).To(Equal(DefaultGRPCPort))
+		Expect(node.Spec.Metrics.Port).To(Equal(DefaultMetricsPort))
 		Expect(node.Spec.CORSDomains).To(ConsistOf(DefaultOrigins))
 		Expect(node.Spec.Hosts).To(ConsistOf(DefaultOrigins))
 	})
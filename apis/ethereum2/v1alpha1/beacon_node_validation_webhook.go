@@ -3,6 +3,7 @@ package v1alpha1
 import (
 	"fmt"
 
+	"github.com/kotalco/kotal/apis/shared"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -11,7 +12,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
-// +kubebuilder:webhook:verbs=create;update,path=/validate-ethereum2-kotal-io-v1alpha1-beaconnode,mutating=false,failurePolicy=fail,groups=ethereum2.kotal.io,resources=beaconnodes,versions=v1alpha1,name=validate-ethereum2-v1alpha1-beaconnode.kb.io,sideEffects=None,admissionReviewVersions=v1
+// +kubebuilder:webhook:verbs=create;update;delete,path=/validate-ethereum2-kotal-io-v1alpha1-beaconnode,mutating=false,failurePolicy=fail,groups=ethereum2.kotal.io,resources=beaconnodes,versions=v1alpha1,name=validate-ethereum2-v1alpha1-beaconnode.kb.io,sideEffects=None,admissionReviewVersions=v1
 
 var _ webhook.Validator = &BeaconNode{}
 
@@ -51,6 +52,12 @@ func (r *BeaconNode) validate() field.ErrorList {
 		nodeErrors = append(nodeErrors, err)
 	}
 
+	// validate cert secret is not loaded from secret store
+	if r.Spec.CertSecretName != "" && r.Spec.SecretStore != nil {
+		err := field.Invalid(path.Child("certSecretName"), r.Spec.CertSecretName, "not supported if keys are loaded from secret store")
+		nodeErrors = append(nodeErrors, err)
+	}
+
 	// rpc is always on in prysm
 	if r.Spec.Client == PrysmClient && !r.Spec.RPC {
 		err := field.Invalid(path.Child("rpc"), r.Spec.RPC, "can't be disabled in prysm client")
@@ -68,12 +75,18 @@ func (r *BeaconNode) ValidateCreate() (admission.Warnings, error) {
 
 	allErrors = append(allErrors, r.validate()...)
 	allErrors = append(allErrors, r.Spec.Resources.ValidateCreate()...)
+	allErrors = append(allErrors, r.Spec.Access.Validate()...)
+	allErrors = append(allErrors, r.Spec.PodExtras.Validate()...)
+	allErrors = append(allErrors, r.Spec.Deletion.Validate()...)
+	allErrors = append(allErrors, r.Spec.SecretStore.Validate()...)
+
+	warnings := r.Spec.ExtraArgs.Warnings(r, beaconNodeArgs)
 
 	if len(allErrors) == 0 {
-		return nil, nil
+		return warnings, nil
 	}
 
-	return nil, apierrors.NewInvalid(schema.GroupKind{}, r.Name, allErrors)
+	return warnings, apierrors.NewInvalid(schema.GroupKind{}, r.Name, allErrors)
 
 }
 
@@ -87,6 +100,11 @@ func (r *BeaconNode) ValidateUpdate(old runtime.Object) (admission.Warnings, err
 
 	allErrors = append(allErrors, r.validate()...)
 	allErrors = append(allErrors, r.Spec.Resources.ValidateUpdate(&oldNode.Spec.Resources)...)
+	allErrors = append(allErrors, r.Spec.Access.Validate()...)
+	allErrors = append(allErrors, r.Spec.PodExtras.Validate()...)
+	allErrors = append(allErrors, r.Spec.Deletion.Validate()...)
+	allErrors = append(allErrors, r.Spec.DataResync.ValidateUpdate(&oldNode.Spec.DataResync, false)...)
+	allErrors = append(allErrors, r.Spec.SecretStore.Validate()...)
 
 	if oldNode.Spec.Client != r.Spec.Client {
 		err := field.Invalid(path.Child("client"), r.Spec.Client, "field is immutable")
@@ -98,16 +116,18 @@ func (r *BeaconNode) ValidateUpdate(old runtime.Object) (admission.Warnings, err
 		allErrors = append(allErrors, err)
 	}
 
+	warnings := r.Spec.ExtraArgs.Warnings(r, beaconNodeArgs)
+
 	if len(allErrors) == 0 {
-		return nil, nil
+		return warnings, nil
 	}
 
-	return nil, apierrors.NewInvalid(schema.GroupKind{}, r.Name, allErrors)
+	return warnings, apierrors.NewInvalid(schema.GroupKind{}, r.Name, allErrors)
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
 func (r *BeaconNode) ValidateDelete() (admission.Warnings, error) {
 	nodelog.Info("validate delete", "name", r.Name)
 
-	return nil, nil
+	return nil, shared.ValidateDeletionProtection(r)
 }
@@ -20,6 +20,8 @@ const (
 	DefaultRPCPort uint = 4000
 	// DefaultGRPCPort is the default GRPC gateway server port
 	DefaultGRPCPort uint = 3500
+	// DefaultMetricsPort is the default prometheus metrics port
+	DefaultMetricsPort uint = 8008
 	// DefaultGraffiti is the default text to include in proposed blocks
 	DefaultGraffiti = "Powered by Kotal"
 	// DefaultLogging is the default logging verbosity
@@ -11,8 +11,12 @@ type ValidatorSpec struct {
 	Image string `json:"image,omitempty"`
 	// ExtraArgs is extra arguments to pass down to the cli
 	ExtraArgs shared.ExtraArgs `json:"extraArgs,omitempty"`
+	// RemoveArgs is names of generated cli arguments to remove
+	// +listType=set
+	RemoveArgs []string `json:"removeArgs,omitempty"`
 	// Replicas is number of replicas
-	// +kubebuilder:validation:Enum=0;1
+	// it must be 0 or 1, validator keys can't be shared by replicas
+	// +kubebuilder:validation:Minimum=0
 	Replicas *uint `json:"replicas,omitempty"`
 
 	// Network is the network this validator is validating blocks for
@@ -36,7 +40,25 @@ type ValidatorSpec struct {
 	// +kubebuilder:validation:MinItems=1
 	Keystores []Keystore `json:"keystores"`
 	// WalletPasswordSecret is wallet password secret
-	WalletPasswordSecret string `json:"walletPasswordSecret,omitempty"`
+	WalletPasswordSecret shared.SecretKeySelector `json:"walletPasswordSecret,omitempty"`
+	// Access is network access settings of node endpoints
+	Access shared.Access `json:"access,omitempty"`
+	// Disruption is pod disruption budget settings
+	Disruption shared.Disruption `json:"disruption,omitempty"`
+	// Metrics is Prometheus metrics settings
+	Metrics shared.Metrics `json:"metrics,omitempty"`
+	// SecretStore is external secret store keys are loaded from instead of Kubernetes secrets
+	SecretStore *shared.SecretStore `json:"secretStore,omitempty"`
+	// Shutdown is graceful shutdown settings
+	Shutdown shared.Shutdown `json:"shutdown,omitempty"`
+	// Suspend scales validator to zero replicas, keeping its data, services and secrets
+	Suspend bool `json:"suspend,omitempty"`
+	// DataResync is node data resync settings
+	shared.DataResync `json:",inline"`
+	// Deletion is deletion settings of node data and generated secrets
+	shared.Deletion `json:",inline"`
+	// PodExtras is extra environment variables, volumes and containers injected into node pods
+	shared.PodExtras `json:",inline"`
 	// Resources is node compute and storage resources
 	shared.Resources `json:"resources,omitempty"`
 }
@@ -51,13 +73,20 @@ type Keystore struct {
 }
 
 // ValidatorStatus defines the observed state of Validator
-type ValidatorStatus struct{}
+type ValidatorStatus struct {
+	// Mode is reconciliation mode
+	Mode shared.Mode `json:"mode,omitempty"`
+	// ResyncStatus is node data resync status
+	shared.ResyncStatus `json:",inline"`
+}
 
 // +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
 
 // Validator is the Schema for the validators API
 // +kubebuilder:printcolumn:name="Client",type=string,JSONPath=".spec.client"
 // +kubebuilder:printcolumn:name="Network",type=string,JSONPath=".spec.network"
+// +kubebuilder:printcolumn:name="Mode",type=string,JSONPath=".status.mode"
 type Validator struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -43,8 +43,14 @@ func (r *Validator) Default() {
 		r.Spec.Replicas = &replicas
 	}
 
+	if r.Spec.Metrics.Port == 0 {
+		r.Spec.Metrics.Port = DefaultMetricsPort
+	}
+
 	r.DefaultNodeResources()
 
+	r.Spec.Disruption.Default()
+
 }
 
 // DefaultNodeResources defaults Ethereum 2.0 validator client cpu, memory and storage resources
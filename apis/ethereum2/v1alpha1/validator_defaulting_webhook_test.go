@@ -18,6 +18,9 @@ var _ = Describe("Ethereum 2.0 validator client defaulting", func() {
 		node.Default()
 		Expect(node.Spec.Image).To(Equal(DefaultTekuValidatorImage))
 		Expect(*node.Spec.Replicas).To(Equal(DefaltReplicas))
+		Expect(node.Spec.Metrics.Port).To(Equal(DefaultMetricsPort))
+		Expect(*node.Spec.Disruption.Enabled).To(BeFalse())
+		Expect(*node.Spec.Disruption.MaxUnavailable).To(Equal(uint(1)))
 		Expect(node.Spec.Graffiti).To(Equal(DefaultGraffiti))
 		Expect(node.Spec.FeeRecipient).To(Equal(shared.EthereumAddress(ZeroAddress)))
 		Expect(node.Spec.Logging).To(Equal(DefaultLogging))
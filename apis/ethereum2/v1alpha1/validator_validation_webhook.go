@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/kotalco/kotal/apis/shared"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -12,7 +13,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
-// +kubebuilder:webhook:verbs=create;update,path=/validate-ethereum2-kotal-io-v1alpha1-validator,mutating=false,failurePolicy=fail,groups=ethereum2.kotal.io,resources=validators,versions=v1alpha1,name=validate-ethereum2-v1alpha1-validator.kb.io,sideEffects=None,admissionReviewVersions=v1
+// +kubebuilder:webhook:verbs=create;update;delete,path=/validate-ethereum2-kotal-io-v1alpha1-validator,mutating=false,failurePolicy=fail,groups=ethereum2.kotal.io,resources=validators,versions=v1alpha1,name=validate-ethereum2-v1alpha1-validator.kb.io,sideEffects=None,admissionReviewVersions=v1
 
 var _ webhook.Validator = &Validator{}
 
@@ -21,9 +22,9 @@ func (r *Validator) validate() field.ErrorList {
 	var validatorErrors field.ErrorList
 
 	// prysm requires wallet password
-	if r.Spec.Client == PrysmClient && r.Spec.WalletPasswordSecret == "" {
+	if r.Spec.Client == PrysmClient && r.Spec.WalletPasswordSecret.Name == "" {
 		msg := "must provide walletPasswordSecret if client is prysm"
-		err := field.Invalid(field.NewPath("spec").Child("walletPasswordSecret"), r.Spec.WalletPasswordSecret, msg)
+		err := field.Invalid(field.NewPath("spec").Child("walletPasswordSecret"), r.Spec.WalletPasswordSecret.Name, msg)
 		validatorErrors = append(validatorErrors, err)
 	}
 
@@ -32,6 +33,11 @@ func (r *Validator) validate() field.ErrorList {
 		validatorErrors = append(validatorErrors, err)
 	}
 
+	if r.Spec.CertSecretName != "" && r.Spec.SecretStore != nil {
+		err := field.Invalid(field.NewPath("spec").Child("certSecretName"), r.Spec.CertSecretName, "not supported if keys are loaded from secret store")
+		validatorErrors = append(validatorErrors, err)
+	}
+
 	if !r.Spec.Client.SupportsVerbosityLevel(r.Spec.Logging, false) {
 		err := field.Invalid(field.NewPath("spec").Child("logging"), r.Spec.Logging, fmt.Sprintf("not supported by %s client", r.Spec.Client))
 		validatorErrors = append(validatorErrors, err)
@@ -65,12 +71,19 @@ func (r *Validator) ValidateCreate() (admission.Warnings, error) {
 
 	allErrors = append(allErrors, r.validate()...)
 	allErrors = append(allErrors, r.Spec.Resources.ValidateCreate()...)
+	allErrors = append(allErrors, r.Spec.Access.Validate()...)
+	allErrors = append(allErrors, r.Spec.PodExtras.Validate()...)
+	allErrors = append(allErrors, r.Spec.Deletion.Validate()...)
+	allErrors = append(allErrors, shared.ValidateReplicas(r.Spec.Replicas, true)...)
+	allErrors = append(allErrors, r.Spec.SecretStore.Validate()...)
+
+	warnings := r.Spec.ExtraArgs.Warnings(r, validatorArgs)
 
 	if len(allErrors) == 0 {
-		return nil, nil
+		return warnings, nil
 	}
 
-	return nil, apierrors.NewInvalid(schema.GroupKind{}, r.Name, allErrors)
+	return warnings, apierrors.NewInvalid(schema.GroupKind{}, r.Name, allErrors)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
@@ -82,6 +95,12 @@ func (r *Validator) ValidateUpdate(old runtime.Object) (admission.Warnings, erro
 
 	allErrors = append(allErrors, r.validate()...)
 	allErrors = append(allErrors, r.Spec.Resources.ValidateUpdate(&oldValidator.Spec.Resources)...)
+	allErrors = append(allErrors, r.Spec.Access.Validate()...)
+	allErrors = append(allErrors, r.Spec.PodExtras.Validate()...)
+	allErrors = append(allErrors, r.Spec.Deletion.Validate()...)
+	allErrors = append(allErrors, shared.ValidateReplicas(r.Spec.Replicas, true)...)
+	allErrors = append(allErrors, r.Spec.DataResync.ValidateUpdate(&oldValidator.Spec.DataResync, true)...)
+	allErrors = append(allErrors, r.Spec.SecretStore.Validate()...)
 
 	if oldValidator.Spec.Client != r.Spec.Client {
 		err := field.Invalid(field.NewPath("spec").Child("client"), r.Spec.Client, "field is immutable")
@@ -95,16 +114,18 @@ func (r *Validator) ValidateUpdate(old runtime.Object) (admission.Warnings, erro
 
 	allErrors = append(allErrors, r.Spec.Resources.ValidateCreate()...)
 
+	warnings := r.Spec.ExtraArgs.Warnings(r, validatorArgs)
+
 	if len(allErrors) == 0 {
-		return nil, nil
+		return warnings, nil
 	}
 
-	return nil, apierrors.NewInvalid(schema.GroupKind{}, r.Name, allErrors)
+	return warnings, apierrors.NewInvalid(schema.GroupKind{}, r.Name, allErrors)
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
 func (r *Validator) ValidateDelete() (admission.Warnings, error) {
 	validatorlog.Info("validate delete", "name", r.Name)
 
-	return nil, nil
+	return nil, shared.ValidateDeletionProtection(r)
 }
@@ -12,6 +12,8 @@ import (
 
 var _ = Describe("Ethereum 2.0 validator client validation", func() {
 
+	var threeReplicas uint = 3
+
 	createCases := []struct {
 		Title     string
 		Validator *Validator
@@ -106,6 +108,24 @@ var _ = Describe("Ethereum 2.0 validator client validation", func() {
 				},
 			},
 		},
+		{
+			Title: "Validator #5",
+			Validator: &Validator{
+				Spec: ValidatorSpec{
+					Network:  "mainnet",
+					Client:   TekuClient,
+					Replicas: &threeReplicas,
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.replicas",
+					BadValue: uint(3),
+					Detail:   "must be 0 or 1 for signing nodes",
+				},
+			},
+		},
 	}
 
 	updateCases := []struct {
@@ -1,6 +1,9 @@
 package v1alpha1
 
 import (
+	"fmt"
+
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -8,8 +11,17 @@ import (
 // log is for logging in this package.
 var validatorlog = logf.Log.WithName("validator-resource")
 
+// validatorArgs generates validator cli arguments checked against extra arguments by validation webhook
+var validatorArgs sharedAPI.ArgsGenerator
+
 // SetupWebhookWithManager sets up the webook with a given controller manager
-func (r *Validator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+// args is validator cli arguments generator, webhook registration fails without it
+func (r *Validator) SetupWebhookWithManager(mgr ctrl.Manager, args sharedAPI.ArgsGenerator) error {
+	if args == nil {
+		return fmt.Errorf("no cli arguments generator is registered for %T", r)
+	}
+	validatorArgs = args
+
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
 		Complete()
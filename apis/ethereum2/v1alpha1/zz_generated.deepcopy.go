@@ -16,7 +16,7 @@ func (in *BeaconNode) DeepCopyInto(out *BeaconNode) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BeaconNode.
@@ -79,11 +79,17 @@ func (in *BeaconNodeSpec) DeepCopyInto(out *BeaconNodeSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.RemoveArgs != nil {
+		in, out := &in.RemoveArgs, &out.RemoveArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Replicas != nil {
 		in, out := &in.Replicas, &out.Replicas
 		*out = new(uint)
 		**out = **in
 	}
+	out.JWTSecretName = in.JWTSecretName
 	if in.Hosts != nil {
 		in, out := &in.Hosts, &out.Hosts
 		*out = make([]string, len(*in))
@@ -94,6 +100,18 @@ func (in *BeaconNodeSpec) DeepCopyInto(out *BeaconNodeSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	in.Access.DeepCopyInto(&out.Access)
+	in.Disruption.DeepCopyInto(&out.Disruption)
+	out.Metrics = in.Metrics
+	if in.SecretStore != nil {
+		in, out := &in.SecretStore, &out.SecretStore
+		*out = new(shared.SecretStore)
+		**out = **in
+	}
+	in.Shutdown.DeepCopyInto(&out.Shutdown)
+	out.DataResync = in.DataResync
+	out.Deletion = in.Deletion
+	in.PodExtras.DeepCopyInto(&out.PodExtras)
 	in.Resources.DeepCopyInto(&out.Resources)
 }
 
@@ -110,6 +128,7 @@ func (in *BeaconNodeSpec) DeepCopy() *BeaconNodeSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BeaconNodeStatus) DeepCopyInto(out *BeaconNodeStatus) {
 	*out = *in
+	in.ResyncStatus.DeepCopyInto(&out.ResyncStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BeaconNodeStatus.
@@ -143,7 +162,7 @@ func (in *Validator) DeepCopyInto(out *Validator) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Validator.
@@ -206,6 +225,11 @@ func (in *ValidatorSpec) DeepCopyInto(out *ValidatorSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.RemoveArgs != nil {
+		in, out := &in.RemoveArgs, &out.RemoveArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Replicas != nil {
 		in, out := &in.Replicas, &out.Replicas
 		*out = new(uint)
@@ -221,6 +245,19 @@ func (in *ValidatorSpec) DeepCopyInto(out *ValidatorSpec) {
 		*out = make([]Keystore, len(*in))
 		copy(*out, *in)
 	}
+	out.WalletPasswordSecret = in.WalletPasswordSecret
+	in.Access.DeepCopyInto(&out.Access)
+	in.Disruption.DeepCopyInto(&out.Disruption)
+	out.Metrics = in.Metrics
+	if in.SecretStore != nil {
+		in, out := &in.SecretStore, &out.SecretStore
+		*out = new(shared.SecretStore)
+		**out = **in
+	}
+	in.Shutdown.DeepCopyInto(&out.Shutdown)
+	out.DataResync = in.DataResync
+	out.Deletion = in.Deletion
+	in.PodExtras.DeepCopyInto(&out.PodExtras)
 	in.Resources.DeepCopyInto(&out.Resources)
 }
 
@@ -237,6 +274,7 @@ func (in *ValidatorSpec) DeepCopy() *ValidatorSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ValidatorStatus) DeepCopyInto(out *ValidatorStatus) {
 	*out = *in
+	in.ResyncStatus.DeepCopyInto(&out.ResyncStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValidatorStatus.
@@ -94,4 +94,6 @@ func (n *Node) Default() {
 		}
 	}
 
+	n.Spec.Disruption.Default()
+
 }
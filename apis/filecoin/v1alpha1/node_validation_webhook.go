@@ -1,6 +1,7 @@
 package v1alpha1
 
 import (
+	"github.com/kotalco/kotal/apis/shared"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -9,23 +10,42 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
-// +kubebuilder:webhook:verbs=create;update,path=/validate-filecoin-kotal-io-v1alpha1-node,mutating=false,failurePolicy=fail,groups=filecoin.kotal.io,resources=nodes,versions=v1alpha1,name=validate-filecoin-v1alpha1-node.kb.io,sideEffects=None,admissionReviewVersions=v1
+// +kubebuilder:webhook:verbs=create;update;delete,path=/validate-filecoin-kotal-io-v1alpha1-node,mutating=false,failurePolicy=fail,groups=filecoin.kotal.io,resources=nodes,versions=v1alpha1,name=validate-filecoin-v1alpha1-node.kb.io,sideEffects=None,admissionReviewVersions=v1
 
 var _ webhook.Validator = &Node{}
 
+// validate is common create and update validation rules
+func (n *Node) validate() field.ErrorList {
+	var nodeErrors field.ErrorList
+
+	// metrics are served by the API server
+	if n.Spec.Metrics.Enabled && !n.Spec.API {
+		err := field.Invalid(field.NewPath("spec").Child("metrics").Child("enabled"), n.Spec.Metrics.Enabled, "must be false if api is disabled")
+		nodeErrors = append(nodeErrors, err)
+	}
+
+	return nodeErrors
+}
+
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (n *Node) ValidateCreate() (admission.Warnings, error) {
 	nodelog.Info("validate create", "name", n.Name)
 
 	var allErrors field.ErrorList
 
+	allErrors = append(allErrors, n.validate()...)
 	allErrors = append(allErrors, n.Spec.Resources.ValidateCreate()...)
+	allErrors = append(allErrors, n.Spec.Access.Validate()...)
+	allErrors = append(allErrors, n.Spec.PodExtras.Validate()...)
+	allErrors = append(allErrors, n.Spec.Deletion.Validate()...)
+
+	warnings := n.Spec.ExtraArgs.Warnings(n, nodeArgs)
 
 	if len(allErrors) == 0 {
-		return nil, nil
+		return warnings, nil
 	}
 
-	return nil, apierrors.NewInvalid(schema.GroupKind{}, n.Name, allErrors)
+	return warnings, apierrors.NewInvalid(schema.GroupKind{}, n.Name, allErrors)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
@@ -42,13 +62,20 @@ func (n *Node) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
 		allErrors = append(allErrors, err)
 	}
 
+	allErrors = append(allErrors, n.validate()...)
 	allErrors = append(allErrors, n.Spec.Resources.ValidateUpdate(&oldNode.Spec.Resources)...)
+	allErrors = append(allErrors, n.Spec.Access.Validate()...)
+	allErrors = append(allErrors, n.Spec.PodExtras.Validate()...)
+	allErrors = append(allErrors, n.Spec.Deletion.Validate()...)
+	allErrors = append(allErrors, n.Spec.DataResync.ValidateUpdate(&oldNode.Spec.DataResync, false)...)
+
+	warnings := n.Spec.ExtraArgs.Warnings(n, nodeArgs)
 
 	if len(allErrors) == 0 {
-		return nil, nil
+		return warnings, nil
 	}
 
-	return nil, apierrors.NewInvalid(schema.GroupKind{}, n.Name, allErrors)
+	return warnings, apierrors.NewInvalid(schema.GroupKind{}, n.Name, allErrors)
 
 }
 
@@ -56,5 +83,5 @@ func (n *Node) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
 func (n *Node) ValidateDelete() (admission.Warnings, error) {
 	nodelog.Info("validate delete", "name", n.Name)
 
-	return nil, nil
+	return nil, shared.ValidateDeletionProtection(n)
 }
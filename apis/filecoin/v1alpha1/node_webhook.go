@@ -1,6 +1,9 @@
 package v1alpha1
 
 import (
+	"fmt"
+
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -8,8 +11,17 @@ import (
 // log is for logging in this package.
 var nodelog = logf.Log.WithName("node-resource")
 
+// nodeArgs generates node cli arguments checked against extra arguments by validation webhook
+var nodeArgs sharedAPI.ArgsGenerator
+
 // SetupWebhookWithManager sets up the webook with a given controller manager
-func (n *Node) SetupWebhookWithManager(mgr ctrl.Manager) error {
+// args is node cli arguments generator, webhook registration fails without it
+func (n *Node) SetupWebhookWithManager(mgr ctrl.Manager, args sharedAPI.ArgsGenerator) error {
+	if args == nil {
+		return fmt.Errorf("no cli arguments generator is registered for %T", n)
+	}
+	nodeArgs = args
+
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(n).
 		Complete()
@@ -11,13 +11,16 @@ type ClusterPeerSpec struct {
 	Image string `json:"image,omitempty"`
 	// ExtraArgs is extra arguments to pass down to the cli
 	ExtraArgs shared.ExtraArgs `json:"extraArgs,omitempty"`
+	// RemoveArgs is names of generated cli arguments to remove
+	// +listType=set
+	RemoveArgs []string `json:"removeArgs,omitempty"`
 	// Replicas is number of replicas
-	// +kubebuilder:validation:Enum=0;1
+	// +kubebuilder:validation:Minimum=0
 	Replicas *uint `json:"replicas,omitempty"`
 	// ID is the the cluster peer id
 	ID string `json:"id,omitempty"`
 	// PrivateKeySecretName is k8s secret holding private key
-	PrivateKeySecretName string `json:"privateKeySecretName,omitempty"`
+	PrivateKeySecretName shared.SecretKeySelector `json:"privateKeySecretName,omitempty"`
 	// TrustedPeers is CRDT trusted cluster peers who can manage the pinset
 	// +listType=set
 	TrustedPeers []string `json:"trustedPeers,omitempty"`
@@ -27,12 +30,28 @@ type ClusterPeerSpec struct {
 	// Consensus is ipfs cluster consensus algorithm
 	Consensus ConsensusAlgorithm `json:"consensus,omitempty"`
 	// ClusterSecretName is k8s secret holding cluster secret
-	ClusterSecretName string `json:"clusterSecretName"`
+	ClusterSecretName shared.SecretKeySelector `json:"clusterSecretName"`
 	// PeerEndpoint is ipfs peer http API endpoint
 	PeerEndpoint string `json:"peerEndpoint"`
 	// Logging is logging verboisty level
 	// +kubebuilder:validation:Enum=error;warn;info;debug
 	Logging shared.VerbosityLevel `json:"logging,omitempty"`
+	// Access is network access settings of node endpoints
+	Access shared.Access `json:"access,omitempty"`
+	// Disruption is pod disruption budget settings
+	Disruption shared.Disruption `json:"disruption,omitempty"`
+	// Metrics is Prometheus metrics settings
+	Metrics shared.Metrics `json:"metrics,omitempty"`
+	// Shutdown is graceful shutdown settings
+	Shutdown shared.Shutdown `json:"shutdown,omitempty"`
+	// Suspend scales peer to zero replicas, keeping its data, services and secrets
+	Suspend bool `json:"suspend,omitempty"`
+	// DataResync is node data resync settings
+	shared.DataResync `json:",inline"`
+	// Deletion is deletion settings of node data and generated secrets
+	shared.Deletion `json:",inline"`
+	// PodExtras is extra environment variables, volumes and containers injected into node pods
+	shared.PodExtras `json:",inline"`
 	// Resources is node compute and storage resources
 	shared.Resources `json:"resources,omitempty"`
 }
@@ -52,6 +71,12 @@ const (
 type ClusterPeerStatus struct {
 	Client    string `json:"client"`
 	Consensus string `json:"consensus"`
+	// Replicas is cluster peer replicas IDs
+	Replicas []shared.ReplicaStatus `json:"replicas,omitempty"`
+	// Mode is reconciliation mode
+	Mode shared.Mode `json:"mode,omitempty"`
+	// ResyncStatus is node data resync status
+	shared.ResyncStatus `json:",inline"`
 }
 
 // +kubebuilder:object:root=true
@@ -60,6 +85,7 @@ type ClusterPeerStatus struct {
 // ClusterPeer is the Schema for the clusterpeers API
 // +kubebuilder:printcolumn:name="Client",type=string,JSONPath=".status.client"
 // +kubebuilder:printcolumn:name="Consensus",type=string,JSONPath=".spec.consensus"
+// +kubebuilder:printcolumn:name="Mode",type=string,JSONPath=".status.mode"
 type ClusterPeer struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -51,9 +51,15 @@ func (r *ClusterPeer) Default() {
 		r.Spec.Consensus = DefaultIPFSClusterConsensus
 	}
 
+	if r.Spec.Metrics.Port == 0 {
+		r.Spec.Metrics.Port = DefaultClusterMetricsPort
+	}
+
 	if len(r.Spec.TrustedPeers) == 0 {
 		r.Spec.TrustedPeers = []string{"*"}
 	}
 
 	r.DefaultResources()
+
+	r.Spec.Disruption.Default()
 }
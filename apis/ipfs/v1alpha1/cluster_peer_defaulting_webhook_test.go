@@ -24,5 +24,6 @@ var _ = Describe("IPFS cluster peer defaulting", func() {
 		Expect(peer.Spec.Resources.MemoryLimit).To(Equal(DefaultNodeMemoryLimit))
 		Expect(peer.Spec.Resources.Storage).To(Equal(DefaultNodeStorageRequest))
 		Expect(peer.Spec.Consensus).To(Equal(DefaultIPFSClusterConsensus))
+		Expect(peer.Spec.Metrics.Port).To(Equal(DefaultClusterMetricsPort))
 	})
 })
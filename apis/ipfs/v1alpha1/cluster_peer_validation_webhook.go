@@ -1,6 +1,7 @@
 package v1alpha1
 
 import (
+	"github.com/kotalco/kotal/apis/shared"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -9,7 +10,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
-// +kubebuilder:webhook:verbs=create;update,path=/validate-ipfs-kotal-io-v1alpha1-clusterpeer,mutating=false,failurePolicy=fail,groups=ipfs.kotal.io,resources=clusterpeers,versions=v1alpha1,name=validate-ipfs-v1alpha1-clusterpeer.kb.io,sideEffects=None,admissionReviewVersions=v1
+// +kubebuilder:webhook:verbs=create;update;delete,path=/validate-ipfs-kotal-io-v1alpha1-clusterpeer,mutating=false,failurePolicy=fail,groups=ipfs.kotal.io,resources=clusterpeers,versions=v1alpha1,name=validate-ipfs-v1alpha1-clusterpeer.kb.io,sideEffects=None,admissionReviewVersions=v1
 
 var _ webhook.Validator = &ClusterPeer{}
 
@@ -18,13 +19,13 @@ func (r *ClusterPeer) validate() field.ErrorList {
 	var nodeErrors field.ErrorList
 
 	// privateKeySecretName is required if id is given
-	if r.Spec.ID != "" && r.Spec.PrivateKeySecretName == "" {
+	if r.Spec.ID != "" && r.Spec.PrivateKeySecretName.Name == "" {
 		err := field.Invalid(field.NewPath("spec").Child("privateKeySecretName"), "", "must provide privateKeySecretName if id is provided")
 		nodeErrors = append(nodeErrors, err)
 	}
 
 	// id is required if privateKeySecretName is given
-	if r.Spec.PrivateKeySecretName != "" && r.Spec.ID == "" {
+	if r.Spec.PrivateKeySecretName.Name != "" && r.Spec.ID == "" {
 		err := field.Invalid(field.NewPath("spec").Child("id"), "", "must provide id if privateKeySecretName is provided")
 		nodeErrors = append(nodeErrors, err)
 	}
@@ -41,12 +42,17 @@ func (r *ClusterPeer) ValidateCreate() (admission.Warnings, error) {
 
 	allErrors = append(allErrors, r.validate()...)
 	allErrors = append(allErrors, r.Spec.Resources.ValidateCreate()...)
+	allErrors = append(allErrors, r.Spec.Access.Validate()...)
+	allErrors = append(allErrors, r.Spec.PodExtras.Validate()...)
+	allErrors = append(allErrors, r.Spec.Deletion.Validate()...)
+
+	warnings := r.Spec.ExtraArgs.Warnings(r, clusterPeerArgs)
 
 	if len(allErrors) == 0 {
-		return nil, nil
+		return warnings, nil
 	}
 
-	return nil, apierrors.NewInvalid(schema.GroupKind{}, r.Name, allErrors)
+	return warnings, apierrors.NewInvalid(schema.GroupKind{}, r.Name, allErrors)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
@@ -67,23 +73,29 @@ func (r *ClusterPeer) ValidateUpdate(old runtime.Object) (admission.Warnings, er
 	}
 
 	if oldClusterPeer.Spec.PrivateKeySecretName != r.Spec.PrivateKeySecretName {
-		err := field.Invalid(field.NewPath("spec").Child("privateKeySecretName"), r.Spec.PrivateKeySecretName, "field is immutable")
+		err := field.Invalid(field.NewPath("spec").Child("privateKeySecretName"), r.Spec.PrivateKeySecretName.Name, "field is immutable")
 		allErrors = append(allErrors, err)
 	}
 
 	allErrors = append(allErrors, r.validate()...)
 	allErrors = append(allErrors, r.Spec.Resources.ValidateUpdate(&oldClusterPeer.Spec.Resources)...)
+	allErrors = append(allErrors, r.Spec.Access.Validate()...)
+	allErrors = append(allErrors, r.Spec.PodExtras.Validate()...)
+	allErrors = append(allErrors, r.Spec.Deletion.Validate()...)
+	allErrors = append(allErrors, r.Spec.DataResync.ValidateUpdate(&oldClusterPeer.Spec.DataResync, false)...)
+
+	warnings := r.Spec.ExtraArgs.Warnings(r, clusterPeerArgs)
 
 	if len(allErrors) == 0 {
-		return nil, nil
+		return warnings, nil
 	}
 
-	return nil, apierrors.NewInvalid(schema.GroupKind{}, r.Name, allErrors)
+	return warnings, apierrors.NewInvalid(schema.GroupKind{}, r.Name, allErrors)
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
 func (r *ClusterPeer) ValidateDelete() (admission.Warnings, error) {
 	clusterpeerlog.Info("validate delete", "name", r.Name)
 
-	return nil, nil
+	return nil, shared.ValidateDeletionProtection(r)
 }
@@ -36,7 +36,7 @@ var _ = Describe("IPFS cluster peer validation", func() {
 			Title: "Cluster Peer #1",
 			Peer: &ClusterPeer{
 				Spec: ClusterPeerSpec{
-					PrivateKeySecretName: "my-cluster-privatekey",
+					PrivateKeySecretName: shared.SecretKeySelector{Name: "my-cluster-privatekey"},
 				},
 			},
 			Errors: field.ErrorList{
@@ -61,7 +61,7 @@ var _ = Describe("IPFS cluster peer validation", func() {
 			Peer: &ClusterPeer{
 				Spec: ClusterPeerSpec{
 					ID:                   "12D3KooWBcEtY8GH4mNkri9kM3haeWhEXtQV7mi81ErWrqLYGuiq",
-					PrivateKeySecretName: "my-cluster-privatekey",
+					PrivateKeySecretName: shared.SecretKeySelector{Name: "my-cluster-privatekey"},
 				},
 			},
 			NewPeer: &ClusterPeer{
@@ -82,13 +82,13 @@ var _ = Describe("IPFS cluster peer validation", func() {
 			Title: "Cluster Peer #2",
 			Peer: &ClusterPeer{
 				Spec: ClusterPeerSpec{
-					PrivateKeySecretName: "my-cluster-privatekey",
+					PrivateKeySecretName: shared.SecretKeySelector{Name: "my-cluster-privatekey"},
 					ID:                   "12D3KooWBcEtY8GH4mNkri9kM3haeWhEXtQV7mi81ErWrqLYGuiq",
 				},
 			},
 			NewPeer: &ClusterPeer{
 				Spec: ClusterPeerSpec{
-					PrivateKeySecretName: "my-cluster-privatekey",
+					PrivateKeySecretName: shared.SecretKeySelector{Name: "my-cluster-privatekey"},
 				},
 			},
 			Errors: field.ErrorList{
@@ -125,13 +125,13 @@ var _ = Describe("IPFS cluster peer validation", func() {
 			Title: "Cluster Peer #4",
 			Peer: &ClusterPeer{
 				Spec: ClusterPeerSpec{
-					PrivateKeySecretName: "my-cluster-privatekey",
+					PrivateKeySecretName: shared.SecretKeySelector{Name: "my-cluster-privatekey"},
 					ID:                   "12D3KooWBcEtY8GH4mNkri9kM3haeWhEXtQV7mi81ErWrqLYGuiq",
 				},
 			},
 			NewPeer: &ClusterPeer{
 				Spec: ClusterPeerSpec{
-					PrivateKeySecretName: "my-cluster-privatekey",
+					PrivateKeySecretName: shared.SecretKeySelector{Name: "my-cluster-privatekey"},
 					ID:                   "12D3KooWBcEtY8GH4mNkri9kM3haeWhEXtQV7mi81ErWrqLYGuir",
 				},
 			},
@@ -148,13 +148,13 @@ var _ = Describe("IPFS cluster peer validation", func() {
 			Title: "Cluster Peer #5",
 			Peer: &ClusterPeer{
 				Spec: ClusterPeerSpec{
-					PrivateKeySecretName: "my-cluster-privatekey",
+					PrivateKeySecretName: shared.SecretKeySelector{Name: "my-cluster-privatekey"},
 					ID:                   "12D3KooWBcEtY8GH4mNkri9kM3haeWhEXtQV7mi81ErWrqLYGuir",
 				},
 			},
 			NewPeer: &ClusterPeer{
 				Spec: ClusterPeerSpec{
-					PrivateKeySecretName: "your-cluster-privatekey",
+					PrivateKeySecretName: shared.SecretKeySelector{Name: "your-cluster-privatekey"},
 					ID:                   "12D3KooWBcEtY8GH4mNkri9kM3haeWhEXtQV7mi81ErWrqLYGuir",
 				},
 			},
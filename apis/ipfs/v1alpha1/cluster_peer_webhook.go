@@ -1,6 +1,9 @@
 package v1alpha1
 
 import (
+	"fmt"
+
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -8,7 +11,17 @@ import (
 // log is for logging in this package.
 var clusterpeerlog = logf.Log.WithName("clusterpeer-resource")
 
-func (r *ClusterPeer) SetupWebhookWithManager(mgr ctrl.Manager) error {
+// clusterPeerArgs generates cluster peer cli arguments checked against extra arguments by validation webhook
+var clusterPeerArgs sharedAPI.ArgsGenerator
+
+// SetupWebhookWithManager sets up the webook with a given controller manager
+// args is cluster peer cli arguments generator, webhook registration fails without it
+func (r *ClusterPeer) SetupWebhookWithManager(mgr ctrl.Manager, args sharedAPI.ArgsGenerator) error {
+	if args == nil {
+		return fmt.Errorf("no cli arguments generator is registered for %T", r)
+	}
+	clusterPeerArgs = args
+
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
 		Complete()
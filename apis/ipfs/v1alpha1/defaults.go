@@ -42,4 +42,6 @@ const (
 const (
 	// DefaultIPFSClusterConsensus is the default ipfs cluster consensus algorithm
 	DefaultIPFSClusterConsensus = CRDT
+	// DefaultClusterMetricsPort is the default ipfs cluster prometheus metrics port
+	DefaultClusterMetricsPort uint = 8888
 )
@@ -11,8 +11,11 @@ type PeerSpec struct {
 	Image string `json:"image,omitempty"`
 	// ExtraArgs is extra arguments to pass down to the cli
 	ExtraArgs shared.ExtraArgs `json:"extraArgs,omitempty"`
+	// RemoveArgs is names of generated cli arguments to remove
+	// +listType=set
+	RemoveArgs []string `json:"removeArgs,omitempty"`
 	// Replicas is number of replicas
-	// +kubebuilder:validation:Enum=0;1
+	// +kubebuilder:validation:Minimum=0
 	Replicas *uint `json:"replicas,omitempty"`
 	// InitProfiles is the intial profiles to apply during
 	// +listType=set
@@ -31,10 +34,26 @@ type PeerSpec struct {
 	// Routing is the content routing mechanism
 	Routing RoutingMechanism `json:"routing,omitempty"`
 	// SwarmKeySecretName is the k8s secret holding swarm key
-	SwarmKeySecretName string `json:"swarmKeySecretName,omitempty"`
+	SwarmKeySecretName shared.SecretKeySelector `json:"swarmKeySecretName,omitempty"`
 	// Logging is logging verboisty level
 	// +kubebuilder:validation:Enum=error;warn;info;debug;notice
 	Logging shared.VerbosityLevel `json:"logging,omitempty"`
+	// Access is network access settings of node endpoints
+	Access shared.Access `json:"access,omitempty"`
+	// Disruption is pod disruption budget settings
+	Disruption shared.Disruption `json:"disruption,omitempty"`
+	// Metrics is Prometheus metrics settings
+	Metrics shared.Metrics `json:"metrics,omitempty"`
+	// Shutdown is graceful shutdown settings
+	Shutdown shared.Shutdown `json:"shutdown,omitempty"`
+	// Suspend scales peer to zero replicas, keeping its data, services and secrets
+	Suspend bool `json:"suspend,omitempty"`
+	// DataResync is node data resync settings
+	shared.DataResync `json:",inline"`
+	// Deletion is deletion settings of node data and generated secrets
+	shared.Deletion `json:",inline"`
+	// PodExtras is extra environment variables, volumes and containers injected into node pods
+	shared.PodExtras `json:",inline"`
 	// Resources is node compute and storage resources
 	shared.Resources `json:"resources,omitempty"`
 }
@@ -82,6 +101,10 @@ const (
 // PeerStatus defines the observed state of Peer
 type PeerStatus struct {
 	Client string `json:"client,omitempty"`
+	// Mode is reconciliation mode
+	Mode shared.Mode `json:"mode,omitempty"`
+	// ResyncStatus is node data resync status
+	shared.ResyncStatus `json:",inline"`
 }
 
 // +kubebuilder:object:root=true
@@ -89,6 +112,7 @@ type PeerStatus struct {
 
 // Peer is the Schema for the peers API
 // +kubebuilder:printcolumn:name="Client",type=string,JSONPath=".status.client"
+// +kubebuilder:printcolumn:name="Mode",type=string,JSONPath=".status.mode"
 type Peer struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
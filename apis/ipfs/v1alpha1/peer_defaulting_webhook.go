@@ -65,4 +65,6 @@ func (r *Peer) Default() {
 
 	r.DefaultPeerResources()
 
+	r.Spec.Disruption.Default()
+
 }
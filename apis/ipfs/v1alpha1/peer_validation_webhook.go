@@ -3,6 +3,7 @@ package v1alpha1
 import (
 	"strings"
 
+	"github.com/kotalco/kotal/apis/shared"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -11,23 +12,42 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
-// +kubebuilder:webhook:verbs=create;update,path=/validate-ipfs-kotal-io-v1alpha1-peer,mutating=false,failurePolicy=fail,groups=ipfs.kotal.io,resources=peers,versions=v1alpha1,name=validate-ipfs-v1alpha1-peer.kb.io,sideEffects=None,admissionReviewVersions=v1
+// +kubebuilder:webhook:verbs=create;update;delete,path=/validate-ipfs-kotal-io-v1alpha1-peer,mutating=false,failurePolicy=fail,groups=ipfs.kotal.io,resources=peers,versions=v1alpha1,name=validate-ipfs-v1alpha1-peer.kb.io,sideEffects=None,admissionReviewVersions=v1
 
 var _ webhook.Validator = &Peer{}
 
+// validate is common create and update validation rules
+func (p *Peer) validate() field.ErrorList {
+	var peerErrors field.ErrorList
+
+	// metrics are served by the API server
+	if p.Spec.Metrics.Enabled && !p.Spec.API {
+		err := field.Invalid(field.NewPath("spec").Child("metrics").Child("enabled"), p.Spec.Metrics.Enabled, "must be false if api is disabled")
+		peerErrors = append(peerErrors, err)
+	}
+
+	return peerErrors
+}
+
 // ValidateCreate valdates ipfs peers during their creation
 func (p *Peer) ValidateCreate() (admission.Warnings, error) {
 	var allErrors field.ErrorList
 
 	peerlog.Info("validate create", "name", p.Name)
 
+	allErrors = append(allErrors, p.validate()...)
 	allErrors = append(allErrors, p.Spec.Resources.ValidateCreate()...)
+	allErrors = append(allErrors, p.Spec.Access.Validate()...)
+	allErrors = append(allErrors, p.Spec.PodExtras.Validate()...)
+	allErrors = append(allErrors, p.Spec.Deletion.Validate()...)
+
+	warnings := p.Spec.ExtraArgs.Warnings(p, peerArgs)
 
 	if len(allErrors) == 0 {
-		return nil, nil
+		return warnings, nil
 	}
 
-	return nil, apierrors.NewInvalid(schema.GroupKind{}, p.Name, allErrors)
+	return warnings, apierrors.NewInvalid(schema.GroupKind{}, p.Name, allErrors)
 }
 
 // initProfilesChanged returns true if initial profiles changed
@@ -48,7 +68,7 @@ func (p *Peer) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
 	peerlog.Info("validate update", "name", p.Name)
 
 	if oldPeer.Spec.SwarmKeySecretName != p.Spec.SwarmKeySecretName {
-		err := field.Invalid(field.NewPath("spec").Child("swarmKeySecretName"), p.Spec.SwarmKeySecretName, "field is immutable")
+		err := field.Invalid(field.NewPath("spec").Child("swarmKeySecretName"), p.Spec.SwarmKeySecretName.Name, "field is immutable")
 		allErrors = append(allErrors, err)
 	}
 
@@ -61,18 +81,25 @@ func (p *Peer) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
 		allErrors = append(allErrors, err)
 	}
 
+	allErrors = append(allErrors, p.validate()...)
 	allErrors = append(allErrors, p.Spec.Resources.ValidateUpdate(&oldPeer.Spec.Resources)...)
+	allErrors = append(allErrors, p.Spec.Access.Validate()...)
+	allErrors = append(allErrors, p.Spec.PodExtras.Validate()...)
+	allErrors = append(allErrors, p.Spec.Deletion.Validate()...)
+	allErrors = append(allErrors, p.Spec.DataResync.ValidateUpdate(&oldPeer.Spec.DataResync, false)...)
+
+	warnings := p.Spec.ExtraArgs.Warnings(p, peerArgs)
 
 	if len(allErrors) == 0 {
-		return nil, nil
+		return warnings, nil
 	}
 
-	return nil, apierrors.NewInvalid(schema.GroupKind{}, p.Name, allErrors)
+	return warnings, apierrors.NewInvalid(schema.GroupKind{}, p.Name, allErrors)
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
 func (p *Peer) ValidateDelete() (admission.Warnings, error) {
 	peerlog.Info("validate delete", "name", p.Name)
 
-	return nil, nil
+	return nil, shared.ValidateDeletionProtection(p)
 }
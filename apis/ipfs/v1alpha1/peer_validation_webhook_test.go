@@ -54,6 +54,24 @@ var _ = Describe("IPFS peer validation", func() {
 				},
 			},
 		},
+		{
+			Title: "Peer #4",
+			Peer: &Peer{
+				Spec: PeerSpec{
+					Metrics: shared.Metrics{
+						Enabled: true,
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.metrics.enabled",
+					BadValue: true,
+					Detail:   "must be false if api is disabled",
+				},
+			},
+		},
 	}
 
 	updateCases := []struct {
@@ -120,12 +138,12 @@ var _ = Describe("IPFS peer validation", func() {
 			Title: "Peer #4",
 			Peer: &Peer{
 				Spec: PeerSpec{
-					SwarmKeySecretName: "my-swarm-key",
+					SwarmKeySecretName: shared.SecretKeySelector{Name: "my-swarm-key"},
 				},
 			},
 			NewPeer: &Peer{
 				Spec: PeerSpec{
-					SwarmKeySecretName: "your-swarm-key",
+					SwarmKeySecretName: shared.SecretKeySelector{Name: "your-swarm-key"},
 				},
 			},
 			Errors: field.ErrorList{
@@ -16,7 +16,7 @@ func (in *ClusterPeer) DeepCopyInto(out *ClusterPeer) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPeer.
@@ -79,11 +79,17 @@ func (in *ClusterPeerSpec) DeepCopyInto(out *ClusterPeerSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.RemoveArgs != nil {
+		in, out := &in.RemoveArgs, &out.RemoveArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Replicas != nil {
 		in, out := &in.Replicas, &out.Replicas
 		*out = new(uint)
 		**out = **in
 	}
+	out.PrivateKeySecretName = in.PrivateKeySecretName
 	if in.TrustedPeers != nil {
 		in, out := &in.TrustedPeers, &out.TrustedPeers
 		*out = make([]string, len(*in))
@@ -94,6 +100,14 @@ func (in *ClusterPeerSpec) DeepCopyInto(out *ClusterPeerSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	out.ClusterSecretName = in.ClusterSecretName
+	in.Access.DeepCopyInto(&out.Access)
+	in.Disruption.DeepCopyInto(&out.Disruption)
+	out.Metrics = in.Metrics
+	in.Shutdown.DeepCopyInto(&out.Shutdown)
+	out.DataResync = in.DataResync
+	out.Deletion = in.Deletion
+	in.PodExtras.DeepCopyInto(&out.PodExtras)
 	in.Resources.DeepCopyInto(&out.Resources)
 }
 
@@ -110,6 +124,12 @@ func (in *ClusterPeerSpec) DeepCopy() *ClusterPeerSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterPeerStatus) DeepCopyInto(out *ClusterPeerStatus) {
 	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = make([]shared.ReplicaStatus, len(*in))
+		copy(*out, *in)
+	}
+	in.ResyncStatus.DeepCopyInto(&out.ResyncStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPeerStatus.
@@ -128,7 +148,7 @@ func (in *Peer) DeepCopyInto(out *Peer) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Peer.
@@ -191,6 +211,11 @@ func (in *PeerSpec) DeepCopyInto(out *PeerSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.RemoveArgs != nil {
+		in, out := &in.RemoveArgs, &out.RemoveArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Replicas != nil {
 		in, out := &in.Replicas, &out.Replicas
 		*out = new(uint)
@@ -206,6 +231,14 @@ func (in *PeerSpec) DeepCopyInto(out *PeerSpec) {
 		*out = make([]Profile, len(*in))
 		copy(*out, *in)
 	}
+	out.SwarmKeySecretName = in.SwarmKeySecretName
+	in.Access.DeepCopyInto(&out.Access)
+	in.Disruption.DeepCopyInto(&out.Disruption)
+	out.Metrics = in.Metrics
+	in.Shutdown.DeepCopyInto(&out.Shutdown)
+	out.DataResync = in.DataResync
+	out.Deletion = in.Deletion
+	in.PodExtras.DeepCopyInto(&out.PodExtras)
 	in.Resources.DeepCopyInto(&out.Resources)
 }
 
@@ -222,6 +255,7 @@ func (in *PeerSpec) DeepCopy() *PeerSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PeerStatus) DeepCopyInto(out *PeerStatus) {
 	*out = *in
+	in.ResyncStatus.DeepCopyInto(&out.ResyncStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeerStatus.
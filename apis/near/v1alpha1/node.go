@@ -11,16 +11,20 @@ type NodeSpec struct {
 	Image string `json:"image,omitempty"`
 	// ExtraArgs is extra arguments to pass down to the cli
 	ExtraArgs shared.ExtraArgs `json:"extraArgs,omitempty"`
+	// RemoveArgs is names of generated cli arguments to remove
+	// +listType=set
+	RemoveArgs []string `json:"removeArgs,omitempty"`
 	// Replicas is number of replicas
-	// +kubebuilder:validation:Enum=0;1
+	// it must be 0 or 1 for validator nodes
+	// +kubebuilder:validation:Minimum=0
 	Replicas *uint `json:"replicas,omitempty"`
 	// Network is NEAR network to join and sync
 	// +kubebuilder:validation:Enum=mainnet;testnet;betanet
 	Network string `json:"network"`
 	// NodePrivateKeySecretName is the secret name holding node Ed25519 private key
-	NodePrivateKeySecretName string `json:"nodePrivateKeySecretName,omitempty"`
+	NodePrivateKeySecretName shared.SecretKeySelector `json:"nodePrivateKeySecretName,omitempty"`
 	// ValidatorSecretName is the secret name holding node Ed25519 validator key
-	ValidatorSecretName string `json:"validatorSecretName,omitempty"`
+	ValidatorSecretName shared.SecretKeySelector `json:"validatorSecretName,omitempty"`
 	// MinPeers is minimum number of peers to start syncing/producing blocks
 	MinPeers uint `json:"minPeers,omitempty"`
 	// Archive keeps old blocks in the storage
@@ -38,6 +42,24 @@ type NodeSpec struct {
 	// Bootnodes is array of boot nodes to bootstrap network from
 	// +listType=set
 	Bootnodes []string `json:"bootnodes,omitempty"`
+	// Access is network access settings of node endpoints
+	Access shared.Access `json:"access,omitempty"`
+	// Disruption is pod disruption budget settings
+	Disruption shared.Disruption `json:"disruption,omitempty"`
+	// Metrics is Prometheus metrics settings
+	Metrics shared.Metrics `json:"metrics,omitempty"`
+	// SecretStore is external secret store keys are loaded from instead of Kubernetes secrets
+	SecretStore *shared.SecretStore `json:"secretStore,omitempty"`
+	// Shutdown is graceful shutdown settings
+	Shutdown shared.Shutdown `json:"shutdown,omitempty"`
+	// Suspend scales node to zero replicas, keeping its data, services and secrets
+	Suspend bool `json:"suspend,omitempty"`
+	// DataResync is node data resync settings
+	shared.DataResync `json:",inline"`
+	// Deletion is deletion settings of node data and generated secrets
+	shared.Deletion `json:",inline"`
+	// PodExtras is extra environment variables, volumes and containers injected into node pods
+	shared.PodExtras `json:",inline"`
 	// Resources is node compute and storage resources
 	shared.Resources `json:"resources,omitempty"`
 }
@@ -45,6 +67,12 @@ type NodeSpec struct {
 // NodeStatus defines the observed state of Node
 type NodeStatus struct {
 	Client string `json:"client,omitempty"`
+	// Replicas is node replicas P2P identities
+	Replicas []shared.ReplicaStatus `json:"replicas,omitempty"`
+	// Mode is reconciliation mode
+	Mode shared.Mode `json:"mode,omitempty"`
+	// ResyncStatus is node data resync status
+	shared.ResyncStatus `json:",inline"`
 }
 
 // +kubebuilder:object:root=true
@@ -54,6 +82,7 @@ type NodeStatus struct {
 // +kubebuilder:printcolumn:name="Network",type=string,JSONPath=".spec.network"
 // +kubebuilder:printcolumn:name="Client",type=string,JSONPath=".status.client"
 // +kubebuilder:printcolumn:name="Validator",type=boolean,JSONPath=".spec.validator",priority=10
+// +kubebuilder:printcolumn:name="Mode",type=string,JSONPath=".status.mode"
 type Node struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
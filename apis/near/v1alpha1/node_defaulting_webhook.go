@@ -34,6 +34,10 @@ func (n *Node) Default() {
 		n.Spec.PrometheusPort = DefaultPrometheusPort
 	}
 
+	if n.Spec.Metrics.Port == 0 {
+		n.Spec.Metrics.Port = n.Spec.PrometheusPort
+	}
+
 	if n.Spec.P2PPort == 0 {
 		n.Spec.P2PPort = DefaultP2PPort
 	}
@@ -60,4 +64,6 @@ func (n *Node) Default() {
 		n.Spec.Storage = storage
 	}
 
+	n.Spec.Disruption.Default()
+
 }
@@ -24,6 +24,7 @@ var _ = Describe("NEAR node defaulting", func() {
 		Expect(node.Spec.P2PPort).To(Equal(DefaultP2PPort))
 		Expect(node.Spec.MinPeers).To(Equal(DefaultMinPeers))
 		Expect(node.Spec.PrometheusPort).To(Equal(DefaultPrometheusPort))
+		Expect(node.Spec.Metrics.Port).To(Equal(DefaultPrometheusPort))
 
 		Expect(node.Spec.Resources.CPU).To(Equal(DefaultNodeCPURequest))
 		Expect(node.Spec.Resources.CPULimit).To(Equal(DefaultNodeCPULimit))
@@ -1,6 +1,7 @@
 package v1alpha1
 
 import (
+	"github.com/kotalco/kotal/apis/shared"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -9,23 +10,45 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
-// +kubebuilder:webhook:verbs=create;update,path=/validate-near-kotal-io-v1alpha1-node,mutating=false,failurePolicy=fail,groups=near.kotal.io,resources=nodes,versions=v1alpha1,name=validate-near-v1alpha1-node.kb.io,sideEffects=None,admissionReviewVersions=v1
+// +kubebuilder:webhook:verbs=create;update;delete,path=/validate-near-kotal-io-v1alpha1-node,mutating=false,failurePolicy=fail,groups=near.kotal.io,resources=nodes,versions=v1alpha1,name=validate-near-v1alpha1-node.kb.io,sideEffects=None,admissionReviewVersions=v1
 
 var _ webhook.Validator = &Node{}
 
+// validate is common create and update validation rules
+func (n *Node) validate() field.ErrorList {
+	var nodeErrors field.ErrorList
+
+	// prometheus server is started with the JSON-RPC server
+	if n.Spec.Metrics.Enabled && !n.Spec.RPC {
+		err := field.Invalid(field.NewPath("spec").Child("metrics").Child("enabled"), n.Spec.Metrics.Enabled, "must be false if rpc is disabled")
+		nodeErrors = append(nodeErrors, err)
+	}
+
+	return nodeErrors
+}
+
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (n *Node) ValidateCreate() (admission.Warnings, error) {
 	var allErrors field.ErrorList
 
 	nodelog.Info("validate create", "name", n.Name)
 
+	allErrors = append(allErrors, n.validate()...)
 	allErrors = append(allErrors, n.Spec.Resources.ValidateCreate()...)
+	allErrors = append(allErrors, n.Spec.Access.Validate()...)
+	allErrors = append(allErrors, n.Spec.PodExtras.Validate()...)
+	allErrors = append(allErrors, n.Spec.Deletion.Validate()...)
+	allErrors = append(allErrors, shared.ValidateReplicas(n.Spec.Replicas, n.Spec.ValidatorSecretName.Name != "")...)
+	allErrors = append(allErrors, n.Spec.SecretStore.Validate()...)
+	allErrors = append(allErrors, n.Spec.SecretStore.ValidateNodeKey(n.Spec.NodePrivateKeySecretName, n.Spec.Replicas)...)
+
+	warnings := n.Spec.ExtraArgs.Warnings(n, nodeArgs)
 
 	if len(allErrors) == 0 {
-		return nil, nil
+		return warnings, nil
 	}
 
-	return nil, apierrors.NewInvalid(schema.GroupKind{}, n.Name, allErrors)
+	return warnings, apierrors.NewInvalid(schema.GroupKind{}, n.Name, allErrors)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
@@ -35,23 +58,33 @@ func (n *Node) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
 
 	nodelog.Info("validate update", "name", n.Name)
 
+	allErrors = append(allErrors, n.validate()...)
 	allErrors = append(allErrors, n.Spec.Resources.ValidateUpdate(&oldNode.Spec.Resources)...)
+	allErrors = append(allErrors, n.Spec.Access.Validate()...)
+	allErrors = append(allErrors, n.Spec.PodExtras.Validate()...)
+	allErrors = append(allErrors, n.Spec.Deletion.Validate()...)
+	allErrors = append(allErrors, shared.ValidateReplicas(n.Spec.Replicas, n.Spec.ValidatorSecretName.Name != "")...)
+	allErrors = append(allErrors, n.Spec.DataResync.ValidateUpdate(&oldNode.Spec.DataResync, n.Spec.ValidatorSecretName.Name != "")...)
+	allErrors = append(allErrors, n.Spec.SecretStore.Validate()...)
+	allErrors = append(allErrors, n.Spec.SecretStore.ValidateNodeKey(n.Spec.NodePrivateKeySecretName, n.Spec.Replicas)...)
 
 	if n.Spec.Network != oldNode.Spec.Network {
 		err := field.Invalid(field.NewPath("spec").Child("network"), n.Spec.Network, "field is immutable")
 		allErrors = append(allErrors, err)
 	}
 
+	warnings := n.Spec.ExtraArgs.Warnings(n, nodeArgs)
+
 	if len(allErrors) == 0 {
-		return nil, nil
+		return warnings, nil
 	}
 
-	return nil, apierrors.NewInvalid(schema.GroupKind{}, n.Name, allErrors)
+	return warnings, apierrors.NewInvalid(schema.GroupKind{}, n.Name, allErrors)
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
 func (n *Node) ValidateDelete() (admission.Warnings, error) {
 	nodelog.Info("validate delete", "name", n.Name)
 
-	return nil, nil
+	return nil, shared.ValidateDeletionProtection(n)
 }
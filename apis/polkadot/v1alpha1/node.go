@@ -34,8 +34,12 @@ type NodeSpec struct {
 	Image string `json:"image,omitempty"`
 	// ExtraArgs is extra arguments to pass down to the cli
 	ExtraArgs shared.ExtraArgs `json:"extraArgs,omitempty"`
+	// RemoveArgs is names of generated cli arguments to remove
+	// +listType=set
+	RemoveArgs []string `json:"removeArgs,omitempty"`
 	// Replicas is number of replicas
-	// +kubebuilder:validation:Enum=0;1
+	// it must be 0 or 1 for validator nodes
+	// +kubebuilder:validation:Minimum=0
 	Replicas *uint `json:"replicas,omitempty"`
 	// Network is the polkadot network/chain to join
 	// +kubebuilder:validation:Enum=polkadot;kusama;rococo;westend
@@ -43,7 +47,7 @@ type NodeSpec struct {
 	// P2PPort is p2p protocol tcp port
 	P2PPort uint `json:"p2pPort,omitempty"`
 	// NodePrivateKeySecretName is the secret name holding node Ed25519 private key
-	NodePrivateKeySecretName string `json:"nodePrivateKeySecretName,omitempty"`
+	NodePrivateKeySecretName shared.SecretKeySelector `json:"nodePrivateKeySecretName,omitempty"`
 	// Validator enables validator mode
 	Validator bool `json:"validator,omitempty"`
 	// SyncMode is the blockchain synchronization mode
@@ -76,12 +80,36 @@ type NodeSpec struct {
 	// CORSDomains is browser origins allowed to access the JSON-RPC HTTP and WS servers
 	// +listType=set
 	CORSDomains []string `json:"corsDomains,omitempty"`
+	// Access is network access settings of node endpoints
+	Access shared.Access `json:"access,omitempty"`
+	// Disruption is pod disruption budget settings
+	Disruption shared.Disruption `json:"disruption,omitempty"`
+	// Metrics is Prometheus metrics settings
+	Metrics shared.Metrics `json:"metrics,omitempty"`
+	// SecretStore is external secret store keys are loaded from instead of Kubernetes secrets
+	SecretStore *shared.SecretStore `json:"secretStore,omitempty"`
+	// Shutdown is graceful shutdown settings
+	Shutdown shared.Shutdown `json:"shutdown,omitempty"`
+	// Suspend scales node to zero replicas, keeping its data, services and secrets
+	Suspend bool `json:"suspend,omitempty"`
+	// DataResync is node data resync settings
+	shared.DataResync `json:",inline"`
+	// Deletion is deletion settings of node data and generated secrets
+	shared.Deletion `json:",inline"`
+	// PodExtras is extra environment variables, volumes and containers injected into node pods
+	shared.PodExtras `json:",inline"`
 	// Resources is node compute and storage resources
 	shared.Resources `json:"resources,omitempty"`
 }
 
 // NodeStatus defines the observed state of Node
 type NodeStatus struct {
+	// Replicas is node replicas P2P identities
+	Replicas []shared.ReplicaStatus `json:"replicas,omitempty"`
+	// Mode is reconciliation mode
+	Mode shared.Mode `json:"mode,omitempty"`
+	// ResyncStatus is node data resync status
+	shared.ResyncStatus `json:",inline"`
 }
 
 // +kubebuilder:object:root=true
@@ -90,6 +118,7 @@ type NodeStatus struct {
 // Node is the Schema for the nodes API
 // +kubebuilder:printcolumn:name="Network",type=string,JSONPath=".spec.network"
 // +kubebuilder:printcolumn:name="Validator",type=boolean,JSONPath=".spec.validator"
+// +kubebuilder:printcolumn:name="Mode",type=string,JSONPath=".status.mode"
 type Node struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -91,4 +91,10 @@ func (r *Node) Default() {
 		r.Spec.PrometheusPort = DefaultPrometheusPort
 	}
 
+	if r.Spec.Metrics.Port == 0 {
+		r.Spec.Metrics.Port = r.Spec.PrometheusPort
+	}
+
+	r.Spec.Disruption.Default()
+
 }
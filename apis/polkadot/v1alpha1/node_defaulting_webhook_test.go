@@ -40,6 +40,9 @@ var _ = Describe("Polkadot node defaulting", func() {
 		Expect(node.Spec.WSPort).To(Equal(DefaultWSPort))
 		Expect(node.Spec.TelemetryURL).To(Equal(DefaultTelemetryURL))
 		Expect(node.Spec.PrometheusPort).To(Equal(DefaultPrometheusPort))
+		Expect(node.Spec.Metrics.Port).To(Equal(DefaultPrometheusPort))
+		Expect(*node.Spec.Disruption.Enabled).To(BeFalse())
+		Expect(*node.Spec.Disruption.MaxUnavailable).To(Equal(uint(1)))
 		Expect(node.Spec.Pruning).To(Equal(&t))
 		Expect(node.Spec.Database).To(Equal(DefaultDatabaseBackend))
 		Expect(node.Spec.CORSDomains).To(ContainElement(DefaultCORSDomain))
@@ -0,0 +1,42 @@
+package shared
+
+import (
+	"net"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// AccessPeers is network peers allowed to access a node endpoint
+// +k8s:deepcopy-gen=true
+type AccessPeers struct {
+	// Namespaces is names of namespaces whose pods are allowed to access the endpoint
+	Namespaces []string `json:"namespaces,omitempty"`
+	// PodSelector is labels of pods in node namespace allowed to access the endpoint
+	PodSelector map[string]string `json:"podSelector,omitempty"`
+	// CIDRs is IP blocks allowed to access the endpoint
+	CIDRs []string `json:"cidrs,omitempty"`
+}
+
+// Access is network access settings of node endpoints
+// +k8s:deepcopy-gen=true
+type Access struct {
+	// Enabled restricts access to node endpoints using network policies
+	// P2P endpoints are always open
+	Enabled bool `json:"enabled,omitempty"`
+	// Endpoints is allowed peers keyed by endpoint port name like rpc, ws, engine, api and metrics
+	// endpoints without allowed peers are only accessible from pods in node namespace
+	Endpoints map[string]AccessPeers `json:"endpoints,omitempty"`
+}
+
+// Validate validates allowed peers of node endpoints
+func (a *Access) Validate() (errors field.ErrorList) {
+	for endpoint, peers := range a.Endpoints {
+		for i, cidr := range peers.CIDRs {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				path := field.NewPath("spec").Child("access").Child("endpoints").Key(endpoint).Child("cidrs").Index(i)
+				errors = append(errors, field.Invalid(path, cidr, "must be a valid CIDR"))
+			}
+		}
+	}
+	return
+}
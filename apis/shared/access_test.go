@@ -0,0 +1,40 @@
+package shared
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var _ = Describe("Access validation", func() {
+
+	It("Should accept valid CIDRs", func() {
+		access := &Access{
+			Enabled: true,
+			Endpoints: map[string]AccessPeers{
+				"rpc": {
+					CIDRs: []string{"10.0.0.0/8", "fd00::/8"},
+				},
+			},
+		}
+		Expect(access.Validate()).To(BeEmpty())
+	})
+
+	It("Should reject invalid CIDRs", func() {
+		access := &Access{
+			Enabled: true,
+			Endpoints: map[string]AccessPeers{
+				"rpc": {
+					CIDRs: []string{"10.0.0.0/8", "10.0.0.1"},
+				},
+			},
+		}
+		Expect(access.Validate()).To(ContainElements(&field.Error{
+			Type:     field.ErrorTypeInvalid,
+			Field:    "spec.access.endpoints[rpc].cidrs[1]",
+			BadValue: "10.0.0.1",
+			Detail:   "must be a valid CIDR",
+		}))
+	})
+
+})
@@ -0,0 +1,65 @@
+package shared
+
+import (
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// DeletionProtectionAnnotation is custom resource annotation, deletion is rejected if it's "true"
+const DeletionProtectionAnnotation = "kotal.io/deletion-protection"
+
+// DeletionPolicy is what happens to node data and generated secrets if custom resource is deleted
+// +kubebuilder:validation:Enum=Delete;Retain;Snapshot
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyDelete deletes persistent volume claims and generated secrets with custom resource
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+	// DeletionPolicyRetain keeps persistent volume claims and generated secrets after custom resource is deleted
+	DeletionPolicyRetain DeletionPolicy = "Retain"
+	// DeletionPolicySnapshot takes volume snapshots of persistent volume claims before they're deleted
+	// persistent volume claims are retained instead if volume snapshot CRDs are not installed
+	DeletionPolicySnapshot DeletionPolicy = "Snapshot"
+)
+
+// Deletion is deletion settings of custom resource
+// +k8s:deepcopy-gen=true
+type Deletion struct {
+	// DeletionPolicy is what happens to node data and generated secrets if custom resource is deleted
+	// +kubebuilder:default:=Delete
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+	// VolumeSnapshotClassName is volume snapshot class used by Snapshot deletion policy
+	// default volume snapshot class is used if it's empty
+	VolumeSnapshotClassName string `json:"volumeSnapshotClassName,omitempty"`
+}
+
+// Policy returns deletion policy, Delete is used if it's not set
+func (d *Deletion) Policy() DeletionPolicy {
+	if d.DeletionPolicy == "" {
+		return DeletionPolicyDelete
+	}
+	return d.DeletionPolicy
+}
+
+// Validate validates deletion settings
+func (d *Deletion) Validate() (errors field.ErrorList) {
+	if d.VolumeSnapshotClassName != "" && d.Policy() != DeletionPolicySnapshot {
+		path := field.NewPath("spec").Child("volumeSnapshotClassName")
+		errors = append(errors, field.Invalid(path, d.VolumeSnapshotClassName, "must be empty if deletionPolicy is not Snapshot"))
+	}
+	return
+}
+
+// ValidateDeletionProtection rejects deleting custom resource protected by deletion protection annotation
+func ValidateDeletionProtection(obj metav1.Object) error {
+	if obj.GetAnnotations()[DeletionProtectionAnnotation] != "true" {
+		return nil
+	}
+
+	err := fmt.Errorf("deletion protection is enabled, remove %s annotation first", DeletionProtectionAnnotation)
+	return apierrors.NewForbidden(schema.GroupResource{}, obj.GetName(), err)
+}
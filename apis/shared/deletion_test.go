@@ -0,0 +1,49 @@
+package shared
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var _ = Describe("Deletion validation", func() {
+
+	It("Should default deletion policy to Delete", func() {
+		deletion := Deletion{}
+		Expect(deletion.Policy()).To(Equal(DeletionPolicyDelete))
+	})
+
+	It("Should accept volume snapshot class if deletion policy is Snapshot", func() {
+		deletion := Deletion{DeletionPolicy: DeletionPolicySnapshot, VolumeSnapshotClassName: "csi-snapclass"}
+		Expect(deletion.Validate()).To(BeEmpty())
+	})
+
+	It("Should reject volume snapshot class if deletion policy is not Snapshot", func() {
+		deletion := Deletion{DeletionPolicy: DeletionPolicyRetain, VolumeSnapshotClassName: "csi-snapclass"}
+		Expect(deletion.Validate()).To(ContainElements(
+			&field.Error{
+				Type:     field.ErrorTypeInvalid,
+				Field:    "spec.volumeSnapshotClassName",
+				BadValue: "csi-snapclass",
+				Detail:   "must be empty if deletionPolicy is not Snapshot",
+			},
+		))
+	})
+
+	It("Should allow deleting unprotected resources", func() {
+		obj := &metav1.ObjectMeta{Name: "my-node"}
+		Expect(ValidateDeletionProtection(obj)).To(Succeed())
+	})
+
+	It("Should reject deleting protected resources", func() {
+		obj := &metav1.ObjectMeta{
+			Name:        "my-node",
+			Annotations: map[string]string{DeletionProtectionAnnotation: "true"},
+		}
+		err := ValidateDeletionProtection(obj)
+		Expect(apierrors.IsForbidden(err)).To(BeTrue())
+	})
+
+})
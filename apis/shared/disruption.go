@@ -0,0 +1,27 @@
+package shared
+
+// Disruption is pod disruption budget settings
+// +k8s:deepcopy-gen=true
+type Disruption struct {
+	// Enabled creates pod disruption budget for node pods
+	Enabled *bool `json:"enabled,omitempty"`
+	// MaxUnavailable is maximum number of node pods unavailable during voluntary disruptions
+	// it's capped to keep at least one pod available if node has multiple replicas
+	// signing nodes can be protected from voluntary disruptions like node drains by setting it to 0
+	// protected pods can't be evicted until pod disruption budget is disabled or maxUnavailable is raised
+	MaxUnavailable *uint `json:"maxUnavailable,omitempty"`
+}
+
+// Default sets pod disruption budget defaults
+// pod disruption budget is disabled by default, so node drains aren't blocked
+func (d *Disruption) Default() {
+	if d.Enabled == nil {
+		enabled := false
+		d.Enabled = &enabled
+	}
+
+	if d.MaxUnavailable == nil {
+		var maxUnavailable uint = 1
+		d.MaxUnavailable = &maxUnavailable
+	}
+}
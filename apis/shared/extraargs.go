@@ -1,26 +1,161 @@
 package shared
 
-import "fmt"
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
 
 // ExtraArgs is extra arguments to add to the cli
 // if kv is true, arguments will bey key=val format
 type ExtraArgs map[string]string
 
+// ArgsGenerator returns cli arguments generated from custom resource spec
+type ArgsGenerator func(obj runtime.Object) []string
+
+// keys returns extra arguments names sorted
+func (extra ExtraArgs) keys() []string {
+	keys := make([]string, 0, len(extra))
+	for key := range extra {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// encode encodes a single argument
+func (extra ExtraArgs) encode(key string, kv bool) []string {
+	val := extra[key]
+
+	// for toggles
+	if val == "" {
+		return []string{key}
+	}
+
+	if kv {
+		return []string{fmt.Sprintf("%s=%s", key, val)}
+	}
+
+	return []string{key, val}
+}
+
+// Encode encodes extra arguments sorted by name
 func (extra ExtraArgs) Encode(kv bool) (args []string) {
+	for _, key := range extra.keys() {
+		args = append(args, extra.encode(key, kv)...)
+	}
+
+	return
+}
+
+// isFlag returns true if argument is a flag and not a flag value or a positional argument
+func isFlag(arg string) bool {
+	return len(arg) > 1 && strings.HasPrefix(arg, "-")
+}
+
+// flags splits cli arguments into flags with their values and positional arguments
+// flag value is either given in flag=value format, or it's the following non flag argument
+func flags(args []string) (groups [][]string) {
+	for i := 0; i < len(args); i++ {
+		group := []string{args[i]}
+		if isFlag(args[i]) && !strings.Contains(args[i], "=") && i+1 < len(args) && !isFlag(args[i+1]) {
+			group = append(group, args[i+1])
+			i++
+		}
+		groups = append(groups, group)
+	}
+	return
+}
+
+// flagName returns flag name without value
+func flagName(arg string) string {
+	name, _, _ := strings.Cut(arg, "=")
+	return name
+}
+
+// Merge merges extra arguments into cli arguments generated from spec
+// generated flags are overridden in place by extra arguments with the same name
+// generated flags in remove list are dropped unless overridden
+// extra arguments that don't override generated flags are appended sorted by name
+func (extra ExtraArgs) Merge(args []string, remove []string, kv bool) (merged []string) {
+	removed := map[string]bool{}
+	for _, name := range remove {
+		removed[name] = true
+	}
+
+	overridden := map[string]bool{}
 
-	for key, val := range extra {
-		// for toggles
-		if val == "" {
-			args = append(args, key)
+	for _, group := range flags(args) {
+		if !isFlag(group[0]) {
+			merged = append(merged, group...)
 			continue
 		}
 
-		if kv {
-			args = append(args, fmt.Sprintf("%s=%s", key, val))
-		} else {
-			args = append(args, key, val)
+		name := flagName(group[0])
+
+		if _, ok := extra[name]; ok {
+			// repeated flags are overridden once
+			if !overridden[name] {
+				merged = append(merged, extra.encode(name, kv)...)
+				overridden[name] = true
+			}
+			continue
+		}
+
+		if removed[name] {
+			continue
+		}
+
+		merged = append(merged, group...)
+	}
+
+	for _, key := range extra.keys() {
+		if !overridden[key] {
+			merged = append(merged, extra.encode(key, kv)...)
 		}
 	}
 
 	return
 }
+
+// Shadowed returns extra arguments names overriding generated flags sorted by name
+func (extra ExtraArgs) Shadowed(args []string) (names []string) {
+	generated := map[string]bool{}
+	for _, group := range flags(args) {
+		if isFlag(group[0]) {
+			generated[flagName(group[0])] = true
+		}
+	}
+
+	for _, key := range extra.keys() {
+		if generated[key] {
+			names = append(names, key)
+		}
+	}
+
+	return
+}
+
+// Warnings returns warnings about extra arguments shadowing arguments generated from custom resource spec
+// generator is injected by the webhook registration, extra arguments can't be checked without it
+func (extra ExtraArgs) Warnings(obj runtime.Object, generator ArgsGenerator) (warnings admission.Warnings) {
+	if len(extra) == 0 {
+		return
+	}
+
+	if generator == nil {
+		path := field.NewPath("spec").Child("extraArgs")
+		return admission.Warnings{fmt.Sprintf("%s: can't be checked against arguments managed by spec", path)}
+	}
+
+	for _, name := range extra.Shadowed(generator(obj)) {
+		path := field.NewPath("spec").Child("extraArgs").Key(name)
+		warnings = append(warnings, fmt.Sprintf("%s: overrides argument managed by spec", path))
+	}
+
+	return
+}
@@ -0,0 +1,72 @@
+package shared
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var _ = Describe("Extra arguments", func() {
+
+	extra := ExtraArgs{
+		"--syncmode":   "full",
+		"--verbosity":  "5",
+		"--nodiscover": "",
+	}
+
+	It("Should encode extra arguments sorted by name", func() {
+		Expect(extra.Encode(false)).To(Equal([]string{
+			"--nodiscover",
+			"--syncmode", "full",
+			"--verbosity", "5",
+		}))
+		Expect(extra.Encode(true)).To(Equal([]string{
+			"--nodiscover",
+			"--syncmode=full",
+			"--verbosity=5",
+		}))
+	})
+
+	It("Should override and remove generated flags", func() {
+		args := []string{"neard", "--home", "/data", "run", "--syncmode", "snap", "--http", "--cache", "1024"}
+		Expect(extra.Merge(args, []string{"--http", "--verbosity"}, false)).To(Equal([]string{
+			"neard", "--home", "/data", "run",
+			"--syncmode", "full",
+			"--cache", "1024",
+			"--nodiscover",
+			"--verbosity", "5",
+		}))
+	})
+
+	It("Should override repeated key=value flags once", func() {
+		args := []string{"-datadir=/data", "-addnode=a", "-addnode=b", "-prune=0"}
+		extra := ExtraArgs{"-addnode": "c"}
+		Expect(extra.Merge(args, []string{"-prune"}, true)).To(Equal([]string{
+			"-datadir=/data",
+			"-addnode=c",
+		}))
+	})
+
+	It("Should list extra arguments shadowing generated flags", func() {
+		args := []string{"--syncmode", "snap", "--http", "--verbosity=3"}
+		Expect(extra.Shadowed(args)).To(Equal([]string{"--syncmode", "--verbosity"}))
+	})
+
+	It("Should warn about extra arguments shadowing generated flags", func() {
+		generator := func(obj runtime.Object) []string {
+			return []string{"--syncmode", "snap"}
+		}
+		Expect(extra.Warnings(&corev1.Pod{}, generator)).To(ConsistOf(
+			"spec.extraArgs[--syncmode]: overrides argument managed by spec",
+		))
+		Expect(ExtraArgs{}.Warnings(&corev1.Pod{}, generator)).To(BeEmpty())
+	})
+
+	It("Should warn about extra arguments when no generator is registered", func() {
+		Expect(extra.Warnings(&corev1.Pod{}, nil)).To(ConsistOf(
+			"spec.extraArgs: can't be checked against arguments managed by spec",
+		))
+	})
+
+})
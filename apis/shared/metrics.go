@@ -0,0 +1,14 @@
+package shared
+
+// Metrics is Prometheus metrics settings
+// +k8s:deepcopy-gen=true
+type Metrics struct {
+	// Enabled enables Prometheus metrics server
+	Enabled bool `json:"enabled,omitempty"`
+	// Port is Prometheus metrics server port
+	// it's ignored by clients serving metrics from their API server
+	Port uint `json:"port,omitempty"`
+	// Interval is Prometheus scrape interval, e.g. 30s
+	// +kubebuilder:validation:Pattern="^([0-9]+(ms|s|m|h))+$"
+	Interval string `json:"interval,omitempty"`
+}
@@ -0,0 +1,16 @@
+package shared
+
+// PausedAnnotation is custom resource annotation, owned objects aren't reconciled if it's "true"
+const PausedAnnotation = "kotal.io/paused"
+
+// Mode is custom resource reconciliation mode
+type Mode string
+
+const (
+	// ModeRunning reconciles owned objects according to custom resource spec
+	ModeRunning Mode = "Running"
+	// ModeSuspended scales node to zero replicas, keeping its data, services and secrets
+	ModeSuspended Mode = "Suspended"
+	// ModePaused leaves owned objects as is, so they can be edited by hand
+	ModePaused Mode = "Paused"
+)
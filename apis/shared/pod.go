@@ -0,0 +1,84 @@
+package shared
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// reservedVolumes is names of volumes generated by the operator
+var reservedVolumes = map[string]bool{
+	"data":      true,
+	"config":    true,
+	"secrets":   true,
+	"node-keys": true,
+}
+
+// PodExtras is extra environment variables, volumes and containers injected into node pods
+// +k8s:deepcopy-gen=true
+type PodExtras struct {
+	// ExtraEnv is extra environment variables added to node containers
+	// it overrides generated environment variables with the same name
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	ExtraEnv []corev1.EnvVar `json:"extraEnv,omitempty"`
+	// ExtraVolumes is extra volumes added to node pods
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	ExtraVolumes []corev1.Volume `json:"extraVolumes,omitempty"`
+	// ExtraVolumeMounts is extra volume mounts added to node containers
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	ExtraVolumeMounts []corev1.VolumeMount `json:"extraVolumeMounts,omitempty"`
+	// Sidecars is extra containers running alongside node containers
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Sidecars []corev1.Container `json:"sidecars,omitempty"`
+	// InitContainers is extra init containers running after generated init containers
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	InitContainers []corev1.Container `json:"initContainers,omitempty"`
+}
+
+// Validate validates extra volumes and containers names
+func (p *PodExtras) Validate() (errors field.ErrorList) {
+	volumes := map[string]bool{}
+	for i, volume := range p.ExtraVolumes {
+		path := field.NewPath("spec").Child("extraVolumes").Index(i).Child("name")
+		if reservedVolumes[volume.Name] {
+			errors = append(errors, field.Invalid(path, volume.Name, "is reserved for volumes generated by the operator"))
+		} else if volumes[volume.Name] {
+			errors = append(errors, field.Duplicate(path, volume.Name))
+		}
+		volumes[volume.Name] = true
+	}
+
+	containers := map[string]bool{}
+	for _, extra := range []struct {
+		name       string
+		containers []corev1.Container
+	}{
+		{"sidecars", p.Sidecars},
+		{"initContainers", p.InitContainers},
+	} {
+		for i, container := range extra.containers {
+			path := field.NewPath("spec").Child(extra.name).Index(i).Child("name")
+			// names of containers generated by the operator are checked against generated pod spec by controllers
+			if containers[container.Name] {
+				errors = append(errors, field.Duplicate(path, container.Name))
+			}
+			containers[container.Name] = true
+		}
+	}
+
+	// network policy endpoints are named container ports
+	for i, container := range p.Sidecars {
+		for j, port := range container.Ports {
+			if port.Name == "" {
+				path := field.NewPath("spec").Child("sidecars").Index(i).Child("ports").Index(j).Child("name")
+				errors = append(errors, field.Required(path, "sidecar ports must be named"))
+			}
+		}
+	}
+
+	return
+}
@@ -0,0 +1,67 @@
+package shared
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var _ = Describe("Pod extras validation", func() {
+
+	It("Should accept extra volumes and containers with unique names", func() {
+		extras := &PodExtras{
+			ExtraVolumes:   []corev1.Volume{{Name: "ca-bundle"}},
+			Sidecars:       []corev1.Container{{Name: "log-shipper"}},
+			InitContainers: []corev1.Container{{Name: "wait-for-peer"}},
+		}
+		Expect(extras.Validate()).To(BeEmpty())
+	})
+
+	It("Should reject volumes reserved for generated volumes", func() {
+		extras := &PodExtras{
+			ExtraVolumes: []corev1.Volume{{Name: "ca-bundle"}, {Name: "data"}, {Name: "secrets"}},
+		}
+		Expect(extras.Validate()).To(ContainElements(
+			&field.Error{
+				Type:     field.ErrorTypeInvalid,
+				Field:    "spec.extraVolumes[1].name",
+				BadValue: "data",
+				Detail:   "is reserved for volumes generated by the operator",
+			},
+			&field.Error{
+				Type:     field.ErrorTypeInvalid,
+				Field:    "spec.extraVolumes[2].name",
+				BadValue: "secrets",
+				Detail:   "is reserved for volumes generated by the operator",
+			},
+		))
+	})
+
+	It("Should reject duplicate volumes and containers", func() {
+		extras := &PodExtras{
+			ExtraVolumes:   []corev1.Volume{{Name: "ca-bundle"}, {Name: "ca-bundle"}},
+			Sidecars:       []corev1.Container{{Name: "log-shipper"}},
+			InitContainers: []corev1.Container{{Name: "log-shipper"}},
+		}
+		Expect(extras.Validate()).To(ConsistOf(
+			field.Duplicate(field.NewPath("spec").Child("extraVolumes").Index(1).Child("name"), "ca-bundle"),
+			field.Duplicate(field.NewPath("spec").Child("initContainers").Index(0).Child("name"), "log-shipper"),
+		))
+	})
+
+	It("Should reject unnamed sidecar ports", func() {
+		extras := &PodExtras{
+			Sidecars: []corev1.Container{
+				{
+					Name:  "log-shipper",
+					Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 8080}, {ContainerPort: 9000}},
+				},
+			},
+		}
+		Expect(extras.Validate()).To(ConsistOf(
+			field.Required(field.NewPath("spec").Child("sidecars").Index(0).Child("ports").Index(1).Child("name"), "sidecar ports must be named"),
+		))
+	})
+
+})
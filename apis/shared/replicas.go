@@ -0,0 +1,27 @@
+package shared
+
+import "k8s.io/apimachinery/pkg/util/validation/field"
+
+// ReplicaStatus is node replica status
+// +k8s:deepcopy-gen=true
+type ReplicaStatus struct {
+	// Name is replica pod name
+	Name string `json:"name"`
+	// ID is replica P2P identity like node public key or peer ID
+	ID string `json:"id,omitempty"`
+	// EnodeURL is replica enode URL, used by Ethereum nodes only
+	EnodeURL string `json:"enodeURL,omitempty"`
+}
+
+// ValidateReplicas validates node replicas
+// signing nodes like validators can't be scaled, replicas would sign with the same keys
+func ValidateReplicas(replicas *uint, signer bool) (errors field.ErrorList) {
+	if !signer || replicas == nil || *replicas <= 1 {
+		return
+	}
+
+	path := field.NewPath("spec").Child("replicas")
+	errors = append(errors, field.Invalid(path, *replicas, "must be 0 or 1 for signing nodes"))
+
+	return
+}
@@ -0,0 +1,33 @@
+package shared
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var _ = Describe("Replicas validation", func() {
+
+	It("Should accept multiple replicas of non-signing nodes", func() {
+		var replicas uint = 3
+		Expect(ValidateReplicas(&replicas, false)).To(BeEmpty())
+	})
+
+	It("Should accept single replica of signing nodes", func() {
+		var replicas uint = 1
+		Expect(ValidateReplicas(&replicas, true)).To(BeEmpty())
+	})
+
+	It("Should reject multiple replicas of signing nodes", func() {
+		var replicas uint = 2
+		Expect(ValidateReplicas(&replicas, true)).To(ConsistOf(
+			&field.Error{
+				Type:     field.ErrorTypeInvalid,
+				Field:    "spec.replicas",
+				BadValue: uint(2),
+				Detail:   "must be 0 or 1 for signing nodes",
+			},
+		))
+	})
+
+})
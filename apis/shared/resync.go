@@ -0,0 +1,43 @@
+package shared
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// DataResync is node data resync settings
+// +k8s:deepcopy-gen=true
+type DataResync struct {
+	// Resync is data resync generation, node data is wiped and synced from scratch whenever it's increased
+	Resync uint `json:"resync,omitempty"`
+	// ForceResync allows wiping validator data, which may hold slashing protection database
+	ForceResync bool `json:"forceResync,omitempty"`
+}
+
+// ValidateUpdate validates data resync update
+// validator data can't be resynced unless forced
+func (r *DataResync) ValidateUpdate(oldResync *DataResync, validator bool) (errors field.ErrorList) {
+	path := field.NewPath("spec").Child("resync")
+
+	if r.Resync < oldResync.Resync {
+		msg := fmt.Sprintf("must be greater than or equal to old resync %d", oldResync.Resync)
+		errors = append(errors, field.Invalid(path, r.Resync, msg))
+	}
+
+	if r.Resync > oldResync.Resync && validator && !r.ForceResync {
+		errors = append(errors, field.Invalid(path, r.Resync, "validator data can't be resynced unless forceResync is true"))
+	}
+
+	return
+}
+
+// ResyncStatus is node data resync status
+// +k8s:deepcopy-gen=true
+type ResyncStatus struct {
+	// Resync is the last rolled out data resync generation
+	Resync uint `json:"resync,omitempty"`
+	// LastResyncTime is when node data resync was last rolled out
+	LastResyncTime *metav1.Time `json:"lastResyncTime,omitempty"`
+}
@@ -0,0 +1,45 @@
+package shared
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var _ = Describe("Data resync validation", func() {
+
+	It("Should accept increasing resync generation", func() {
+		resync := DataResync{Resync: 2}
+		Expect(resync.ValidateUpdate(&DataResync{Resync: 1}, false)).To(BeEmpty())
+	})
+
+	It("Should reject decreasing resync generation", func() {
+		resync := DataResync{Resync: 1}
+		Expect(resync.ValidateUpdate(&DataResync{Resync: 2}, false)).To(ContainElements(
+			&field.Error{
+				Type:     field.ErrorTypeInvalid,
+				Field:    "spec.resync",
+				BadValue: uint(1),
+				Detail:   "must be greater than or equal to old resync 2",
+			},
+		))
+	})
+
+	It("Should reject resyncing validator data unless forced", func() {
+		resync := DataResync{Resync: 1}
+		Expect(resync.ValidateUpdate(&DataResync{}, true)).To(ContainElements(
+			&field.Error{
+				Type:     field.ErrorTypeInvalid,
+				Field:    "spec.resync",
+				BadValue: uint(1),
+				Detail:   "validator data can't be resynced unless forceResync is true",
+			},
+		))
+	})
+
+	It("Should accept forced validator data resync", func() {
+		resync := DataResync{Resync: 1, ForceResync: true}
+		Expect(resync.ValidateUpdate(&DataResync{}, true)).To(BeEmpty())
+	})
+
+})
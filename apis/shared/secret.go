@@ -0,0 +1,54 @@
+package shared
+
+import (
+	"encoding/json"
+)
+
+// SecretKeySelector selects a key of a secret in custom resource namespace
+// it's either a bare secret name using the default key, or an object with secret name and key
+// +kubebuilder:validation:Type=""
+// +kubebuilder:pruning:PreserveUnknownFields
+// +k8s:deepcopy-gen=true
+type SecretKeySelector struct {
+	// Name is the secret name
+	Name string `json:"name"`
+	// Key is the secret key holding the value, default key is used if it's empty
+	Key string `json:"key,omitempty"`
+}
+
+// KeyOr returns selected key or default key if no key is selected
+func (s SecretKeySelector) KeyOr(defaultKey string) string {
+	if s.Key == "" {
+		return defaultKey
+	}
+	return s.Key
+}
+
+// UnmarshalJSON decodes secret key selector from bare secret name or secret name and key object
+func (s *SecretKeySelector) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*s = SecretKeySelector{Name: name}
+		return nil
+	}
+
+	// alias has no UnmarshalJSON method, avoiding infinite recursion
+	type selector SecretKeySelector
+	var sel selector
+	if err := json.Unmarshal(data, &sel); err != nil {
+		return err
+	}
+
+	*s = SecretKeySelector(sel)
+	return nil
+}
+
+// MarshalJSON encodes secret key selector as bare secret name if default key is used
+func (s SecretKeySelector) MarshalJSON() ([]byte, error) {
+	if s.Key == "" {
+		return json.Marshal(s.Name)
+	}
+
+	type selector SecretKeySelector
+	return json.Marshal(selector(s))
+}
@@ -0,0 +1,70 @@
+package shared
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// SecretStoreProvider is external secret store provider
+// +kubebuilder:validation:Enum=csi;vault
+type SecretStoreProvider string
+
+const (
+	// CSISecretStore mounts keys using Secrets Store CSI driver
+	// secret key selectors name secret provider class and the object alias holding the key
+	CSISecretStore SecretStoreProvider = "csi"
+	// VaultSecretStore injects keys using Vault agent injector
+	// secret key selectors name Vault secret path and the secret field holding the key
+	VaultSecretStore SecretStoreProvider = "vault"
+)
+
+// SecretStore is external secret store keys are loaded from instead of Kubernetes secrets
+// keys are mounted into node pods, the operator never reads them
+// +k8s:deepcopy-gen=true
+type SecretStore struct {
+	// Provider is external secret store provider
+	Provider SecretStoreProvider `json:"provider"`
+	// VaultRole is Vault Kubernetes auth role used by Vault agent
+	VaultRole string `json:"vaultRole,omitempty"`
+	// VaultPath is Vault KV v2 secrets path prefix, secret key selectors names are relative to it
+	VaultPath string `json:"vaultPath,omitempty"`
+}
+
+// Validate validates secret store settings
+func (s *SecretStore) Validate() (errors field.ErrorList) {
+	if s == nil {
+		return
+	}
+
+	path := field.NewPath("spec").Child("secretStore")
+
+	switch s.Provider {
+	case CSISecretStore:
+		if s.VaultRole != "" {
+			errors = append(errors, field.Invalid(path.Child("vaultRole"), s.VaultRole, "not supported by csi provider"))
+		}
+		if s.VaultPath != "" {
+			errors = append(errors, field.Invalid(path.Child("vaultPath"), s.VaultPath, "not supported by csi provider"))
+		}
+	case VaultSecretStore:
+		if s.VaultRole == "" {
+			errors = append(errors, field.Required(path.Child("vaultRole"), "must provide vaultRole if provider is vault"))
+		}
+	default:
+		errors = append(errors, field.NotSupported(path.Child("provider"), s.Provider, []string{string(CSISecretStore), string(VaultSecretStore)}))
+	}
+
+	return
+}
+
+// ValidateNodeKey validates node private key loaded from secret store is used by a single replica
+// replicas node keys are derived from node private key, which the operator can't read from secret store
+func (s *SecretStore) ValidateNodeKey(nodeKey SecretKeySelector, replicas *uint) (errors field.ErrorList) {
+	if s == nil || nodeKey.Name == "" || replicas == nil || *replicas <= 1 {
+		return
+	}
+
+	path := field.NewPath("spec").Child("replicas")
+	errors = append(errors, field.Invalid(path, *replicas, "must be 1 if node private key is loaded from secret store"))
+
+	return
+}
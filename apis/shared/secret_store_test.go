@@ -0,0 +1,68 @@
+package shared
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var _ = Describe("Secret store validation", func() {
+
+	It("Should accept missing secret store", func() {
+		var store *SecretStore
+		Expect(store.Validate()).To(BeEmpty())
+	})
+
+	It("Should accept csi and vault secret stores", func() {
+		Expect((&SecretStore{Provider: CSISecretStore}).Validate()).To(BeEmpty())
+		Expect((&SecretStore{Provider: VaultSecretStore, VaultRole: "kotal", VaultPath: "secret/data/kotal"}).Validate()).To(BeEmpty())
+	})
+
+	It("Should require vault role if provider is vault", func() {
+		store := &SecretStore{Provider: VaultSecretStore}
+		Expect(store.Validate()).To(ContainElements(
+			&field.Error{
+				Type:     field.ErrorTypeRequired,
+				Field:    "spec.secretStore.vaultRole",
+				BadValue: "",
+				Detail:   "must provide vaultRole if provider is vault",
+			},
+		))
+	})
+
+	It("Should reject vault settings if provider is csi", func() {
+		store := &SecretStore{Provider: CSISecretStore, VaultRole: "kotal", VaultPath: "secret/data/kotal"}
+		Expect(store.Validate()).To(ContainElements(
+			&field.Error{
+				Type:     field.ErrorTypeInvalid,
+				Field:    "spec.secretStore.vaultRole",
+				BadValue: "kotal",
+				Detail:   "not supported by csi provider",
+			},
+			&field.Error{
+				Type:     field.ErrorTypeInvalid,
+				Field:    "spec.secretStore.vaultPath",
+				BadValue: "secret/data/kotal",
+				Detail:   "not supported by csi provider",
+			},
+		))
+	})
+
+	It("Should reject node private key loaded from secret store by multiple replicas", func() {
+		store := &SecretStore{Provider: CSISecretStore}
+		single, multiple := uint(1), uint(3)
+		nodeKey := SecretKeySelector{Name: "node-key"}
+
+		Expect(store.ValidateNodeKey(nodeKey, &single)).To(BeEmpty())
+		Expect(store.ValidateNodeKey(SecretKeySelector{}, &multiple)).To(BeEmpty())
+		Expect(store.ValidateNodeKey(nodeKey, &multiple)).To(ContainElements(
+			&field.Error{
+				Type:     field.ErrorTypeInvalid,
+				Field:    "spec.replicas",
+				BadValue: multiple,
+				Detail:   "must be 1 if node private key is loaded from secret store",
+			},
+		))
+	})
+
+})
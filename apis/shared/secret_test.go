@@ -0,0 +1,41 @@
+package shared
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Secret key selector", func() {
+
+	It("Should decode bare secret name", func() {
+		var selector SecretKeySelector
+		Expect(json.Unmarshal([]byte(`"my-secret"`), &selector)).To(Succeed())
+		Expect(selector).To(Equal(SecretKeySelector{Name: "my-secret"}))
+		Expect(selector.KeyOr("password")).To(Equal("password"))
+	})
+
+	It("Should decode secret name and key", func() {
+		var selector SecretKeySelector
+		Expect(json.Unmarshal([]byte(`{"name":"my-secret","key":"pass"}`), &selector)).To(Succeed())
+		Expect(selector).To(Equal(SecretKeySelector{Name: "my-secret", Key: "pass"}))
+		Expect(selector.KeyOr("password")).To(Equal("pass"))
+	})
+
+	It("Should reject invalid secret key selector", func() {
+		var selector SecretKeySelector
+		Expect(json.Unmarshal([]byte(`123`), &selector)).NotTo(Succeed())
+	})
+
+	It("Should encode secret key selector", func() {
+		data, err := json.Marshal(SecretKeySelector{Name: "my-secret"})
+		Expect(err).To(BeNil())
+		Expect(string(data)).To(Equal(`"my-secret"`))
+
+		data, err = json.Marshal(SecretKeySelector{Name: "my-secret", Key: "pass"})
+		Expect(err).To(BeNil())
+		Expect(string(data)).To(Equal(`{"name":"my-secret","key":"pass"}`))
+	})
+
+})
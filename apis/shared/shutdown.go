@@ -0,0 +1,12 @@
+package shared
+
+// Shutdown is node graceful shutdown settings
+// client recommended settings are used if they're not set
+// +k8s:deepcopy-gen=true
+type Shutdown struct {
+	// TerminationGracePeriodSeconds is seconds to wait for client graceful shutdown before it's killed
+	// +kubebuilder:validation:Minimum=0
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+	// PreStop is command executed in client container before it's stopped
+	PreStop []string `json:"preStop,omitempty"`
+}
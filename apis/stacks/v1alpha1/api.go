@@ -0,0 +1,87 @@
+package v1alpha1
+
+import (
+	"github.com/kotalco/kotal/apis/shared"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Postgres is PostgreSQL database connection settings
+type Postgres struct {
+	// Host is PostgreSQL server host
+	Host string `json:"host"`
+	// Port is PostgreSQL server port
+	Port uint `json:"port,omitempty"`
+	// Database is PostgreSQL database name
+	Database string `json:"database,omitempty"`
+	// Username is PostgreSQL user name
+	Username string `json:"username"`
+	// PasswordSecretName is k8s secret name holding PostgreSQL user password
+	PasswordSecretName shared.SecretKeySelector `json:"passwordSecretName"`
+}
+
+// APISpec defines the desired state of API
+type APISpec struct {
+	// Image is Stacks blockchain API image
+	Image string `json:"image,omitempty"`
+	// Replicas is number of replicas
+	// +kubebuilder:validation:Minimum=0
+	Replicas *uint `json:"replicas,omitempty"`
+	// StacksNode is Stacks node name in the same namespace
+	// API is registered as events observer of the node, and proxies its JSON-RPC server
+	StacksNode string `json:"stacksNode"`
+	// Port is REST and WebSocket server port
+	Port uint `json:"port,omitempty"`
+	// EventPort is port Stacks node events are received on
+	EventPort uint `json:"eventPort,omitempty"`
+	// Postgres is PostgreSQL database connection settings
+	Postgres Postgres `json:"postgres"`
+	// Access is network access settings of API endpoints
+	Access shared.Access `json:"access,omitempty"`
+	// Disruption is pod disruption budget settings
+	Disruption shared.Disruption `json:"disruption,omitempty"`
+	// Shutdown is graceful shutdown settings
+	Shutdown shared.Shutdown `json:"shutdown,omitempty"`
+	// Suspend scales API to zero replicas, keeping its services
+	Suspend bool `json:"suspend,omitempty"`
+	// Deletion is deletion settings of API generated objects
+	shared.Deletion `json:",inline"`
+	// PodExtras is extra environment variables, volumes and containers injected into API pods
+	shared.PodExtras `json:",inline"`
+	// Resources is API compute resources
+	shared.Resources `json:"resources,omitempty"`
+}
+
+// APIStatus defines the observed state of API
+type APIStatus struct {
+	Client string `json:"client,omitempty"`
+	// Mode is reconciliation mode
+	Mode shared.Mode `json:"mode,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// API is the Schema for the apis API
+// +kubebuilder:printcolumn:name="Stacks Node",type=string,JSONPath=".spec.stacksNode"
+// +kubebuilder:printcolumn:name="Client",type=string,JSONPath=".status.client"
+// +kubebuilder:printcolumn:name="Mode",type=string,JSONPath=".status.mode"
+type API struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   APISpec   `json:"spec,omitempty"`
+	Status APIStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// APIList contains a list of API
+type APIList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []API `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&API{}, &APIList{})
+}
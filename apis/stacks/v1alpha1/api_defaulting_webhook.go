@@ -0,0 +1,63 @@
+package v1alpha1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// +kubebuilder:webhook:path=/mutate-stacks-kotal-io-v1alpha1-api,mutating=true,failurePolicy=fail,groups=stacks.kotal.io,resources=apis,verbs=create;update,versions=v1alpha1,name=mutate-stacks-v1alpha1-api.kb.io,sideEffects=None,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &API{}
+
+func (r *API) DefaultAPIResources() {
+	if r.Spec.Resources.CPU == "" {
+		r.Spec.Resources.CPU = DefaultAPICPURequest
+	}
+
+	if r.Spec.Resources.CPULimit == "" {
+		r.Spec.Resources.CPULimit = DefaultAPICPULimit
+	}
+
+	if r.Spec.Resources.Memory == "" {
+		r.Spec.Resources.Memory = DefaultAPIMemoryRequest
+	}
+
+	if r.Spec.Resources.MemoryLimit == "" {
+		r.Spec.Resources.MemoryLimit = DefaultAPIMemoryLimit
+	}
+}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type
+func (r *API) Default() {
+	apilog.Info("default", "name", r.Name)
+
+	r.DefaultAPIResources()
+
+	if r.Spec.Image == "" {
+		r.Spec.Image = DefaultAPIImage
+	}
+
+	if r.Spec.Replicas == nil {
+		// constants are not addressable
+		replicas := DefaltReplicas
+		r.Spec.Replicas = &replicas
+	}
+
+	if r.Spec.Port == 0 {
+		r.Spec.Port = DefaultAPIPort
+	}
+
+	if r.Spec.EventPort == 0 {
+		r.Spec.EventPort = DefaultAPIEventPort
+	}
+
+	if r.Spec.Postgres.Port == 0 {
+		r.Spec.Postgres.Port = DefaultPostgresPort
+	}
+
+	if r.Spec.Postgres.Database == "" {
+		r.Spec.Postgres.Database = DefaultPostgresDatabase
+	}
+
+	r.Spec.Disruption.Default()
+
+}
@@ -0,0 +1,31 @@
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Stacks API defaulting", func() {
+	It("Should default Stacks API", func() {
+		api := API{
+			ObjectMeta: metav1.ObjectMeta{},
+			Spec: APISpec{
+				StacksNode: "stacks-node",
+			},
+		}
+
+		api.Default()
+
+		Expect(api.Spec.Image).To(Equal(DefaultAPIImage))
+		Expect(*api.Spec.Replicas).To(Equal(DefaltReplicas))
+		Expect(api.Spec.Port).To(Equal(DefaultAPIPort))
+		Expect(api.Spec.EventPort).To(Equal(DefaultAPIEventPort))
+		Expect(api.Spec.Postgres.Port).To(Equal(DefaultPostgresPort))
+		Expect(api.Spec.Postgres.Database).To(Equal(DefaultPostgresDatabase))
+		Expect(api.Spec.CPU).To(Equal(DefaultAPICPURequest))
+		Expect(api.Spec.CPULimit).To(Equal(DefaultAPICPULimit))
+		Expect(api.Spec.Memory).To(Equal(DefaultAPIMemoryRequest))
+		Expect(api.Spec.MemoryLimit).To(Equal(DefaultAPIMemoryLimit))
+	})
+})
@@ -0,0 +1,103 @@
+package v1alpha1
+
+import (
+	"github.com/kotalco/kotal/apis/shared"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// +kubebuilder:webhook:verbs=create;update;delete,path=/validate-stacks-kotal-io-v1alpha1-api,mutating=false,failurePolicy=fail,groups=stacks.kotal.io,resources=apis,versions=v1alpha1,name=validate-stacks-v1alpha1-api.kb.io,sideEffects=None,admissionReviewVersions=v1
+
+var _ webhook.Validator = &API{}
+
+// validate is common create and update validation rules
+func (r *API) validate() field.ErrorList {
+	var apiErrors field.ErrorList
+
+	path := field.NewPath("spec")
+
+	if r.Spec.StacksNode == "" {
+		err := field.Required(path.Child("stacksNode"), "must provide Stacks node name")
+		apiErrors = append(apiErrors, err)
+	}
+
+	if r.Spec.EventPort == r.Spec.Port {
+		err := field.Invalid(path.Child("eventPort"), r.Spec.EventPort, "must be different from port")
+		apiErrors = append(apiErrors, err)
+	}
+
+	postgresPath := path.Child("postgres")
+
+	if r.Spec.Postgres.Host == "" {
+		err := field.Required(postgresPath.Child("host"), "must provide PostgreSQL server host")
+		apiErrors = append(apiErrors, err)
+	}
+
+	if r.Spec.Postgres.Username == "" {
+		err := field.Required(postgresPath.Child("username"), "must provide PostgreSQL user name")
+		apiErrors = append(apiErrors, err)
+	}
+
+	if r.Spec.Postgres.PasswordSecretName.Name == "" {
+		err := field.Required(postgresPath.Child("passwordSecretName"), "must provide PostgreSQL user password secret name")
+		apiErrors = append(apiErrors, err)
+	}
+
+	return apiErrors
+}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *API) ValidateCreate() (admission.Warnings, error) {
+	var allErrors field.ErrorList
+
+	apilog.Info("validate create", "name", r.Name)
+
+	allErrors = append(allErrors, r.validate()...)
+	allErrors = append(allErrors, r.Spec.Resources.ValidateCreate()...)
+	allErrors = append(allErrors, r.Spec.Access.Validate()...)
+	allErrors = append(allErrors, r.Spec.PodExtras.Validate()...)
+	allErrors = append(allErrors, r.Spec.Deletion.Validate()...)
+
+	if len(allErrors) == 0 {
+		return nil, nil
+	}
+
+	return nil, apierrors.NewInvalid(schema.GroupKind{}, r.Name, allErrors)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *API) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	var allErrors field.ErrorList
+	oldAPI := old.(*API)
+
+	apilog.Info("validate update", "name", r.Name)
+
+	allErrors = append(allErrors, r.validate()...)
+	allErrors = append(allErrors, r.Spec.Resources.ValidateUpdate(&oldAPI.Spec.Resources)...)
+	allErrors = append(allErrors, r.Spec.Access.Validate()...)
+	allErrors = append(allErrors, r.Spec.PodExtras.Validate()...)
+	allErrors = append(allErrors, r.Spec.Deletion.Validate()...)
+
+	// database holds blocks of the node network
+	if r.Spec.StacksNode != oldAPI.Spec.StacksNode {
+		err := field.Invalid(field.NewPath("spec").Child("stacksNode"), r.Spec.StacksNode, "field is immutable")
+		allErrors = append(allErrors, err)
+	}
+
+	if len(allErrors) == 0 {
+		return nil, nil
+	}
+
+	return nil, apierrors.NewInvalid(schema.GroupKind{}, r.Name, allErrors)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *API) ValidateDelete() (admission.Warnings, error) {
+	apilog.Info("validate delete", "name", r.Name)
+
+	return nil, shared.ValidateDeletionProtection(r)
+}
@@ -0,0 +1,170 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	"github.com/kotalco/kotal/apis/shared"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var _ = Describe("Stacks API validation", func() {
+	postgres := Postgres{
+		Host:               "postgres",
+		Username:           "stacks",
+		PasswordSecretName: shared.SecretKeySelector{Name: "postgres-password"},
+	}
+
+	createCases := []struct {
+		Title  string
+		API    *API
+		Errors field.ErrorList
+	}{
+		{
+			Title: "missing stacks node",
+			API: &API{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-api",
+				},
+				Spec: APISpec{
+					Postgres: postgres,
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:   field.ErrorTypeRequired,
+					Field:  "spec.stacksNode",
+					Detail: "must provide Stacks node name",
+				},
+			},
+		},
+		{
+			Title: "event port is the same as port",
+			API: &API{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-api",
+				},
+				Spec: APISpec{
+					StacksNode: "stacks-node",
+					Port:       3999,
+					EventPort:  3999,
+					Postgres:   postgres,
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.eventPort",
+					BadValue: uint(3999),
+					Detail:   "must be different from port",
+				},
+			},
+		},
+		{
+			Title: "missing postgres connection settings",
+			API: &API{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-api",
+				},
+				Spec: APISpec{
+					StacksNode: "stacks-node",
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:   field.ErrorTypeRequired,
+					Field:  "spec.postgres.host",
+					Detail: "must provide PostgreSQL server host",
+				},
+				{
+					Type:   field.ErrorTypeRequired,
+					Field:  "spec.postgres.username",
+					Detail: "must provide PostgreSQL user name",
+				},
+				{
+					Type:   field.ErrorTypeRequired,
+					Field:  "spec.postgres.passwordSecretName",
+					Detail: "must provide PostgreSQL user password secret name",
+				},
+			},
+		},
+	}
+
+	updateCases := []struct {
+		Title  string
+		OldAPI *API
+		NewAPI *API
+		Errors field.ErrorList
+	}{
+		{
+			Title: "updated stacks node",
+			OldAPI: &API{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-api",
+				},
+				Spec: APISpec{
+					StacksNode: "stacks-node",
+					Postgres:   postgres,
+				},
+			},
+			NewAPI: &API{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-api",
+				},
+				Spec: APISpec{
+					StacksNode: "stacks-testnet-node",
+					Postgres:   postgres,
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.stacksNode",
+					BadValue: "stacks-testnet-node",
+					Detail:   "field is immutable",
+				},
+			},
+		},
+	}
+
+	Context("While creating API", func() {
+		for _, c := range createCases {
+			func() {
+				cc := c
+				It(fmt.Sprintf("Should validate %s", cc.Title), func() {
+					cc.API.Default()
+					_, err := cc.API.ValidateCreate()
+
+					errStatus := err.(*errors.StatusError)
+
+					causes := shared.ErrorsToCauses(cc.Errors)
+
+					Expect(errStatus.ErrStatus.Details.Causes).To(ContainElements(causes))
+				})
+			}()
+		}
+	})
+
+	Context("While updating API", func() {
+		for _, c := range updateCases {
+			func() {
+				cc := c
+				It(fmt.Sprintf("Should validate %s", cc.Title), func() {
+					cc.OldAPI.Default()
+					cc.NewAPI.Default()
+					_, err := cc.NewAPI.ValidateUpdate(cc.OldAPI)
+
+					errStatus := err.(*errors.StatusError)
+
+					causes := shared.ErrorsToCauses(cc.Errors)
+
+					Expect(errStatus.ErrStatus.Details.Causes).To(ContainElements(causes))
+				})
+			}()
+		}
+	})
+
+})
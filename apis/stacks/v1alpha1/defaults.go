@@ -9,6 +9,10 @@ const (
 	DefaultRPCPort uint = 20443
 	// DefaultP2PPort is the default p2p bind port
 	DefaultP2PPort uint = 20444
+	// DefaultMetricsPort is the default prometheus metrics port
+	DefaultMetricsPort uint = 9153
+	// DefaultEventsKey is the default key of events sent to events observers
+	DefaultEventsKey = "*"
 )
 
 const (
@@ -31,3 +35,48 @@ const (
 	// DefaultNodeStorageRequest is the Storage requested by Stacks node
 	DefaultNodeStorageRequest = "100Gi"
 )
+
+// Stacks blockchain API
+const (
+	// DefaultAPIImage is the default Stacks blockchain API image
+	DefaultAPIImage = "hirosystems/stacks-blockchain-api:7.10.0"
+	// DefaultAPIPort is the default REST and WebSocket server port
+	DefaultAPIPort uint = 3999
+	// DefaultAPIEventPort is the default port Stacks node events are received on
+	DefaultAPIEventPort uint = 3700
+	// DefaultPostgresPort is the default PostgreSQL server port
+	DefaultPostgresPort uint = 5432
+	// DefaultPostgresDatabase is the default PostgreSQL database name
+	DefaultPostgresDatabase = "stacks_blockchain_api"
+
+	// DefaultAPICPURequest is the cpu requested by Stacks blockchain API
+	DefaultAPICPURequest = "1"
+	// DefaultAPICPULimit is the cpu limit for Stacks blockchain API
+	DefaultAPICPULimit = "2"
+
+	// DefaultAPIMemoryRequest is the memory requested by Stacks blockchain API
+	DefaultAPIMemoryRequest = "2Gi"
+	// DefaultAPIMemoryLimit is the memory limit for Stacks blockchain API
+	DefaultAPIMemoryLimit = "4Gi"
+)
+
+// Stacks signer
+const (
+	// DefaultSignerImage is the default Stacks signer image
+	DefaultSignerImage = "blockstack/stacks-signer:2.5.0.0.3.0"
+	// DefaultSignerPort is the default port Stacks node events are received on
+	DefaultSignerPort uint = 30000
+
+	// DefaultSignerCPURequest is the cpu requested by Stacks signer
+	DefaultSignerCPURequest = "1"
+	// DefaultSignerCPULimit is the cpu limit for Stacks signer
+	DefaultSignerCPULimit = "2"
+
+	// DefaultSignerMemoryRequest is the memory requested by Stacks signer
+	DefaultSignerMemoryRequest = "1Gi"
+	// DefaultSignerMemoryLimit is the memory limit for Stacks signer
+	DefaultSignerMemoryLimit = "2Gi"
+
+	// DefaultSignerStorageRequest is the Storage requested by Stacks signer
+	DefaultSignerStorageRequest = "10Gi"
+)
@@ -25,7 +25,16 @@ type BitcoinNode struct {
 	// RpcUsername is bitcoin node JSON-RPC username
 	RpcUsername string `json:"rpcUsername"`
 	// RpcPasswordSecretName is k8s secret name holding bitcoin node JSON-RPC password
-	RpcPasswordSecretName string `json:"rpcPasswordSecretName"`
+	RpcPasswordSecretName shared.SecretKeySelector `json:"rpcPasswordSecretName"`
+}
+
+// EventObserver is Stacks node events observer
+type EventObserver struct {
+	// Endpoint is events observer host:port
+	Endpoint string `json:"endpoint"`
+	// EventsKeys is keys of events sent to the observer
+	// +listType=set
+	EventsKeys []string `json:"eventsKeys,omitempty"`
 }
 
 // NodeSpec defines the desired state of Node
@@ -34,8 +43,11 @@ type NodeSpec struct {
 	Image string `json:"image,omitempty"`
 	// ExtraArgs is extra arguments to pass down to the cli
 	ExtraArgs shared.ExtraArgs `json:"extraArgs,omitempty"`
+	// RemoveArgs is names of generated cli arguments to remove
+	// +listType=set
+	RemoveArgs []string `json:"removeArgs,omitempty"`
 	// Replicas is number of replicas
-	// +kubebuilder:validation:Enum=0;1
+	// +kubebuilder:validation:Minimum=0
 	Replicas *uint `json:"replicas,omitempty"`
 	// Network is stacks network
 	// +kubebuilder:validation:Enum=mainnet;testnet;xenon
@@ -47,15 +59,39 @@ type NodeSpec struct {
 	// P2PPort is p2p bind port
 	P2PPort uint `json:"p2pPort,omitempty"`
 	// BitcoinNode is Bitcoin node
-	BitcoinNode BitcoinNode `json:"bitcoinNode"`
+	BitcoinNode *BitcoinNode `json:"bitcoinNode,omitempty"`
+	// BitcoinNodeRef is Kotal Bitcoin node name in the same namespace, used instead of BitcoinNode
+	// its service, ports and the first rpc user credentials are used
+	BitcoinNodeRef string `json:"bitcoinNodeRef,omitempty"`
 	// Miner enables mining
 	Miner bool `json:"miner,omitempty"`
 	// SeedPrivateKeySecretName is k8s secret holding seed private key used for mining
-	SeedPrivateKeySecretName string `json:"seedPrivateKeySecretName,omitempty"`
+	SeedPrivateKeySecretName shared.SecretKeySelector `json:"seedPrivateKeySecretName,omitempty"`
 	// MineMicroblocks mines Stacks micro blocks
 	MineMicroblocks bool `json:"mineMicroblocks,omitempty"`
 	// NodePrivateKeySecretName is k8s secret holding node private key
-	NodePrivateKeySecretName string `json:"nodePrivateKeySecretName,omitempty"`
+	NodePrivateKeySecretName shared.SecretKeySelector `json:"nodePrivateKeySecretName,omitempty"`
+	// EventObservers is events observers the node sends events to
+	// Stacks APIs referencing the node are registered as observers automatically
+	EventObservers []EventObserver `json:"eventObservers,omitempty"`
+	// Access is network access settings of node endpoints
+	Access shared.Access `json:"access,omitempty"`
+	// Disruption is pod disruption budget settings
+	Disruption shared.Disruption `json:"disruption,omitempty"`
+	// Metrics is Prometheus metrics settings
+	Metrics shared.Metrics `json:"metrics,omitempty"`
+	// SecretStore is external secret store keys are loaded from instead of Kubernetes secrets
+	SecretStore *shared.SecretStore `json:"secretStore,omitempty"`
+	// Shutdown is graceful shutdown settings
+	Shutdown shared.Shutdown `json:"shutdown,omitempty"`
+	// Suspend scales node to zero replicas, keeping its data, services and secrets
+	Suspend bool `json:"suspend,omitempty"`
+	// DataResync is node data resync settings
+	shared.DataResync `json:",inline"`
+	// Deletion is deletion settings of node data and generated secrets
+	shared.Deletion `json:",inline"`
+	// PodExtras is extra environment variables, volumes and containers injected into node pods
+	shared.PodExtras `json:",inline"`
 	// Resources is node compute and storage resources
 	shared.Resources `json:"resources,omitempty"`
 }
@@ -63,6 +99,10 @@ type NodeSpec struct {
 // NodeStatus defines the observed state of Node
 type NodeStatus struct {
 	Client string `json:"client,omitempty"`
+	// Mode is reconciliation mode
+	Mode shared.Mode `json:"mode,omitempty"`
+	// ResyncStatus is node data resync status
+	shared.ResyncStatus `json:",inline"`
 }
 
 // +kubebuilder:object:root=true
@@ -72,6 +112,7 @@ type NodeStatus struct {
 // +kubebuilder:printcolumn:name="Network",type=string,JSONPath=".spec.network"
 // +kubebuilder:printcolumn:name="Client",type=string,JSONPath=".status.client"
 // +kubebuilder:printcolumn:name="Miner",type=boolean,JSONPath=".spec.miner"
+// +kubebuilder:printcolumn:name="Mode",type=string,JSONPath=".status.mode"
 type Node struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -46,6 +46,10 @@ func (r *Node) Default() {
 		r.Spec.Replicas = &replicas
 	}
 
+	if r.Spec.Metrics.Port == 0 {
+		r.Spec.Metrics.Port = DefaultMetricsPort
+	}
+
 	if r.Spec.P2PPort == 0 {
 		r.Spec.P2PPort = DefaultP2PPort
 	}
@@ -54,4 +58,12 @@ func (r *Node) Default() {
 		r.Spec.RPCPort = DefaultRPCPort
 	}
 
+	for i := range r.Spec.EventObservers {
+		if len(r.Spec.EventObservers[i].EventsKeys) == 0 {
+			r.Spec.EventObservers[i].EventsKeys = []string{DefaultEventsKey}
+		}
+	}
+
+	r.Spec.Disruption.Default()
+
 }
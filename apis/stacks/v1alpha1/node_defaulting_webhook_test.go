@@ -19,6 +19,7 @@ var _ = Describe("Stacks node defaulting", func() {
 
 		Expect(node.Spec.Image).To(Equal(DefaultStacksNodeImage))
 		Expect(*node.Spec.Replicas).To(Equal(DefaltReplicas))
+		Expect(node.Spec.Metrics.Port).To(Equal(DefaultMetricsPort))
 		Expect(node.Spec.P2PPort).To(Equal(DefaultP2PPort))
 		Expect(node.Spec.RPCPort).To(Equal(DefaultRPCPort))
 		Expect(node.Spec.CPU).To(Equal(DefaultNodeCPURequest))
@@ -28,4 +29,22 @@ var _ = Describe("Stacks node defaulting", func() {
 		Expect(node.Spec.Storage).To(Equal(DefaultNodeStorageRequest))
 
 	})
+
+	It("Should default Stacks node events observers keys", func() {
+		node := Node{
+			ObjectMeta: metav1.ObjectMeta{},
+			Spec: NodeSpec{
+				Network: Mainnet,
+				EventObservers: []EventObserver{
+					{Endpoint: "stacks-api:3700"},
+					{Endpoint: "indexer:3800", EventsKeys: []string{"stx"}},
+				},
+			},
+		}
+
+		node.Default()
+
+		Expect(node.Spec.EventObservers[0].EventsKeys).To(Equal([]string{DefaultEventsKey}))
+		Expect(node.Spec.EventObservers[1].EventsKeys).To(Equal([]string{"stx"}))
+	})
 })
@@ -1,6 +1,7 @@
 package v1alpha1
 
 import (
+	"github.com/kotalco/kotal/apis/shared"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -9,24 +10,69 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
-// +kubebuilder:webhook:verbs=create;update,path=/validate-stacks-kotal-io-v1alpha1-node,mutating=false,failurePolicy=fail,groups=stacks.kotal.io,resources=nodes,versions=v1alpha1,name=validate-stacks-v1alpha1-node.kb.io,sideEffects=None,admissionReviewVersions=v1
+// +kubebuilder:webhook:verbs=create;update;delete,path=/validate-stacks-kotal-io-v1alpha1-node,mutating=false,failurePolicy=fail,groups=stacks.kotal.io,resources=nodes,versions=v1alpha1,name=validate-stacks-v1alpha1-node.kb.io,sideEffects=None,admissionReviewVersions=v1
 
 var _ webhook.Validator = &Node{}
 
+// validateBitcoinNode validates Bitcoin node settings
+// referenced Bitcoin node network and rpc settings are validated by the controller
+func (r *Node) validateBitcoinNode() field.ErrorList {
+	var nodeErrors field.ErrorList
+
+	path := field.NewPath("spec").Child("bitcoinNodeRef")
+
+	if r.Spec.BitcoinNodeRef == "" {
+		if r.Spec.BitcoinNode == nil {
+			err := field.Required(path, "must provide bitcoinNode or bitcoinNodeRef")
+			nodeErrors = append(nodeErrors, err)
+		}
+		return nodeErrors
+	}
+
+	if r.Spec.BitcoinNode != nil {
+		err := field.Invalid(path, r.Spec.BitcoinNodeRef, "can't be set with bitcoinNode")
+		nodeErrors = append(nodeErrors, err)
+	}
+
+	return nodeErrors
+}
+
+// validateEventObservers validates events observers endpoints are unique
+func (r *Node) validateEventObservers() field.ErrorList {
+	var nodeErrors field.ErrorList
+
+	endpoints := map[string]bool{}
+	for i, observer := range r.Spec.EventObservers {
+		if endpoints[observer.Endpoint] {
+			path := field.NewPath("spec").Child("eventObservers").Index(i).Child("endpoint")
+			nodeErrors = append(nodeErrors, field.Duplicate(path, observer.Endpoint))
+		}
+		endpoints[observer.Endpoint] = true
+	}
+
+	return nodeErrors
+}
+
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (r *Node) ValidateCreate() (admission.Warnings, error) {
 	var allErrors field.ErrorList
 
 	nodelog.Info("validate create", "name", r.Name)
 
+	allErrors = append(allErrors, r.validateBitcoinNode()...)
+	allErrors = append(allErrors, r.validateEventObservers()...)
 	allErrors = append(allErrors, r.Spec.Resources.ValidateCreate()...)
+	allErrors = append(allErrors, r.Spec.Access.Validate()...)
+	allErrors = append(allErrors, r.Spec.PodExtras.Validate()...)
+	allErrors = append(allErrors, r.Spec.Deletion.Validate()...)
+	allErrors = append(allErrors, r.Spec.SecretStore.Validate()...)
 
-	if r.Spec.Miner && r.Spec.SeedPrivateKeySecretName == "" {
-		err := field.Invalid(field.NewPath("spec").Child("seedPrivateKeySecretName"), r.Spec.SeedPrivateKeySecretName, "seedPrivateKeySecretName is required if node is miner")
+	if r.Spec.Miner && r.Spec.SeedPrivateKeySecretName.Name == "" {
+		err := field.Invalid(field.NewPath("spec").Child("seedPrivateKeySecretName"), r.Spec.SeedPrivateKeySecretName.Name, "seedPrivateKeySecretName is required if node is miner")
 		allErrors = append(allErrors, err)
 	}
 
-	if r.Spec.SeedPrivateKeySecretName != "" && !r.Spec.Miner {
+	if r.Spec.SeedPrivateKeySecretName.Name != "" && !r.Spec.Miner {
 		err := field.Invalid(field.NewPath("spec").Child("miner"), r.Spec.Miner, "node must be a miner if seedPrivateKeySecretName is given")
 		allErrors = append(allErrors, err)
 	}
@@ -36,11 +82,13 @@ func (r *Node) ValidateCreate() (admission.Warnings, error) {
 		allErrors = append(allErrors, err)
 	}
 
+	warnings := r.Spec.ExtraArgs.Warnings(r, nodeArgs)
+
 	if len(allErrors) == 0 {
-		return nil, nil
+		return warnings, nil
 	}
 
-	return nil, apierrors.NewInvalid(schema.GroupKind{}, r.Name, allErrors)
+	return warnings, apierrors.NewInvalid(schema.GroupKind{}, r.Name, allErrors)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
@@ -50,23 +98,32 @@ func (r *Node) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
 
 	nodelog.Info("validate update", "name", r.Name)
 
+	allErrors = append(allErrors, r.validateBitcoinNode()...)
+	allErrors = append(allErrors, r.validateEventObservers()...)
 	allErrors = append(allErrors, r.Spec.Resources.ValidateUpdate(&oldNode.Spec.Resources)...)
+	allErrors = append(allErrors, r.Spec.Access.Validate()...)
+	allErrors = append(allErrors, r.Spec.PodExtras.Validate()...)
+	allErrors = append(allErrors, r.Spec.Deletion.Validate()...)
+	allErrors = append(allErrors, r.Spec.DataResync.ValidateUpdate(&oldNode.Spec.DataResync, false)...)
+	allErrors = append(allErrors, r.Spec.SecretStore.Validate()...)
 
 	if r.Spec.Network != oldNode.Spec.Network {
 		err := field.Invalid(field.NewPath("spec").Child("network"), r.Spec.Network, "field is immutable")
 		allErrors = append(allErrors, err)
 	}
 
+	warnings := r.Spec.ExtraArgs.Warnings(r, nodeArgs)
+
 	if len(allErrors) == 0 {
-		return nil, nil
+		return warnings, nil
 	}
 
-	return nil, apierrors.NewInvalid(schema.GroupKind{}, r.Name, allErrors)
+	return warnings, apierrors.NewInvalid(schema.GroupKind{}, r.Name, allErrors)
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
 func (r *Node) ValidateDelete() (admission.Warnings, error) {
 	nodelog.Info("validate delete", "name", r.Name)
 
-	return nil, nil
+	return nil, shared.ValidateDeletionProtection(r)
 }
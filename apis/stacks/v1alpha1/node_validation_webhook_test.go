@@ -39,7 +39,7 @@ var _ = Describe("Stacks node validation", func() {
 			Node: &Node{
 				Spec: NodeSpec{
 					Network:                  Mainnet,
-					SeedPrivateKeySecretName: "seed-private-key",
+					SeedPrivateKeySecretName: shared.SecretKeySelector{Name: "seed-private-key"},
 				},
 			},
 			Errors: []*field.Error{
@@ -68,6 +68,58 @@ var _ = Describe("Stacks node validation", func() {
 				},
 			},
 		},
+		{
+			Title: "duplicate events observer endpoint",
+			Node: &Node{
+				Spec: NodeSpec{
+					Network: Mainnet,
+					EventObservers: []EventObserver{
+						{Endpoint: "stacks-api:3700"},
+						{Endpoint: "stacks-api:3700"},
+					},
+				},
+			},
+			Errors: []*field.Error{
+				{
+					Type:     field.ErrorTypeDuplicate,
+					Field:    "spec.eventObservers[1].endpoint",
+					BadValue: "stacks-api:3700",
+				},
+			},
+		},
+		{
+			Title: "missing bitcoinNode and bitcoinNodeRef",
+			Node: &Node{
+				Spec: NodeSpec{
+					Network: Mainnet,
+				},
+			},
+			Errors: []*field.Error{
+				{
+					Type:   field.ErrorTypeRequired,
+					Field:  "spec.bitcoinNodeRef",
+					Detail: "must provide bitcoinNode or bitcoinNodeRef",
+				},
+			},
+		},
+		{
+			Title: "bitcoinNodeRef is given with bitcoinNode",
+			Node: &Node{
+				Spec: NodeSpec{
+					Network:        Mainnet,
+					BitcoinNode:    &BitcoinNode{Endpoint: "bitcoin"},
+					BitcoinNodeRef: "bitcoin-testnet",
+				},
+			},
+			Errors: []*field.Error{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.bitcoinNodeRef",
+					BadValue: "bitcoin-testnet",
+					Detail:   "can't be set with bitcoinNode",
+				},
+			},
+		},
 	}
 
 	updateCases := []struct {
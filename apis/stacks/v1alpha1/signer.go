@@ -0,0 +1,84 @@
+package v1alpha1
+
+import (
+	"github.com/kotalco/kotal/apis/shared"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SignerSpec defines the desired state of Signer
+type SignerSpec struct {
+	// Image is Stacks signer image
+	Image string `json:"image,omitempty"`
+	// ExtraArgs is extra arguments to pass down to the cli
+	ExtraArgs shared.ExtraArgs `json:"extraArgs,omitempty"`
+	// RemoveArgs is names of generated cli arguments to remove
+	// +listType=set
+	RemoveArgs []string `json:"removeArgs,omitempty"`
+	// Replicas is number of replicas
+	// it must be 0 or 1, signer keys can't be shared by replicas
+	// +kubebuilder:validation:Minimum=0
+	Replicas *uint `json:"replicas,omitempty"`
+	// StacksNode is Stacks node name in the same namespace
+	// signer is registered as events observer of the node, and authenticates to it using auth token
+	StacksNode string `json:"stacksNode"`
+	// PrivateKeySecretName is k8s secret holding signer private key
+	PrivateKeySecretName shared.SecretKeySelector `json:"privateKeySecretName"`
+	// AuthTokenSecretName is k8s secret holding token shared by signer and Stacks node
+	// signers of the same Stacks node must use the same auth token
+	AuthTokenSecretName shared.SecretKeySelector `json:"authTokenSecretName"`
+	// Port is port Stacks node events are received on
+	Port uint `json:"port,omitempty"`
+	// Access is network access settings of signer endpoints
+	Access shared.Access `json:"access,omitempty"`
+	// Disruption is pod disruption budget settings
+	Disruption shared.Disruption `json:"disruption,omitempty"`
+	// Shutdown is graceful shutdown settings
+	Shutdown shared.Shutdown `json:"shutdown,omitempty"`
+	// Suspend scales signer to zero replicas, keeping its data, services and secrets
+	Suspend bool `json:"suspend,omitempty"`
+	// DataResync is signer data resync settings
+	shared.DataResync `json:",inline"`
+	// Deletion is deletion settings of signer data and generated secrets
+	shared.Deletion `json:",inline"`
+	// PodExtras is extra environment variables, volumes and containers injected into signer pods
+	shared.PodExtras `json:",inline"`
+	// Resources is signer compute and storage resources
+	shared.Resources `json:"resources,omitempty"`
+}
+
+// SignerStatus defines the observed state of Signer
+type SignerStatus struct {
+	Client string `json:"client,omitempty"`
+	// Mode is reconciliation mode
+	Mode shared.Mode `json:"mode,omitempty"`
+	// ResyncStatus is signer data resync status
+	shared.ResyncStatus `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// Signer is the Schema for the signers API
+// +kubebuilder:printcolumn:name="Stacks Node",type=string,JSONPath=".spec.stacksNode"
+// +kubebuilder:printcolumn:name="Client",type=string,JSONPath=".status.client"
+// +kubebuilder:printcolumn:name="Mode",type=string,JSONPath=".status.mode"
+type Signer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SignerSpec   `json:"spec,omitempty"`
+	Status SignerStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SignerList contains a list of Signer
+type SignerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Signer `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Signer{}, &SignerList{})
+}
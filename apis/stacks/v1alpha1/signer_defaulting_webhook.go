@@ -0,0 +1,55 @@
+package v1alpha1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// +kubebuilder:webhook:path=/mutate-stacks-kotal-io-v1alpha1-signer,mutating=true,failurePolicy=fail,groups=stacks.kotal.io,resources=signers,verbs=create;update,versions=v1alpha1,name=mutate-stacks-v1alpha1-signer.kb.io,sideEffects=None,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &Signer{}
+
+func (r *Signer) DefaultSignerResources() {
+	if r.Spec.Resources.CPU == "" {
+		r.Spec.Resources.CPU = DefaultSignerCPURequest
+	}
+
+	if r.Spec.Resources.CPULimit == "" {
+		r.Spec.Resources.CPULimit = DefaultSignerCPULimit
+	}
+
+	if r.Spec.Resources.Memory == "" {
+		r.Spec.Resources.Memory = DefaultSignerMemoryRequest
+	}
+
+	if r.Spec.Resources.MemoryLimit == "" {
+		r.Spec.Resources.MemoryLimit = DefaultSignerMemoryLimit
+	}
+
+	if r.Spec.Resources.Storage == "" {
+		r.Spec.Resources.Storage = DefaultSignerStorageRequest
+	}
+}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type
+func (r *Signer) Default() {
+	signerlog.Info("default", "name", r.Name)
+
+	r.DefaultSignerResources()
+
+	if r.Spec.Image == "" {
+		r.Spec.Image = DefaultSignerImage
+	}
+
+	if r.Spec.Replicas == nil {
+		// constants are not addressable
+		replicas := DefaltReplicas
+		r.Spec.Replicas = &replicas
+	}
+
+	if r.Spec.Port == 0 {
+		r.Spec.Port = DefaultSignerPort
+	}
+
+	r.Spec.Disruption.Default()
+
+}
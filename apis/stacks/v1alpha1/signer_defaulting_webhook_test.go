@@ -0,0 +1,31 @@
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Stacks signer defaulting", func() {
+	It("Should default Stacks signer", func() {
+		signer := Signer{
+			ObjectMeta: metav1.ObjectMeta{},
+			Spec: SignerSpec{
+				StacksNode: "stacks-node",
+			},
+		}
+
+		signer.Default()
+
+		Expect(signer.Spec.Image).To(Equal(DefaultSignerImage))
+		Expect(*signer.Spec.Replicas).To(Equal(DefaltReplicas))
+		Expect(signer.Spec.Port).To(Equal(DefaultSignerPort))
+		Expect(*signer.Spec.Disruption.Enabled).To(BeFalse())
+		Expect(*signer.Spec.Disruption.MaxUnavailable).To(Equal(uint(1)))
+		Expect(signer.Spec.CPU).To(Equal(DefaultSignerCPURequest))
+		Expect(signer.Spec.CPULimit).To(Equal(DefaultSignerCPULimit))
+		Expect(signer.Spec.Memory).To(Equal(DefaultSignerMemoryRequest))
+		Expect(signer.Spec.MemoryLimit).To(Equal(DefaultSignerMemoryLimit))
+		Expect(signer.Spec.Storage).To(Equal(DefaultSignerStorageRequest))
+	})
+})
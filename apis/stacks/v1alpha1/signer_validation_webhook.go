@@ -0,0 +1,98 @@
+package v1alpha1
+
+import (
+	"github.com/kotalco/kotal/apis/shared"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// +kubebuilder:webhook:verbs=create;update;delete,path=/validate-stacks-kotal-io-v1alpha1-signer,mutating=false,failurePolicy=fail,groups=stacks.kotal.io,resources=signers,versions=v1alpha1,name=validate-stacks-v1alpha1-signer.kb.io,sideEffects=None,admissionReviewVersions=v1
+
+var _ webhook.Validator = &Signer{}
+
+// validate is common create and update validation rules
+func (r *Signer) validate() field.ErrorList {
+	var signerErrors field.ErrorList
+
+	path := field.NewPath("spec")
+
+	if r.Spec.StacksNode == "" {
+		err := field.Required(path.Child("stacksNode"), "must provide Stacks node name")
+		signerErrors = append(signerErrors, err)
+	}
+
+	if r.Spec.PrivateKeySecretName.Name == "" {
+		err := field.Required(path.Child("privateKeySecretName"), "must provide signer private key secret name")
+		signerErrors = append(signerErrors, err)
+	}
+
+	if r.Spec.AuthTokenSecretName.Name == "" {
+		err := field.Required(path.Child("authTokenSecretName"), "must provide auth token secret name")
+		signerErrors = append(signerErrors, err)
+	}
+
+	return signerErrors
+}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *Signer) ValidateCreate() (admission.Warnings, error) {
+	var allErrors field.ErrorList
+
+	signerlog.Info("validate create", "name", r.Name)
+
+	allErrors = append(allErrors, r.validate()...)
+	allErrors = append(allErrors, r.Spec.Resources.ValidateCreate()...)
+	allErrors = append(allErrors, r.Spec.Access.Validate()...)
+	allErrors = append(allErrors, r.Spec.PodExtras.Validate()...)
+	allErrors = append(allErrors, r.Spec.Deletion.Validate()...)
+	allErrors = append(allErrors, shared.ValidateReplicas(r.Spec.Replicas, true)...)
+
+	warnings := r.Spec.ExtraArgs.Warnings(r, signerArgs)
+
+	if len(allErrors) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(schema.GroupKind{}, r.Name, allErrors)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *Signer) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	var allErrors field.ErrorList
+	oldSigner := old.(*Signer)
+
+	signerlog.Info("validate update", "name", r.Name)
+
+	allErrors = append(allErrors, r.validate()...)
+	allErrors = append(allErrors, r.Spec.Resources.ValidateUpdate(&oldSigner.Spec.Resources)...)
+	allErrors = append(allErrors, r.Spec.Access.Validate()...)
+	allErrors = append(allErrors, r.Spec.PodExtras.Validate()...)
+	allErrors = append(allErrors, r.Spec.Deletion.Validate()...)
+	allErrors = append(allErrors, shared.ValidateReplicas(r.Spec.Replicas, true)...)
+	allErrors = append(allErrors, r.Spec.DataResync.ValidateUpdate(&oldSigner.Spec.DataResync, true)...)
+
+	// signer database holds signatures of the node network
+	if r.Spec.StacksNode != oldSigner.Spec.StacksNode {
+		err := field.Invalid(field.NewPath("spec").Child("stacksNode"), r.Spec.StacksNode, "field is immutable")
+		allErrors = append(allErrors, err)
+	}
+
+	warnings := r.Spec.ExtraArgs.Warnings(r, signerArgs)
+
+	if len(allErrors) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(schema.GroupKind{}, r.Name, allErrors)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *Signer) ValidateDelete() (admission.Warnings, error) {
+	signerlog.Info("validate delete", "name", r.Name)
+
+	return nil, shared.ValidateDeletionProtection(r)
+}
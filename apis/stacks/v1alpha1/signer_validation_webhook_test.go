@@ -0,0 +1,155 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	"github.com/kotalco/kotal/apis/shared"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var _ = Describe("Stacks signer validation", func() {
+	createCases := []struct {
+		Title  string
+		Signer *Signer
+		Errors field.ErrorList
+	}{
+		{
+			Title: "missing stacks node and secrets",
+			Signer: &Signer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-signer",
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:   field.ErrorTypeRequired,
+					Field:  "spec.stacksNode",
+					Detail: "must provide Stacks node name",
+				},
+				{
+					Type:   field.ErrorTypeRequired,
+					Field:  "spec.privateKeySecretName",
+					Detail: "must provide signer private key secret name",
+				},
+				{
+					Type:   field.ErrorTypeRequired,
+					Field:  "spec.authTokenSecretName",
+					Detail: "must provide auth token secret name",
+				},
+			},
+		},
+	}
+
+	updateCases := []struct {
+		Title     string
+		OldSigner *Signer
+		NewSigner *Signer
+		Errors    field.ErrorList
+	}{
+		{
+			Title: "updated stacks node",
+			OldSigner: &Signer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-signer",
+				},
+				Spec: SignerSpec{
+					StacksNode:           "stacks-node",
+					PrivateKeySecretName: shared.SecretKeySelector{Name: "signer-private-key"},
+					AuthTokenSecretName:  shared.SecretKeySelector{Name: "signer-auth-token"},
+				},
+			},
+			NewSigner: &Signer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-signer",
+				},
+				Spec: SignerSpec{
+					StacksNode:           "stacks-testnet-node",
+					PrivateKeySecretName: shared.SecretKeySelector{Name: "signer-private-key"},
+					AuthTokenSecretName:  shared.SecretKeySelector{Name: "signer-auth-token"},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.stacksNode",
+					BadValue: "stacks-testnet-node",
+					Detail:   "field is immutable",
+				},
+			},
+		},
+		{
+			Title: "resynced signer data without force",
+			OldSigner: &Signer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-signer",
+				},
+				Spec: SignerSpec{
+					StacksNode:           "stacks-node",
+					PrivateKeySecretName: shared.SecretKeySelector{Name: "signer-private-key"},
+					AuthTokenSecretName:  shared.SecretKeySelector{Name: "signer-auth-token"},
+				},
+			},
+			NewSigner: &Signer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-signer",
+				},
+				Spec: SignerSpec{
+					StacksNode:           "stacks-node",
+					PrivateKeySecretName: shared.SecretKeySelector{Name: "signer-private-key"},
+					AuthTokenSecretName:  shared.SecretKeySelector{Name: "signer-auth-token"},
+					DataResync:           shared.DataResync{Resync: 1},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.resync",
+					BadValue: uint(1),
+					Detail:   "validator data can't be resynced unless forceResync is true",
+				},
+			},
+		},
+	}
+
+	Context("While creating signer", func() {
+		for _, c := range createCases {
+			func() {
+				cc := c
+				It(fmt.Sprintf("Should validate %s", cc.Title), func() {
+					cc.Signer.Default()
+					_, err := cc.Signer.ValidateCreate()
+
+					errStatus := err.(*errors.StatusError)
+
+					causes := shared.ErrorsToCauses(cc.Errors)
+
+					Expect(errStatus.ErrStatus.Details.Causes).To(ContainElements(causes))
+				})
+			}()
+		}
+	})
+
+	Context("While updating signer", func() {
+		for _, c := range updateCases {
+			func() {
+				cc := c
+				It(fmt.Sprintf("Should validate %s", cc.Title), func() {
+					cc.OldSigner.Default()
+					cc.NewSigner.Default()
+					_, err := cc.NewSigner.ValidateUpdate(cc.OldSigner)
+
+					errStatus := err.(*errors.StatusError)
+
+					causes := shared.ErrorsToCauses(cc.Errors)
+
+					Expect(errStatus.ErrStatus.Details.Causes).To(ContainElements(causes))
+				})
+			}()
+		}
+	})
+
+})
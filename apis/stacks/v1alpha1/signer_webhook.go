@@ -0,0 +1,28 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// log is for logging in this package.
+var signerlog = logf.Log.WithName("signer-resource")
+
+// signerArgs generates signer cli arguments checked against extra arguments by validation webhook
+var signerArgs sharedAPI.ArgsGenerator
+
+// SetupWebhookWithManager sets up the webook with a given controller manager
+// args is signer cli arguments generator, webhook registration fails without it
+func (r *Signer) SetupWebhookWithManager(mgr ctrl.Manager, args sharedAPI.ArgsGenerator) error {
+	if args == nil {
+		return fmt.Errorf("no cli arguments generator is registered for %T", r)
+	}
+	signerArgs = args
+
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
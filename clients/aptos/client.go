@@ -3,8 +3,14 @@ package aptos
 import (
 	aptosv1alpha1 "github.com/kotalco/kotal/apis/aptos/v1alpha1"
 	clients "github.com/kotalco/kotal/clients"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// Args generates node cli arguments, it's injected into validation webhook
+func Args(obj runtime.Object) []string {
+	return NewClient(obj.(*aptosv1alpha1.Node)).Args()
+}
+
 // NewClient returns new Aptos client
 func NewClient(node *aptosv1alpha1.Node) clients.Interface {
 	return &AptosCoreClient{node}
@@ -1,7 +1,6 @@
 package bitcoin
 
 import (
-	"context"
 	"crypto/hmac"
 	"crypto/rand"
 	"crypto/sha256"
@@ -12,19 +11,16 @@ import (
 	bitcoinv1alpha1 "github.com/kotalco/kotal/apis/bitcoin/v1alpha1"
 	"github.com/kotalco/kotal/controllers/shared"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/types"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // BitcoinCoreClient is Bitcoin core client
 // https://github.com/bitcoin/bitcoin
 type BitcoinCoreClient struct {
-	node   *bitcoinv1alpha1.Node
-	client client.Client
+	node *bitcoinv1alpha1.Node
+	// rpcAuth is rpc users salted credentials in the format of salt$hash keyed by username
+	rpcAuth map[string]string
 }
 
-var hashCash map[string]string = map[string]string{}
-
 // Images
 const (
 	// BitcoinCoreHomeDir is Bitcoin core image home dir
@@ -54,6 +50,8 @@ func (c *BitcoinCoreClient) Args() (args []string) {
 	networks := map[string]string{
 		"mainnet": "main",
 		"testnet": "test",
+		"regtest": "regtest",
+		"signet":  "signet",
 	}
 
 	// convert bool to 0 or 1
@@ -70,21 +68,24 @@ func (c *BitcoinCoreClient) Args() (args []string) {
 	args = append(args, fmt.Sprintf("%s=%s", BitcoinArgChain, networks[string(node.Spec.Network)]))
 	args = append(args, fmt.Sprintf("%s=%s:%d", BitcoinArgBind, shared.Host(true), node.Spec.P2PPort))
 
+	if node.Spec.SignetChallenge != "" {
+		args = append(args, fmt.Sprintf("%s=%s", BitcoinArgSignetChallenge, node.Spec.SignetChallenge))
+	}
+
+	for _, seedNode := range node.Spec.SignetSeedNodes {
+		args = append(args, fmt.Sprintf("%s=%s", BitcoinArgSignetSeedNode, seedNode))
+	}
+
 	if c.node.Spec.RPC {
 		args = append(args, fmt.Sprintf("%s=1", BitcoinArgServer))
 		args = append(args, fmt.Sprintf("%s=%d", BitcoinArgRPCPort, node.Spec.RPCPort))
 		args = append(args, fmt.Sprintf("%s=%s", BitcoinArgRPCBind, shared.Host(node.Spec.RPC)))
 		args = append(args, fmt.Sprintf("%s=%s/0", BitcoinArgRPCAllowIp, shared.Host(node.Spec.RPC)))
 
-		// TODO: mock k8s secret getter to test rpc users and whitelist
 		for _, rpcUser := range node.Spec.RPCUsers {
-			name := types.NamespacedName{Name: rpcUser.PasswordSecretName, Namespace: node.Namespace}
-			password, _ := shared.GetSecret(context.TODO(), c.client, name, "password")
-			saltedHash, found := hashCash[password]
+			saltedHash, found := c.rpcAuth[rpcUser.Username]
 			if !found {
-				salt, hash := HmacSha256(password)
-				saltedHash = fmt.Sprintf("%s$%s", salt, hash)
-				hashCash[password] = saltedHash
+				continue
 			}
 			args = append(args, fmt.Sprintf("%s=%s:%s", BitcoinArgRPCAuth, rpcUser.Username, saltedHash))
 
@@ -94,15 +95,40 @@ func (c *BitcoinCoreClient) Args() (args []string) {
 			}
 		}
 
+		if node.Spec.REST {
+			args = append(args, fmt.Sprintf("%s=1", BitcoinArgREST))
+		}
+
 	} else {
 		args = append(args, fmt.Sprintf("%s=0", BitcoinArgServer))
 	}
 
+	zmq := []struct {
+		arg  string
+		port uint
+	}{
+		{BitcoinArgZMQPubRawBlock, node.Spec.ZMQ.RawBlockPort},
+		{BitcoinArgZMQPubRawTx, node.Spec.ZMQ.RawTxPort},
+		{BitcoinArgZMQPubHashBlock, node.Spec.ZMQ.HashBlockPort},
+		{BitcoinArgZMQPubSequence, node.Spec.ZMQ.SequencePort},
+	}
+
+	for _, notification := range zmq {
+		if notification.port != 0 {
+			args = append(args, fmt.Sprintf("%s=tcp://%s:%d", notification.arg, shared.Host(true), notification.port))
+		}
+	}
+
 	args = append(args, fmt.Sprintf("%s=%d", BitcoinArgReIndex, Btoi(node.Spec.ReIndex)))
 	args = append(args, fmt.Sprintf("%s=%d", BitcoinArgTransactionIndex, Btoi(node.Spec.TransactionIndex)))
 	args = append(args, fmt.Sprintf("%s=%d", BitcoinArgBlocksOnly, Btoi(node.Spec.BlocksOnly)))
 	args = append(args, fmt.Sprintf("%s=%d", BitcoinArgCoinStatsIndex, Btoi(node.Spec.CoinStatsIndex)))
-	args = append(args, fmt.Sprintf("%s=%d", BitcoinArgPrune, Btoi(node.Spec.Pruning)))
+	args = append(args, fmt.Sprintf("%s=%d", BitcoinArgBlockFilterIndex, Btoi(node.Spec.BlockFilterIndex)))
+	if node.Spec.PruneTargetMiB != 0 {
+		args = append(args, fmt.Sprintf("%s=%d", BitcoinArgPrune, node.Spec.PruneTargetMiB))
+	} else {
+		args = append(args, fmt.Sprintf("%s=%d", BitcoinArgPrune, Btoi(node.Spec.Pruning)))
+	}
 
 	args = append(args, fmt.Sprintf("%s=%d", BitcoinArgDBCacheSize, node.Spec.DBCacheSize))
 
@@ -118,6 +144,16 @@ func (c *BitcoinCoreClient) HomeDir() string {
 	return BitcoinCoreHomeDir
 }
 
+// TerminationGracePeriod returns seconds to wait for bitcoind to flush database cache
+func (c *BitcoinCoreClient) TerminationGracePeriod() int64 {
+	return 600
+}
+
+// PreStop returns nil, bitcoind shuts down gracefully on SIGTERM
+func (c *BitcoinCoreClient) PreStop() []string {
+	return nil
+}
+
 // HmacSha256 creates new hmac sha256 hash
 // reference implementation:
 // https://github.com/bitcoin/bitcoin/blob/master/share/rpcauth/rpcauth.py
@@ -133,3 +169,18 @@ func HmacSha256(password string) (salt, hash string) {
 
 	return
 }
+
+// RPCAuth returns rpc user salted credentials in the format of salt$hash
+// salt of previous salted credentials is kept if they match the password, so node args don't change
+func RPCAuth(password, previous string) string {
+	if salt, hash, found := strings.Cut(previous, "$"); found {
+		h := hmac.New(sha256.New, []byte(salt))
+		h.Write([]byte(password))
+		if hmac.Equal([]byte(fmt.Sprintf("%x", h.Sum(nil))), []byte(hash)) {
+			return previous
+		}
+	}
+
+	salt, hash := HmacSha256(password)
+	return fmt.Sprintf("%s$%s", salt, hash)
+}
@@ -2,6 +2,8 @@ package bitcoin
 
 import (
 	bitcoinv1alpha1 "github.com/kotalco/kotal/apis/bitcoin/v1alpha1"
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+	"github.com/kotalco/kotal/clients"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -34,8 +36,6 @@ var _ = Describe("Bitcoin core client", func() {
 	}
 
 	node.Default()
-	// nil is passed because there's no reconciler client
-	// TODO: create test for rpcUsers where client is not nil
 	client := NewClient(node, nil)
 
 	It("Should get correct command", func() {
@@ -48,6 +48,12 @@ var _ = Describe("Bitcoin core client", func() {
 		Expect(client.HomeDir()).To(Equal(BitcoinCoreHomeDir))
 	})
 
+	It("Should recommend graceful shutdown settings", func() {
+		shutdown := client.(clients.ShutdownInterface)
+		Expect(shutdown.TerminationGracePeriod()).To(Equal(int64(600)))
+		Expect(shutdown.PreStop()).To(BeNil())
+	})
+
 	It("Should generate correct client arguments", func() {
 		Expect(client.Args()).To(ContainElements([]string{
 			"-chain=main",
@@ -63,10 +69,96 @@ var _ = Describe("Bitcoin core client", func() {
 			"-blocksonly=1",
 			"-reindex=1",
 			"-coinstatsindex=1",
+			"-blockfilterindex=0",
 			"-prune=1",
 			"-dbcache=2048",
 			"-maxconnections=123",
 		}))
 	})
 
+	It("Should generate correct custom signet arguments", func() {
+		signet := &bitcoinv1alpha1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "bitcoin-signet-node",
+				Namespace: "default",
+			},
+			Spec: bitcoinv1alpha1.NodeSpec{
+				Network:         "signet",
+				SignetChallenge: "512103ad5e0edad18cb1f0fc0d28a3d4f1f3e445640337489abb10404f2d1e086be43051ae",
+				SignetSeedNodes: []string{"seed1.signet.example:38333", "seed2.signet.example:38333"},
+			},
+		}
+		signet.Default()
+
+		Expect(NewClient(signet, nil).Args()).To(ContainElements([]string{
+			"-chain=signet",
+			"-bind=0.0.0.0:38333",
+			"-signetchallenge=512103ad5e0edad18cb1f0fc0d28a3d4f1f3e445640337489abb10404f2d1e086be43051ae",
+			"-signetseednode=seed1.signet.example:38333",
+			"-signetseednode=seed2.signet.example:38333",
+		}))
+	})
+
+	It("Should generate rpc users arguments from salted credentials", func() {
+		rpcNode := node.DeepCopy()
+		rpcNode.Spec.RPCUsers = []bitcoinv1alpha1.RPCUser{
+			{
+				Username:           "kotal",
+				PasswordSecretName: sharedAPI.SecretKeySelector{Name: "kotal-password"},
+			},
+			{
+				Username:           "missing",
+				PasswordSecretName: sharedAPI.SecretKeySelector{Name: "missing-password"},
+			},
+		}
+		rpcNode.Spec.RPCWhitelist = []string{"getblockcount", "getbestblockhash"}
+
+		args := NewClient(rpcNode, map[string]string{"kotal": "salt$hash"}).Args()
+
+		Expect(args).To(ContainElements([]string{
+			"-rpcauth=kotal:salt$hash",
+			"-rpcwhitelist=kotal:getblockcount,getbestblockhash",
+		}))
+		Expect(args).NotTo(ContainElement(ContainSubstring("missing")))
+	})
+
+	It("Should keep salt of matching salted credentials", func() {
+		saltedHash := RPCAuth("s3cr3t", "")
+		Expect(saltedHash).To(MatchRegexp("^[0-9a-f]{32}\\$[0-9a-f]{64}$"))
+		Expect(RPCAuth("s3cr3t", saltedHash)).To(Equal(saltedHash))
+		Expect(RPCAuth("changed", saltedHash)).NotTo(Equal(saltedHash))
+	})
+
+	It("Should generate correct REST, ZeroMQ and block filter index arguments", func() {
+		indexer := node.DeepCopy()
+		indexer.Spec.Pruning = false
+		indexer.Spec.REST = true
+		indexer.Spec.BlockFilterIndex = true
+		indexer.Spec.ZMQ = bitcoinv1alpha1.ZMQ{
+			RawBlockPort:  28332,
+			RawTxPort:     28333,
+			HashBlockPort: 28334,
+			SequencePort:  28335,
+		}
+
+		Expect(NewClient(indexer, nil).Args()).To(ContainElements([]string{
+			"-rest=1",
+			"-blockfilterindex=1",
+			"-prune=0",
+			"-zmqpubrawblock=tcp://0.0.0.0:28332",
+			"-zmqpubrawtx=tcp://0.0.0.0:28333",
+			"-zmqpubhashblock=tcp://0.0.0.0:28334",
+			"-zmqpubsequence=tcp://0.0.0.0:28335",
+		}))
+	})
+
+	It("Should generate prune target argument", func() {
+		pruned := node.DeepCopy()
+		pruned.Spec.Pruning = false
+		pruned.Spec.TransactionIndex = false
+		pruned.Spec.PruneTargetMiB = 10240
+
+		Expect(NewClient(pruned, nil).Args()).To(ContainElement("-prune=10240"))
+	})
+
 })
@@ -1,11 +1,101 @@
 package bitcoin
 
 import (
+	"fmt"
+
 	bitcoinv1alpha1 "github.com/kotalco/kotal/apis/bitcoin/v1alpha1"
 	clients "github.com/kotalco/kotal/clients"
-	"sigs.k8s.io/controller-runtime/pkg/client"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
-func NewClient(node *bitcoinv1alpha1.Node, client client.Client) clients.Interface {
-	return &BitcoinCoreClient{node, client}
+// NodeArgs generates Bitcoin node cli arguments, it's injected into validation webhook
+func NodeArgs(obj runtime.Object) []string {
+	// rpc users passwords are read from secrets, which webhooks can't access
+	node := obj.(*bitcoinv1alpha1.Node).DeepCopy()
+	node.Spec.RPCUsers = nil
+	return NewClient(node, nil).Args()
+}
+
+// LightningNodeArgs generates Lightning Network node cli arguments, it's injected into validation webhook
+func LightningNodeArgs(obj runtime.Object) []string {
+	// referenced Bitcoin node isn't available to webhooks
+	client, err := NewLightningClient(obj.(*bitcoinv1alpha1.LightningNode), &bitcoinv1alpha1.Node{})
+	if err != nil {
+		return nil
+	}
+	return client.Args()
+}
+
+// ElectrumServerArgs generates Electrum server cli arguments, it's injected into validation webhook
+func ElectrumServerArgs(obj runtime.Object) []string {
+	client, err := NewElectrumClient(obj.(*bitcoinv1alpha1.ElectrumServer))
+	if err != nil {
+		return nil
+	}
+	return client.Args()
+}
+
+// NewClient returns Bitcoin client
+// rpcAuth is rpc users salted credentials keyed by username, users without salted credentials are skipped
+func NewClient(node *bitcoinv1alpha1.Node, rpcAuth map[string]string) clients.Interface {
+	return &BitcoinCoreClient{node, rpcAuth}
+}
+
+// NewLightningClient returns Lightning Network node client
+// bitcoinNode is the Bitcoin node used as chain backend
+func NewLightningClient(node *bitcoinv1alpha1.LightningNode, bitcoinNode *bitcoinv1alpha1.Node) (clients.Interface, error) {
+	switch node.Spec.Client {
+	case bitcoinv1alpha1.LNDClient:
+		return &LNDClient{node, bitcoinNode}, nil
+	}
+	return nil, fmt.Errorf("client %s is not supported", node.Spec.Client)
+}
+
+// NewElectrumClient returns Electrum server client
+func NewElectrumClient(server *bitcoinv1alpha1.ElectrumServer) (clients.Interface, error) {
+	switch server.Spec.Client {
+	case bitcoinv1alpha1.ElectrsClient:
+		return &ElectrsClient{server}, nil
+	case bitcoinv1alpha1.FulcrumClient:
+		return &FulcrumClient{server}, nil
+	}
+	return nil, fmt.Errorf("client %s is not supported", server.Spec.Client)
+}
+
+// bitcoinRPCPasswordEnv returns environment variable holding the first rpc user password of Bitcoin node
+// Lightning Network nodes connect to Bitcoin node JSON-RPC server using the first rpc user credentials
+func bitcoinRPCPasswordEnv(bitcoinNode *bitcoinv1alpha1.Node) (env []corev1.EnvVar) {
+	if len(bitcoinNode.Spec.RPCUsers) == 0 {
+		return
+	}
+
+	user := bitcoinNode.Spec.RPCUsers[0]
+
+	env = append(env, corev1.EnvVar{
+		Name: EnvBitcoinRPCPassword,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: user.PasswordSecretName.Name,
+				},
+				Key: user.PasswordSecretName.KeyOr("password"),
+			},
+		},
+	})
+
+	return
+}
+
+// bitcoinNodeHost returns Bitcoin node service host
+func bitcoinNodeHost(bitcoinNode *bitcoinv1alpha1.Node) string {
+	return fmt.Sprintf("%s.%s.svc", bitcoinNode.Name, bitcoinNode.Namespace)
+}
+
+// NetworkName returns Bitcoin network name used by electrs, which names mainnet bitcoin
+func NetworkName(network bitcoinv1alpha1.BitcoinNetwork) string {
+	if network == bitcoinv1alpha1.Mainnet || network == "" {
+		return "bitcoin"
+	}
+	return string(network)
 }
@@ -0,0 +1,54 @@
+package bitcoin
+
+import (
+	"fmt"
+
+	bitcoinv1alpha1 "github.com/kotalco/kotal/apis/bitcoin/v1alpha1"
+	"github.com/kotalco/kotal/controllers/shared"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ElectrsClient is Rust Electrum server client
+// https://github.com/romanz/electrs
+type ElectrsClient struct {
+	server *bitcoinv1alpha1.ElectrumServer
+}
+
+const (
+	// ElectrsHomeDir is electrs image home dir
+	ElectrsHomeDir = "/data"
+	// ElectrsConfigFile is electrs config file name in config directory
+	ElectrsConfigFile = "config.toml"
+)
+
+// Env returns environment variables for the client
+func (c *ElectrsClient) Env() []corev1.EnvVar {
+	return nil
+}
+
+// Command is electrs client entrypoint
+func (c *ElectrsClient) Command() []string {
+	return []string{"electrs"}
+}
+
+// Args returns electrs client args
+// Bitcoin node endpoints and rpc credentials are generated into config file
+func (c *ElectrsClient) Args() (args []string) {
+	args = append(args, fmt.Sprintf("%s=%s/%s", ElectrsArgConf, shared.PathConfig(c.HomeDir()), ElectrsConfigFile))
+	return
+}
+
+// HomeDir is the home directory of electrs client image
+func (c *ElectrsClient) HomeDir() string {
+	return ElectrsHomeDir
+}
+
+// TerminationGracePeriod returns seconds to wait for electrs to flush its index
+func (c *ElectrsClient) TerminationGracePeriod() int64 {
+	return 120
+}
+
+// PreStop returns nil, electrs shuts down gracefully on SIGTERM
+func (c *ElectrsClient) PreStop() []string {
+	return nil
+}
@@ -0,0 +1,93 @@
+package bitcoin
+
+import (
+	bitcoinv1alpha1 "github.com/kotalco/kotal/apis/bitcoin/v1alpha1"
+	"github.com/kotalco/kotal/clients"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Electrum server clients", func() {
+
+	server := &bitcoinv1alpha1.ElectrumServer{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "electrum-server",
+			Namespace: "default",
+		},
+		Spec: bitcoinv1alpha1.ElectrumServerSpec{
+			Client:      bitcoinv1alpha1.ElectrsClient,
+			BitcoinNode: "bitcoin-node",
+		},
+	}
+
+	server.Default()
+
+	Context("electrs client", func() {
+		client, err := NewElectrumClient(server)
+
+		It("Should create electrs client", func() {
+			Expect(err).To(BeNil())
+			Expect(client).To(BeAssignableToTypeOf(&ElectrsClient{}))
+		})
+
+		It("Should get correct command", func() {
+			Expect(client.Command()).To(Equal([]string{"electrs"}))
+		})
+
+		It("Should get correct home directory", func() {
+			Expect(client.HomeDir()).To(Equal(ElectrsHomeDir))
+		})
+
+		It("Should recommend graceful shutdown settings", func() {
+			shutdown := client.(clients.ShutdownInterface)
+			Expect(shutdown.TerminationGracePeriod()).To(Equal(int64(120)))
+			Expect(shutdown.PreStop()).To(BeNil())
+		})
+
+		It("Should generate correct client arguments", func() {
+			Expect(client.Args()).To(Equal([]string{
+				"--conf=/data/kotal-config/config.toml",
+			}))
+		})
+	})
+
+	Context("Fulcrum client", func() {
+		fulcrum := server.DeepCopy()
+		fulcrum.Spec.Client = bitcoinv1alpha1.FulcrumClient
+		client, err := NewElectrumClient(fulcrum)
+
+		It("Should create Fulcrum client", func() {
+			Expect(err).To(BeNil())
+			Expect(client).To(BeAssignableToTypeOf(&FulcrumClient{}))
+		})
+
+		It("Should get correct command", func() {
+			Expect(client.Command()).To(Equal([]string{"Fulcrum"}))
+		})
+
+		It("Should get correct home directory", func() {
+			Expect(client.HomeDir()).To(Equal(FulcrumHomeDir))
+		})
+
+		It("Should recommend graceful shutdown settings", func() {
+			shutdown := client.(clients.ShutdownInterface)
+			Expect(shutdown.TerminationGracePeriod()).To(Equal(int64(300)))
+			Expect(shutdown.PreStop()).To(BeNil())
+		})
+
+		It("Should generate correct client arguments", func() {
+			Expect(client.Args()).To(Equal([]string{
+				"/data/kotal-config/fulcrum.conf",
+			}))
+		})
+	})
+
+	It("Should fail to create unsupported client", func() {
+		unsupported := server.DeepCopy()
+		unsupported.Spec.Client = "electrumx"
+		_, err := NewElectrumClient(unsupported)
+		Expect(err).To(MatchError("client electrumx is not supported"))
+	})
+
+})
@@ -0,0 +1,143 @@
+package bitcoin
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"time"
+
+	bitcoinv1alpha1 "github.com/kotalco/kotal/apis/bitcoin/v1alpha1"
+)
+
+// ElectrumProtocolVersion is Electrum protocol version negotiated with Electrum servers
+const ElectrumProtocolVersion = "1.4"
+
+// ElectrumRPCClient is Electrum protocol client
+// https://electrumx-spesmilo.readthedocs.io/en/latest/protocol.html
+type ElectrumRPCClient struct {
+	// Address is Electrum protocol TCP server address
+	Address string
+	// Timeout is the time limit of a session, including connecting and all requests
+	Timeout time.Duration
+}
+
+// electrumRequest is Electrum protocol JSON-RPC 2.0 request
+type electrumRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      uint          `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+// electrumResponse is Electrum protocol JSON-RPC 2.0 response
+type electrumResponse struct {
+	ID     *uint           `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *rpcError       `json:"error"`
+}
+
+// electrumSession is newline delimited JSON-RPC session over a single connection
+type electrumSession struct {
+	conn   net.Conn
+	reader *bufio.Reader
+	id     uint
+}
+
+// ElectrumAddress returns Electrum protocol TCP server address of Electrum server service
+func ElectrumAddress(server *bitcoinv1alpha1.ElectrumServer) string {
+	return fmt.Sprintf("%s.%s.svc:%d", server.Name, server.Namespace, server.Spec.Port)
+}
+
+// NewElectrumRPCClient creates Electrum protocol client for Electrum server service
+func NewElectrumRPCClient(server *bitcoinv1alpha1.ElectrumServer) *ElectrumRPCClient {
+	return &ElectrumRPCClient{
+		Address: ElectrumAddress(server),
+		Timeout: 10 * time.Second,
+	}
+}
+
+// call sends request, and decodes response with the same id into result
+// notifications and responses to other requests are skipped
+func (s *electrumSession) call(method string, result interface{}, params ...interface{}) error {
+	if params == nil {
+		params = []interface{}{}
+	}
+
+	s.id++
+	req, err := json.Marshal(electrumRequest{
+		JSONRPC: "2.0",
+		ID:      s.id,
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.conn.Write(append(req, '\n')); err != nil {
+		return err
+	}
+
+	for {
+		line, err := s.reader.ReadBytes('\n')
+		if err != nil {
+			return err
+		}
+
+		var resp electrumResponse
+		if err := json.Unmarshal(line, &resp); err != nil {
+			return fmt.Errorf("unable to decode %s response: %w", method, err)
+		}
+
+		if resp.ID == nil || *resp.ID != s.id {
+			continue
+		}
+
+		if resp.Error != nil {
+			return resp.Error
+		}
+
+		if result == nil {
+			return nil
+		}
+
+		return json.Unmarshal(resp.Result, result)
+	}
+}
+
+// IndexedHeight returns the height of the best block indexed by the server
+// protocol version is negotiated first, as required by servers before any other request
+func (c *ElectrumRPCClient) IndexedHeight(ctx context.Context) (uint, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.Timeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", c.Address)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return 0, err
+		}
+	}
+
+	session := &electrumSession{conn: conn, reader: bufio.NewReader(conn)}
+
+	if err := session.call("server.version", nil, "kotal", ElectrumProtocolVersion); err != nil {
+		return 0, err
+	}
+
+	var header struct {
+		Height uint `json:"height"`
+	}
+	if err := session.call("blockchain.headers.subscribe", &header); err != nil {
+		return 0, err
+	}
+
+	return header.Height, nil
+}
@@ -0,0 +1,93 @@
+package bitcoin
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"time"
+
+	bitcoinv1alpha1 "github.com/kotalco/kotal/apis/bitcoin/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Electrum protocol client", func() {
+
+	var listener net.Listener
+	var rpc *ElectrumRPCClient
+
+	// serve responds to Electrum protocol requests on accepted connections
+	// headers subscription is answered after an unrelated notification
+	serve := func(versionError bool) {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				scanner := bufio.NewScanner(conn)
+				for scanner.Scan() {
+					var req electrumRequest
+					if json.Unmarshal(scanner.Bytes(), &req) != nil {
+						return
+					}
+					switch req.Method {
+					case "server.version":
+						if versionError {
+							conn.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":1,"message":"unsupported protocol version: 1.4"}}` + "\n"))
+							continue
+						}
+						conn.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":["Fulcrum 1.9.8","1.4"]}` + "\n"))
+					case "blockchain.headers.subscribe":
+						conn.Write([]byte(`{"jsonrpc":"2.0","method":"blockchain.headers.subscribe","params":[{"height":838000,"hex":"00"}]}` + "\n"))
+						conn.Write([]byte(`{"jsonrpc":"2.0","id":2,"result":{"height":838001,"hex":"00"}}` + "\n"))
+					}
+				}
+			}()
+		}
+	}
+
+	BeforeEach(func() {
+		var err error
+		listener, err = net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).To(BeNil())
+
+		rpc = NewElectrumRPCClient(&bitcoinv1alpha1.ElectrumServer{})
+		rpc.Address = listener.Addr().String()
+		rpc.Timeout = 5 * time.Second
+	})
+
+	AfterEach(func() {
+		listener.Close()
+	})
+
+	It("Should get Electrum server address", func() {
+		server := &bitcoinv1alpha1.ElectrumServer{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "electrum-server",
+				Namespace: "default",
+			},
+			Spec: bitcoinv1alpha1.ElectrumServerSpec{
+				Port: 50001,
+			},
+		}
+		Expect(ElectrumAddress(server)).To(Equal("electrum-server.default.svc:50001"))
+	})
+
+	It("Should get indexed height", func() {
+		go serve(false)
+		height, err := rpc.IndexedHeight(context.Background())
+		Expect(err).To(BeNil())
+		Expect(height).To(Equal(uint(838001)))
+	})
+
+	It("Should return Electrum server errors", func() {
+		go serve(true)
+		_, err := rpc.IndexedHeight(context.Background())
+		Expect(err).To(MatchError("JSON-RPC error 1: unsupported protocol version: 1.4"))
+	})
+
+})
@@ -0,0 +1,55 @@
+package bitcoin
+
+import (
+	"fmt"
+
+	bitcoinv1alpha1 "github.com/kotalco/kotal/apis/bitcoin/v1alpha1"
+	"github.com/kotalco/kotal/controllers/shared"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// FulcrumClient is Fulcrum Electrum server client
+// https://github.com/cculianu/Fulcrum
+type FulcrumClient struct {
+	server *bitcoinv1alpha1.ElectrumServer
+}
+
+const (
+	// FulcrumHomeDir is Fulcrum image home dir
+	FulcrumHomeDir = "/data"
+	// FulcrumConfigFile is Fulcrum config file name in config directory
+	FulcrumConfigFile = "fulcrum.conf"
+)
+
+// Env returns environment variables for the client
+func (c *FulcrumClient) Env() []corev1.EnvVar {
+	return nil
+}
+
+// Command is Fulcrum client entrypoint
+func (c *FulcrumClient) Command() []string {
+	return []string{"Fulcrum"}
+}
+
+// Args returns Fulcrum client args
+// Bitcoin node endpoint, rpc credentials and TLS certificate are generated into config file
+func (c *FulcrumClient) Args() (args []string) {
+	args = append(args, fmt.Sprintf("%s/%s", shared.PathConfig(c.HomeDir()), FulcrumConfigFile))
+	return
+}
+
+// HomeDir is the home directory of Fulcrum client image
+func (c *FulcrumClient) HomeDir() string {
+	return FulcrumHomeDir
+}
+
+// TerminationGracePeriod returns seconds to wait for Fulcrum to flush its database
+// Fulcrum database is corrupted if it's killed while flushing
+func (c *FulcrumClient) TerminationGracePeriod() int64 {
+	return 300
+}
+
+// PreStop returns nil, Fulcrum shuts down gracefully on SIGTERM
+func (c *FulcrumClient) PreStop() []string {
+	return nil
+}
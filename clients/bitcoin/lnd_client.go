@@ -0,0 +1,96 @@
+package bitcoin
+
+import (
+	"fmt"
+
+	bitcoinv1alpha1 "github.com/kotalco/kotal/apis/bitcoin/v1alpha1"
+	"github.com/kotalco/kotal/controllers/shared"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// LNDClient is Lightning Labs Lightning Network Daemon client
+// https://github.com/lightningnetwork/lnd
+type LNDClient struct {
+	node        *bitcoinv1alpha1.LightningNode
+	bitcoinNode *bitcoinv1alpha1.Node
+}
+
+const (
+	// LNDHomeDir is lnd image home dir
+	LNDHomeDir = "/data"
+	// LNDTLSCertFile is lnd TLS certificate file name in secrets directory
+	LNDTLSCertFile = "tls.cert"
+	// LNDTLSKeyFile is lnd TLS private key file name in secrets directory
+	LNDTLSKeyFile = "tls.key"
+	// LNDWalletPasswordFile is lnd wallet password file name in secrets directory
+	LNDWalletPasswordFile = "wallet-password"
+)
+
+// Env returns environment variables for the client
+func (c *LNDClient) Env() []corev1.EnvVar {
+	return bitcoinRPCPasswordEnv(c.bitcoinNode)
+}
+
+// Command is lnd client entrypoint
+func (c *LNDClient) Command() []string {
+	return []string{"lnd"}
+}
+
+// Args returns lnd client args
+func (c *LNDClient) Args() (args []string) {
+	node := c.node
+	bitcoinNode := c.bitcoinNode
+	secretsDir := shared.PathSecrets(c.HomeDir())
+
+	args = append(args, fmt.Sprintf("%s=%s", LNDArgLNDDir, shared.PathData(c.HomeDir())))
+	args = append(args, fmt.Sprintf("%s=%s:%d", LNDArgListen, shared.Host(true), node.Spec.P2PPort))
+	args = append(args, fmt.Sprintf("%s=%s:%d", LNDArgRPCListen, shared.Host(true), node.Spec.GRPCPort))
+	args = append(args, fmt.Sprintf("%s=%s:%d", LNDArgRESTListen, shared.Host(true), node.Spec.RESTPort))
+	args = append(args, fmt.Sprintf("%s=%s/%s", LNDArgTLSCertPath, secretsDir, LNDTLSCertFile))
+	args = append(args, fmt.Sprintf("%s=%s/%s", LNDArgTLSKeyPath, secretsDir, LNDTLSKeyFile))
+	args = append(args, fmt.Sprintf("%s=%s/%s", LNDArgWalletUnlockPasswordFile, secretsDir, LNDWalletPasswordFile))
+	args = append(args, LNDArgWalletUnlockAllowCreate)
+
+	if node.Spec.Alias != "" {
+		args = append(args, fmt.Sprintf("%s=%s", LNDArgAlias, node.Spec.Alias))
+	}
+
+	args = append(args, LNDArgBitcoinActive)
+	if bitcoinNode.Spec.Network != "" {
+		args = append(args, fmt.Sprintf("--bitcoin.%s", bitcoinNode.Spec.Network))
+	}
+	args = append(args, fmt.Sprintf("%s=bitcoind", LNDArgBitcoinNode))
+
+	host := bitcoinNodeHost(bitcoinNode)
+	args = append(args, fmt.Sprintf("%s=%s:%d", LNDArgBitcoindRPCHost, host, bitcoinNode.Spec.RPCPort))
+
+	if len(bitcoinNode.Spec.RPCUsers) != 0 {
+		args = append(args, fmt.Sprintf("%s=%s", LNDArgBitcoindRPCUser, bitcoinNode.Spec.RPCUsers[0].Username))
+		args = append(args, fmt.Sprintf("%s=$(%s)", LNDArgBitcoindRPCPass, EnvBitcoinRPCPassword))
+	}
+
+	if port := bitcoinNode.Spec.ZMQ.RawBlockPort; port != 0 {
+		args = append(args, fmt.Sprintf("%s=tcp://%s:%d", LNDArgBitcoindZMQPubRawBlock, host, port))
+	}
+
+	if port := bitcoinNode.Spec.ZMQ.RawTxPort; port != 0 {
+		args = append(args, fmt.Sprintf("%s=tcp://%s:%d", LNDArgBitcoindZMQPubRawTx, host, port))
+	}
+
+	return
+}
+
+// HomeDir is the home directory of lnd client image
+func (c *LNDClient) HomeDir() string {
+	return LNDHomeDir
+}
+
+// TerminationGracePeriod returns seconds to wait for lnd to close its channels database
+func (c *LNDClient) TerminationGracePeriod() int64 {
+	return 120
+}
+
+// PreStop returns nil, lnd shuts down gracefully on SIGTERM
+func (c *LNDClient) PreStop() []string {
+	return nil
+}
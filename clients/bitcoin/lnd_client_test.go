@@ -0,0 +1,114 @@
+package bitcoin
+
+import (
+	bitcoinv1alpha1 "github.com/kotalco/kotal/apis/bitcoin/v1alpha1"
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+	"github.com/kotalco/kotal/clients"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("lnd client", func() {
+
+	bitcoinNode := &bitcoinv1alpha1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "bitcoin-node",
+			Namespace: "default",
+		},
+		Spec: bitcoinv1alpha1.NodeSpec{
+			Network: bitcoinv1alpha1.Testnet,
+			RPC:     true,
+			RPCPort: 18332,
+			RPCUsers: []bitcoinv1alpha1.RPCUser{
+				{
+					Username:           "kotal",
+					PasswordSecretName: sharedAPI.SecretKeySelector{Name: "kotal-password"},
+				},
+			},
+			ZMQ: bitcoinv1alpha1.ZMQ{
+				RawBlockPort: 28332,
+				RawTxPort:    28333,
+			},
+		},
+	}
+
+	node := &bitcoinv1alpha1.LightningNode{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "lightning-node",
+			Namespace: "default",
+		},
+		Spec: bitcoinv1alpha1.LightningNodeSpec{
+			Client:      bitcoinv1alpha1.LNDClient,
+			BitcoinNode: bitcoinNode.Name,
+			Alias:       "kotal",
+		},
+	}
+
+	node.Default()
+	client, err := NewLightningClient(node, bitcoinNode)
+
+	It("Should create lnd client", func() {
+		Expect(err).To(BeNil())
+		Expect(client).To(BeAssignableToTypeOf(&LNDClient{}))
+	})
+
+	It("Should get correct command", func() {
+		Expect(client.Command()).To(Equal([]string{"lnd"}))
+	})
+
+	It("Should get correct home directory", func() {
+		Expect(client.HomeDir()).To(Equal(LNDHomeDir))
+	})
+
+	It("Should recommend graceful shutdown settings", func() {
+		shutdown := client.(clients.ShutdownInterface)
+		Expect(shutdown.TerminationGracePeriod()).To(Equal(int64(120)))
+		Expect(shutdown.PreStop()).To(BeNil())
+	})
+
+	It("Should read Bitcoin node rpc user password from secret", func() {
+		Expect(client.Env()).To(Equal([]corev1.EnvVar{
+			{
+				Name: EnvBitcoinRPCPassword,
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "kotal-password"},
+						Key:                  "password",
+					},
+				},
+			},
+		}))
+	})
+
+	It("Should generate correct client arguments", func() {
+		Expect(client.Args()).To(ContainElements([]string{
+			"--lnddir=/data/kotal-data",
+			"--alias=kotal",
+			"--listen=0.0.0.0:9735",
+			"--rpclisten=0.0.0.0:10009",
+			"--restlisten=0.0.0.0:8080",
+			"--tlscertpath=/data/.kotal-secrets/tls.cert",
+			"--tlskeypath=/data/.kotal-secrets/tls.key",
+			"--wallet-unlock-password-file=/data/.kotal-secrets/wallet-password",
+			"--wallet-unlock-allow-create",
+			"--bitcoin.active",
+			"--bitcoin.testnet",
+			"--bitcoin.node=bitcoind",
+			"--bitcoind.rpchost=bitcoin-node.default.svc:18332",
+			"--bitcoind.rpcuser=kotal",
+			"--bitcoind.rpcpass=$(KOTAL_BITCOIN_RPC_PASSWORD)",
+			"--bitcoind.zmqpubrawblock=tcp://bitcoin-node.default.svc:28332",
+			"--bitcoind.zmqpubrawtx=tcp://bitcoin-node.default.svc:28333",
+		}))
+	})
+
+	It("Should fail to create unsupported client", func() {
+		unsupported := node.DeepCopy()
+		unsupported.Spec.Client = "eclair"
+		_, err := NewLightningClient(unsupported, bitcoinNode)
+		Expect(err).To(MatchError("client eclair is not supported"))
+	})
+
+})
@@ -0,0 +1,163 @@
+package bitcoin
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	bitcoinv1alpha1 "github.com/kotalco/kotal/apis/bitcoin/v1alpha1"
+)
+
+// lnd wallet states
+const (
+	// LNDWalletNonExisting is the state of lnd waiting for wallet to be created
+	LNDWalletNonExisting = "NON_EXISTING"
+	// LNDWalletServerActive is the state of lnd fully started with unlocked wallet
+	LNDWalletServerActive = "SERVER_ACTIVE"
+)
+
+// LNDRESTClient is lnd REST API client
+type LNDRESTClient struct {
+	// URL is REST API server url
+	URL string
+	// Macaroon is admin macaroon used to authenticate requests
+	Macaroon []byte
+	// HTTPClient is the http client used to send REST API requests
+	HTTPClient *http.Client
+}
+
+// lndError is lnd REST API error
+type lndError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *lndError) Error() string {
+	return fmt.Sprintf("lnd error %d: %s", e.Code, e.Message)
+}
+
+// LNDInfo is lnd node info
+type LNDInfo struct {
+	// IdentityPubkey is node public key
+	IdentityPubkey string `json:"identity_pubkey"`
+	// NumActiveChannels is active channels count
+	NumActiveChannels uint `json:"num_active_channels"`
+	// NumPendingChannels is pending channels count
+	NumPendingChannels uint `json:"num_pending_channels"`
+	// NumPeers is connected peers count
+	NumPeers uint `json:"num_peers"`
+	// BlockHeight is node best block height
+	BlockHeight uint `json:"block_height"`
+	// SyncedToChain is true if node is synced to chain backend
+	SyncedToChain bool `json:"synced_to_chain"`
+}
+
+// LNDRESTURL returns REST API server url of lnd node service
+func LNDRESTURL(node *bitcoinv1alpha1.LightningNode) string {
+	return fmt.Sprintf("https://%s.%s.svc:%d", node.Name, node.Namespace, node.Spec.RESTPort)
+}
+
+// NewLNDRESTClient creates REST API client for lnd node service
+// TLS connections are verified using node TLS certificate generated by the operator
+func NewLNDRESTClient(node *bitcoinv1alpha1.LightningNode, tlsCert, macaroon []byte) (*LNDRESTClient, error) {
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(tlsCert) {
+		return nil, errors.New("invalid lnd TLS certificate")
+	}
+
+	return &LNDRESTClient{
+		URL:      LNDRESTURL(node),
+		Macaroon: macaroon,
+		HTTPClient: &http.Client{
+			Timeout: 10 * time.Second,
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12},
+			},
+		},
+	}, nil
+}
+
+// do sends REST API request, and decodes response body into result
+func (c *LNDRESTClient) do(ctx context.Context, method, path string, body, result interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.URL+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(c.Macaroon) != 0 {
+		req.Header.Set("Grpc-Metadata-macaroon", hex.EncodeToString(c.Macaroon))
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		lndErr := &lndError{}
+		if err := json.NewDecoder(resp.Body).Decode(lndErr); err != nil {
+			return fmt.Errorf("%s %s responded with status %s", method, path, resp.Status)
+		}
+		return lndErr
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// State returns lnd wallet state
+func (c *LNDRESTClient) State(ctx context.Context) (string, error) {
+	var resp struct {
+		State string `json:"state"`
+	}
+	err := c.do(ctx, http.MethodGet, "/v1/state", nil, &resp)
+	return resp.State, err
+}
+
+// GenSeed generates new wallet seed mnemonic
+func (c *LNDRESTClient) GenSeed(ctx context.Context) ([]string, error) {
+	var resp struct {
+		Mnemonic []string `json:"cipher_seed_mnemonic"`
+	}
+	err := c.do(ctx, http.MethodGet, "/v1/genseed", nil, &resp)
+	return resp.Mnemonic, err
+}
+
+// InitWallet creates wallet from seed mnemonic encrypted using password, and returns admin macaroon
+func (c *LNDRESTClient) InitWallet(ctx context.Context, password string, mnemonic []string) ([]byte, error) {
+	req := struct {
+		// bytes are base64 encoded
+		Password []byte   `json:"wallet_password"`
+		Mnemonic []string `json:"cipher_seed_mnemonic"`
+	}{[]byte(password), mnemonic}
+
+	var resp struct {
+		AdminMacaroon []byte `json:"admin_macaroon"`
+	}
+	err := c.do(ctx, http.MethodPost, "/v1/initwallet", req, &resp)
+	return resp.AdminMacaroon, err
+}
+
+// GetInfo returns lnd node info, it requires admin macaroon
+func (c *LNDRESTClient) GetInfo(ctx context.Context) (info *LNDInfo, err error) {
+	info = &LNDInfo{}
+	err = c.do(ctx, http.MethodGet, "/v1/getinfo", nil, info)
+	return
+}
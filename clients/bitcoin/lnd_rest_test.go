@@ -0,0 +1,98 @@
+package bitcoin
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+
+	bitcoinv1alpha1 "github.com/kotalco/kotal/apis/bitcoin/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("lnd REST API client", func() {
+
+	var server *httptest.Server
+	var rest *LNDRESTClient
+	macaroon := []byte("kotal-macaroon")
+
+	BeforeEach(func() {
+		server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/v1/state":
+				w.Write([]byte(`{"state":"NON_EXISTING"}`))
+			case "/v1/genseed":
+				w.Write([]byte(`{"cipher_seed_mnemonic":["abandon","ability"],"enciphered_seed":"AA=="}`))
+			case "/v1/initwallet":
+				var req map[string]interface{}
+				Expect(json.NewDecoder(r.Body).Decode(&req)).To(Succeed())
+				// "s3cr3t" base64 encoded
+				Expect(req["wallet_password"]).To(Equal("czNjcjN0"))
+				Expect(req["cipher_seed_mnemonic"]).To(Equal([]interface{}{"abandon", "ability"}))
+				w.Write([]byte(`{"admin_macaroon":"a290YWwtbWFjYXJvb24="}`))
+			case "/v1/getinfo":
+				if r.Header.Get("Grpc-Metadata-macaroon") != hex.EncodeToString(macaroon) {
+					w.WriteHeader(http.StatusInternalServerError)
+					w.Write([]byte(`{"code":2,"message":"verification failed: signature mismatch after caveat verification"}`))
+					return
+				}
+				w.Write([]byte(`{"identity_pubkey":"02b3b3f0a4c1d1e7a3b9fa5d3c5e4f9f0a4c1d1e7a3b9fa5d3c5e4f9f0a4c1d1e7","num_active_channels":3,"num_pending_channels":1,"num_peers":8,"block_height":2500000,"synced_to_chain":true}`))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+
+		node := &bitcoinv1alpha1.LightningNode{}
+		cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+		var err error
+		rest, err = NewLNDRESTClient(node, cert, macaroon)
+		Expect(err).To(BeNil())
+		rest.URL = server.URL
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("Should reject invalid TLS certificate", func() {
+		_, err := NewLNDRESTClient(&bitcoinv1alpha1.LightningNode{}, []byte("invalid"), nil)
+		Expect(err).To(MatchError("invalid lnd TLS certificate"))
+	})
+
+	It("Should get wallet state", func() {
+		state, err := rest.State(context.Background())
+		Expect(err).To(BeNil())
+		Expect(state).To(Equal(LNDWalletNonExisting))
+	})
+
+	It("Should generate seed and create wallet", func() {
+		seed, err := rest.GenSeed(context.Background())
+		Expect(err).To(BeNil())
+		Expect(seed).To(Equal([]string{"abandon", "ability"}))
+
+		adminMacaroon, err := rest.InitWallet(context.Background(), "s3cr3t", seed)
+		Expect(err).To(BeNil())
+		Expect(adminMacaroon).To(Equal(macaroon))
+	})
+
+	It("Should get node info using admin macaroon", func() {
+		info, err := rest.GetInfo(context.Background())
+		Expect(err).To(BeNil())
+		Expect(info.NumActiveChannels).To(Equal(uint(3)))
+		Expect(info.NumPendingChannels).To(Equal(uint(1)))
+		Expect(info.NumPeers).To(Equal(uint(8)))
+		Expect(info.BlockHeight).To(Equal(uint(2500000)))
+		Expect(info.SyncedToChain).To(BeTrue())
+	})
+
+	It("Should return lnd errors", func() {
+		rest.Macaroon = []byte("wrong")
+		_, err := rest.GetInfo(context.Background())
+		Expect(err).To(MatchError("lnd error 2: verification failed: signature mismatch after caveat verification"))
+	})
+
+})
@@ -0,0 +1,176 @@
+package bitcoin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	bitcoinv1alpha1 "github.com/kotalco/kotal/apis/bitcoin/v1alpha1"
+)
+
+// ErrRPCUnauthorized is returned if JSON-RPC server rejected user credentials
+var ErrRPCUnauthorized = errors.New("unauthorized JSON-RPC user")
+
+// RPCClient is Bitcoin node JSON-RPC client
+type RPCClient struct {
+	// URL is JSON-RPC server url
+	URL string
+	// Username is JSON-RPC username
+	Username string
+	// Password is JSON-RPC user password
+	Password string
+	// HTTPClient is the http client used to send JSON-RPC requests
+	HTTPClient *http.Client
+}
+
+// rpcRequest is JSON-RPC 1.0 request
+type rpcRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      string        `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+// rpcError is JSON-RPC error
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return fmt.Sprintf("JSON-RPC error %d: %s", e.Code, e.Message)
+}
+
+// rpcResponse is JSON-RPC 1.0 response
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *rpcError       `json:"error"`
+}
+
+// ChainState is node chainstate
+type ChainState struct {
+	// Blocks is chainstate blocks height
+	Blocks uint `json:"blocks"`
+	// BestBlockHash is chainstate tip block hash
+	BestBlockHash string `json:"bestblockhash"`
+	// SnapshotBlockHash is UTXO snapshot base block hash, it's empty if chainstate isn't created from snapshot
+	SnapshotBlockHash string `json:"snapshot_blockhash,omitempty"`
+	// Validated is true if chainstate is fully validated
+	Validated bool `json:"validated"`
+}
+
+// ChainStates is node active chainstates
+type ChainStates struct {
+	// Headers is headers height
+	Headers uint `json:"headers"`
+	// ChainStates is node chainstates, background validation chainstate is first if there're two
+	ChainStates []ChainState `json:"chainstates"`
+}
+
+// BlockHeader is block header
+type BlockHeader struct {
+	// Hash is block hash
+	Hash string `json:"hash"`
+	// Height is block height
+	Height uint `json:"height"`
+}
+
+// RPCURL returns JSON-RPC server url of Bitcoin node service
+func RPCURL(node *bitcoinv1alpha1.Node) string {
+	return fmt.Sprintf("http://%s.%s.svc:%d", node.Name, node.Namespace, node.Spec.RPCPort)
+}
+
+// NewRPCClient creates JSON-RPC client for Bitcoin node service
+func NewRPCClient(node *bitcoinv1alpha1.Node, username, password string) *RPCClient {
+	return &RPCClient{
+		URL:        RPCURL(node),
+		Username:   username,
+		Password:   password,
+		HTTPClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Call calls JSON-RPC method with params, and decodes method result into result
+func (c *RPCClient) Call(ctx context.Context, method string, result interface{}, params ...interface{}) error {
+	if params == nil {
+		params = []interface{}{}
+	}
+
+	body, err := json.Marshal(rpcRequest{
+		JSONRPC: "1.0",
+		ID:      "kotal",
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(c.Username, c.Password)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return ErrRPCUnauthorized
+	}
+
+	// bitcoind responds with non 200 status code on method errors, with error in response body
+	var rpcResp rpcResponse
+	if err := json.NewDecoder(resp.Body).Decode(&rpcResp); err != nil {
+		return fmt.Errorf("unable to decode %s response with status %s: %w", method, resp.Status, err)
+	}
+
+	if rpcResp.Error != nil {
+		return rpcResp.Error
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.Unmarshal(rpcResp.Result, result)
+}
+
+// GenerateToAddress mines blocks immediately to address, it's available on regtest only
+func (c *RPCClient) GenerateToAddress(ctx context.Context, blocks uint, address string) (hashes []string, err error) {
+	err = c.Call(ctx, "generatetoaddress", &hashes, blocks, address)
+	return
+}
+
+// GetChainStates returns node chainstates
+func (c *RPCClient) GetChainStates(ctx context.Context) (chainStates *ChainStates, err error) {
+	err = c.Call(ctx, "getchainstates", &chainStates)
+	return
+}
+
+// GetBlockHeader returns block header by block hash
+func (c *RPCClient) GetBlockHeader(ctx context.Context, hash string) (header *BlockHeader, err error) {
+	err = c.Call(ctx, "getblockheader", &header, hash)
+	return
+}
+
+// LoadTxOutSet loads UTXO snapshot at path in node file system
+// node keeps loading the snapshot if the request timed out
+func (c *RPCClient) LoadTxOutSet(ctx context.Context, path string) error {
+	return c.Call(ctx, "loadtxoutset", nil, path)
+}
+
+// IsTimeout returns true if JSON-RPC request timed out
+func IsTimeout(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
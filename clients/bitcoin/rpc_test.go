@@ -0,0 +1,81 @@
+package bitcoin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Bitcoin JSON-RPC client", func() {
+
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if username, password, ok := r.BasicAuth(); !ok || username != "kotal" || password != "s3cr3t" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			var req rpcRequest
+			Expect(json.NewDecoder(r.Body).Decode(&req)).To(Succeed())
+
+			switch req.Method {
+			case "generatetoaddress":
+				Expect(req.Params).To(Equal([]interface{}{float64(1), "bcrt1qjxs5y6zvmq5d5r9p0mnvlhcd8sf2ajkgyfuw0z"}))
+				w.Write([]byte(`{"result":["0f9188f13cb7b2c71f2a335e3a4fc328bf5beb436012afca590b1a11466e2206"],"error":null,"id":"kotal"}`))
+			case "getchainstates":
+				w.Write([]byte(`{"result":{"headers":840000,"chainstates":[{"blocks":120000,"bestblockhash":"00000000000000000001a0a3c8bc7a4e7d2de3b6b7e3a4a8b9f5bc4f1f8c2b1a","validated":true},{"blocks":840000,"bestblockhash":"0000000000000000000320283a032748cef8227873ff4872689bf23f1cda83a5","snapshot_blockhash":"0000000000000000000320283a032748cef8227873ff4872689bf23f1cda83a5","validated":false}]},"error":null,"id":"kotal"}`))
+			case "loadtxoutset":
+				Expect(req.Params).To(Equal([]interface{}{"/data/kotal-data/utxo-snapshot.dat"}))
+				w.Write([]byte(`{"result":{"coins_loaded":176948713,"base_height":840000},"error":null,"id":"kotal"}`))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"result":null,"error":{"code":-32601,"message":"Method not found"},"id":"kotal"}`))
+			}
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("Should generate blocks to address", func() {
+		client := &RPCClient{URL: server.URL, Username: "kotal", Password: "s3cr3t", HTTPClient: server.Client()}
+		hashes, err := client.GenerateToAddress(context.Background(), 1, "bcrt1qjxs5y6zvmq5d5r9p0mnvlhcd8sf2ajkgyfuw0z")
+		Expect(err).To(BeNil())
+		Expect(hashes).To(Equal([]string{"0f9188f13cb7b2c71f2a335e3a4fc328bf5beb436012afca590b1a11466e2206"}))
+	})
+
+	It("Should return JSON-RPC method errors", func() {
+		client := &RPCClient{URL: server.URL, Username: "kotal", Password: "s3cr3t", HTTPClient: server.Client()}
+		err := client.Call(context.Background(), "unknownmethod", nil)
+		Expect(err).To(MatchError("JSON-RPC error -32601: Method not found"))
+	})
+
+	It("Should return unauthorized error for wrong credentials", func() {
+		client := &RPCClient{URL: server.URL, Username: "kotal", Password: "wrong", HTTPClient: server.Client()}
+		err := client.Call(context.Background(), "getblockcount", nil)
+		Expect(err).To(MatchError(ErrRPCUnauthorized))
+	})
+
+	It("Should get node chainstates", func() {
+		client := &RPCClient{URL: server.URL, Username: "kotal", Password: "s3cr3t", HTTPClient: server.Client()}
+		chainStates, err := client.GetChainStates(context.Background())
+		Expect(err).To(BeNil())
+		Expect(chainStates.Headers).To(Equal(uint(840000)))
+		Expect(chainStates.ChainStates).To(HaveLen(2))
+		Expect(chainStates.ChainStates[0].SnapshotBlockHash).To(BeEmpty())
+		Expect(chainStates.ChainStates[1].SnapshotBlockHash).To(Equal("0000000000000000000320283a032748cef8227873ff4872689bf23f1cda83a5"))
+	})
+
+	It("Should load UTXO snapshot", func() {
+		client := &RPCClient{URL: server.URL, Username: "kotal", Password: "s3cr3t", HTTPClient: server.Client()}
+		Expect(client.LoadTxOutSet(context.Background(), "/data/kotal-data/utxo-snapshot.dat")).To(Succeed())
+	})
+
+})
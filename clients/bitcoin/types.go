@@ -42,4 +42,65 @@ const (
 	BitcoinArgDBCacheSize = "-dbcache"
 	// BitcoinArgMaxConnections is argument used to set maximum connections to peers
 	BitcoinArgMaxConnections = "-maxconnections"
+	// BitcoinArgSignetChallenge is argument used to set custom signet blocks challenge
+	BitcoinArgSignetChallenge = "-signetchallenge"
+	// BitcoinArgSignetSeedNode is argument used to set custom signet seed node
+	BitcoinArgSignetSeedNode = "-signetseednode"
+	// BitcoinArgREST is argument used to enable REST interface
+	BitcoinArgREST = "-rest"
+	// BitcoinArgBlockFilterIndex is argument used to maintain compact block filters index
+	BitcoinArgBlockFilterIndex = "-blockfilterindex"
+	// BitcoinArgZMQPubRawBlock is argument used to publish raw blocks at address
+	BitcoinArgZMQPubRawBlock = "-zmqpubrawblock"
+	// BitcoinArgZMQPubRawTx is argument used to publish raw transactions at address
+	BitcoinArgZMQPubRawTx = "-zmqpubrawtx"
+	// BitcoinArgZMQPubHashBlock is argument used to publish blocks hashes at address
+	BitcoinArgZMQPubHashBlock = "-zmqpubhashblock"
+	// BitcoinArgZMQPubSequence is argument used to publish blocks and mempool transactions sequence at address
+	BitcoinArgZMQPubSequence = "-zmqpubsequence"
+)
+
+const (
+	// EnvBitcoinRPCPassword is environment variable holding Bitcoin node rpc user password
+	EnvBitcoinRPCPassword = "KOTAL_BITCOIN_RPC_PASSWORD"
+)
+
+const (
+	// LNDArgLNDDir is argument used to set base directory
+	LNDArgLNDDir = "--lnddir"
+	// LNDArgAlias is argument used to set node alias
+	LNDArgAlias = "--alias"
+	// LNDArgListen is argument used to set p2p listening address
+	LNDArgListen = "--listen"
+	// LNDArgRPCListen is argument used to set gRPC server listening address
+	LNDArgRPCListen = "--rpclisten"
+	// LNDArgRESTListen is argument used to set REST server listening address
+	LNDArgRESTListen = "--restlisten"
+	// LNDArgTLSCertPath is argument used to set TLS certificate path
+	LNDArgTLSCertPath = "--tlscertpath"
+	// LNDArgTLSKeyPath is argument used to set TLS private key path
+	LNDArgTLSKeyPath = "--tlskeypath"
+	// LNDArgWalletUnlockPasswordFile is argument used to unlock wallet on startup using password file
+	LNDArgWalletUnlockPasswordFile = "--wallet-unlock-password-file"
+	// LNDArgWalletUnlockAllowCreate is argument used to wait for wallet creation if it doesn't exist
+	LNDArgWalletUnlockAllowCreate = "--wallet-unlock-allow-create"
+	// LNDArgBitcoinActive is argument used to enable Bitcoin chain
+	LNDArgBitcoinActive = "--bitcoin.active"
+	// LNDArgBitcoinNode is argument used to set Bitcoin chain backend
+	LNDArgBitcoinNode = "--bitcoin.node"
+	// LNDArgBitcoindRPCHost is argument used to set Bitcoin node JSON-RPC server host
+	LNDArgBitcoindRPCHost = "--bitcoind.rpchost"
+	// LNDArgBitcoindRPCUser is argument used to set Bitcoin node JSON-RPC user
+	LNDArgBitcoindRPCUser = "--bitcoind.rpcuser"
+	// LNDArgBitcoindRPCPass is argument used to set Bitcoin node JSON-RPC user password
+	LNDArgBitcoindRPCPass = "--bitcoind.rpcpass"
+	// LNDArgBitcoindZMQPubRawBlock is argument used to set Bitcoin node ZeroMQ raw blocks address
+	LNDArgBitcoindZMQPubRawBlock = "--bitcoind.zmqpubrawblock"
+	// LNDArgBitcoindZMQPubRawTx is argument used to set Bitcoin node ZeroMQ raw transactions address
+	LNDArgBitcoindZMQPubRawTx = "--bitcoind.zmqpubrawtx"
+)
+
+const (
+	// ElectrsArgConf is argument used to set config file path
+	ElectrsArgConf = "--conf"
 )
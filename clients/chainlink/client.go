@@ -3,8 +3,14 @@ package chainlink
 import (
 	chainlinkv1alpha1 "github.com/kotalco/kotal/apis/chainlink/v1alpha1"
 	clients "github.com/kotalco/kotal/clients"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// Args generates node cli arguments, it's injected into validation webhook
+func Args(obj runtime.Object) []string {
+	return NewClient(obj.(*chainlinkv1alpha1.Node)).Args()
+}
+
 // NewClient returns chainlink client for the given node
 func NewClient(node *chainlinkv1alpha1.Node) clients.Interface {
 	return &ChainlinkClient{node}
@@ -26,6 +26,16 @@ func (b *BesuClient) HomeDir() string {
 	return BesuHomeDir
 }
 
+// TerminationGracePeriod returns seconds to wait for besu to close its database
+func (b *BesuClient) TerminationGracePeriod() int64 {
+	return 120
+}
+
+// PreStop returns nil, besu shuts down gracefully on SIGTERM
+func (b *BesuClient) PreStop() []string {
+	return nil
+}
+
 func (b *BesuClient) Command() []string {
 	return nil
 }
@@ -45,7 +55,9 @@ func (b *BesuClient) Args() (args []string) {
 	args = append(args, BesuSyncMode, string(node.Spec.SyncMode))
 	args = append(args, BesuLogging, strings.ToUpper(string(node.Spec.Logging)))
 
-	if node.Spec.NodePrivateKeySecretName != "" {
+	if shared.HasReplicaKeys(node.Spec.Replicas) {
+		args = append(args, BesuNodePrivateKey, fmt.Sprintf("%s/nodekey", shared.PathNodeKeys(b.HomeDir())))
+	} else if node.Spec.NodePrivateKeySecretName.Name != "" {
 		args = append(args, BesuNodePrivateKey, fmt.Sprintf("%s/nodekey", shared.PathSecrets(b.HomeDir())))
 	}
 
@@ -130,6 +142,12 @@ func (b *BesuClient) Args() (args []string) {
 		// no ws cors setting
 	}
 
+	if node.Spec.Metrics.Enabled {
+		args = append(args, BesuMetricsEnabled)
+		args = append(args, BesuMetricsHost, shared.Host(true))
+		args = append(args, BesuMetricsPort, fmt.Sprintf("%d", node.Spec.Metrics.Port))
+	}
+
 	return args
 }
 
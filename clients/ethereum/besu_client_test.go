@@ -50,7 +50,7 @@ var _ = Describe("Besu Client", func() {
 				Client:                   ethereumv1alpha1.BesuClient,
 				Network:                  ethereumv1alpha1.MainNetwork,
 				Bootnodes:                []ethereumv1alpha1.Enode{enode},
-				NodePrivateKeySecretName: "besu-mainnet-nodekey",
+				NodePrivateKeySecretName: sharedAPI.SecretKeySelector{Name: "besu-mainnet-nodekey"},
 				StaticNodes:              []ethereumv1alpha1.Enode{enode},
 				P2PPort:                  3333,
 				SyncMode:                 ethereumv1alpha1.LightSynchronization,
@@ -66,7 +66,7 @@ var _ = Describe("Besu Client", func() {
 				},
 				Engine:        true,
 				EnginePort:    8552,
-				JWTSecretName: "jwt-secret",
+				JWTSecretName: sharedAPI.SecretKeySelector{Name: "jwt-secret"},
 				WS:            true,
 				WSPort:        7777,
 				WSAPI: []ethereumv1alpha1.API{
@@ -75,6 +75,10 @@ var _ = Describe("Besu Client", func() {
 				},
 				GraphQL:     true,
 				GraphQLPort: 9999,
+				Metrics: sharedAPI.Metrics{
+					Enabled: true,
+					Port:    6061,
+				},
 			},
 		}
 		node.Default()
@@ -135,6 +139,11 @@ var _ = Describe("Besu Client", func() {
 				"allowed.domain.com",
 				BesuGraphQLHTTPCorsOrigins,
 				"allowed.domain.com",
+				BesuMetricsEnabled,
+				BesuMetricsHost,
+				"0.0.0.0",
+				BesuMetricsPort,
+				"6061",
 			))
 		})
 
@@ -153,7 +162,7 @@ var _ = Describe("Besu Client", func() {
 				},
 				Client:                   ethereumv1alpha1.BesuClient,
 				Miner:                    true,
-				NodePrivateKeySecretName: "besu-pow-nodekey",
+				NodePrivateKeySecretName: sharedAPI.SecretKeySelector{Name: "besu-pow-nodekey"},
 				Coinbase:                 sharedAPI.EthereumAddress(coinbase),
 			},
 		}
@@ -199,7 +208,7 @@ var _ = Describe("Besu Client", func() {
 				},
 				Client:                   ethereumv1alpha1.BesuClient,
 				Miner:                    true,
-				NodePrivateKeySecretName: "besu-poa-nodekey",
+				NodePrivateKeySecretName: sharedAPI.SecretKeySelector{Name: "besu-poa-nodekey"},
 				Coinbase:                 sharedAPI.EthereumAddress(coinbase),
 			},
 		}
@@ -245,7 +254,7 @@ var _ = Describe("Besu Client", func() {
 				},
 				Client:                   ethereumv1alpha1.BesuClient,
 				Miner:                    true,
-				NodePrivateKeySecretName: "besu-ibft2-nodekey",
+				NodePrivateKeySecretName: sharedAPI.SecretKeySelector{Name: "besu-ibft2-nodekey"},
 				Coinbase:                 sharedAPI.EthereumAddress(coinbase),
 			},
 		}
@@ -5,6 +5,7 @@ import (
 
 	ethereumv1alpha1 "github.com/kotalco/kotal/apis/ethereum/v1alpha1"
 	"github.com/kotalco/kotal/clients"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // EthereumClient is Ethereum client
@@ -14,6 +15,15 @@ type EthereumClient interface {
 	EncodeStaticNodes() string
 }
 
+// Args generates node cli arguments, it's injected into validation webhook
+func Args(obj runtime.Object) []string {
+	client, err := NewClient(obj.(*ethereumv1alpha1.Node))
+	if err != nil {
+		return nil
+	}
+	return client.Args()
+}
+
 // NewClient returns an Ethereum client instance
 func NewClient(node *ethereumv1alpha1.Node) (EthereumClient, error) {
 	switch node.Spec.Client {
@@ -38,6 +38,16 @@ func (g *GethClient) HomeDir() string {
 	return GethHomeDir
 }
 
+// TerminationGracePeriod returns seconds to wait for go-ethereum to flush state to the database
+func (g *GethClient) TerminationGracePeriod() int64 {
+	return 300
+}
+
+// PreStop returns nil, go-ethereum shuts down gracefully on SIGTERM
+func (g *GethClient) PreStop() []string {
+	return nil
+}
+
 func (g *GethClient) Command() []string {
 	return nil
 }
@@ -67,7 +77,9 @@ func (g *GethClient) Args() (args []string) {
 		args = append(args, GethConfig, fmt.Sprintf("%s/config.toml", shared.PathConfig(g.HomeDir())))
 	}
 
-	if node.Spec.NodePrivateKeySecretName != "" {
+	if shared.HasReplicaKeys(node.Spec.Replicas) {
+		args = append(args, GethNodeKey, fmt.Sprintf("%s/nodekey", shared.PathNodeKeys(g.HomeDir())))
+	} else if node.Spec.NodePrivateKeySecretName.Name != "" {
 		args = append(args, GethNodeKey, fmt.Sprintf("%s/nodekey", shared.PathSecrets(g.HomeDir())))
 	}
 
@@ -159,6 +171,12 @@ func (g *GethClient) Args() (args []string) {
 		}
 	}
 
+	if node.Spec.Metrics.Enabled {
+		args = append(args, GethMetrics)
+		args = append(args, GethMetricsAddress, shared.Host(true))
+		args = append(args, GethMetricsPort, fmt.Sprintf("%d", node.Spec.Metrics.Port))
+	}
+
 	return args
 }
 
@@ -49,7 +49,7 @@ var _ = Describe("Geth Client", func() {
 				Network:                  ethereumv1alpha1.MainNetwork,
 				Client:                   ethereumv1alpha1.GethClient,
 				Bootnodes:                []ethereumv1alpha1.Enode{enode},
-				NodePrivateKeySecretName: "geth-mainnet-nodekey",
+				NodePrivateKeySecretName: sharedAPI.SecretKeySelector{Name: "geth-mainnet-nodekey"},
 				StaticNodes:              []ethereumv1alpha1.Enode{enode},
 				P2PPort:                  3333,
 				SyncMode:                 ethereumv1alpha1.LightSynchronization,
@@ -65,7 +65,7 @@ var _ = Describe("Geth Client", func() {
 				},
 				Engine:        true,
 				EnginePort:    8552,
-				JWTSecretName: "jwt-secret",
+				JWTSecretName: sharedAPI.SecretKeySelector{Name: "jwt-secret"},
 				WS:            true,
 				WSPort:        7777,
 				WSAPI: []ethereumv1alpha1.API{
@@ -74,6 +74,10 @@ var _ = Describe("Geth Client", func() {
 				},
 				GraphQL:     true,
 				GraphQLPort: 9999,
+				Metrics: sharedAPI.Metrics{
+					Enabled: true,
+					Port:    6061,
+				},
 			},
 		}
 		node.Default()
@@ -133,6 +137,11 @@ var _ = Describe("Geth Client", func() {
 				"allowed.domain.com",
 				GethWSOrigins,
 				"allowed.domain.com",
+				GethMetrics,
+				GethMetricsAddress,
+				"0.0.0.0",
+				GethMetricsPort,
+				"6061",
 			))
 		})
 	})
@@ -152,8 +161,8 @@ var _ = Describe("Geth Client", func() {
 				Miner:    true,
 				Coinbase: sharedAPI.EthereumAddress(coinbase),
 				Import: &ethereumv1alpha1.ImportedAccount{
-					PrivateKeySecretName: "geth-pow-account-key",
-					PasswordSecretName:   "geth-pow-account-password",
+					PrivateKeySecretName: sharedAPI.SecretKeySelector{Name: "geth-pow-account-key"},
+					PasswordSecretName:   sharedAPI.SecretKeySelector{Name: "geth-pow-account-password"},
 				},
 			},
 		}
@@ -202,8 +211,8 @@ var _ = Describe("Geth Client", func() {
 				Miner:    true,
 				Coinbase: sharedAPI.EthereumAddress(coinbase),
 				Import: &ethereumv1alpha1.ImportedAccount{
-					PrivateKeySecretName: "geth-poa-account-key",
-					PasswordSecretName:   "geth-poa-account-password",
+					PrivateKeySecretName: sharedAPI.SecretKeySelector{Name: "geth-poa-account-key"},
+					PasswordSecretName:   sharedAPI.SecretKeySelector{Name: "geth-poa-account-password"},
 				},
 			},
 		}
@@ -235,4 +244,35 @@ var _ = Describe("Geth Client", func() {
 
 	})
 
+	Context("multiple replicas", func() {
+		replicas := uint(3)
+		node := &ethereumv1alpha1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "geth-replicas",
+			},
+			Spec: ethereumv1alpha1.NodeSpec{
+				Network:                  ethereumv1alpha1.MainNetwork,
+				Client:                   ethereumv1alpha1.GethClient,
+				NodePrivateKeySecretName: sharedAPI.SecretKeySelector{Name: "geth-replicas-nodekey"},
+				Replicas:                 &replicas,
+			},
+		}
+		node.Default()
+
+		It("should use replica own node key", func() {
+
+			client, err := NewClient(node)
+
+			Expect(err).To(BeNil())
+			Expect(client.Args()).To(ContainElements(
+				GethNodeKey,
+				fmt.Sprintf("%s/nodekey", shared.PathNodeKeys(client.HomeDir())),
+			))
+			Expect(client.Args()).NotTo(ContainElement(
+				fmt.Sprintf("%s/nodekey", shared.PathSecrets(client.HomeDir())),
+			))
+		})
+
+	})
+
 })
@@ -27,6 +27,16 @@ func (n *NethermindClient) HomeDir() string {
 	return NethermindHomeDir
 }
 
+// TerminationGracePeriod returns seconds to wait for nethermind to flush state to the database
+func (n *NethermindClient) TerminationGracePeriod() int64 {
+	return 180
+}
+
+// PreStop returns nil, nethermind shuts down gracefully on SIGTERM
+func (n *NethermindClient) PreStop() []string {
+	return nil
+}
+
 func (n *NethermindClient) Command() []string {
 	return nil
 }
@@ -46,7 +56,7 @@ func (n *NethermindClient) Args() (args []string) {
 	args = append(args, NethermindP2PPort, fmt.Sprintf("%d", node.Spec.P2PPort))
 	args = append(args, NethermindLogging, strings.ToUpper(string(node.Spec.Logging)))
 
-	if node.Spec.NodePrivateKeySecretName != "" {
+	if node.Spec.NodePrivateKeySecretName.Name != "" || shared.HasReplicaKeys(node.Spec.Replicas) {
 		// use enode private key in binary format
 		// that has been converted using nethermind_convert_enode_privatekey.sh script
 		args = append(args, NethermindNodePrivateKey, fmt.Sprintf("%s/kotal_nodekey", shared.PathData(n.HomeDir())))
@@ -120,6 +130,11 @@ func (n *NethermindClient) Args() (args []string) {
 		// nethermind ws reuses enabled JSON-RPC modules
 	}
 
+	if node.Spec.Metrics.Enabled {
+		args = append(args, NethermindMetricsEnabled, "true")
+		args = append(args, NethermindMetricsExposePort, fmt.Sprintf("%d", node.Spec.Metrics.Port))
+	}
+
 	return args
 }
 
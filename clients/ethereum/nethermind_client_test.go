@@ -47,7 +47,7 @@ var _ = Describe("Nethermind Client", func() {
 			Spec: ethereumv1alpha1.NodeSpec{
 				Client:                   ethereumv1alpha1.NethermindClient,
 				Network:                  ethereumv1alpha1.MainNetwork,
-				NodePrivateKeySecretName: "mainnet-nethermind-nodekey",
+				NodePrivateKeySecretName: sharedAPI.SecretKeySelector{Name: "mainnet-nethermind-nodekey"},
 				Logging:                  sharedAPI.WarnLogs,
 				RPC:                      true,
 				RPCPort:                  8799,
@@ -57,7 +57,7 @@ var _ = Describe("Nethermind Client", func() {
 				},
 				Engine:        true,
 				EnginePort:    8552,
-				JWTSecretName: "jwt-secret",
+				JWTSecretName: sharedAPI.SecretKeySelector{Name: "jwt-secret"},
 				P2PPort:       30306,
 				WS:            true,
 				WSPort:        30307,
@@ -65,6 +65,10 @@ var _ = Describe("Nethermind Client", func() {
 				StaticNodes: []ethereumv1alpha1.Enode{
 					enode,
 				},
+				Metrics: sharedAPI.Metrics{
+					Enabled: true,
+					Port:    6061,
+				},
 			},
 		}
 
@@ -111,6 +115,10 @@ var _ = Describe("Nethermind Client", func() {
 				"true",
 				NethermindRPCWSPort,
 				"30307",
+				NethermindMetricsEnabled,
+				"true",
+				NethermindMetricsExposePort,
+				"6061",
 			))
 
 		})
@@ -132,8 +140,8 @@ var _ = Describe("Nethermind Client", func() {
 				Miner:    true,
 				Coinbase: sharedAPI.EthereumAddress(coinbase),
 				Import: &ethereumv1alpha1.ImportedAccount{
-					PrivateKeySecretName: "nethermind-pow-account-key",
-					PasswordSecretName:   "nethermind-pow-account-password",
+					PrivateKeySecretName: sharedAPI.SecretKeySelector{Name: "nethermind-pow-account-key"},
+					PasswordSecretName:   sharedAPI.SecretKeySelector{Name: "nethermind-pow-account-password"},
 				},
 			},
 		}
@@ -183,8 +191,8 @@ var _ = Describe("Nethermind Client", func() {
 				Miner:    true,
 				Coinbase: sharedAPI.EthereumAddress(coinbase),
 				Import: &ethereumv1alpha1.ImportedAccount{
-					PrivateKeySecretName: "nethermind-poa-account-key",
-					PasswordSecretName:   "nethermind-poa-account-password",
+					PrivateKeySecretName: sharedAPI.SecretKeySelector{Name: "nethermind-poa-account-key"},
+					PasswordSecretName:   sharedAPI.SecretKeySelector{Name: "nethermind-poa-account-password"},
 				},
 			},
 		}
@@ -68,6 +68,12 @@ const (
 	BesuHostAllowlist = "--host-allowlist"
 	// BesuStaticNodesFile is the argument used to locate static nodes file
 	BesuStaticNodesFile = "--static-nodes-file"
+	// BesuMetricsEnabled is the argument used to enable prometheus metrics server
+	BesuMetricsEnabled = "--metrics-enabled"
+	// BesuMetricsHost is the argument used for prometheus metrics server host
+	BesuMetricsHost = "--metrics-host"
+	// BesuMetricsPort is the argument used for prometheus metrics server port
+	BesuMetricsPort = "--metrics-port"
 )
 
 // Go ethereum client arguments
@@ -149,6 +155,12 @@ const (
 	GethUnlock = "--unlock"
 	// GethPassword is the argument used for locking imported ethereum address
 	GethPassword = "--password"
+	// GethMetrics is the argument used to enable metrics collection and reporting
+	GethMetrics = "--metrics"
+	// GethMetricsAddress is the argument used for prometheus metrics server address
+	GethMetricsAddress = "--metrics.addr"
+	// GethMetricsPort is the argument used for prometheus metrics server port
+	GethMetricsPort = "--metrics.port"
 )
 
 // Parity client arguments
@@ -268,4 +280,8 @@ const (
 	NethermindPasswordFiles = "--KeyStore.PasswordFiles"
 	// NethermindMiningEnabled is the argument used for turning on mining
 	NethermindMiningEnabled = "--Mining.Enabled"
+	// NethermindMetricsEnabled is the argument used to enable metrics
+	NethermindMetricsEnabled = "--Metrics.Enabled"
+	// NethermindMetricsExposePort is the argument used for prometheus metrics server port
+	NethermindMetricsExposePort = "--Metrics.ExposePort"
 )
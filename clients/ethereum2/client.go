@@ -13,6 +13,15 @@ type Ethereum2Client interface {
 	clients.Interface
 }
 
+// Args generates beacon node or validator cli arguments, it's injected into validation webhook
+func Args(obj runtime.Object) []string {
+	client, err := NewClient(obj)
+	if err != nil {
+		return nil
+	}
+	return client.Args()
+}
+
 // NewClient creates new ethereum 2.0 beacon node or validator client
 func NewClient(obj runtime.Object) (Ethereum2Client, error) {
 
@@ -57,6 +57,12 @@ func (t *LighthouseBeaconNode) Args() (args []string) {
 	args = append(args, LighthousePort, fmt.Sprintf("%d", node.Spec.P2PPort))
 	args = append(args, LighthouseDiscoveryPort, fmt.Sprintf("%d", node.Spec.P2PPort))
 
+	if node.Spec.Metrics.Enabled {
+		args = append(args, LighthouseMetrics)
+		args = append(args, LighthouseMetricsAddress, shared.Host(true))
+		args = append(args, LighthouseMetricsPort, fmt.Sprintf("%d", node.Spec.Metrics.Port))
+	}
+
 	return
 }
 
@@ -62,7 +62,7 @@ var _ = Describe("Lighthouse beacon node", func() {
 					Client:                  ethereum2v1alpha1.LighthouseClient,
 					Network:                 "mainnet",
 					ExecutionEngineEndpoint: "https://localhost:8551",
-					JWTSecretName:           "jwt-secret",
+					JWTSecretName:           sharedAPI.SecretKeySelector{Name: "jwt-secret"},
 					FeeRecipient:            "0xd8da6bf26964af9d7eed9e03e53415d37aa96045",
 				},
 			},
@@ -85,7 +85,7 @@ var _ = Describe("Lighthouse beacon node", func() {
 					Client:                  ethereum2v1alpha1.LighthouseClient,
 					Network:                 "mainnet",
 					ExecutionEngineEndpoint: "https://localhost:8551",
-					JWTSecretName:           "jwt-secret",
+					JWTSecretName:           sharedAPI.SecretKeySelector{Name: "jwt-secret"},
 					REST:                    true,
 					CheckpointSyncURL:       "https://kotal.cloud/eth2/beacon/checkpoint",
 				},
@@ -112,7 +112,7 @@ var _ = Describe("Lighthouse beacon node", func() {
 					Client:                  ethereum2v1alpha1.LighthouseClient,
 					Network:                 "mainnet",
 					ExecutionEngineEndpoint: "https://localhost:8551",
-					JWTSecretName:           "jwt-secret",
+					JWTSecretName:           sharedAPI.SecretKeySelector{Name: "jwt-secret"},
 					REST:                    true,
 					RESTPort:                4444,
 				},
@@ -139,7 +139,7 @@ var _ = Describe("Lighthouse beacon node", func() {
 					Client:                  ethereum2v1alpha1.LighthouseClient,
 					Network:                 "mainnet",
 					ExecutionEngineEndpoint: "https://localhost:8551",
-					JWTSecretName:           "jwt-secret",
+					JWTSecretName:           sharedAPI.SecretKeySelector{Name: "jwt-secret"},
 					REST:                    true,
 					RESTPort:                4444,
 				},
@@ -169,7 +169,7 @@ var _ = Describe("Lighthouse beacon node", func() {
 					P2PPort:                 7891,
 					Network:                 "mainnet",
 					ExecutionEngineEndpoint: "https://localhost:8551",
-					JWTSecretName:           "jwt-secret",
+					JWTSecretName:           sharedAPI.SecretKeySelector{Name: "jwt-secret"},
 					REST:                    true,
 					RESTPort:                4444,
 				},
@@ -195,6 +195,27 @@ var _ = Describe("Lighthouse beacon node", func() {
 				"*",
 			},
 		},
+		{
+			title: "beacon node syncing mainnet with metrics enabled",
+			node: &ethereum2v1alpha1.BeaconNode{
+				Spec: ethereum2v1alpha1.BeaconNodeSpec{
+					Client:                  ethereum2v1alpha1.LighthouseClient,
+					Network:                 "mainnet",
+					ExecutionEngineEndpoint: "https://localhost:8551",
+					JWTSecretName:           sharedAPI.SecretKeySelector{Name: "jwt-secret"},
+					Metrics: sharedAPI.Metrics{
+						Enabled: true,
+					},
+				},
+			},
+			result: []string{
+				LighthouseMetrics,
+				LighthouseMetricsAddress,
+				"0.0.0.0",
+				LighthouseMetricsPort,
+				"8008",
+			},
+		},
 	}
 
 	for _, c := range cases {
@@ -1,6 +1,7 @@
 package ethereum2
 
 import (
+	"fmt"
 	"strings"
 
 	ethereum2v1alpha1 "github.com/kotalco/kotal/apis/ethereum2/v1alpha1"
@@ -45,6 +46,12 @@ func (t *LighthouseValidatorClient) Args() (args []string) {
 		args = append(args, LighthouseGraffiti, validator.Spec.Graffiti)
 	}
 
+	if validator.Spec.Metrics.Enabled {
+		args = append(args, LighthouseMetrics)
+		args = append(args, LighthouseMetricsAddress, shared.Host(true))
+		args = append(args, LighthouseMetricsPort, fmt.Sprintf("%d", validator.Spec.Metrics.Port))
+	}
+
 	return
 }
 
@@ -55,6 +55,12 @@ func (t *NimbusBeaconNode) Args() (args []string) {
 	args = append(args, argWithVal(NimbusTCPPort, fmt.Sprintf("%d", node.Spec.P2PPort)))
 	args = append(args, argWithVal(NimbusUDPPort, fmt.Sprintf("%d", node.Spec.P2PPort)))
 
+	if node.Spec.Metrics.Enabled {
+		args = append(args, NimbusMetrics)
+		args = append(args, argWithVal(NimbusMetricsAddress, shared.Host(true)))
+		args = append(args, argWithVal(NimbusMetricsPort, fmt.Sprintf("%d", node.Spec.Metrics.Port)))
+	}
+
 	return
 }
 
@@ -59,7 +59,7 @@ var _ = Describe("Nimbus beacon node", func() {
 					Client:                  ethereum2v1alpha1.NimbusClient,
 					Network:                 "mainnet",
 					ExecutionEngineEndpoint: "https://localhost:8551",
-					JWTSecretName:           "jwt-secret",
+					JWTSecretName:           sharedAPI.SecretKeySelector{Name: "jwt-secret"},
 					REST:                    true,
 					RESTPort:                8957,
 					CORSDomains:             []string{"kotal.pro", "kotal.cloud"},
@@ -82,7 +82,7 @@ var _ = Describe("Nimbus beacon node", func() {
 					Client:                  ethereum2v1alpha1.NimbusClient,
 					Network:                 "mainnet",
 					ExecutionEngineEndpoint: "https://localhost:8551",
-					JWTSecretName:           "jwt-secret",
+					JWTSecretName:           sharedAPI.SecretKeySelector{Name: "jwt-secret"},
 				},
 			},
 			result: []string{
@@ -99,7 +99,7 @@ var _ = Describe("Nimbus beacon node", func() {
 					Client:                  ethereum2v1alpha1.NimbusClient,
 					Network:                 "mainnet",
 					ExecutionEngineEndpoint: "https://localhost:8551",
-					JWTSecretName:           "jwt-secret",
+					JWTSecretName:           sharedAPI.SecretKeySelector{Name: "jwt-secret"},
 				},
 			},
 			result: []string{
@@ -116,7 +116,7 @@ var _ = Describe("Nimbus beacon node", func() {
 					Client:                  ethereum2v1alpha1.NimbusClient,
 					Network:                 "mainnet",
 					ExecutionEngineEndpoint: "https://localhost:8551",
-					JWTSecretName:           "jwt-secret",
+					JWTSecretName:           sharedAPI.SecretKeySelector{Name: "jwt-secret"},
 				},
 			},
 			result: []string{
@@ -134,7 +134,7 @@ var _ = Describe("Nimbus beacon node", func() {
 					P2PPort:                 7891,
 					Network:                 "mainnet",
 					ExecutionEngineEndpoint: "https://localhost:8551",
-					JWTSecretName:           "jwt-secret",
+					JWTSecretName:           sharedAPI.SecretKeySelector{Name: "jwt-secret"},
 					FeeRecipient:            "0xd8da6bf26964af9d7eed9e03e53415d37aa96045",
 				},
 			},
@@ -148,6 +148,25 @@ var _ = Describe("Nimbus beacon node", func() {
 				argWithVal(NimbusFeeRecipient, "0xd8da6bf26964af9d7eed9e03e53415d37aa96045"),
 			},
 		},
+		{
+			title: "beacon node syncing mainnet with metrics enabled",
+			node: &ethereum2v1alpha1.BeaconNode{
+				Spec: ethereum2v1alpha1.BeaconNodeSpec{
+					Client:                  ethereum2v1alpha1.NimbusClient,
+					Network:                 "mainnet",
+					ExecutionEngineEndpoint: "https://localhost:8551",
+					JWTSecretName:           sharedAPI.SecretKeySelector{Name: "jwt-secret"},
+					Metrics: sharedAPI.Metrics{
+						Enabled: true,
+					},
+				},
+			},
+			result: []string{
+				NimbusMetrics,
+				"--metrics-address=0.0.0.0",
+				"--metrics-port=8008",
+			},
+		},
 	}
 
 	for _, c := range cases {
@@ -48,6 +48,12 @@ func (t *NimbusValidatorClient) Args() (args []string) {
 		args = append(args, argWithVal(NimbusGraffiti, validator.Spec.Graffiti))
 	}
 
+	if validator.Spec.Metrics.Enabled {
+		args = append(args, NimbusMetrics)
+		args = append(args, argWithVal(NimbusMetricsAddress, shared.Host(true)))
+		args = append(args, argWithVal(NimbusMetricsPort, fmt.Sprintf("%d", validator.Spec.Metrics.Port)))
+	}
+
 	return
 }
 
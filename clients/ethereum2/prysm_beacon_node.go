@@ -73,6 +73,14 @@ func (t *PrysmBeaconNode) Args() (args []string) {
 	args = append(args, PrysmP2PTCPPort, fmt.Sprintf("%d", node.Spec.P2PPort))
 	args = append(args, PrysmP2PUDPPort, fmt.Sprintf("%d", node.Spec.P2PPort))
 
+	// prysm enables monitoring by default
+	if node.Spec.Metrics.Enabled {
+		args = append(args, PrysmMonitoringHost, shared.Host(true))
+		args = append(args, PrysmMonitoringPort, fmt.Sprintf("%d", node.Spec.Metrics.Port))
+	} else {
+		args = append(args, PrysmDisableMonitoring)
+	}
+
 	return
 }
 
@@ -62,7 +62,7 @@ var _ = Describe("Prysm beacon node", func() {
 					Client:                  ethereum2v1alpha1.PrysmClient,
 					Network:                 "mainnet",
 					ExecutionEngineEndpoint: "https://localhost:8551",
-					JWTSecretName:           "jwt-secret",
+					JWTSecretName:           sharedAPI.SecretKeySelector{Name: "jwt-secret"},
 					FeeRecipient:            "0xd8da6bf26964af9d7eed9e03e53415d37aa96045",
 					RPC:                     true,
 					CheckpointSyncURL:       "https://kotal.cloud/eth2/beacon/checkpoint",
@@ -91,7 +91,7 @@ var _ = Describe("Prysm beacon node", func() {
 					Client:                  ethereum2v1alpha1.PrysmClient,
 					Network:                 "mainnet",
 					ExecutionEngineEndpoint: "https://localhost:8551",
-					JWTSecretName:           "jwt-secret",
+					JWTSecretName:           sharedAPI.SecretKeySelector{Name: "jwt-secret"},
 					RPC:                     true,
 					RPCPort:                 9976,
 				},
@@ -115,7 +115,7 @@ var _ = Describe("Prysm beacon node", func() {
 					Client:                  ethereum2v1alpha1.PrysmClient,
 					Network:                 "mainnet",
 					ExecutionEngineEndpoint: "https://localhost:8551",
-					JWTSecretName:           "jwt-secret",
+					JWTSecretName:           sharedAPI.SecretKeySelector{Name: "jwt-secret"},
 					RPC:                     true,
 					RPCPort:                 9976,
 				},
@@ -141,7 +141,7 @@ var _ = Describe("Prysm beacon node", func() {
 					Client:                  ethereum2v1alpha1.PrysmClient,
 					Network:                 "mainnet",
 					ExecutionEngineEndpoint: "https://localhost:8551",
-					JWTSecretName:           "jwt-secret",
+					JWTSecretName:           sharedAPI.SecretKeySelector{Name: "jwt-secret"},
 				},
 			},
 			result: []string{
@@ -162,7 +162,7 @@ var _ = Describe("Prysm beacon node", func() {
 					Client:                  ethereum2v1alpha1.PrysmClient,
 					Network:                 "mainnet",
 					ExecutionEngineEndpoint: "https://localhost:8551",
-					JWTSecretName:           "jwt-secret",
+					JWTSecretName:           sharedAPI.SecretKeySelector{Name: "jwt-secret"},
 					GRPC:                    true,
 					GRPCPort:                4445,
 					CertSecretName:          "my-certificate",
@@ -193,7 +193,7 @@ var _ = Describe("Prysm beacon node", func() {
 					Client:                  ethereum2v1alpha1.PrysmClient,
 					Network:                 "mainnet",
 					ExecutionEngineEndpoint: "https://localhost:8551",
-					JWTSecretName:           "jwt-secret",
+					JWTSecretName:           sharedAPI.SecretKeySelector{Name: "jwt-secret"},
 					GRPC:                    true,
 					GRPCPort:                4445,
 				},
@@ -222,7 +222,7 @@ var _ = Describe("Prysm beacon node", func() {
 					P2PPort:                 7891,
 					Network:                 "mainnet",
 					ExecutionEngineEndpoint: "https://localhost:8551",
-					JWTSecretName:           "jwt-secret",
+					JWTSecretName:           sharedAPI.SecretKeySelector{Name: "jwt-secret"},
 					GRPC:                    true,
 					GRPCPort:                4445,
 				},
@@ -247,6 +247,27 @@ var _ = Describe("Prysm beacon node", func() {
 				"*",
 			},
 		},
+		{
+			title: "beacon node syncing mainnet with metrics enabled",
+			node: &ethereum2v1alpha1.BeaconNode{
+				Spec: ethereum2v1alpha1.BeaconNodeSpec{
+					Client:                  ethereum2v1alpha1.PrysmClient,
+					Network:                 "mainnet",
+					ExecutionEngineEndpoint: "https://localhost:8551",
+					JWTSecretName:           sharedAPI.SecretKeySelector{Name: "jwt-secret"},
+					RPC:                     true,
+					Metrics: sharedAPI.Metrics{
+						Enabled: true,
+					},
+				},
+			},
+			result: []string{
+				PrysmMonitoringHost,
+				"0.0.0.0",
+				PrysmMonitoringPort,
+				"8008",
+			},
+		},
 	}
 
 	for _, c := range cases {
@@ -55,6 +55,14 @@ func (t *PrysmValidatorClient) Args() (args []string) {
 		args = append(args, PrysmTLSCert, fmt.Sprintf("%s/cert/tls.crt", shared.PathSecrets(t.HomeDir())))
 	}
 
+	// prysm enables monitoring by default
+	if validator.Spec.Metrics.Enabled {
+		args = append(args, PrysmMonitoringHost, shared.Host(true))
+		args = append(args, PrysmMonitoringPort, fmt.Sprintf("%d", validator.Spec.Metrics.Port))
+	} else {
+		args = append(args, PrysmDisableMonitoring)
+	}
+
 	return args
 }
 
@@ -23,7 +23,7 @@ var _ = Describe("Prysm validator client", func() {
 					SecretName: "my-validator",
 				},
 			},
-			WalletPasswordSecret: "wallet-password",
+			WalletPasswordSecret: sharedAPI.SecretKeySelector{Name: "wallet-password"},
 			FeeRecipient:         "0xd8da6bf26964af9d7eed9e03e53415d37aa96045",
 			CertSecretName:       "my-cert",
 			Logging:              sharedAPI.ErrorLogs,
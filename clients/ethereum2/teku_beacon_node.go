@@ -52,6 +52,13 @@ func (t *TekuBeaconNode) Args() (args []string) {
 
 	args = append(args, TekuP2PPort, fmt.Sprintf("%d", node.Spec.P2PPort))
 
+	if node.Spec.Metrics.Enabled {
+		args = append(args, TekuMetricsEnabled)
+		args = append(args, TekuMetricsInterface, shared.Host(true))
+		args = append(args, TekuMetricsPort, fmt.Sprintf("%d", node.Spec.Metrics.Port))
+		args = append(args, TekuMetricsHostAllowlist, "*")
+	}
+
 	return
 }
 
@@ -62,7 +62,7 @@ var _ = Describe("Teku beacon node", func() {
 					Client:                  ethereum2v1alpha1.TekuClient,
 					Network:                 "mainnet",
 					ExecutionEngineEndpoint: "https://localhost:8551",
-					JWTSecretName:           "jwt-secret",
+					JWTSecretName:           sharedAPI.SecretKeySelector{Name: "jwt-secret"},
 					FeeRecipient:            "0xd8da6bf26964af9d7eed9e03e53415d37aa96045",
 					CheckpointSyncURL:       "https://kotal.cloud/eth2/beacon/checkpoint",
 				},
@@ -88,7 +88,7 @@ var _ = Describe("Teku beacon node", func() {
 					Client:                  ethereum2v1alpha1.TekuClient,
 					Network:                 "mainnet",
 					ExecutionEngineEndpoint: "https://localhost:8551",
-					JWTSecretName:           "jwt-secret",
+					JWTSecretName:           sharedAPI.SecretKeySelector{Name: "jwt-secret"},
 					REST:                    true,
 				},
 			},
@@ -114,7 +114,7 @@ var _ = Describe("Teku beacon node", func() {
 					Client:                  ethereum2v1alpha1.TekuClient,
 					Network:                 "mainnet",
 					ExecutionEngineEndpoint: "https://localhost:8551",
-					JWTSecretName:           "jwt-secret",
+					JWTSecretName:           sharedAPI.SecretKeySelector{Name: "jwt-secret"},
 					REST:                    true,
 					RESTPort:                3333,
 				},
@@ -143,7 +143,7 @@ var _ = Describe("Teku beacon node", func() {
 					Client:                  ethereum2v1alpha1.TekuClient,
 					Network:                 "mainnet",
 					ExecutionEngineEndpoint: "https://localhost:8551",
-					JWTSecretName:           "jwt-secret",
+					JWTSecretName:           sharedAPI.SecretKeySelector{Name: "jwt-secret"},
 					REST:                    true,
 					RESTPort:                3333,
 				},
@@ -175,7 +175,7 @@ var _ = Describe("Teku beacon node", func() {
 					P2PPort:                 7891,
 					Network:                 "mainnet",
 					ExecutionEngineEndpoint: "https://localhost:8551",
-					JWTSecretName:           "jwt-secret",
+					JWTSecretName:           sharedAPI.SecretKeySelector{Name: "jwt-secret"},
 					REST:                    true,
 					RESTPort:                3333,
 				},
@@ -201,6 +201,29 @@ var _ = Describe("Teku beacon node", func() {
 				"*",
 			},
 		},
+		{
+			title: "beacon node syncing mainnet with metrics enabled",
+			node: &ethereum2v1alpha1.BeaconNode{
+				Spec: ethereum2v1alpha1.BeaconNodeSpec{
+					Client:                  ethereum2v1alpha1.TekuClient,
+					Network:                 "mainnet",
+					ExecutionEngineEndpoint: "https://localhost:8551",
+					JWTSecretName:           sharedAPI.SecretKeySelector{Name: "jwt-secret"},
+					Metrics: sharedAPI.Metrics{
+						Enabled: true,
+					},
+				},
+			},
+			result: []string{
+				TekuMetricsEnabled,
+				TekuMetricsInterface,
+				"0.0.0.0",
+				TekuMetricsPort,
+				"8008",
+				TekuMetricsHostAllowlist,
+				"*",
+			},
+		},
 	}
 
 	for _, c := range cases {
@@ -56,6 +56,13 @@ func (t *TekuValidatorClient) Args() (args []string) {
 
 	args = append(args, TekuValidatorKeys, strings.Join(keyPass, ","))
 
+	if validator.Spec.Metrics.Enabled {
+		args = append(args, TekuMetricsEnabled)
+		args = append(args, TekuMetricsInterface, shared.Host(true))
+		args = append(args, TekuMetricsPort, fmt.Sprintf("%d", validator.Spec.Metrics.Port))
+		args = append(args, TekuMetricsHostAllowlist, "*")
+	}
+
 	return args
 }
 
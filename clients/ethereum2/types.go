@@ -50,6 +50,14 @@ const (
 	TekuValidatorKeys = "--validator-keys"
 	// TekuValidatorsKeystoreLockingEnabled is the argument used to enable keystore locking files
 	TekuValidatorsKeystoreLockingEnabled = "--validators-keystore-locking-enabled"
+	// TekuMetricsEnabled is the argument used to enable prometheus metrics server
+	TekuMetricsEnabled = "--metrics-enabled"
+	// TekuMetricsInterface is the argument used for prometheus metrics server host
+	TekuMetricsInterface = "--metrics-interface"
+	// TekuMetricsPort is the argument used for prometheus metrics server port
+	TekuMetricsPort = "--metrics-port"
+	// TekuMetricsHostAllowlist is the argument used to whitelist hosts for metrics access
+	TekuMetricsHostAllowlist = "--metrics-host-allowlist"
 )
 
 // Prysm client arguments
@@ -104,6 +112,13 @@ const (
 	PrysmAccountPasswordFile = "--account-password-file"
 	// PrysmWalletPasswordFile is the argument used to locate wallet password file
 	PrysmWalletPasswordFile = "--wallet-password-file"
+
+	// PrysmDisableMonitoring is the argument used to disable prometheus metrics server
+	PrysmDisableMonitoring = "--disable-monitoring"
+	// PrysmMonitoringHost is the argument used for prometheus metrics server host
+	PrysmMonitoringHost = "--monitoring-host"
+	// PrysmMonitoringPort is the argument used for prometheus metrics server port
+	PrysmMonitoringPort = "--monitoring-port"
 )
 
 // Lighthouse client arguments
@@ -149,6 +164,13 @@ const (
 	LighthouseKeystore = "--keystore"
 	// LighthousePasswordFile is the argument used to locate password file
 	LighthousePasswordFile = "--password-file"
+
+	// LighthouseMetrics is the argument used to enable prometheus metrics server
+	LighthouseMetrics = "--metrics"
+	// LighthouseMetricsAddress is the argument used for prometheus metrics server host
+	LighthouseMetricsAddress = "--metrics-address"
+	// LighthouseMetricsPort is the argument used for prometheus metrics server port
+	LighthouseMetricsPort = "--metrics-port"
 )
 
 // Nimbus client arguments
@@ -187,4 +209,10 @@ const (
 	NimbusSecretsDir = "--secrets-dir"
 	// NimbusBeaconNodes is the argument used to set one or more beacon node HTTP REST APIs
 	NimbusBeaconNodes = "--beacon-node"
+	// NimbusMetrics is the argument used to enable prometheus metrics server
+	NimbusMetrics = "--metrics"
+	// NimbusMetricsAddress is the argument used for prometheus metrics server host
+	NimbusMetricsAddress = "--metrics-address"
+	// NimbusMetricsPort is the argument used for prometheus metrics server port
+	NimbusMetricsPort = "--metrics-port"
 )
@@ -3,8 +3,14 @@ package filecoin
 import (
 	filecoinv1alpha1 "github.com/kotalco/kotal/apis/filecoin/v1alpha1"
 	clients "github.com/kotalco/kotal/clients"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// Args generates node cli arguments, it's injected into validation webhook
+func Args(obj runtime.Object) []string {
+	return NewClient(obj.(*filecoinv1alpha1.Node)).Args()
+}
+
 func NewClient(node *filecoinv1alpha1.Node) clients.Interface {
 	return &LotusClient{node}
 }
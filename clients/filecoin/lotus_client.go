@@ -47,3 +47,13 @@ func (c *LotusClient) Args() []string {
 func (c *LotusClient) HomeDir() string {
 	return LotusHomeDir
 }
+
+// TerminationGracePeriod returns seconds to wait for lotus to stop
+func (c *LotusClient) TerminationGracePeriod() int64 {
+	return 120
+}
+
+// PreStop stops lotus daemon using its API before the container is sent SIGTERM
+func (c *LotusClient) PreStop() []string {
+	return []string{"lotus", "daemon", "stop"}
+}
@@ -2,6 +2,7 @@ package filecoin
 
 import (
 	filecoinv1alpha1 "github.com/kotalco/kotal/apis/filecoin/v1alpha1"
+	"github.com/kotalco/kotal/clients"
 	"github.com/kotalco/kotal/controllers/shared"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -49,4 +50,10 @@ var _ = Describe("Lotus Filecoin Client", func() {
 	It("Should get image home directory", func() {
 		Expect(client.HomeDir()).To(Equal(LotusHomeDir))
 	})
+
+	It("Should stop lotus daemon before shutdown", func() {
+		shutdown := client.(clients.ShutdownInterface)
+		Expect(shutdown.TerminationGracePeriod()).To(Equal(int64(120)))
+		Expect(shutdown.PreStop()).To(Equal([]string{"lotus", "daemon", "stop"}))
+	})
 })
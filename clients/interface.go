@@ -9,3 +9,11 @@ type Interface interface {
 	Env() []corev1.EnvVar
 	HomeDir() string
 }
+
+// ShutdownInterface is implemented by clients requiring graceful shutdown tuning
+type ShutdownInterface interface {
+	// TerminationGracePeriod returns recommended seconds to wait for client graceful shutdown
+	TerminationGracePeriod() int64
+	// PreStop returns command executed before client is stopped, or nil if client handles SIGTERM
+	PreStop() []string
+}
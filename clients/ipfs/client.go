@@ -13,6 +13,15 @@ type IPFSClient interface {
 	clients.Interface
 }
 
+// Args generates peer or cluster peer cli arguments, it's injected into validation webhook
+func Args(obj runtime.Object) []string {
+	client, err := NewClient(obj)
+	if err != nil {
+		return nil
+	}
+	return client.Args()
+}
+
 // NewClient creates a new client for ipfs peer or cluster peer
 func NewClient(obj runtime.Object) (IPFSClient, error) {
 	switch peer := obj.(type) {
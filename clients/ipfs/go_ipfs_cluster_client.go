@@ -1,6 +1,7 @@
 package ipfs
 
 import (
+	"fmt"
 	"strings"
 
 	ipfsv1alpha1 "github.com/kotalco/kotal/apis/ipfs/v1alpha1"
@@ -26,7 +27,7 @@ func (c *GoIPFSClusterClient) Command() []string {
 
 // Command returns environment variables for the client
 func (c *GoIPFSClusterClient) Env() []corev1.EnvVar {
-	return []corev1.EnvVar{
+	env := []corev1.EnvVar{
 		{
 			Name:  EnvIPFSClusterPath,
 			Value: shared.PathData(c.HomeDir()),
@@ -40,6 +41,21 @@ func (c *GoIPFSClusterClient) Env() []corev1.EnvVar {
 			Value: string(c.peer.Spec.Logging),
 		},
 	}
+
+	if c.peer.Spec.Metrics.Enabled {
+		env = append(env,
+			corev1.EnvVar{
+				Name:  EnvIPFSClusterMetricsEnableStats,
+				Value: "true",
+			},
+			corev1.EnvVar{
+				Name:  EnvIPFSClusterMetricsPrometheusEndpoint,
+				Value: fmt.Sprintf("/ip4/%s/tcp/%d", shared.Host(true), c.peer.Spec.Metrics.Port),
+			},
+		)
+	}
+
+	return env
 }
 
 // Arg returns go ipfs cluster arguments
@@ -2,6 +2,7 @@ package ipfs
 
 import (
 	ipfsv1alpha1 "github.com/kotalco/kotal/apis/ipfs/v1alpha1"
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
 	"github.com/kotalco/kotal/controllers/shared"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -16,7 +17,7 @@ var _ = Describe("Go IPFS Cluster Client", func() {
 			BootstrapPeers: []string{
 				"/ip4/95.111.253.236/tcp/4001/p2p/Qmd3FERyCvxvkC8su1DYhjybRaLueHveKysUVPxWAqR4U7",
 			},
-			ClusterSecretName: "cluster-secret",
+			ClusterSecretName: sharedAPI.SecretKeySelector{Name: "cluster-secret"},
 		},
 	}
 
@@ -41,6 +42,25 @@ var _ = Describe("Go IPFS Cluster Client", func() {
 		))
 	})
 
+	It("Should get correct env with metrics enabled", func() {
+		metricsPeer := peer.DeepCopy()
+		metricsPeer.Spec.Metrics = sharedAPI.Metrics{
+			Enabled: true,
+			Port:    8888,
+		}
+		metricsClient, _ := NewClient(metricsPeer)
+		Expect(metricsClient.Env()).To(ContainElements(
+			corev1.EnvVar{
+				Name:  EnvIPFSClusterMetricsEnableStats,
+				Value: "true",
+			},
+			corev1.EnvVar{
+				Name:  EnvIPFSClusterMetricsPrometheusEndpoint,
+				Value: "/ip4/0.0.0.0/tcp/8888",
+			},
+		))
+	})
+
 	It("Should get correct command", func() {
 		Expect(client.Command()).To(ConsistOf("ipfs-cluster-service"))
 	})
@@ -36,6 +36,10 @@ const (
 	EnvIPFSClusterId = "CLUSTER_ID"
 	// EnvIPFSClusterPrivateKey is the environment variables used for ipfs cluster private key
 	EnvIPFSClusterPrivateKey = "CLUSTER_PRIVATEKEY"
+	// EnvIPFSClusterMetricsEnableStats is the environment variables used to enable ipfs cluster metrics
+	EnvIPFSClusterMetricsEnableStats = "CLUSTER_METRICS_ENABLESTATS"
+	// EnvIPFSClusterMetricsPrometheusEndpoint is the environment variables used for ipfs cluster prometheus endpoint
+	EnvIPFSClusterMetricsPrometheusEndpoint = "CLUSTER_METRICS_PROMETHEUSENDPOINT"
 )
 
 const (
@@ -45,7 +45,7 @@ func (c *NearClient) Args() (args []string) {
 
 	if node.Spec.RPC {
 		args = append(args, NearArgRPCAddress, fmt.Sprintf("%s:%d", shared.Host(node.Spec.RPC), node.Spec.RPCPort))
-		args = append(args, NearArgPrometheusAddress, fmt.Sprintf("%s:%d", shared.Host(true), node.Spec.PrometheusPort))
+		args = append(args, NearArgPrometheusAddress, fmt.Sprintf("%s:%d", shared.Host(true), node.Spec.Metrics.Port))
 	} else {
 		args = append(args, NearArgDisableRPC)
 	}
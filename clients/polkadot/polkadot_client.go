@@ -60,7 +60,7 @@ func (c *PolkadotClient) Args() (args []string) {
 		args = append(args, PolkadotArgRPCCors, strings.Join(node.Spec.CORSDomains, ","))
 	}
 
-	if node.Spec.NodePrivateKeySecretName != "" {
+	if node.Spec.NodePrivateKeySecretName.Name != "" || shared.HasReplicaKeys(node.Spec.Replicas) {
 		args = append(args, PolkadotArgNodeKeyType, "Ed25519")
 		args = append(args, PolkadotArgNodeKeyFile, fmt.Sprintf("%s/kotal_nodekey", shared.PathData(c.HomeDir())))
 	}
@@ -71,9 +71,9 @@ func (c *PolkadotClient) Args() (args []string) {
 		args = append(args, PolkadotArgNoTelemetry)
 	}
 
-	if node.Spec.Prometheus {
+	if node.Spec.Prometheus || node.Spec.Metrics.Enabled {
 		args = append(args, PolkadotArgPrometheusExternal)
-		args = append(args, PolkadotArgPrometheusPort, fmt.Sprintf("%d", node.Spec.PrometheusPort))
+		args = append(args, PolkadotArgPrometheusPort, fmt.Sprintf("%d", node.Spec.Metrics.Port))
 	} else {
 		args = append(args, PolkadotArgNoPrometheus)
 	}
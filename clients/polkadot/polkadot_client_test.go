@@ -22,7 +22,7 @@ var _ = Describe("Polkadot client", func() {
 		Spec: polkadotv1alpha1.NodeSpec{
 			Network:                  "kusama",
 			P2PPort:                  4444,
-			NodePrivateKeySecretName: "kusama-node-key",
+			NodePrivateKeySecretName: sharedAPI.SecretKeySelector{Name: "kusama-node-key"},
 			Validator:                true,
 			SyncMode:                 "fast",
 			Logging:                  "warn",
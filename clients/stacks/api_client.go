@@ -0,0 +1,111 @@
+package stacks
+
+import (
+	"fmt"
+
+	stacksv1alpha1 "github.com/kotalco/kotal/apis/stacks/v1alpha1"
+	"github.com/kotalco/kotal/controllers/shared"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// StacksAPIClient is Stacks blockchain API client
+// https://github.com/hirosystems/stacks-blockchain-api
+type StacksAPIClient struct {
+	api  *stacksv1alpha1.API
+	node *stacksv1alpha1.Node
+}
+
+const (
+	// StacksAPIHomeDir is Stacks blockchain API image home dir
+	StacksAPIHomeDir = "/app"
+)
+
+// chainIDs is Stacks networks chain ids
+var chainIDs = map[stacksv1alpha1.StacksNetwork]string{
+	stacksv1alpha1.Mainnet: "0x00000001",
+	stacksv1alpha1.Testnet: "0x80000000",
+	stacksv1alpha1.Xenon:   "0x80000000",
+}
+
+// Env returns environment variables for the client
+// Stacks blockchain API is configured using environment variables only
+func (c *StacksAPIClient) Env() []corev1.EnvVar {
+	postgres := c.api.Spec.Postgres
+
+	return []corev1.EnvVar{
+		{
+			Name:  EnvNodeEnv,
+			Value: "production",
+		},
+		{
+			Name:  EnvStacksChainID,
+			Value: chainIDs[c.node.Spec.Network],
+		},
+		{
+			Name:  EnvAPIHost,
+			Value: shared.Host(true),
+		},
+		{
+			Name:  EnvAPIPort,
+			Value: fmt.Sprintf("%d", c.api.Spec.Port),
+		},
+		{
+			Name:  EnvEventHost,
+			Value: shared.Host(true),
+		},
+		{
+			Name:  EnvEventPort,
+			Value: fmt.Sprintf("%d", c.api.Spec.EventPort),
+		},
+		{
+			Name:  EnvCoreRPCHost,
+			Value: fmt.Sprintf("%s.%s.svc", c.node.Name, c.node.Namespace),
+		},
+		{
+			Name:  EnvCoreRPCPort,
+			Value: fmt.Sprintf("%d", c.node.Spec.RPCPort),
+		},
+		{
+			Name:  EnvPostgresHost,
+			Value: postgres.Host,
+		},
+		{
+			Name:  EnvPostgresPort,
+			Value: fmt.Sprintf("%d", postgres.Port),
+		},
+		{
+			Name:  EnvPostgresDatabase,
+			Value: postgres.Database,
+		},
+		{
+			Name:  EnvPostgresUser,
+			Value: postgres.Username,
+		},
+		{
+			Name: EnvPostgresPassword,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: postgres.PasswordSecretName.Name,
+					},
+					Key: postgres.PasswordSecretName.KeyOr("password"),
+				},
+			},
+		},
+	}
+}
+
+// Command returns nil, Stacks blockchain API image entrypoint is used
+func (c *StacksAPIClient) Command() []string {
+	return nil
+}
+
+// Args returns nil, Stacks blockchain API doesn't accept cli arguments
+func (c *StacksAPIClient) Args() []string {
+	return nil
+}
+
+// HomeDir is the home directory of Stacks blockchain API image
+func (c *StacksAPIClient) HomeDir() string {
+	return StacksAPIHomeDir
+}
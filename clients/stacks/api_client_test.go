@@ -0,0 +1,77 @@
+package stacks
+
+import (
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+	stacksv1alpha1 "github.com/kotalco/kotal/apis/stacks/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Stacks blockchain API client", func() {
+
+	node := &stacksv1alpha1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "stacks-node",
+			Namespace: "default",
+		},
+		Spec: stacksv1alpha1.NodeSpec{
+			Network: stacksv1alpha1.Xenon,
+		},
+	}
+
+	node.Default()
+
+	api := &stacksv1alpha1.API{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "stacks-api",
+			Namespace: "default",
+		},
+		Spec: stacksv1alpha1.APISpec{
+			StacksNode: "stacks-node",
+			Postgres: stacksv1alpha1.Postgres{
+				Host:               "postgres",
+				Username:           "stacks",
+				PasswordSecretName: sharedAPI.SecretKeySelector{Name: "postgres-password"},
+			},
+		},
+	}
+
+	api.Default()
+
+	client := NewAPIClient(api, node)
+
+	It("Should use image entrypoint", func() {
+		Expect(client.Command()).To(BeNil())
+		Expect(client.Args()).To(BeNil())
+	})
+
+	It("Should get correct home directory", func() {
+		Expect(client.HomeDir()).To(Equal(StacksAPIHomeDir))
+	})
+
+	It("Should generate correct environment variables", func() {
+		Expect(client.Env()).To(ContainElements(
+			corev1.EnvVar{Name: EnvStacksChainID, Value: "0x80000000"},
+			corev1.EnvVar{Name: EnvAPIPort, Value: "3999"},
+			corev1.EnvVar{Name: EnvEventPort, Value: "3700"},
+			corev1.EnvVar{Name: EnvCoreRPCHost, Value: "stacks-node.default.svc"},
+			corev1.EnvVar{Name: EnvCoreRPCPort, Value: "20443"},
+			corev1.EnvVar{Name: EnvPostgresHost, Value: "postgres"},
+			corev1.EnvVar{Name: EnvPostgresPort, Value: "5432"},
+			corev1.EnvVar{Name: EnvPostgresDatabase, Value: "stacks_blockchain_api"},
+			corev1.EnvVar{Name: EnvPostgresUser, Value: "stacks"},
+			corev1.EnvVar{
+				Name: EnvPostgresPassword,
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "postgres-password"},
+						Key:                  "password",
+					},
+				},
+			},
+		))
+	})
+
+})
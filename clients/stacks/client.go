@@ -3,8 +3,30 @@ package stacks
 import (
 	stacksv1alpha1 "github.com/kotalco/kotal/apis/stacks/v1alpha1"
 	clients "github.com/kotalco/kotal/clients"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// NodeArgs generates node cli arguments, it's injected into validation webhook
+func NodeArgs(obj runtime.Object) []string {
+	return NewClient(obj.(*stacksv1alpha1.Node)).Args()
+}
+
+// SignerArgs generates signer cli arguments, it's injected into validation webhook
+func SignerArgs(obj runtime.Object) []string {
+	return NewSignerClient(obj.(*stacksv1alpha1.Signer)).Args()
+}
+
 func NewClient(node *stacksv1alpha1.Node) clients.Interface {
 	return &StacksNodeClient{node}
 }
+
+// NewAPIClient returns Stacks blockchain API client
+// node is the Stacks node the API receives events from
+func NewAPIClient(api *stacksv1alpha1.API, node *stacksv1alpha1.Node) clients.Interface {
+	return &StacksAPIClient{api, node}
+}
+
+// NewSignerClient returns Stacks signer client
+func NewSignerClient(signer *stacksv1alpha1.Signer) clients.Interface {
+	return &StacksSignerClient{signer}
+}
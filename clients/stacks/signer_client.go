@@ -0,0 +1,44 @@
+package stacks
+
+import (
+	"fmt"
+
+	stacksv1alpha1 "github.com/kotalco/kotal/apis/stacks/v1alpha1"
+	"github.com/kotalco/kotal/controllers/shared"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// StacksSignerClient is Stacks signer client
+// https://github.com/stacks-network/stacks-core/tree/master/stacks-signer
+type StacksSignerClient struct {
+	signer *stacksv1alpha1.Signer
+}
+
+const (
+	// StacksSignerHomeDir is Stacks signer image home dir
+	StacksSignerHomeDir = "/home/stacks"
+	// StacksSignerConfigFile is Stacks signer config file name in config directory
+	StacksSignerConfigFile = "signer.toml"
+)
+
+// Env returns environment variables for the client
+func (c *StacksSignerClient) Env() []corev1.EnvVar {
+	return nil
+}
+
+// Command is Stacks signer client entrypoint
+func (c *StacksSignerClient) Command() []string {
+	return []string{StacksSignerCommand, StacksStartSignerCommand}
+}
+
+// Args returns Stacks signer client args
+// Stacks node endpoint, private key and auth token are generated into config file
+func (c *StacksSignerClient) Args() (args []string) {
+	args = append(args, StacksArgConfig, fmt.Sprintf("%s/%s", shared.PathConfig(c.HomeDir()), StacksSignerConfigFile))
+	return
+}
+
+// HomeDir is the home directory of Stacks signer image
+func (c *StacksSignerClient) HomeDir() string {
+	return StacksSignerHomeDir
+}
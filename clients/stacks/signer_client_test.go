@@ -0,0 +1,51 @@
+package stacks
+
+import (
+	"fmt"
+
+	stacksv1alpha1 "github.com/kotalco/kotal/apis/stacks/v1alpha1"
+	"github.com/kotalco/kotal/controllers/shared"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Stacks signer client", func() {
+
+	signer := &stacksv1alpha1.Signer{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "stacks-signer",
+			Namespace: "default",
+		},
+		Spec: stacksv1alpha1.SignerSpec{
+			StacksNode: "stacks-node",
+		},
+	}
+
+	signer.Default()
+
+	client := NewSignerClient(signer)
+
+	It("Should get correct command", func() {
+		Expect(client.Command()).To(Equal(
+			[]string{
+				StacksSignerCommand,
+				StacksStartSignerCommand,
+			},
+		))
+	})
+
+	It("Should get correct home directory", func() {
+		Expect(client.HomeDir()).To(Equal(StacksSignerHomeDir))
+	})
+
+	It("Should generate correct client arguments", func() {
+		Expect(client.Args()).To(Equal(
+			[]string{
+				StacksArgConfig,
+				fmt.Sprintf("%s/signer.toml", shared.PathConfig(client.HomeDir())),
+			},
+		))
+	})
+
+})
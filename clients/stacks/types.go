@@ -5,9 +5,43 @@ const (
 	StacksNodeCommand = "stacks-node"
 	// StacksStartCommand is command used to start stacks node
 	StacksStartCommand = "start"
+	// StacksSignerCommand is stacks signer exec command
+	StacksSignerCommand = "stacks-signer"
+	// StacksStartSignerCommand is command used to start stacks signer
+	StacksStartSignerCommand = "run"
 )
 
 const (
 	// StacksArgConfig is argument used to set configuration file
 	StacksArgConfig = "--config"
 )
+
+// Stacks blockchain API environment variables
+const (
+	// EnvNodeEnv is Node.js environment
+	EnvNodeEnv = "NODE_ENV"
+	// EnvStacksChainID is Stacks network chain id
+	EnvStacksChainID = "STACKS_CHAIN_ID"
+	// EnvAPIHost is REST and WebSocket server host
+	EnvAPIHost = "STACKS_BLOCKCHAIN_API_HOST"
+	// EnvAPIPort is REST and WebSocket server port
+	EnvAPIPort = "STACKS_BLOCKCHAIN_API_PORT"
+	// EnvEventHost is Stacks node events server host
+	EnvEventHost = "STACKS_CORE_EVENT_HOST"
+	// EnvEventPort is Stacks node events server port
+	EnvEventPort = "STACKS_CORE_EVENT_PORT"
+	// EnvCoreRPCHost is Stacks node JSON-RPC server host
+	EnvCoreRPCHost = "STACKS_CORE_RPC_HOST"
+	// EnvCoreRPCPort is Stacks node JSON-RPC server port
+	EnvCoreRPCPort = "STACKS_CORE_RPC_PORT"
+	// EnvPostgresHost is PostgreSQL server host
+	EnvPostgresHost = "PG_HOST"
+	// EnvPostgresPort is PostgreSQL server port
+	EnvPostgresPort = "PG_PORT"
+	// EnvPostgresDatabase is PostgreSQL database name
+	EnvPostgresDatabase = "PG_DATABASE"
+	// EnvPostgresUser is PostgreSQL user name
+	EnvPostgresUser = "PG_USER"
+	// EnvPostgresPassword is PostgreSQL user password
+	EnvPostgresPassword = "PG_PASSWORD"
+)
@@ -29,8 +29,15 @@ type Base struct {
 
 type Identity struct {
 	Type   string `yaml:"type"`
-	Key    string `yaml:"key"`
-	PeerId string `yaml:"peer_id"`
+	Key    string `yaml:"key,omitempty"`
+	PeerId string `yaml:"peer_id,omitempty"`
+	Path   string `yaml:"path,omitempty"`
+}
+
+// IdentityBlob is node identity file loaded by from_file identity
+type IdentityBlob struct {
+	AccountAddress    string `yaml:"account_address,omitempty"`
+	NetworkPrivateKey string `yaml:"network_private_key"`
 }
 
 type Peer struct {
@@ -72,15 +79,29 @@ func ConfigFromSpec(node *aptosv1alpha1.Node, client client.Client) (config stri
 		role = "full_node"
 	}
 
+	homeDir := aptosClients.NewClient(node).HomeDir()
+
 	var nodePrivateKey string
 	var identity Identity
-	if node.Spec.NodePrivateKeySecretName != "" {
+	if shared.HasReplicaKeys(node.Spec.Replicas) {
+		// every replica loads its own identity file
+		identity = Identity{
+			Type: "from_file",
+			Path: fmt.Sprintf("%s/identity.yaml", shared.PathNodeKeys(homeDir)),
+		}
+	} else if node.Spec.NodePrivateKeySecretName.Name != "" && node.Spec.SecretStore != nil {
+		// identity file is rendered from node private key loaded from secret store
+		identity = Identity{
+			Type: "from_file",
+			Path: fmt.Sprintf("%s/identity.yaml", shared.PathNodeKeys(homeDir)),
+		}
+	} else if node.Spec.NodePrivateKeySecretName.Name != "" {
 		key := types.NamespacedName{
-			Name:      node.Spec.NodePrivateKeySecretName,
+			Name:      node.Spec.NodePrivateKeySecretName.Name,
 			Namespace: node.Namespace,
 		}
 
-		if nodePrivateKey, err = shared.GetSecret(context.Background(), client, key, "key"); err != nil {
+		if nodePrivateKey, err = shared.GetSecret(context.Background(), client, key, node.Spec.NodePrivateKeySecretName.KeyOr("key")); err != nil {
 			return
 		}
 
@@ -103,7 +124,6 @@ func ConfigFromSpec(node *aptosv1alpha1.Node, client client.Client) (config stri
 		}
 	}
 
-	homeDir := aptosClients.NewClient(node).HomeDir()
 	configDir := shared.PathConfig(homeDir)
 	dataDir := shared.PathData(homeDir)
 
@@ -145,7 +165,7 @@ func ConfigFromSpec(node *aptosv1alpha1.Node, client client.Client) (config stri
 			Address: fmt.Sprintf("%s:%d", shared.Host(node.Spec.API), node.Spec.APIPort),
 		},
 		InspectionService: InspectionService{
-			Port: node.Spec.MetricsPort,
+			Port: node.Spec.Metrics.Port,
 		},
 	}
 
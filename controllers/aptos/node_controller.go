@@ -2,19 +2,29 @@ package controllers
 
 import (
 	"context"
+	"crypto/ecdh"
+	"crypto/rand"
 	_ "embed"
+	"encoding/hex"
 	"fmt"
+	"strings"
 
 	aptosv1alpha1 "github.com/kotalco/kotal/apis/aptos/v1alpha1"
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
 	aptosClients "github.com/kotalco/kotal/clients/aptos"
 	"github.com/kotalco/kotal/controllers/shared"
+	"gopkg.in/yaml.v2"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 // NodeReconciler reconciles a Node object
@@ -27,23 +37,37 @@ var (
 	downloadWaypoint string
 	//go:embed download_genesis_block.sh
 	downloadGenesisBlock string
+	//go:embed render_identity.sh
+	renderIdentity string
 )
 
 // +kubebuilder:rbac:groups=aptos.kotal.io,resources=nodes,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=aptos.kotal.io,resources=nodes/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=watch;get;list;create;update;delete
-// +kubebuilder:rbac:groups=core,resources=services;configmaps;persistentvolumeclaims,verbs=watch;get;create;update;list;delete
+// +kubebuilder:rbac:groups=core,resources=services;configmaps;secrets;persistentvolumeclaims,verbs=watch;get;create;update;list;delete
 
 func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 	defer shared.IgnoreConflicts(&err)
 
 	var node aptosv1alpha1.Node
+	defer r.ObserveReconcile(&node, req.NamespacedName, &err)
 
 	if err = r.Client.Get(ctx, req.NamespacedName, &node); err != nil {
 		err = client.IgnoreNotFound(err)
 		return
 	}
 
+	var deleting bool
+	if deleting, result, err = r.ReconcileDeletion(ctx, &node, node.Spec.Deletion); deleting || err != nil {
+		return
+	}
+
+	// owned objects are left as is while node is paused
+	if shared.IsPaused(&node) {
+		err = r.ReconcilePaused(ctx, &node, &node.Status.Mode)
+		return
+	}
+
 	// default the node if webhooks are disabled
 	if !shared.IsWebhookEnabled() {
 		node.Default()
@@ -52,39 +76,183 @@ func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (resul
 	shared.UpdateLabels(&node, "aptos-core", string(node.Spec.Network))
 
 	// reconcile config map
-	r.ReconcileOwned(ctx, &node, &corev1.ConfigMap{}, func(obj client.Object) error {
+	if err = r.ReconcileOwned(ctx, &node, &corev1.ConfigMap{}, func(obj client.Object) error {
 		r.specConfigmap(&node, obj.(*corev1.ConfigMap))
 		return nil
-	})
+	}); err != nil {
+		return
+	}
 
 	// reconcile service
-	r.ReconcileOwned(ctx, &node, &corev1.Service{}, func(obj client.Object) error {
+	if err = r.ReconcileOwned(ctx, &node, &corev1.Service{}, func(obj client.Object) error {
 		r.specService(&node, obj.(*corev1.Service))
 		return nil
-	})
+	}); err != nil {
+		return
+	}
 
-	// reconcile persistent volume claim
-	r.ReconcileOwned(ctx, &node, &corev1.PersistentVolumeClaim{}, func(obj client.Object) error {
-		r.specPVC(&node, obj.(*corev1.PersistentVolumeClaim))
-		return nil
-	})
+	// reconcile persistent volume claims
+	if err = r.ReconcilePVCs(ctx, &node, *node.Spec.Replicas, func(pvc *corev1.PersistentVolumeClaim) {
+		r.specPVC(&node, pvc)
+	}); err != nil {
+		return
+	}
+
+	// reconcile replicas node keys
+	var nodeKeys []map[string]string
+	if nodeKeys, err = r.reconcileNodeKeys(ctx, &node); err != nil {
+		return
+	}
 
 	// reconcile statefulset
-	r.ReconcileOwned(ctx, &node, &appsv1.StatefulSet{}, func(obj client.Object) error {
+	sts := &appsv1.StatefulSet{}
+	if err = r.ReconcileOwned(ctx, &node, sts, func(obj client.Object) error {
 		client := aptosClients.NewClient(&node)
 
 		homeDir := client.HomeDir()
 		cmd := client.Command()
 		args := client.Args()
-		args = append(args, node.Spec.ExtraArgs.Encode(false)...)
+		args = node.Spec.ExtraArgs.Merge(args, node.Spec.RemoveArgs, false)
 		env := client.Env()
 
-		return r.specStatefulSet(&node, obj.(*appsv1.StatefulSet), homeDir, env, cmd, args)
-	})
+		sts := obj.(*appsv1.StatefulSet)
+		if err := r.specStatefulSet(&node, sts, homeDir, env, cmd, args); err != nil {
+			return err
+		}
+		shared.SpecShutdown(node.Spec.Shutdown, client, &sts.Spec.Template.Spec)
+		return r.SpecContentHash(ctx, &node, sts, references(&node))
+	}); err != nil {
+		return
+	}
+
+	// reconcile pod disruption budget
+	if err = r.ReconcilePodDisruptionBudget(ctx, &node, node.Spec.Disruption, *node.Spec.Replicas); err != nil {
+		return
+	}
+
+	// reconcile network policy
+	if err = r.ReconcileNetworkPolicy(ctx, &node, node.Spec.Access, &sts.Spec.Template.Spec, nil); err != nil {
+		return
+	}
+
+	// reconcile prometheus service monitor
+	endpoint := shared.MetricsEndpoint{Port: "metrics", Path: "/metrics"}
+	if err = r.ReconcileServiceMonitor(ctx, &node, node.Spec.Metrics, endpoint); err != nil {
+		return
+	}
+
+	err = r.updateStatus(ctx, &node, nodeKeys)
 
 	return
 }
 
+// reconcileNodeKeys reconciles identity of every replica if node has multiple replicas
+// first replica uses node private key and peer id if provided
+func (r *NodeReconciler) reconcileNodeKeys(ctx context.Context, node *aptosv1alpha1.Node) ([]map[string]string, error) {
+	if !shared.HasReplicaKeys(node.Spec.Replicas) {
+		return nil, nil
+	}
+
+	var primary map[string]string
+	if node.Spec.NodePrivateKeySecretName.Name != "" {
+		key := types.NamespacedName{
+			Name:      node.Spec.NodePrivateKeySecretName.Name,
+			Namespace: node.Namespace,
+		}
+
+		nodePrivateKey, err := r.GetSecret(ctx, node, key, node.Spec.NodePrivateKeySecretName.KeyOr("key"))
+		if err != nil {
+			return nil, err
+		}
+
+		identity, err := yaml.Marshal(&IdentityBlob{
+			AccountAddress:    node.Spec.PeerId,
+			NetworkPrivateKey: nodePrivateKey,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		primary = map[string]string{"identity.yaml": string(identity)}
+	}
+
+	return r.ReconcileNodeKeys(ctx, node, *node.Spec.Replicas, primary, func() (map[string]string, error) {
+		privateKey, err := ecdh.X25519().GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+
+		identity, err := yaml.Marshal(&IdentityBlob{
+			NetworkPrivateKey: hex.EncodeToString(privateKey.Bytes()),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return map[string]string{"identity.yaml": string(identity)}, nil
+	})
+}
+
+// references returns secrets and config maps referenced by Aptos node
+func references(node *aptosv1alpha1.Node) shared.References {
+	refs := shared.PodExtrasReferences(node.Spec.PodExtras)
+	// node private key loaded from secret store isn't kubernetes secret
+	if node.Spec.SecretStore == nil {
+		refs.Add(node.Spec.NodePrivateKeySecretName.Name)
+	}
+	return refs
+}
+
+// updateStatus updates Aptos node status
+func (r *NodeReconciler) updateStatus(ctx context.Context, node *aptosv1alpha1.Node, nodeKeys []map[string]string) error {
+	previous := node.Status
+
+	var replicas []sharedAPI.ReplicaStatus
+	for ordinal, files := range nodeKeys {
+		name := shared.ReplicaName(node, uint(ordinal))
+
+		identity := IdentityBlob{}
+		if err := yaml.Unmarshal([]byte(files["identity.yaml"]), &identity); err != nil {
+			return fmt.Errorf("invalid identity of replica %s: %w", name, err)
+		}
+
+		// peer id is derived from network public key if account address is missing
+		peerID := identity.AccountAddress
+		if peerID == "" {
+			key, err := hex.DecodeString(strings.TrimPrefix(identity.NetworkPrivateKey, "0x"))
+			if err != nil {
+				return fmt.Errorf("invalid identity of replica %s: %w", name, err)
+			}
+			privateKey, err := ecdh.X25519().NewPrivateKey(key)
+			if err != nil {
+				return fmt.Errorf("invalid identity of replica %s: %w", name, err)
+			}
+			peerID = hex.EncodeToString(privateKey.PublicKey().Bytes())
+		}
+
+		replicas = append(replicas, sharedAPI.ReplicaStatus{
+			Name: name,
+			ID:   peerID,
+		})
+	}
+
+	node.Status.Replicas = replicas
+
+	node.Status.Mode = shared.Mode(node, node.Spec.Suspend)
+	if err := r.UpdateResyncStatus(ctx, node, &node.Status.ResyncStatus, node.Spec.DataResync); err != nil {
+		return err
+	}
+
+	if err := r.Status().Update(ctx, node); err != nil {
+		log.FromContext(ctx).Error(err, "unable to update node status")
+		return err
+	}
+
+	r.RecordStatusTransition(node, previous, node.Status)
+
+	return nil
+}
+
 // specConfigmap updates node configmap
 func (n *NodeReconciler) specConfigmap(node *aptosv1alpha1.Node, configmap *corev1.ConfigMap) {
 	configmap.ObjectMeta.Labels = node.Labels
@@ -101,6 +269,7 @@ func (n *NodeReconciler) specConfigmap(node *aptosv1alpha1.Node, configmap *core
 	configmap.Data["config.yaml"] = config
 	configmap.Data["download_waypoint.sh"] = downloadWaypoint
 	configmap.Data["download_genesis_block.sh"] = downloadGenesisBlock
+	configmap.Data["render_identity.sh"] = renderIdentity
 
 }
 
@@ -141,7 +310,7 @@ func (r *NodeReconciler) specService(node *aptosv1alpha1.Node, svc *corev1.Servi
 		},
 		{
 			Name:       "metrics",
-			Port:       int32(node.Spec.MetricsPort),
+			Port:       int32(node.Spec.Metrics.Port),
 			TargetPort: intstr.FromString("metrics"),
 		},
 	}
@@ -251,7 +420,7 @@ func (r *NodeReconciler) specStatefulSet(node *aptosv1alpha1.Node, sts *appsv1.S
 		},
 		{
 			Name:          "metrics",
-			ContainerPort: int32(node.Spec.MetricsPort),
+			ContainerPort: int32(node.Spec.Metrics.Port),
 		},
 	}
 
@@ -262,7 +431,108 @@ func (r *NodeReconciler) specStatefulSet(node *aptosv1alpha1.Node, sts *appsv1.S
 		})
 	}
 
-	replicas := int32(*node.Spec.Replicas)
+	mounts := []corev1.VolumeMount{
+		{
+			Name:      "config",
+			MountPath: shared.PathConfig(homeDir),
+			ReadOnly:  true,
+		},
+		{
+			Name:      "data",
+			MountPath: shared.PathData(homeDir),
+		},
+	}
+
+	volumes := []corev1.Volume{
+		{
+			Name: "config",
+			VolumeSource: corev1.VolumeSource{
+				Projected: &corev1.ProjectedVolumeSource{
+					Sources: sources,
+				},
+			},
+		},
+	}
+
+	// replica node keys volume is mounted by pod name
+	if shared.HasReplicaKeys(node.Spec.Replicas) {
+		env = append(env, shared.PodNameEnv())
+		mounts = append(mounts, shared.NodeKeysVolumeMount(homeDir))
+		volumes = append(volumes, shared.NodeKeysVolume(node, *node.Spec.Replicas, "identity.yaml"))
+	}
+
+	// node private key loaded from secret store is rendered into identity file
+	if node.Spec.SecretStore != nil && node.Spec.NodePrivateKeySecretName.Name != "" {
+		identityMount := corev1.VolumeMount{
+			Name:      shared.NodeKeysVolumeName,
+			MountPath: shared.PathNodeKeys(homeDir),
+		}
+
+		initContainers = append(initContainers, corev1.Container{
+			Name:  "render-identity",
+			Image: shared.BusyboxImage,
+			Env: []corev1.EnvVar{
+				{
+					Name:  "KOTAL_PEER_ID",
+					Value: node.Spec.PeerId,
+				},
+				{
+					Name:  shared.EnvSecretsPath,
+					Value: shared.PathSecrets(homeDir),
+				},
+				{
+					Name:  shared.EnvNodeKeysPath,
+					Value: shared.PathNodeKeys(homeDir),
+				},
+			},
+			Command: []string{"/bin/sh"},
+			Args:    []string{fmt.Sprintf("%s/render_identity.sh", shared.PathConfig(homeDir))},
+			VolumeMounts: []corev1.VolumeMount{
+				{
+					Name:      "config",
+					MountPath: shared.PathConfig(homeDir),
+					ReadOnly:  true,
+				},
+				{
+					Name:      "secrets",
+					MountPath: shared.PathSecrets(homeDir),
+					ReadOnly:  true,
+				},
+				identityMount,
+			},
+		})
+
+		identityMount.ReadOnly = true
+		mounts = append(mounts, identityMount)
+
+		volumes = append(volumes, corev1.Volume{
+			Name: "secrets",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: node.Spec.NodePrivateKeySecretName.Name,
+					Items: []corev1.KeyToPath{
+						{
+							Key:  node.Spec.NodePrivateKeySecretName.KeyOr("key"),
+							Path: "nodekey",
+						},
+					},
+				},
+			},
+		}, corev1.Volume{
+			Name: shared.NodeKeysVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{
+					Medium: corev1.StorageMediumMemory,
+				},
+			},
+		})
+	}
+
+	replicas := shared.StatefulSetReplicas(*node.Spec.Replicas, node.Spec.Suspend)
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	r.specPVC(node, pvc)
+	dataVolumes, volumeClaimTemplates := shared.SpecDataStorage(node, sts, pvc)
 
 	sts.Spec = appsv1.StatefulSetSpec{
 		Selector: &metav1.LabelSelector{
@@ -295,50 +565,40 @@ func (r *NodeReconciler) specStatefulSet(node *aptosv1alpha1.Node, sts *appsv1.S
 								corev1.ResourceMemory: resource.MustParse(node.Spec.MemoryLimit),
 							},
 						},
-						VolumeMounts: []corev1.VolumeMount{
-							{
-								Name:      "config",
-								MountPath: shared.PathConfig(homeDir),
-								ReadOnly:  true,
-							},
-							{
-								Name:      "data",
-								MountPath: shared.PathData(homeDir),
-							},
-						},
-					},
-				},
-				Volumes: []corev1.Volume{
-					{
-						Name: "data",
-						VolumeSource: corev1.VolumeSource{
-							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-								ClaimName: node.Name,
-							},
-						},
-					},
-					{
-						Name: "config",
-						VolumeSource: corev1.VolumeSource{
-							Projected: &corev1.ProjectedVolumeSource{
-								Sources: sources,
-							},
-						},
+						VolumeMounts: mounts,
 					},
 				},
+				Volumes: append(dataVolumes, volumes...),
 			},
 		},
+		VolumeClaimTemplates: volumeClaimTemplates,
 	}
 
+	shared.SpecResync(node.Spec.DataResync, &sts.Spec.Template.Spec, homeDir)
+	if err := shared.SpecPodExtras(node.Spec.PodExtras, &sts.Spec.Template.Spec); err != nil {
+		return err
+	}
+	shared.SpecSecretStore(node.Spec.SecretStore, &sts.Spec.Template, "secrets")
+
 	return nil
 }
 
 func (r *NodeReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := shared.IndexReferences(mgr, &aptosv1alpha1.Node{}, func(obj client.Object) shared.References {
+		return references(obj.(*aptosv1alpha1.Node))
+	}); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&aptosv1alpha1.Node{}).
 		Owns(&appsv1.StatefulSet{}).
+		Owns(&policyv1.PodDisruptionBudget{}).
+		Owns(&networkingv1.NetworkPolicy{}).
 		Owns(&corev1.PersistentVolumeClaim{}).
 		Owns(&corev1.Service{}).
 		Owns(&corev1.ConfigMap{}).
+		Watches(&corev1.Secret{}, r.EnqueueReferencing(&aptosv1alpha1.NodeList{}, shared.IndexSecrets)).
+		Watches(&corev1.ConfigMap{}, r.EnqueueReferencing(&aptosv1alpha1.NodeList{}, shared.IndexConfigMaps)).
 		Complete(r)
 }
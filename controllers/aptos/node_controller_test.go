@@ -118,7 +118,11 @@ var _ = Describe("aptos node controller", func() {
 
 	It("Should create node data persistent volume with correct resources", func() {
 		fetched := &corev1.PersistentVolumeClaim{}
-		Expect(k8sClient.Get(context.Background(), key, fetched)).To(Succeed())
+		pvcKey := types.NamespacedName{
+			Name:      shared.ReplicaPVCName(&metav1.ObjectMeta{Name: key.Name}, 0),
+			Namespace: key.Namespace,
+		}
+		Expect(k8sClient.Get(context.Background(), pvcKey, fetched)).To(Succeed())
 		Expect(fetched.OwnerReferences).To(ContainElements(nodeOwnerReference))
 		expectedResources := corev1.VolumeResourceRequirements{
 			Requests: corev1.ResourceList{
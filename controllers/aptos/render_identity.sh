@@ -0,0 +1,9 @@
+#!/bin/sh
+
+set -e
+
+# render node identity file from node private key loaded from secret store
+cat > $KOTAL_NODE_KEYS_PATH/identity.yaml <<IDENTITY
+account_address: "$KOTAL_PEER_ID"
+network_private_key: "$(cat $KOTAL_SECRETS_PATH/nodekey)"
+IDENTITY
@@ -0,0 +1,10 @@
+set -e
+
+if [ -f "$KOTAL_UTXO_SNAPSHOT_PATH" ]
+then
+    echo "utxo snapshot has been downloaded before"
+else
+    echo "downloading utxo snapshot"
+    curl -fL "$KOTAL_UTXO_SNAPSHOT_URL" -o "$KOTAL_UTXO_SNAPSHOT_PATH.part"
+    mv "$KOTAL_UTXO_SNAPSHOT_PATH.part" "$KOTAL_UTXO_SNAPSHOT_PATH"
+fi
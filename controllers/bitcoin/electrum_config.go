@@ -0,0 +1,78 @@
+package controllers
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	bitcoinv1alpha1 "github.com/kotalco/kotal/apis/bitcoin/v1alpha1"
+	bitcoinClients "github.com/kotalco/kotal/clients/bitcoin"
+	"github.com/kotalco/kotal/controllers/shared"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ElectrsConfig is electrs config.toml file
+type ElectrsConfig struct {
+	Network         string `toml:"network"`
+	DaemonRPCAddr   string `toml:"daemon_rpc_addr"`
+	DaemonP2PAddr   string `toml:"daemon_p2p_addr"`
+	Auth            string `toml:"auth"`
+	DBDir           string `toml:"db_dir"`
+	ElectrumRPCAddr string `toml:"electrum_rpc_addr"`
+}
+
+// ElectrumConfigFromSpec generates Electrum server config file from server spec
+// Bitcoin node is connected to using its first rpc user credentials, password is read from its secret
+func ElectrumConfigFromSpec(server *bitcoinv1alpha1.ElectrumServer, bitcoinNode *bitcoinv1alpha1.Node, password string) (config string, err error) {
+	bitcoinHost := fmt.Sprintf("%s.%s.svc", bitcoinNode.Name, bitcoinNode.Namespace)
+	username := bitcoinNode.Spec.RPCUsers[0].Username
+
+	switch server.Spec.Client {
+	case bitcoinv1alpha1.ElectrsClient:
+		homeDir := bitcoinClients.ElectrsHomeDir
+
+		c := &ElectrsConfig{
+			Network:         bitcoinClients.NetworkName(bitcoinNode.Spec.Network),
+			DaemonRPCAddr:   fmt.Sprintf("%s:%d", bitcoinHost, bitcoinNode.Spec.RPCPort),
+			DaemonP2PAddr:   fmt.Sprintf("%s:%d", bitcoinHost, bitcoinNode.Spec.P2PPort),
+			Auth:            fmt.Sprintf("%s:%s", username, password),
+			DBDir:           shared.PathData(homeDir),
+			ElectrumRPCAddr: fmt.Sprintf("%s:%d", shared.Host(true), server.Spec.Port),
+		}
+
+		var buff bytes.Buffer
+		if err = toml.NewEncoder(&buff).Encode(c); err != nil {
+			return
+		}
+		config = buff.String()
+
+	case bitcoinv1alpha1.FulcrumClient:
+		homeDir := bitcoinClients.FulcrumHomeDir
+
+		// fulcrum.conf is key = value lines
+		lines := []string{
+			fmt.Sprintf("datadir = %s", shared.PathData(homeDir)),
+			fmt.Sprintf("bitcoind = %s:%d", bitcoinHost, bitcoinNode.Spec.RPCPort),
+			fmt.Sprintf("rpcuser = %s", username),
+			fmt.Sprintf("rpcpassword = %s", password),
+			fmt.Sprintf("tcp = %s:%d", shared.Host(true), server.Spec.Port),
+		}
+
+		if server.Spec.TLS {
+			secretsDir := shared.PathSecrets(homeDir)
+			lines = append(lines,
+				fmt.Sprintf("ssl = %s:%d", shared.Host(true), server.Spec.TLSPort),
+				fmt.Sprintf("cert = %s/%s", secretsDir, corev1.TLSCertKey),
+				fmt.Sprintf("key = %s/%s", secretsDir, corev1.TLSPrivateKeyKey),
+			)
+		}
+
+		config = strings.Join(lines, "\n") + "\n"
+
+	default:
+		err = fmt.Errorf("client %s is not supported", server.Spec.Client)
+	}
+
+	return
+}
@@ -0,0 +1,461 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	bitcoinv1alpha1 "github.com/kotalco/kotal/apis/bitcoin/v1alpha1"
+	bitcoinClients "github.com/kotalco/kotal/clients/bitcoin"
+	"github.com/kotalco/kotal/controllers/shared"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// ElectrumServerReconciler reconciles a ElectrumServer object
+type ElectrumServerReconciler struct {
+	shared.Reconciler
+}
+
+const (
+	// electrumStatusInterval is interval of refreshing Electrum server indexed height
+	electrumStatusInterval = time.Minute
+)
+
+// +kubebuilder:rbac:groups=bitcoin.kotal.io,resources=electrumservers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=bitcoin.kotal.io,resources=electrumservers/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=bitcoin.kotal.io,resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=watch;get;list;create;update;delete
+// +kubebuilder:rbac:groups=core,resources=services;persistentvolumeclaims,verbs=watch;get;create;update;list;delete
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=watch;get;create;update;list;delete
+
+// Reconcile Electrum server
+func (r *ElectrumServerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	defer shared.IgnoreConflicts(&err)
+
+	var server bitcoinv1alpha1.ElectrumServer
+	defer r.ObserveReconcile(&server, req.NamespacedName, &err)
+
+	if err = r.Client.Get(ctx, req.NamespacedName, &server); err != nil {
+		err = client.IgnoreNotFound(err)
+		return
+	}
+
+	var deleting bool
+	if deleting, result, err = r.ReconcileDeletion(ctx, &server, server.Spec.Deletion); deleting || err != nil {
+		return
+	}
+
+	// owned objects are left as is while server is paused
+	if shared.IsPaused(&server) {
+		err = r.ReconcilePaused(ctx, &server, &server.Status.Mode)
+		return
+	}
+
+	// default the server if webhooks are disabled
+	if !shared.IsWebhookEnabled() {
+		server.Default()
+	}
+
+	var bitcoinNode *bitcoinv1alpha1.Node
+	if bitcoinNode, err = r.getBitcoinNode(ctx, &server); err != nil {
+		return
+	}
+
+	shared.UpdateLabels(&server, string(server.Spec.Client), string(bitcoinNode.Spec.Network))
+
+	// reconcile persistent volume claims
+	if err = r.ReconcilePVCs(ctx, &server, *server.Spec.Replicas, func(pvc *corev1.PersistentVolumeClaim) {
+		r.specPVC(&server, pvc)
+	}); err != nil {
+		return
+	}
+
+	// reconcile service
+	if err = r.ReconcileOwned(ctx, &server, &corev1.Service{}, func(obj client.Object) error {
+		r.specService(&server, obj.(*corev1.Service))
+		return nil
+	}); err != nil {
+		return
+	}
+
+	// reconcile generated TLS certificate
+	if server.Spec.TLS && server.Spec.CertSecretName == "" {
+		if _, err = reconcileTLSSecret(ctx, r.Reconciler, &server, electrumTLSSecretName(&server)); err != nil {
+			return
+		}
+	}
+
+	// reconcile config secret, config holds Bitcoin node rpc user password
+	user := bitcoinNode.Spec.RPCUsers[0]
+	passwordName := types.NamespacedName{Name: user.PasswordSecretName.Name, Namespace: server.Namespace}
+	var password string
+	if password, err = r.GetSecret(ctx, &server, passwordName, user.PasswordSecretName.KeyOr("password")); err != nil {
+		return
+	}
+
+	var config string
+	if config, err = ElectrumConfigFromSpec(&server, bitcoinNode, password); err != nil {
+		return
+	}
+
+	if err = r.ReconcileOwned(ctx, &server, &corev1.Secret{}, func(obj client.Object) error {
+		r.specConfigSecret(&server, obj.(*corev1.Secret), config)
+		return nil
+	}); err != nil {
+		return
+	}
+
+	// reconcile statefulset
+	sts := &appsv1.StatefulSet{}
+	if err = r.ReconcileOwned(ctx, &server, sts, func(obj client.Object) error {
+		client, err := bitcoinClients.NewElectrumClient(&server)
+		if err != nil {
+			return err
+		}
+		homeDir := client.HomeDir()
+		cmd := client.Command()
+		args := client.Args()
+		args = server.Spec.ExtraArgs.Merge(args, server.Spec.RemoveArgs, true)
+		env := client.Env()
+		sts := obj.(*appsv1.StatefulSet)
+		if err := r.specStatefulSet(&server, sts, homeDir, env, cmd, args); err != nil {
+			return err
+		}
+		shared.SpecShutdown(server.Spec.Shutdown, client, &sts.Spec.Template.Spec)
+		refs := electrumServerReferences(&server, bitcoinNode)
+		// restart server on config changes
+		refs.Add(server.Name)
+		return r.SpecContentHash(ctx, &server, sts, refs)
+	}); err != nil {
+		return
+	}
+
+	// reconcile pod disruption budget
+	if err = r.ReconcilePodDisruptionBudget(ctx, &server, server.Spec.Disruption, *server.Spec.Replicas); err != nil {
+		return
+	}
+
+	// reconcile network policy
+	if err = r.ReconcileNetworkPolicy(ctx, &server, server.Spec.Access, &sts.Spec.Template.Spec, nil); err != nil {
+		return
+	}
+
+	if !server.Spec.Suspend && *server.Spec.Replicas != 0 {
+		r.reconcileIndexedHeight(ctx, &server)
+		result.RequeueAfter = electrumStatusInterval
+	}
+
+	err = r.updateStatus(ctx, &server)
+
+	return
+}
+
+// getBitcoinNode returns Bitcoin node indexed by Electrum server
+// Bitcoin node must enable JSON-RPC server with at least one rpc user, and keep all blocks
+// Fulcrum requires transaction index in addition
+func (r *ElectrumServerReconciler) getBitcoinNode(ctx context.Context, server *bitcoinv1alpha1.ElectrumServer) (*bitcoinv1alpha1.Node, error) {
+	bitcoinNode := &bitcoinv1alpha1.Node{}
+	name := types.NamespacedName{Name: server.Spec.BitcoinNode, Namespace: server.Namespace}
+
+	if err := r.Client.Get(ctx, name, bitcoinNode); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.Recorder.Eventf(server, corev1.EventTypeWarning, shared.EventReasonMissingReference, "Bitcoin node %s not found", name.Name)
+		}
+		return nil, err
+	}
+
+	var problems []string
+
+	if !bitcoinNode.Spec.RPC || len(bitcoinNode.Spec.RPCUsers) == 0 {
+		problems = append(problems, "must enable rpc and have at least one rpc user")
+	}
+
+	if bitcoinNode.Spec.Pruning || bitcoinNode.Spec.PruneTargetMiB != 0 {
+		problems = append(problems, "must not be pruned")
+	}
+
+	if server.Spec.Client == bitcoinv1alpha1.FulcrumClient && !bitcoinNode.Spec.TransactionIndex {
+		problems = append(problems, "must maintain transaction index")
+	}
+
+	if len(problems) != 0 {
+		err := fmt.Errorf("bitcoin node %s %s", name.Name, strings.Join(problems, ", and "))
+		r.Recorder.Event(server, corev1.EventTypeWarning, shared.EventReasonReconcileFailed, err.Error())
+		return nil, err
+	}
+
+	return bitcoinNode, nil
+}
+
+// electrumTLSSecretName returns Electrum server TLS certificate secret name
+// certificate is generated if it's not provided by the user
+func electrumTLSSecretName(server *bitcoinv1alpha1.ElectrumServer) string {
+	if server.Spec.CertSecretName != "" {
+		return server.Spec.CertSecretName
+	}
+	return fmt.Sprintf("%s-tls", server.Name)
+}
+
+// electrumConfigFile returns Electrum server client config file name
+func electrumConfigFile(server *bitcoinv1alpha1.ElectrumServer) string {
+	if server.Spec.Client == bitcoinv1alpha1.FulcrumClient {
+		return bitcoinClients.FulcrumConfigFile
+	}
+	return bitcoinClients.ElectrsConfigFile
+}
+
+// reconcileIndexedHeight updates server status with indexed height reported over Electrum protocol
+// errors are logged only, server doesn't accept connections until initial indexing is done
+func (r *ElectrumServerReconciler) reconcileIndexedHeight(ctx context.Context, server *bitcoinv1alpha1.ElectrumServer) {
+	height, err := bitcoinClients.NewElectrumRPCClient(server).IndexedHeight(ctx)
+	if err != nil {
+		log.FromContext(ctx).Info("Electrum server isn't serving yet", "error", err.Error())
+		return
+	}
+
+	server.Status.IndexedHeight = height
+
+	r.SetSyncHeight(server, uint64(height))
+}
+
+// updateStatus updates Electrum server status
+func (r *ElectrumServerReconciler) updateStatus(ctx context.Context, server *bitcoinv1alpha1.ElectrumServer) error {
+	previous := server.Status
+
+	server.Status.Client = string(server.Spec.Client)
+
+	server.Status.Mode = shared.Mode(server, server.Spec.Suspend)
+	if err := r.UpdateResyncStatus(ctx, server, &server.Status.ResyncStatus, server.Spec.DataResync); err != nil {
+		return err
+	}
+
+	if err := r.Status().Update(ctx, server); err != nil {
+		log.FromContext(ctx).Error(err, "unable to update electrum server status")
+		return err
+	}
+
+	r.RecordStatusTransition(server, previous, server.Status)
+
+	return nil
+}
+
+// electrumServerReferences returns secrets and config maps referenced by Electrum server
+func electrumServerReferences(server *bitcoinv1alpha1.ElectrumServer, bitcoinNode *bitcoinv1alpha1.Node) shared.References {
+	refs := shared.PodExtrasReferences(server.Spec.PodExtras)
+	refs.Add(server.Spec.CertSecretName)
+	if bitcoinNode != nil && len(bitcoinNode.Spec.RPCUsers) != 0 {
+		refs.Add(bitcoinNode.Spec.RPCUsers[0].PasswordSecretName.Name)
+	}
+	return refs
+}
+
+// specConfigSecret updates Electrum server config secret
+func (r *ElectrumServerReconciler) specConfigSecret(server *bitcoinv1alpha1.ElectrumServer, secret *corev1.Secret, config string) {
+	secret.ObjectMeta.Labels = server.Labels
+	secret.Data = map[string][]byte{
+		electrumConfigFile(server): []byte(config),
+	}
+}
+
+// specPVC updates Electrum server persistent volume claim
+func (r *ElectrumServerReconciler) specPVC(server *bitcoinv1alpha1.ElectrumServer, pvc *corev1.PersistentVolumeClaim) {
+	request := corev1.ResourceList{
+		corev1.ResourceStorage: resource.MustParse(server.Spec.Storage),
+	}
+
+	// spec is immutable after creation except resources.requests for bound claims
+	if !pvc.CreationTimestamp.IsZero() {
+		pvc.Spec.Resources.Requests = request
+		return
+	}
+
+	pvc.ObjectMeta.Labels = server.Labels
+	pvc.Spec = corev1.PersistentVolumeClaimSpec{
+		AccessModes: []corev1.PersistentVolumeAccessMode{
+			corev1.ReadWriteOnce,
+		},
+		Resources: corev1.VolumeResourceRequirements{
+			Requests: request,
+		},
+	}
+}
+
+// electrumPorts returns Electrum server container ports
+func electrumPorts(server *bitcoinv1alpha1.ElectrumServer) []corev1.ContainerPort {
+	ports := []corev1.ContainerPort{
+		{
+			Name:          "tcp",
+			ContainerPort: int32(server.Spec.Port),
+		},
+	}
+
+	if server.Spec.TLS {
+		ports = append(ports, corev1.ContainerPort{
+			Name:          "ssl",
+			ContainerPort: int32(server.Spec.TLSPort),
+		})
+	}
+
+	return ports
+}
+
+// specService updates Electrum server service spec
+func (r *ElectrumServerReconciler) specService(server *bitcoinv1alpha1.ElectrumServer, svc *corev1.Service) {
+	labels := server.Labels
+
+	svc.ObjectMeta.Labels = labels
+
+	svc.Spec.Ports = nil
+	for _, port := range electrumPorts(server) {
+		svc.Spec.Ports = append(svc.Spec.Ports, corev1.ServicePort{
+			Name:       port.Name,
+			Port:       port.ContainerPort,
+			TargetPort: intstr.FromString(port.Name),
+		})
+	}
+
+	svc.Spec.Selector = labels
+}
+
+// specStatefulSet updates Electrum server statefulset spec
+func (r *ElectrumServerReconciler) specStatefulSet(server *bitcoinv1alpha1.ElectrumServer, sts *appsv1.StatefulSet, homeDir string, env []corev1.EnvVar, cmd, args []string) error {
+
+	sts.ObjectMeta.Labels = server.Labels
+
+	replicas := shared.StatefulSetReplicas(*server.Spec.Replicas, server.Spec.Suspend)
+
+	mounts := []corev1.VolumeMount{
+		{
+			Name:      "data",
+			MountPath: shared.PathData(homeDir),
+		},
+		{
+			Name:      "config",
+			MountPath: shared.PathConfig(homeDir),
+			ReadOnly:  true,
+		},
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	r.specPVC(server, pvc)
+	dataVolumes, volumeClaimTemplates := shared.SpecDataStorage(server, sts, pvc)
+
+	volumes := append(dataVolumes, corev1.Volume{
+		Name: "config",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: server.Name,
+			},
+		},
+	})
+
+	if server.Spec.TLS {
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      "secrets",
+			MountPath: shared.PathSecrets(homeDir),
+			ReadOnly:  true,
+		})
+		volumes = append(volumes, corev1.Volume{
+			Name: "secrets",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: electrumTLSSecretName(server),
+					Items: []corev1.KeyToPath{
+						{Key: corev1.TLSCertKey, Path: corev1.TLSCertKey},
+						{Key: corev1.TLSPrivateKeyKey, Path: corev1.TLSPrivateKeyKey},
+					},
+				},
+			},
+		})
+	}
+
+	sts.Spec = appsv1.StatefulSetSpec{
+		Selector: &metav1.LabelSelector{
+			MatchLabels: server.Labels,
+		},
+		Replicas:    &replicas,
+		ServiceName: server.Name,
+		Template: corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: server.Labels,
+			},
+			Spec: corev1.PodSpec{
+				SecurityContext: shared.SecurityContext(),
+				Containers: []corev1.Container{
+					{
+						Name:         "server",
+						Image:        server.Spec.Image,
+						Command:      cmd,
+						Args:         args,
+						Env:          env,
+						Ports:        electrumPorts(server),
+						VolumeMounts: mounts,
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse(server.Spec.CPU),
+								corev1.ResourceMemory: resource.MustParse(server.Spec.Memory),
+							},
+							Limits: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse(server.Spec.CPULimit),
+								corev1.ResourceMemory: resource.MustParse(server.Spec.MemoryLimit),
+							},
+						},
+					},
+				},
+				Volumes: volumes,
+			},
+		},
+		VolumeClaimTemplates: volumeClaimTemplates,
+	}
+
+	shared.SpecResync(server.Spec.DataResync, &sts.Spec.Template.Spec, homeDir)
+	if err := shared.SpecPodExtras(server.Spec.PodExtras, &sts.Spec.Template.Spec); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ElectrumServerReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := shared.IndexReferences(mgr, &bitcoinv1alpha1.ElectrumServer{}, func(obj client.Object) shared.References {
+		// referenced Bitcoin node rpc user password secret can't be indexed without fetching Bitcoin node
+		// its changes are picked up on status refresh interval
+		return electrumServerReferences(obj.(*bitcoinv1alpha1.ElectrumServer), nil)
+	}); err != nil {
+		return err
+	}
+
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &bitcoinv1alpha1.ElectrumServer{}, indexBitcoinNode, func(obj client.Object) []string {
+		return []string{obj.(*bitcoinv1alpha1.ElectrumServer).Spec.BitcoinNode}
+	}); err != nil {
+		return err
+	}
+
+	// owned objects updates are filtered, referenced objects are watched without predicate so their updates always pass
+	pred := builder.WithPredicates(shared.GenerationOrContentChangedPredicate())
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&bitcoinv1alpha1.ElectrumServer{}, pred).
+		Owns(&corev1.PersistentVolumeClaim{}, pred).
+		Owns(&appsv1.StatefulSet{}, pred).
+		Owns(&policyv1.PodDisruptionBudget{}, pred).
+		Owns(&networkingv1.NetworkPolicy{}, pred).
+		Owns(&corev1.Service{}, pred).
+		Owns(&corev1.Secret{}, pred).
+		Watches(&bitcoinv1alpha1.Node{}, r.EnqueueReferencing(&bitcoinv1alpha1.ElectrumServerList{}, indexBitcoinNode)).
+		Watches(&corev1.Secret{}, r.EnqueueReferencing(&bitcoinv1alpha1.ElectrumServerList{}, shared.IndexSecrets)).
+		Watches(&corev1.ConfigMap{}, r.EnqueueReferencing(&bitcoinv1alpha1.ElectrumServerList{}, shared.IndexConfigMaps)).
+		Complete(r)
+}
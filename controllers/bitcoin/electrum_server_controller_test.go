@@ -0,0 +1,191 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	bitcoinv1alpha1 "github.com/kotalco/kotal/apis/bitcoin/v1alpha1"
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+	bitcoinClients "github.com/kotalco/kotal/clients/bitcoin"
+	"github.com/kotalco/kotal/controllers/shared"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+var _ = Describe("Bitcoin Electrum server controller", func() {
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "bitcoin-electrum",
+		},
+	}
+
+	key := types.NamespacedName{
+		Name:      "electrum-server",
+		Namespace: ns.Name,
+	}
+
+	rpcPassword := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "rpc-password",
+			Namespace: ns.Name,
+		},
+		StringData: map[string]string{
+			"password": "s3cr3t",
+		},
+	}
+
+	bitcoinNode := &bitcoinv1alpha1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "bitcoin-node",
+			Namespace: ns.Name,
+		},
+		Spec: bitcoinv1alpha1.NodeSpec{
+			Network:          bitcoinv1alpha1.Regtest,
+			RPC:              true,
+			TransactionIndex: true,
+			RPCUsers: []bitcoinv1alpha1.RPCUser{
+				{
+					Username: "kotal",
+					PasswordSecretName: sharedAPI.SecretKeySelector{
+						Name: rpcPassword.Name,
+					},
+				},
+			},
+		},
+	}
+
+	testImage := "kotalco/fulcrum:controller-test"
+
+	toCreate := &bitcoinv1alpha1.ElectrumServer{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+		},
+		Spec: bitcoinv1alpha1.ElectrumServerSpec{
+			Image:       testImage,
+			Client:      bitcoinv1alpha1.FulcrumClient,
+			BitcoinNode: bitcoinNode.Name,
+			TLS:         true,
+		},
+	}
+
+	t := true
+
+	serverOwnerReference := metav1.OwnerReference{
+		APIVersion:         "bitcoin.kotal.io/v1alpha1",
+		Kind:               "ElectrumServer",
+		Name:               toCreate.Name,
+		Controller:         &t,
+		BlockOwnerDeletion: &t,
+	}
+
+	It(fmt.Sprintf("Should create %s namespace", ns.Name), func() {
+		Expect(k8sClient.Create(context.TODO(), ns)).To(Succeed())
+	})
+
+	It("Should create referenced Bitcoin node and its rpc password", func() {
+		Expect(k8sClient.Create(context.Background(), rpcPassword)).Should(Succeed())
+		if os.Getenv(shared.EnvUseExistingCluster) != "true" {
+			bitcoinNode.Default()
+		}
+		Expect(k8sClient.Create(context.Background(), bitcoinNode)).Should(Succeed())
+	})
+
+	It("Should create Electrum server", func() {
+		if os.Getenv(shared.EnvUseExistingCluster) != "true" {
+			toCreate.Default()
+		}
+		Expect(k8sClient.Create(context.Background(), toCreate)).Should(Succeed())
+	})
+
+	It("Should get Electrum server", func() {
+		fetched := &bitcoinv1alpha1.ElectrumServer{}
+		Expect(k8sClient.Get(context.Background(), key, fetched)).To(Succeed())
+		Expect(fetched.Spec).To(Equal(toCreate.Spec))
+		serverOwnerReference.UID = fetched.UID
+		time.Sleep(5 * time.Second)
+	})
+
+	It("Should generate TLS certificate secret", func() {
+		tls := &corev1.Secret{}
+		tlsKey := types.NamespacedName{Name: electrumTLSSecretName(toCreate), Namespace: ns.Name}
+		Expect(k8sClient.Get(context.Background(), tlsKey, tls)).To(Succeed())
+		Expect(tls.OwnerReferences).To(ContainElements(serverOwnerReference))
+		Expect(tls.Type).To(Equal(corev1.SecretTypeTLS))
+		Expect(tls.Data).To(HaveKey(corev1.TLSCertKey))
+		Expect(tls.Data).To(HaveKey(corev1.TLSPrivateKeyKey))
+	})
+
+	It("Should generate config secret from Bitcoin node rpc credentials", func() {
+		fetchedBitcoinNode := &bitcoinv1alpha1.Node{}
+		bitcoinNodeKey := types.NamespacedName{Name: bitcoinNode.Name, Namespace: ns.Name}
+		Expect(k8sClient.Get(context.Background(), bitcoinNodeKey, fetchedBitcoinNode)).To(Succeed())
+		expected, err := ElectrumConfigFromSpec(toCreate, fetchedBitcoinNode, "s3cr3t")
+		Expect(err).To(BeNil())
+
+		config := &corev1.Secret{}
+		Expect(k8sClient.Get(context.Background(), key, config)).To(Succeed())
+		Expect(config.OwnerReferences).To(ContainElements(serverOwnerReference))
+		Expect(string(config.Data[bitcoinClients.FulcrumConfigFile])).To(Equal(expected))
+		Expect(string(config.Data[bitcoinClients.FulcrumConfigFile])).To(ContainSubstring("rpcpassword = s3cr3t"))
+	})
+
+	It("Should create Electrum server statefulset", func() {
+		client, err := bitcoinClients.NewElectrumClient(toCreate)
+		Expect(err).To(BeNil())
+
+		fetched := &appsv1.StatefulSet{}
+		Expect(k8sClient.Get(context.Background(), key, fetched)).To(Succeed())
+		Expect(fetched.OwnerReferences).To(ContainElements(serverOwnerReference))
+		Expect(fetched.Spec.Template.Spec.Containers[0].Image).To(Equal(testImage))
+		Expect(fetched.Spec.Template.Spec.Containers[0].Command).To(Equal(client.Command()))
+		Expect(fetched.Spec.Template.Spec.Containers[0].Args).To(Equal(client.Args()))
+		Expect(fetched.Spec.Template.Spec.Containers[0].VolumeMounts).To(ContainElements(
+			corev1.VolumeMount{
+				Name:      "data",
+				MountPath: shared.PathData(client.HomeDir()),
+			},
+			corev1.VolumeMount{
+				Name:      "config",
+				MountPath: shared.PathConfig(client.HomeDir()),
+				ReadOnly:  true,
+			},
+			corev1.VolumeMount{
+				Name:      "secrets",
+				MountPath: shared.PathSecrets(client.HomeDir()),
+				ReadOnly:  true,
+			},
+		))
+	})
+
+	It("Should create Electrum server service", func() {
+		fetched := &corev1.Service{}
+		Expect(k8sClient.Get(context.Background(), key, fetched)).To(Succeed())
+		Expect(fetched.OwnerReferences).To(ContainElements(serverOwnerReference))
+		Expect(fetched.Spec.Ports).To(ContainElements(
+			corev1.ServicePort{
+				Name:       "tcp",
+				Port:       int32(bitcoinv1alpha1.DefaultElectrumPort),
+				TargetPort: intstr.FromString("tcp"),
+				Protocol:   corev1.ProtocolTCP,
+			},
+			corev1.ServicePort{
+				Name:       "ssl",
+				Port:       int32(bitcoinv1alpha1.DefaultElectrumTLSPort),
+				TargetPort: intstr.FromString("ssl"),
+				Protocol:   corev1.ProtocolTCP,
+			},
+		))
+	})
+
+	It(fmt.Sprintf("Should delete %s namespace", ns.Name), func() {
+		Expect(k8sClient.Delete(context.Background(), ns)).To(Succeed())
+	})
+})
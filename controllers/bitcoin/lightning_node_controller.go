@@ -0,0 +1,585 @@
+package controllers
+
+import (
+	"context"
+	"crypto/rand"
+	_ "embed"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	bitcoinv1alpha1 "github.com/kotalco/kotal/apis/bitcoin/v1alpha1"
+	bitcoinClients "github.com/kotalco/kotal/clients/bitcoin"
+	"github.com/kotalco/kotal/controllers/shared"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// LightningNodeReconciler reconciles a LightningNode object
+type LightningNodeReconciler struct {
+	shared.Reconciler
+}
+
+const (
+	// indexBitcoinNode is field index of referenced Bitcoin node name
+	indexBitcoinNode = ".spec.bitcoinNode"
+	// lightningStatusInterval is interval of refreshing Lightning Network node status
+	lightningStatusInterval = time.Minute
+)
+
+// Lightning Network node generated secrets keys
+const (
+	// walletPasswordKey is lnd wallet password key in wallet secret
+	walletPasswordKey = "password"
+	// walletSeedKey is lnd wallet seed mnemonic key in wallet secret
+	walletSeedKey = "seed"
+	// adminMacaroonKey is lnd admin macaroon key in macaroon secret
+	adminMacaroonKey = "admin.macaroon"
+)
+
+// +kubebuilder:rbac:groups=bitcoin.kotal.io,resources=lightningnodes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=bitcoin.kotal.io,resources=lightningnodes/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=bitcoin.kotal.io,resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=watch;get;list;create;update;delete
+// +kubebuilder:rbac:groups=core,resources=services;persistentvolumeclaims,verbs=watch;get;create;update;list;delete
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=watch;get;create;update;list;delete
+
+// Reconcile Lightning Network node
+func (r *LightningNodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	defer shared.IgnoreConflicts(&err)
+
+	var node bitcoinv1alpha1.LightningNode
+	defer r.ObserveReconcile(&node, req.NamespacedName, &err)
+
+	if err = r.Client.Get(ctx, req.NamespacedName, &node); err != nil {
+		err = client.IgnoreNotFound(err)
+		return
+	}
+
+	var deleting bool
+	if deleting, result, err = r.ReconcileDeletion(ctx, &node, node.Spec.Deletion); deleting || err != nil {
+		return
+	}
+
+	// owned objects are left as is while node is paused
+	if shared.IsPaused(&node) {
+		err = r.ReconcilePaused(ctx, &node, &node.Status.Mode)
+		return
+	}
+
+	// default the node if webhooks are disabled
+	if !shared.IsWebhookEnabled() {
+		node.Default()
+	}
+
+	var bitcoinNode *bitcoinv1alpha1.Node
+	if bitcoinNode, err = r.getBitcoinNode(ctx, &node); err != nil {
+		return
+	}
+
+	shared.UpdateLabels(&node, string(node.Spec.Client), string(bitcoinNode.Spec.Network))
+
+	// reconcile persistent volume claims
+	if err = r.ReconcilePVCs(ctx, &node, *node.Spec.Replicas, func(pvc *corev1.PersistentVolumeClaim) {
+		r.specPVC(&node, pvc)
+	}); err != nil {
+		return
+	}
+
+	// reconcile service
+	if err = r.ReconcileOwned(ctx, &node, &corev1.Service{}, func(obj client.Object) error {
+		r.specService(&node, obj.(*corev1.Service))
+		return nil
+	}); err != nil {
+		return
+	}
+
+	// reconcile generated wallet password
+	var wallet map[string][]byte
+	if wallet, err = r.reconcileWalletSecret(ctx, &node); err != nil {
+		return
+	}
+
+	// reconcile lnd TLS certificate
+	var tlsCert []byte
+	if node.Spec.Client == bitcoinv1alpha1.LNDClient {
+		if tlsCert, err = reconcileTLSSecret(ctx, r.Reconciler, &node, tlsSecretName(&node)); err != nil {
+			return
+		}
+	}
+
+	// reconcile statefulset
+	sts := &appsv1.StatefulSet{}
+	if err = r.ReconcileOwned(ctx, &node, sts, func(obj client.Object) error {
+		client, err := bitcoinClients.NewLightningClient(&node, bitcoinNode)
+		if err != nil {
+			return err
+		}
+		homeDir := client.HomeDir()
+		cmd := client.Command()
+		args := client.Args()
+		args = node.Spec.ExtraArgs.Merge(args, node.Spec.RemoveArgs, true)
+		env := client.Env()
+		sts := obj.(*appsv1.StatefulSet)
+		if err := r.specStatefulSet(&node, bitcoinNode, sts, homeDir, env, cmd, args); err != nil {
+			return err
+		}
+		shared.SpecShutdown(node.Spec.Shutdown, client, &sts.Spec.Template.Spec)
+		return r.SpecContentHash(ctx, &node, sts, lightningNodeReferences(&node, bitcoinNode))
+	}); err != nil {
+		return
+	}
+
+	// reconcile pod disruption budget
+	if err = r.ReconcilePodDisruptionBudget(ctx, &node, node.Spec.Disruption, *node.Spec.Replicas); err != nil {
+		return
+	}
+
+	// reconcile network policy
+	if err = r.ReconcileNetworkPolicy(ctx, &node, node.Spec.Access, &sts.Spec.Template.Spec, nil); err != nil {
+		return
+	}
+
+	if !node.Spec.Suspend && *node.Spec.Replicas != 0 {
+		switch node.Spec.Client {
+		case bitcoinv1alpha1.LNDClient:
+			r.reconcileLND(ctx, &node, wallet, tlsCert)
+		}
+		result.RequeueAfter = lightningStatusInterval
+	}
+
+	err = r.updateStatus(ctx, &node)
+
+	return
+}
+
+// getBitcoinNode returns Bitcoin node used by Lightning Network node as chain backend
+// Bitcoin node must enable JSON-RPC server with at least one rpc user, and lnd requires raw blocks and transactions ZeroMQ notifications
+func (r *LightningNodeReconciler) getBitcoinNode(ctx context.Context, node *bitcoinv1alpha1.LightningNode) (*bitcoinv1alpha1.Node, error) {
+	bitcoinNode := &bitcoinv1alpha1.Node{}
+	name := types.NamespacedName{Name: node.Spec.BitcoinNode, Namespace: node.Namespace}
+
+	if err := r.Client.Get(ctx, name, bitcoinNode); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.Recorder.Eventf(node, corev1.EventTypeWarning, shared.EventReasonMissingReference, "Bitcoin node %s not found", name.Name)
+		}
+		return nil, err
+	}
+
+	var problems []string
+
+	if !bitcoinNode.Spec.RPC || len(bitcoinNode.Spec.RPCUsers) == 0 {
+		problems = append(problems, "must enable rpc and have at least one rpc user")
+	}
+
+	if node.Spec.Client == bitcoinv1alpha1.LNDClient && (bitcoinNode.Spec.ZMQ.RawBlockPort == 0 || bitcoinNode.Spec.ZMQ.RawTxPort == 0) {
+		problems = append(problems, "must publish raw blocks and raw transactions ZeroMQ notifications")
+	}
+
+	if len(problems) != 0 {
+		err := fmt.Errorf("bitcoin node %s %s", name.Name, strings.Join(problems, ", and "))
+		r.Recorder.Event(node, corev1.EventTypeWarning, shared.EventReasonReconcileFailed, err.Error())
+		return nil, err
+	}
+
+	return bitcoinNode, nil
+}
+
+// walletSecretName returns generated wallet password secret name
+func walletSecretName(node *bitcoinv1alpha1.LightningNode) string {
+	return fmt.Sprintf("%s-wallet", node.Name)
+}
+
+// tlsSecretName returns generated lnd TLS certificate secret name
+func tlsSecretName(node *bitcoinv1alpha1.LightningNode) string {
+	return fmt.Sprintf("%s-tls", node.Name)
+}
+
+// macaroonSecretName returns lnd admin macaroon secret name
+func macaroonSecretName(node *bitcoinv1alpha1.LightningNode) string {
+	return fmt.Sprintf("%s-macaroon", node.Name)
+}
+
+// reconcileWalletSecret reconciles wallet secret and returns its data
+// lnd wallet password is generated once, and kept as long as the secret exists
+// wallet secret isn't owned by the node, so funds keys aren't garbage collected if the node is deleted
+func (r *LightningNodeReconciler) reconcileWalletSecret(ctx context.Context, node *bitcoinv1alpha1.LightningNode) (data map[string][]byte, err error) {
+	var generated bool
+
+	secret := &corev1.Secret{}
+	secret.SetName(walletSecretName(node))
+	secret.SetNamespace(node.Namespace)
+
+	_, err = ctrl.CreateOrUpdate(ctx, r.Client, secret, func() error {
+		secret.ObjectMeta.Labels = node.Labels
+
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+
+		if len(secret.Data[walletPasswordKey]) == 0 {
+			random := make([]byte, 32)
+			if _, err := rand.Read(random); err != nil {
+				return err
+			}
+			secret.Data[walletPasswordKey] = []byte(hex.EncodeToString(random))
+			generated = true
+		}
+
+		data = secret.Data
+		return nil
+	})
+
+	if err != nil {
+		r.Recorder.Eventf(node, corev1.EventTypeWarning, shared.EventReasonReconcileFailed, "Failed to reconcile wallet secret %s: %s", secret.Name, err)
+		return nil, err
+	}
+
+	if generated {
+		r.Recorder.Eventf(node, corev1.EventTypeNormal, shared.EventReasonKeyGenerated, "Generated wallet secret %s", secret.Name)
+	}
+
+	return
+}
+
+// updateWalletSecret adds data to generated wallet secret
+func (r *LightningNodeReconciler) updateWalletSecret(ctx context.Context, node *bitcoinv1alpha1.LightningNode, key string, value []byte) error {
+	secret := &corev1.Secret{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: walletSecretName(node), Namespace: node.Namespace}, secret); err != nil {
+		return err
+	}
+
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[key] = value
+
+	return r.Client.Update(ctx, secret)
+}
+
+// reconcileLND creates lnd wallet if it doesn't exist yet, and updates node status from lnd node info
+// wallet seed is stored in wallet secret before creating the wallet, so it's never lost
+// errors are logged only, lnd might not be up yet, and they're retried next status interval
+func (r *LightningNodeReconciler) reconcileLND(ctx context.Context, node *bitcoinv1alpha1.LightningNode, wallet map[string][]byte, tlsCert []byte) {
+	logger := log.FromContext(ctx)
+
+	macaroonName := types.NamespacedName{Name: macaroonSecretName(node), Namespace: node.Namespace}
+	macaroon, err := shared.GetSecret(ctx, r.Client, macaroonName, adminMacaroonKey)
+	if client.IgnoreNotFound(err) != nil {
+		logger.Error(err, "unable to get lnd admin macaroon")
+		return
+	}
+
+	rest, err := bitcoinClients.NewLNDRESTClient(node, tlsCert, []byte(macaroon))
+	if err != nil {
+		logger.Error(err, "unable to create lnd REST API client")
+		return
+	}
+
+	state, err := rest.State(ctx)
+	if err != nil {
+		logger.Info("lnd REST API server isn't up yet", "error", err.Error())
+		return
+	}
+
+	if state == bitcoinClients.LNDWalletNonExisting {
+		seed := strings.Fields(string(wallet[walletSeedKey]))
+		if len(seed) == 0 {
+			if seed, err = rest.GenSeed(ctx); err != nil {
+				logger.Error(err, "unable to generate lnd wallet seed")
+				return
+			}
+			if err = r.updateWalletSecret(ctx, node, walletSeedKey, []byte(strings.Join(seed, " "))); err != nil {
+				logger.Error(err, "unable to store lnd wallet seed")
+				return
+			}
+			r.Recorder.Eventf(node, corev1.EventTypeNormal, shared.EventReasonKeyGenerated, "Generated wallet seed in secret %s", walletSecretName(node))
+		}
+
+		adminMacaroon, err := rest.InitWallet(ctx, string(wallet[walletPasswordKey]), seed)
+		if err != nil {
+			r.Recorder.Eventf(node, corev1.EventTypeWarning, shared.EventReasonReconcileFailed, "Failed to create lnd wallet: %s", err)
+			return
+		}
+
+		if err = r.reconcileMacaroonSecret(ctx, node, adminMacaroon); err != nil {
+			logger.Error(err, "unable to store lnd admin macaroon")
+		}
+		return
+	}
+
+	if state != bitcoinClients.LNDWalletServerActive || macaroon == "" {
+		logger.Info("lnd isn't active yet", "state", state)
+		return
+	}
+
+	info, err := rest.GetInfo(ctx)
+	if err != nil {
+		logger.Error(err, "unable to get lnd node info")
+		return
+	}
+
+	node.Status.IdentityPubkey = info.IdentityPubkey
+	node.Status.SyncedToChain = info.SyncedToChain
+	node.Status.BlockHeight = info.BlockHeight
+	node.Status.ActiveChannels = info.NumActiveChannels
+	node.Status.PendingChannels = info.NumPendingChannels
+
+	r.SetSyncHeight(node, uint64(info.BlockHeight))
+	r.SetPeerCount(node, info.NumPeers)
+}
+
+// reconcileMacaroonSecret stores lnd admin macaroon returned on wallet creation
+func (r *LightningNodeReconciler) reconcileMacaroonSecret(ctx context.Context, node *bitcoinv1alpha1.LightningNode, macaroon []byte) error {
+	secret := &corev1.Secret{}
+	secret.SetName(macaroonSecretName(node))
+
+	return r.ReconcileOwned(ctx, node, secret, func(obj client.Object) error {
+		secret := obj.(*corev1.Secret)
+		secret.ObjectMeta.Labels = node.Labels
+		secret.Data = map[string][]byte{
+			adminMacaroonKey: macaroon,
+		}
+		return nil
+	})
+}
+
+// updateStatus updates Lightning Network node status
+func (r *LightningNodeReconciler) updateStatus(ctx context.Context, node *bitcoinv1alpha1.LightningNode) error {
+	previous := node.Status
+
+	node.Status.Client = string(node.Spec.Client)
+
+	node.Status.Mode = shared.Mode(node, node.Spec.Suspend)
+	if err := r.UpdateResyncStatus(ctx, node, &node.Status.ResyncStatus, node.Spec.DataResync); err != nil {
+		return err
+	}
+
+	if err := r.Status().Update(ctx, node); err != nil {
+		log.FromContext(ctx).Error(err, "unable to update lightning node status")
+		return err
+	}
+
+	r.RecordStatusTransition(node, previous, node.Status)
+
+	return nil
+}
+
+// lightningNodeReferences returns secrets and config maps referenced by Lightning Network node
+func lightningNodeReferences(node *bitcoinv1alpha1.LightningNode, bitcoinNode *bitcoinv1alpha1.Node) shared.References {
+	refs := shared.PodExtrasReferences(node.Spec.PodExtras)
+	if bitcoinNode != nil && len(bitcoinNode.Spec.RPCUsers) != 0 {
+		refs.Add(bitcoinNode.Spec.RPCUsers[0].PasswordSecretName.Name)
+	}
+	return refs
+}
+
+// specPVC updates Lightning Network node persistent volume claim
+func (r *LightningNodeReconciler) specPVC(node *bitcoinv1alpha1.LightningNode, pvc *corev1.PersistentVolumeClaim) {
+	request := corev1.ResourceList{
+		corev1.ResourceStorage: resource.MustParse(node.Spec.Storage),
+	}
+
+	// spec is immutable after creation except resources.requests for bound claims
+	if !pvc.CreationTimestamp.IsZero() {
+		pvc.Spec.Resources.Requests = request
+		return
+	}
+
+	pvc.ObjectMeta.Labels = node.Labels
+	pvc.Spec = corev1.PersistentVolumeClaimSpec{
+		AccessModes: []corev1.PersistentVolumeAccessMode{
+			corev1.ReadWriteOnce,
+		},
+		Resources: corev1.VolumeResourceRequirements{
+			Requests: request,
+		},
+	}
+}
+
+// lightningPorts returns Lightning Network node container ports
+func lightningPorts(node *bitcoinv1alpha1.LightningNode) []corev1.ContainerPort {
+	return []corev1.ContainerPort{
+		{
+			Name:          "p2p",
+			ContainerPort: int32(node.Spec.P2PPort),
+		},
+		{
+			Name:          "grpc",
+			ContainerPort: int32(node.Spec.GRPCPort),
+		},
+		{
+			Name:          "rest",
+			ContainerPort: int32(node.Spec.RESTPort),
+		},
+	}
+}
+
+// specService updates Lightning Network node service spec
+func (r *LightningNodeReconciler) specService(node *bitcoinv1alpha1.LightningNode, svc *corev1.Service) {
+	labels := node.Labels
+
+	svc.ObjectMeta.Labels = labels
+
+	svc.Spec.Ports = nil
+	for _, port := range lightningPorts(node) {
+		svc.Spec.Ports = append(svc.Spec.Ports, corev1.ServicePort{
+			Name:       port.Name,
+			Port:       port.ContainerPort,
+			TargetPort: intstr.FromString(port.Name),
+		})
+	}
+
+	svc.Spec.Selector = labels
+}
+
+// secretsVolume returns Lightning Network node generated secrets volume
+// lnd wallet password and TLS certificate are projected into secrets directory
+func secretsVolume(node *bitcoinv1alpha1.LightningNode) corev1.Volume {
+	var sources []corev1.VolumeProjection
+
+	switch node.Spec.Client {
+	case bitcoinv1alpha1.LNDClient:
+		sources = []corev1.VolumeProjection{
+			{
+				Secret: &corev1.SecretProjection{
+					LocalObjectReference: corev1.LocalObjectReference{Name: walletSecretName(node)},
+					Items: []corev1.KeyToPath{
+						{Key: walletPasswordKey, Path: bitcoinClients.LNDWalletPasswordFile},
+					},
+				},
+			},
+			{
+				Secret: &corev1.SecretProjection{
+					LocalObjectReference: corev1.LocalObjectReference{Name: tlsSecretName(node)},
+					Items: []corev1.KeyToPath{
+						{Key: corev1.TLSCertKey, Path: bitcoinClients.LNDTLSCertFile},
+						{Key: corev1.TLSPrivateKeyKey, Path: bitcoinClients.LNDTLSKeyFile},
+					},
+				},
+			},
+		}
+	}
+
+	return corev1.Volume{
+		Name: "secrets",
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: sources,
+			},
+		},
+	}
+}
+
+// specStatefulSet updates Lightning Network node statefulset spec
+func (r *LightningNodeReconciler) specStatefulSet(node *bitcoinv1alpha1.LightningNode, bitcoinNode *bitcoinv1alpha1.Node, sts *appsv1.StatefulSet, homeDir string, env []corev1.EnvVar, cmd, args []string) error {
+
+	sts.ObjectMeta.Labels = node.Labels
+
+	replicas := shared.StatefulSetReplicas(*node.Spec.Replicas, node.Spec.Suspend)
+
+	mounts := []corev1.VolumeMount{
+		{
+			Name:      "data",
+			MountPath: shared.PathData(homeDir),
+		},
+		{
+			Name:      "secrets",
+			MountPath: shared.PathSecrets(homeDir),
+			ReadOnly:  true,
+		},
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	r.specPVC(node, pvc)
+	dataVolumes, volumeClaimTemplates := shared.SpecDataStorage(node, sts, pvc)
+
+	sts.Spec = appsv1.StatefulSetSpec{
+		Selector: &metav1.LabelSelector{
+			MatchLabels: node.Labels,
+		},
+		Replicas:    &replicas,
+		ServiceName: node.Name,
+		Template: corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: node.Labels,
+			},
+			Spec: corev1.PodSpec{
+				SecurityContext: shared.SecurityContext(),
+				Containers: []corev1.Container{
+					{
+						Name:         "node",
+						Image:        node.Spec.Image,
+						Command:      cmd,
+						Args:         args,
+						Env:          env,
+						Ports:        lightningPorts(node),
+						VolumeMounts: mounts,
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse(node.Spec.CPU),
+								corev1.ResourceMemory: resource.MustParse(node.Spec.Memory),
+							},
+							Limits: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse(node.Spec.CPULimit),
+								corev1.ResourceMemory: resource.MustParse(node.Spec.MemoryLimit),
+							},
+						},
+					},
+				},
+				Volumes: append(dataVolumes, secretsVolume(node)),
+			},
+		},
+		VolumeClaimTemplates: volumeClaimTemplates,
+	}
+
+	shared.SpecResync(node.Spec.DataResync, &sts.Spec.Template.Spec, homeDir)
+	if err := shared.SpecPodExtras(node.Spec.PodExtras, &sts.Spec.Template.Spec); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *LightningNodeReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := shared.IndexReferences(mgr, &bitcoinv1alpha1.LightningNode{}, func(obj client.Object) shared.References {
+		// referenced Bitcoin node rpc user password secret can't be indexed without fetching Bitcoin node
+		// its changes are picked up on status refresh interval
+		return lightningNodeReferences(obj.(*bitcoinv1alpha1.LightningNode), nil)
+	}); err != nil {
+		return err
+	}
+
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &bitcoinv1alpha1.LightningNode{}, indexBitcoinNode, func(obj client.Object) []string {
+		return []string{obj.(*bitcoinv1alpha1.LightningNode).Spec.BitcoinNode}
+	}); err != nil {
+		return err
+	}
+
+	// owned objects updates are filtered, referenced objects are watched without predicate so their updates always pass
+	pred := builder.WithPredicates(shared.GenerationOrContentChangedPredicate())
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&bitcoinv1alpha1.LightningNode{}, pred).
+		Owns(&corev1.PersistentVolumeClaim{}, pred).
+		Owns(&appsv1.StatefulSet{}, pred).
+		Owns(&policyv1.PodDisruptionBudget{}, pred).
+		Owns(&networkingv1.NetworkPolicy{}, pred).
+		Owns(&corev1.Service{}, pred).
+		Owns(&corev1.Secret{}, pred).
+		Watches(&bitcoinv1alpha1.Node{}, r.EnqueueReferencing(&bitcoinv1alpha1.LightningNodeList{}, indexBitcoinNode)).
+		Watches(&corev1.Secret{}, r.EnqueueReferencing(&bitcoinv1alpha1.LightningNodeList{}, shared.IndexSecrets)).
+		Watches(&corev1.ConfigMap{}, r.EnqueueReferencing(&bitcoinv1alpha1.LightningNodeList{}, shared.IndexConfigMaps)).
+		Complete(r)
+}
@@ -0,0 +1,176 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	bitcoinv1alpha1 "github.com/kotalco/kotal/apis/bitcoin/v1alpha1"
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+	bitcoinClients "github.com/kotalco/kotal/clients/bitcoin"
+	"github.com/kotalco/kotal/controllers/shared"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+var _ = Describe("Bitcoin lightning node controller", func() {
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "bitcoin-lightning",
+		},
+	}
+
+	key := types.NamespacedName{
+		Name:      "lightning-node",
+		Namespace: ns.Name,
+	}
+
+	bitcoinNode := &bitcoinv1alpha1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "bitcoin-node",
+			Namespace: ns.Name,
+		},
+		Spec: bitcoinv1alpha1.NodeSpec{
+			Network: bitcoinv1alpha1.Regtest,
+			RPC:     true,
+			RPCUsers: []bitcoinv1alpha1.RPCUser{
+				{
+					Username: "kotal",
+					PasswordSecretName: sharedAPI.SecretKeySelector{
+						Name: "rpc-password",
+					},
+				},
+			},
+			ZMQ: bitcoinv1alpha1.ZMQ{
+				RawBlockPort: 28332,
+				RawTxPort:    28333,
+			},
+		},
+	}
+
+	testImage := "kotalco/lnd:controller-test"
+
+	toCreate := &bitcoinv1alpha1.LightningNode{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+		},
+		Spec: bitcoinv1alpha1.LightningNodeSpec{
+			Image:       testImage,
+			Client:      bitcoinv1alpha1.LNDClient,
+			BitcoinNode: bitcoinNode.Name,
+		},
+	}
+
+	t := true
+
+	nodeOwnerReference := metav1.OwnerReference{
+		APIVersion:         "bitcoin.kotal.io/v1alpha1",
+		Kind:               "LightningNode",
+		Name:               toCreate.Name,
+		Controller:         &t,
+		BlockOwnerDeletion: &t,
+	}
+
+	It(fmt.Sprintf("Should create %s namespace", ns.Name), func() {
+		Expect(k8sClient.Create(context.TODO(), ns)).To(Succeed())
+	})
+
+	It("Should create referenced Bitcoin node", func() {
+		if os.Getenv(shared.EnvUseExistingCluster) != "true" {
+			bitcoinNode.Default()
+		}
+		Expect(k8sClient.Create(context.Background(), bitcoinNode)).Should(Succeed())
+	})
+
+	It("Should create lightning node", func() {
+		if os.Getenv(shared.EnvUseExistingCluster) != "true" {
+			toCreate.Default()
+		}
+		Expect(k8sClient.Create(context.Background(), toCreate)).Should(Succeed())
+	})
+
+	It("Should get lightning node", func() {
+		fetched := &bitcoinv1alpha1.LightningNode{}
+		Expect(k8sClient.Get(context.Background(), key, fetched)).To(Succeed())
+		Expect(fetched.Spec).To(Equal(toCreate.Spec))
+		nodeOwnerReference.UID = fetched.UID
+		time.Sleep(5 * time.Second)
+	})
+
+	It("Should generate wallet password and TLS certificate secrets", func() {
+		wallet := &corev1.Secret{}
+		walletKey := types.NamespacedName{Name: walletSecretName(toCreate), Namespace: ns.Name}
+		Expect(k8sClient.Get(context.Background(), walletKey, wallet)).To(Succeed())
+		// wallet secret outlives the node
+		Expect(wallet.OwnerReferences).To(BeEmpty())
+		Expect(wallet.Data[walletPasswordKey]).To(HaveLen(64))
+
+		tls := &corev1.Secret{}
+		tlsKey := types.NamespacedName{Name: tlsSecretName(toCreate), Namespace: ns.Name}
+		Expect(k8sClient.Get(context.Background(), tlsKey, tls)).To(Succeed())
+		Expect(tls.Type).To(Equal(corev1.SecretTypeTLS))
+		Expect(tls.Data).To(HaveKey(corev1.TLSCertKey))
+		Expect(tls.Data).To(HaveKey(corev1.TLSPrivateKeyKey))
+	})
+
+	It("Should create lightning node statefulset", func() {
+		client, err := bitcoinClients.NewLightningClient(toCreate, bitcoinNode)
+		Expect(err).To(BeNil())
+
+		fetched := &appsv1.StatefulSet{}
+		Expect(k8sClient.Get(context.Background(), key, fetched)).To(Succeed())
+		Expect(fetched.OwnerReferences).To(ContainElements(nodeOwnerReference))
+		Expect(fetched.Spec.Template.Spec.Containers[0].Image).To(Equal(testImage))
+		Expect(fetched.Spec.Template.Spec.Containers[0].Env).To(Equal(client.Env()))
+		Expect(fetched.Spec.Template.Spec.Containers[0].Command).To(Equal(client.Command()))
+		Expect(fetched.Spec.Template.Spec.Containers[0].Args).To(Equal(client.Args()))
+		Expect(fetched.Spec.Template.Spec.Containers[0].VolumeMounts).To(ContainElements(
+			corev1.VolumeMount{
+				Name:      "data",
+				MountPath: shared.PathData(client.HomeDir()),
+			},
+			corev1.VolumeMount{
+				Name:      "secrets",
+				MountPath: shared.PathSecrets(client.HomeDir()),
+				ReadOnly:  true,
+			},
+		))
+	})
+
+	It("Should create lightning node service", func() {
+		fetched := &corev1.Service{}
+		Expect(k8sClient.Get(context.Background(), key, fetched)).To(Succeed())
+		Expect(fetched.OwnerReferences).To(ContainElements(nodeOwnerReference))
+		Expect(fetched.Spec.Ports).To(ContainElements(
+			corev1.ServicePort{
+				Name:       "p2p",
+				Port:       int32(bitcoinv1alpha1.DefaultLightningP2PPort),
+				TargetPort: intstr.FromString("p2p"),
+				Protocol:   corev1.ProtocolTCP,
+			},
+			corev1.ServicePort{
+				Name:       "grpc",
+				Port:       int32(bitcoinv1alpha1.DefaultLightningGRPCPort),
+				TargetPort: intstr.FromString("grpc"),
+				Protocol:   corev1.ProtocolTCP,
+			},
+			corev1.ServicePort{
+				Name:       "rest",
+				Port:       int32(bitcoinv1alpha1.DefaultLightningRESTPort),
+				TargetPort: intstr.FromString("rest"),
+				Protocol:   corev1.ProtocolTCP,
+			},
+		))
+	})
+
+	It(fmt.Sprintf("Should delete %s namespace", ns.Name), func() {
+		Expect(k8sClient.Delete(context.Background(), ns)).To(Succeed())
+	})
+})
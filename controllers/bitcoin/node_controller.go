@@ -2,43 +2,97 @@ package controllers
 
 import (
 	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
 
 	bitcoinClients "github.com/kotalco/kotal/clients/bitcoin"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
-	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	bitcoinv1alpha1 "github.com/kotalco/kotal/apis/bitcoin/v1alpha1"
 	"github.com/kotalco/kotal/controllers/shared"
 )
 
+var (
+	//go:embed download_utxo_snapshot.sh
+	downloadUTXOSnapshotScript string
+)
+
 // NodeReconciler reconciles a Node object
 type NodeReconciler struct {
 	shared.Reconciler
 }
 
+// Bitcoin prometheus exporter environment variables
+const (
+	envExporterRPCHost     = "BITCOIN_RPC_HOST"
+	envExporterRPCPort     = "BITCOIN_RPC_PORT"
+	envExporterRPCUser     = "BITCOIN_RPC_USER"
+	envExporterRPCPassword = "BITCOIN_RPC_PASSWORD"
+	envExporterMetricsAddr = "METRICS_ADDR"
+	envExporterMetricsPort = "METRICS_PORT"
+)
+
+// UTXO snapshot download environment variables
+const (
+	envUTXOSnapshotURL  = "KOTAL_UTXO_SNAPSHOT_URL"
+	envUTXOSnapshotPath = "KOTAL_UTXO_SNAPSHOT_PATH"
+)
+
+const (
+	// utxoSnapshotFile is downloaded or config map UTXO snapshot file name
+	utxoSnapshotFile = "kotal-utxo-snapshot.dat"
+	// utxoSnapshotVolumeName is config map UTXO snapshot volume name
+	utxoSnapshotVolumeName = "utxo-snapshot"
+	// assumeUTXOInterval is interval of checking UTXO snapshot loading and background validation
+	assumeUTXOInterval = time.Minute
+	// assumeUTXOLoadTimeout is how long UTXO snapshot loading is awaited before it's requested again
+	assumeUTXOLoadTimeout = time.Hour
+)
+
 // +kubebuilder:rbac:groups=bitcoin.kotal.io,resources=nodes,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=bitcoin.kotal.io,resources=nodes/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=watch;get;list;create;update;delete
 // +kubebuilder:rbac:groups=core,resources=services;persistentvolumeclaims,verbs=watch;get;create;update;list;delete
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=watch;get;create;update;list;delete
 
 // Reconcile Bitcoin node
 func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 	defer shared.IgnoreConflicts(&err)
 
 	var node bitcoinv1alpha1.Node
+	defer r.ObserveReconcile(&node, req.NamespacedName, &err)
 
 	if err = r.Client.Get(ctx, req.NamespacedName, &node); err != nil {
 		err = client.IgnoreNotFound(err)
 		return
 	}
 
+	var deleting bool
+	if deleting, result, err = r.ReconcileDeletion(ctx, &node, node.Spec.Deletion); deleting || err != nil {
+		return
+	}
+
+	// owned objects are left as is while node is paused
+	if shared.IsPaused(&node) {
+		err = r.ReconcilePaused(ctx, &node, &node.Status.Mode)
+		return
+	}
+
 	// default the node if webhooks are disabled
 	if !shared.IsWebhookEnabled() {
 		node.Default()
@@ -46,10 +100,9 @@ func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (resul
 
 	shared.UpdateLabels(&node, "bitcoind", string(node.Spec.Network))
 
-	// reconcile persistent volume claim
-	if err = r.ReconcileOwned(ctx, &node, &corev1.PersistentVolumeClaim{}, func(obj client.Object) error {
-		r.specPVC(&node, obj.(*corev1.PersistentVolumeClaim))
-		return nil
+	// reconcile persistent volume claims
+	if err = r.ReconcilePVCs(ctx, &node, *node.Spec.Replicas, func(pvc *corev1.PersistentVolumeClaim) {
+		r.specPVC(&node, pvc)
 	}); err != nil {
 		return
 	}
@@ -62,19 +115,65 @@ func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (resul
 		return
 	}
 
+	// reconcile rpc users salted credentials
+	var rpcAuth map[string]string
+	if rpcAuth, err = r.reconcileRPCAuth(ctx, &node); err != nil {
+		return
+	}
+
+	// reconcile JSON-RPC connection secret
+	if err = r.reconcileConnectionSecret(ctx, &node); err != nil {
+		return
+	}
+
 	// reconcile statefulset
-	if err = r.ReconcileOwned(ctx, &node, &appsv1.StatefulSet{}, func(obj client.Object) error {
-		client := bitcoinClients.NewClient(&node, r.Client)
+	sts := &appsv1.StatefulSet{}
+	if err = r.ReconcileOwned(ctx, &node, sts, func(obj client.Object) error {
+		client := bitcoinClients.NewClient(&node, rpcAuth)
 		homeDir := client.HomeDir()
 		cmd := client.Command()
 		args := client.Args()
-		args = append(args, node.Spec.ExtraArgs.Encode(true)...)
+		args = node.Spec.ExtraArgs.Merge(args, node.Spec.RemoveArgs, true)
 		env := client.Env()
-		return r.specStatefulSet(&node, obj.(*appsv1.StatefulSet), homeDir, env, cmd, args)
+		sts := obj.(*appsv1.StatefulSet)
+		if err := r.specStatefulSet(&node, sts, homeDir, env, cmd, args); err != nil {
+			return err
+		}
+		shared.SpecShutdown(node.Spec.Shutdown, client, &sts.Spec.Template.Spec)
+		return r.SpecContentHash(ctx, &node, sts, references(&node))
 	}); err != nil {
 		return
 	}
 
+	// reconcile pod disruption budget
+	if err = r.ReconcilePodDisruptionBudget(ctx, &node, node.Spec.Disruption, *node.Spec.Replicas); err != nil {
+		return
+	}
+
+	// reconcile network policy
+	if err = r.ReconcileNetworkPolicy(ctx, &node, node.Spec.Access, &sts.Spec.Template.Spec, nil); err != nil {
+		return
+	}
+
+	// reconcile prometheus service monitor
+	endpoint := shared.MetricsEndpoint{Port: "metrics", Path: "/metrics"}
+	if err = r.ReconcileServiceMonitor(ctx, &node, node.Spec.Metrics, endpoint); err != nil {
+		return
+	}
+
+	// load UTXO snapshot and track its background validation
+	if node.Spec.AssumeUTXO != nil && !node.Spec.Suspend && r.reconcileAssumeUTXO(ctx, &node) {
+		result.RequeueAfter = assumeUTXOInterval
+	}
+
+	// generate regtest blocks on an interval
+	if node.Spec.Network == bitcoinv1alpha1.Regtest && node.Spec.BlockGeneration != nil && !node.Spec.Suspend {
+		next := r.generateBlocks(ctx, &node)
+		if result.RequeueAfter == 0 || next < result.RequeueAfter {
+			result.RequeueAfter = next
+		}
+	}
+
 	if err = r.updateStatus(ctx, &node); err != nil {
 		return
 	}
@@ -82,18 +181,308 @@ func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (resul
 	return
 }
 
+// rpcClient returns node JSON-RPC client using the first rpc user credentials
+func (r *NodeReconciler) rpcClient(ctx context.Context, node *bitcoinv1alpha1.Node) (*bitcoinClients.RPCClient, error) {
+	if len(node.Spec.RPCUsers) == 0 {
+		return nil, errors.New("no rpc users to call JSON-RPC server")
+	}
+	user := node.Spec.RPCUsers[0]
+
+	name := types.NamespacedName{Name: user.PasswordSecretName.Name, Namespace: node.Namespace}
+	password, err := shared.GetSecret(ctx, r.Client, name, user.PasswordSecretName.KeyOr("password"))
+	if err != nil {
+		return nil, err
+	}
+
+	return bitcoinClients.NewRPCClient(node, user.Username, password), nil
+}
+
+// utxoSnapshotPath returns UTXO snapshot file path in node file system
+func utxoSnapshotPath(node *bitcoinv1alpha1.Node, homeDir string) string {
+	switch {
+	case node.Spec.AssumeUTXO.ConfigMapName != "":
+		return filepath.Join(homeDir, utxoSnapshotVolumeName, utxoSnapshotFile)
+	case node.Spec.AssumeUTXO.URL != "":
+		return filepath.Join(shared.PathData(homeDir), utxoSnapshotFile)
+	default:
+		return filepath.Join(shared.PathData(homeDir), node.Spec.AssumeUTXO.Path)
+	}
+}
+
+// reconcileAssumeUTXO loads UTXO snapshot once JSON-RPC server is up, and tracks background validation in node status
+// node JSON-RPC server is called using the first rpc user credentials, enforced by validation webhook
+// it returns true if background validation hasn't finished yet
+func (r *NodeReconciler) reconcileAssumeUTXO(ctx context.Context, node *bitcoinv1alpha1.Node) (pending bool) {
+	logger := log.FromContext(ctx)
+
+	if node.Status.AssumeUTXO == nil {
+		node.Status.AssumeUTXO = &bitcoinv1alpha1.AssumeUTXOStatus{}
+	}
+	status := node.Status.AssumeUTXO
+
+	if status.Phase == bitcoinv1alpha1.AssumeUTXOValidated {
+		return false
+	}
+
+	rpc, err := r.rpcClient(ctx, node)
+	if err != nil {
+		logger.Error(err, "unable to get rpc client to load UTXO snapshot")
+		return true
+	}
+
+	chainStates, err := rpc.GetChainStates(ctx)
+	if err != nil {
+		logger.Info("JSON-RPC server isn't up yet", "error", err.Error())
+		return true
+	}
+
+	// background validation chainstate is first if there're two chainstates
+	var background, snapshot *bitcoinClients.ChainState
+	for i := range chainStates.ChainStates {
+		if chainStates.ChainStates[i].SnapshotBlockHash != "" {
+			snapshot = &chainStates.ChainStates[i]
+		} else {
+			background = &chainStates.ChainStates[i]
+		}
+	}
+
+	// node tip is the snapshot chainstate tip once UTXO snapshot is loaded
+	switch {
+	case snapshot != nil:
+		r.SetSyncHeight(node, uint64(snapshot.Blocks))
+	case background != nil:
+		r.SetSyncHeight(node, uint64(background.Blocks))
+	}
+
+	// node might have restarted or failed to load UTXO snapshot after the request timed out
+	loadTimedOut := status.Phase == bitcoinv1alpha1.AssumeUTXOLoading &&
+		(status.LoadTime == nil || time.Since(status.LoadTime.Time) > assumeUTXOLoadTimeout)
+
+	switch {
+	case snapshot == nil && (status.Phase == "" || loadTimedOut):
+		homeDir := bitcoinClients.NewClient(node, nil).HomeDir()
+		path := utxoSnapshotPath(node, homeDir)
+		if loadTimedOut {
+			r.Recorder.Eventf(node, corev1.EventTypeWarning, shared.EventReasonReconcileFailed, "UTXO snapshot %s isn't loaded after %s, loading it again", path, assumeUTXOLoadTimeout)
+		}
+		// node keeps loading UTXO snapshot if the request timed out
+		if err := rpc.LoadTxOutSet(ctx, path); err != nil && !bitcoinClients.IsTimeout(err) {
+			r.Recorder.Eventf(node, corev1.EventTypeWarning, shared.EventReasonReconcileFailed, "Failed to load UTXO snapshot %s: %s", path, err)
+			return true
+		}
+		now := metav1.Now()
+		status.Phase = bitcoinv1alpha1.AssumeUTXOLoading
+		status.LoadTime = &now
+	case snapshot == nil && status.Phase == bitcoinv1alpha1.AssumeUTXOValidating:
+		// background validation chainstate is removed on restart after it has finished
+		status.Phase = bitcoinv1alpha1.AssumeUTXOValidated
+		status.ValidatedHeight = status.SnapshotHeight
+		status.ValidationProgress = "100.00%"
+	case snapshot == nil:
+		// UTXO snapshot is still being loaded
+	default:
+		if status.SnapshotBlockHash != snapshot.SnapshotBlockHash {
+			header, err := rpc.GetBlockHeader(ctx, snapshot.SnapshotBlockHash)
+			if err != nil {
+				logger.Error(err, "unable to get UTXO snapshot base block header")
+				return true
+			}
+			status.SnapshotBlockHash = header.Hash
+			status.SnapshotHeight = header.Height
+		}
+
+		status.Phase = bitcoinv1alpha1.AssumeUTXOValidating
+		if background != nil {
+			status.ValidatedHeight = background.Blocks
+		}
+
+		if snapshot.Validated || background == nil {
+			status.Phase = bitcoinv1alpha1.AssumeUTXOValidated
+			status.ValidatedHeight = status.SnapshotHeight
+		}
+
+		if status.SnapshotHeight != 0 {
+			progress := float64(status.ValidatedHeight) / float64(status.SnapshotHeight) * 100
+			status.ValidationProgress = fmt.Sprintf("%.2f%%", progress)
+		}
+	}
+
+	return status.Phase != bitcoinv1alpha1.AssumeUTXOValidated
+}
+
+// generateBlocks generates a regtest block to block generation address once interval has elapsed since last generated block
+// node JSON-RPC server is called using the first rpc user credentials, enforced by validation webhook
+// errors are logged only, node might not be up yet, and blocks generation is retried next interval
+// it returns duration until next block generation
+func (r *NodeReconciler) generateBlocks(ctx context.Context, node *bitcoinv1alpha1.Node) time.Duration {
+	logger := log.FromContext(ctx)
+	interval := time.Duration(node.Spec.BlockGeneration.Interval) * time.Second
+
+	if last := node.Status.LastBlockGenerationTime; last != nil {
+		if elapsed := time.Since(last.Time); elapsed < interval {
+			return interval - elapsed
+		}
+	}
+
+	rpc, err := r.rpcClient(ctx, node)
+	if err != nil {
+		logger.Error(err, "unable to get rpc client to generate blocks")
+		return interval
+	}
+
+	hashes, err := rpc.GenerateToAddress(ctx, 1, node.Spec.BlockGeneration.Address)
+	if err != nil {
+		logger.Error(err, "unable to generate blocks")
+		return interval
+	}
+
+	logger.Info("generated blocks", "hashes", hashes)
+	now := metav1.Now()
+	node.Status.LastBlockGenerationTime = &now
+
+	return interval
+}
+
 // updateStatus updates Bitcoin node status
 func (r *NodeReconciler) updateStatus(ctx context.Context, node *bitcoinv1alpha1.Node) error {
+	previous := node.Status
+
 	node.Status.Client = "bitcoincore"
 
+	node.Status.Mode = shared.Mode(node, node.Spec.Suspend)
+	if err := r.UpdateResyncStatus(ctx, node, &node.Status.ResyncStatus, node.Spec.DataResync); err != nil {
+		return err
+	}
+
 	if err := r.Status().Update(ctx, node); err != nil {
 		log.FromContext(ctx).Error(err, "unable to update node status")
 		return err
 	}
 
+	r.RecordStatusTransition(node, previous, node.Status)
+
 	return nil
 }
 
+// rpcAuthSecretName returns rpc users salted credentials secret name
+func rpcAuthSecretName(node *bitcoinv1alpha1.Node) string {
+	return fmt.Sprintf("%s-rpcauth", node.Name)
+}
+
+// connectionSecretName returns JSON-RPC connection secret name
+func connectionSecretName(node *bitcoinv1alpha1.Node) string {
+	return fmt.Sprintf("%s-connection", node.Name)
+}
+
+// reconcileRPCAuth reconciles rpc users salted credentials secret and returns salted credentials keyed by username
+// salted credentials are persisted, so node args don't change on operator restarts
+// they're generated again only if rpc user password has changed
+func (r *NodeReconciler) reconcileRPCAuth(ctx context.Context, node *bitcoinv1alpha1.Node) (rpcAuth map[string]string, err error) {
+	if !node.Spec.RPC || len(node.Spec.RPCUsers) == 0 {
+		meta.RemoveStatusCondition(&node.Status.Conditions, bitcoinv1alpha1.ConditionRPCAuthReady)
+		return
+	}
+
+	passwords := map[string]string{}
+	for _, user := range node.Spec.RPCUsers {
+		name := types.NamespacedName{Name: user.PasswordSecretName.Name, Namespace: node.Namespace}
+		var password string
+		if password, err = r.GetSecret(ctx, node, name, user.PasswordSecretName.KeyOr("password")); err != nil {
+			err = fmt.Errorf("unable to get rpc user %s password from secret %s: %w", user.Username, name.Name, err)
+			r.setRPCAuthCondition(ctx, node, metav1.ConditionFalse, bitcoinv1alpha1.ReasonRPCPasswordNotFound, err.Error())
+			return
+		}
+		passwords[user.Username] = password
+	}
+
+	secret := &corev1.Secret{}
+	secret.SetName(rpcAuthSecretName(node))
+
+	err = r.ReconcileOwned(ctx, node, secret, func(obj client.Object) error {
+		secret := obj.(*corev1.Secret)
+		secret.ObjectMeta.Labels = node.Labels
+
+		rpcAuth = map[string]string{}
+		data := map[string][]byte{}
+		for username, password := range passwords {
+			rpcAuth[username] = bitcoinClients.RPCAuth(password, string(secret.Data[username]))
+			data[username] = []byte(rpcAuth[username])
+		}
+		secret.Data = data
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	meta.SetStatusCondition(&node.Status.Conditions, metav1.Condition{
+		Type:               bitcoinv1alpha1.ConditionRPCAuthReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             bitcoinv1alpha1.ReasonRPCAuthGenerated,
+		Message:            "Generated rpc users salted credentials",
+		ObservedGeneration: node.Generation,
+	})
+
+	return
+}
+
+// setRPCAuthCondition updates node rpc auth condition, status update errors are logged only
+func (r *NodeReconciler) setRPCAuthCondition(ctx context.Context, node *bitcoinv1alpha1.Node, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&node.Status.Conditions, metav1.Condition{
+		Type:               bitcoinv1alpha1.ConditionRPCAuthReady,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: node.Generation,
+	})
+
+	if err := r.Status().Update(ctx, node); err != nil {
+		log.FromContext(ctx).Error(err, "unable to update node status")
+	}
+}
+
+// reconcileConnectionSecret reconciles secret holding JSON-RPC url and the first rpc user credentials
+// connection secret is deleted if it's disabled
+func (r *NodeReconciler) reconcileConnectionSecret(ctx context.Context, node *bitcoinv1alpha1.Node) error {
+	secret := &corev1.Secret{}
+	secret.SetName(connectionSecretName(node))
+
+	// rpc and rpc users are required by connection secret, enforced by validation webhook
+	if !node.Spec.ConnectionSecret || !node.Spec.RPC || len(node.Spec.RPCUsers) == 0 {
+		secret.SetNamespace(node.Namespace)
+		return client.IgnoreNotFound(r.Client.Delete(ctx, secret))
+	}
+
+	user := node.Spec.RPCUsers[0]
+	name := types.NamespacedName{Name: user.PasswordSecretName.Name, Namespace: node.Namespace}
+	password, err := r.GetSecret(ctx, node, name, user.PasswordSecretName.KeyOr("password"))
+	if err != nil {
+		return err
+	}
+
+	return r.ReconcileOwned(ctx, node, secret, func(obj client.Object) error {
+		secret := obj.(*corev1.Secret)
+		secret.ObjectMeta.Labels = node.Labels
+		secret.Data = map[string][]byte{
+			"url":      []byte(bitcoinClients.RPCURL(node)),
+			"username": []byte(user.Username),
+			"password": []byte(password),
+		}
+		return nil
+	})
+}
+
+// references returns secrets and config maps referenced by Bitcoin node
+func references(node *bitcoinv1alpha1.Node) shared.References {
+	refs := shared.PodExtrasReferences(node.Spec.PodExtras)
+	for _, user := range node.Spec.RPCUsers {
+		refs.Add(user.PasswordSecretName.Name)
+	}
+	return refs
+}
+
 // specPVC updates Bitcoin node persistent volume claim
 func (r *NodeReconciler) specPVC(node *bitcoinv1alpha1.Node, pvc *corev1.PersistentVolumeClaim) {
 	request := corev1.ResourceList{
@@ -139,9 +528,49 @@ func (r *NodeReconciler) specService(node *bitcoinv1alpha1.Node, svc *corev1.Ser
 		})
 	}
 
+	for _, port := range zmqPorts(node) {
+		svc.Spec.Ports = append(svc.Spec.Ports, corev1.ServicePort{
+			Name:       port.Name,
+			Port:       port.ContainerPort,
+			TargetPort: intstr.FromString(port.Name),
+		})
+	}
+
+	if node.Spec.Metrics.Enabled {
+		svc.Spec.Ports = append(svc.Spec.Ports, corev1.ServicePort{
+			Name:       "metrics",
+			Port:       int32(node.Spec.Metrics.Port),
+			TargetPort: intstr.FromString("metrics"),
+		})
+	}
+
 	svc.Spec.Selector = labels
 }
 
+// zmqPorts returns enabled ZeroMQ notifications container ports
+func zmqPorts(node *bitcoinv1alpha1.Node) (ports []corev1.ContainerPort) {
+	zmq := []struct {
+		name string
+		port uint
+	}{
+		{"zmq-rawblock", node.Spec.ZMQ.RawBlockPort},
+		{"zmq-rawtx", node.Spec.ZMQ.RawTxPort},
+		{"zmq-hashblock", node.Spec.ZMQ.HashBlockPort},
+		{"zmq-sequence", node.Spec.ZMQ.SequencePort},
+	}
+
+	for _, notification := range zmq {
+		if notification.port != 0 {
+			ports = append(ports, corev1.ContainerPort{
+				Name:          notification.name,
+				ContainerPort: int32(notification.port),
+			})
+		}
+	}
+
+	return
+}
+
 // specStatefulSet updates node statefulset spec
 func (r *NodeReconciler) specStatefulSet(node *bitcoinv1alpha1.Node, sts *appsv1.StatefulSet, homeDir string, env []corev1.EnvVar, cmd, args []string) error {
 
@@ -161,7 +590,45 @@ func (r *NodeReconciler) specStatefulSet(node *bitcoinv1alpha1.Node, sts *appsv1
 		})
 	}
 
-	replicas := int32(*node.Spec.Replicas)
+	ports = append(ports, zmqPorts(node)...)
+
+	replicas := shared.StatefulSetReplicas(*node.Spec.Replicas, node.Spec.Suspend)
+
+	containers := []corev1.Container{
+		{
+			Name:    "node",
+			Image:   node.Spec.Image,
+			Command: cmd,
+			Args:    args,
+			Env:     env,
+			Ports:   ports,
+			VolumeMounts: []corev1.VolumeMount{
+				{
+					Name:      "data",
+					MountPath: shared.PathData(homeDir),
+				},
+			},
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse(node.Spec.CPU),
+					corev1.ResourceMemory: resource.MustParse(node.Spec.Memory),
+				},
+				Limits: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse(node.Spec.CPULimit),
+					corev1.ResourceMemory: resource.MustParse(node.Spec.MemoryLimit),
+				},
+			},
+		},
+	}
+
+	// exporter requires rpc user credentials, enforced by validation webhook
+	if node.Spec.Metrics.Enabled && len(node.Spec.RPCUsers) != 0 {
+		containers = append(containers, r.specExporterContainer(node))
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	r.specPVC(node, pvc)
+	dataVolumes, volumeClaimTemplates := shared.SpecDataStorage(node, sts, pvc)
 
 	sts.Spec = appsv1.StatefulSetSpec{
 		Selector: &metav1.LabelSelector{
@@ -175,56 +642,147 @@ func (r *NodeReconciler) specStatefulSet(node *bitcoinv1alpha1.Node, sts *appsv1
 			},
 			Spec: corev1.PodSpec{
 				SecurityContext: shared.SecurityContext(),
-				Containers: []corev1.Container{
-					{
-						Name:    "node",
-						Image:   node.Spec.Image,
-						Command: cmd,
-						Args:    args,
-						Env:     env,
-						Ports:   ports,
-						VolumeMounts: []corev1.VolumeMount{
-							{
-								Name:      "data",
-								MountPath: shared.PathData(homeDir),
-							},
-						},
-						Resources: corev1.ResourceRequirements{
-							Requests: corev1.ResourceList{
-								corev1.ResourceCPU:    resource.MustParse(node.Spec.CPU),
-								corev1.ResourceMemory: resource.MustParse(node.Spec.Memory),
-							},
-							Limits: corev1.ResourceList{
-								corev1.ResourceCPU:    resource.MustParse(node.Spec.CPULimit),
-								corev1.ResourceMemory: resource.MustParse(node.Spec.MemoryLimit),
-							},
+				Containers:      containers,
+				Volumes:         dataVolumes,
+			},
+		},
+		VolumeClaimTemplates: volumeClaimTemplates,
+	}
+
+	if node.Spec.AssumeUTXO != nil {
+		r.specUTXOSnapshot(node, &sts.Spec.Template.Spec, homeDir)
+	}
+
+	shared.SpecResync(node.Spec.DataResync, &sts.Spec.Template.Spec, homeDir)
+	if err := shared.SpecPodExtras(node.Spec.PodExtras, &sts.Spec.Template.Spec); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// specUTXOSnapshot makes UTXO snapshot file available to the node
+// snapshot url is downloaded into node data directory, and config map snapshot is mounted into node home directory
+func (r *NodeReconciler) specUTXOSnapshot(node *bitcoinv1alpha1.Node, pod *corev1.PodSpec, homeDir string) {
+	source := node.Spec.AssumeUTXO
+
+	if source.URL != "" {
+		pod.InitContainers = append(pod.InitContainers, corev1.Container{
+			Name:    "download-utxo-snapshot",
+			Image:   "curlimages/curl:8.00.1",
+			Command: []string{"/bin/sh", "-c", downloadUTXOSnapshotScript},
+			Env: []corev1.EnvVar{
+				{
+					Name:  envUTXOSnapshotURL,
+					Value: source.URL,
+				},
+				{
+					Name:  envUTXOSnapshotPath,
+					Value: utxoSnapshotPath(node, homeDir),
+				},
+			},
+			VolumeMounts: []corev1.VolumeMount{
+				{
+					Name:      "data",
+					MountPath: shared.PathData(homeDir),
+				},
+			},
+		})
+	}
+
+	if source.ConfigMapName != "" {
+		pod.Volumes = append(pod.Volumes, corev1.Volume{
+			Name: utxoSnapshotVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: source.ConfigMapName,
+					},
+					Items: []corev1.KeyToPath{
+						{
+							Key:  utxoSnapshotFile,
+							Path: utxoSnapshotFile,
 						},
 					},
 				},
-				Volumes: []corev1.Volume{
-					{
-						Name: "data",
-						VolumeSource: corev1.VolumeSource{
-							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-								ClaimName: node.Name,
-							},
+			},
+		})
+		pod.Containers[0].VolumeMounts = append(pod.Containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      utxoSnapshotVolumeName,
+			MountPath: filepath.Dir(utxoSnapshotPath(node, homeDir)),
+			ReadOnly:  true,
+		})
+	}
+}
+
+// specExporterContainer returns Bitcoin prometheus exporter sidecar container
+// exporter uses the first rpc user credentials to query node JSON-RPC server
+func (r *NodeReconciler) specExporterContainer(node *bitcoinv1alpha1.Node) corev1.Container {
+	user := node.Spec.RPCUsers[0]
+
+	return corev1.Container{
+		Name:  "exporter",
+		Image: bitcoinv1alpha1.DefaultBitcoinExporterImage,
+		Env: []corev1.EnvVar{
+			{
+				Name:  envExporterRPCHost,
+				Value: "127.0.0.1",
+			},
+			{
+				Name:  envExporterRPCPort,
+				Value: fmt.Sprintf("%d", node.Spec.RPCPort),
+			},
+			{
+				Name:  envExporterRPCUser,
+				Value: user.Username,
+			},
+			{
+				Name: envExporterRPCPassword,
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: user.PasswordSecretName.Name,
 						},
+						Key: user.PasswordSecretName.KeyOr("password"),
 					},
 				},
 			},
+			{
+				Name:  envExporterMetricsAddr,
+				Value: shared.Host(true),
+			},
+			{
+				Name:  envExporterMetricsPort,
+				Value: fmt.Sprintf("%d", node.Spec.Metrics.Port),
+			},
+		},
+		Ports: []corev1.ContainerPort{
+			{
+				Name:          "metrics",
+				ContainerPort: int32(node.Spec.Metrics.Port),
+			},
 		},
 	}
-
-	return nil
 }
 
 func (r *NodeReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	pred := predicate.GenerationChangedPredicate{}
+	if err := shared.IndexReferences(mgr, &bitcoinv1alpha1.Node{}, func(obj client.Object) shared.References {
+		return references(obj.(*bitcoinv1alpha1.Node))
+	}); err != nil {
+		return err
+	}
+
+	// owned objects updates are filtered, referenced objects are watched without predicate so their updates always pass
+	pred := builder.WithPredicates(shared.GenerationOrContentChangedPredicate())
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&bitcoinv1alpha1.Node{}).
-		WithEventFilter(pred).
-		Owns(&corev1.PersistentVolumeClaim{}).
-		Owns(&appsv1.StatefulSet{}).
-		Owns(&corev1.Service{}).
+		For(&bitcoinv1alpha1.Node{}, pred).
+		Owns(&corev1.PersistentVolumeClaim{}, pred).
+		Owns(&appsv1.StatefulSet{}, pred).
+		Owns(&policyv1.PodDisruptionBudget{}, pred).
+		Owns(&networkingv1.NetworkPolicy{}, pred).
+		Owns(&corev1.Service{}, pred).
+		Owns(&corev1.Secret{}, pred).
+		Watches(&corev1.Secret{}, r.EnqueueReferencing(&bitcoinv1alpha1.NodeList{}, shared.IndexSecrets)).
+		Watches(&corev1.ConfigMap{}, r.EnqueueReferencing(&bitcoinv1alpha1.NodeList{}, shared.IndexConfigMaps)).
 		Complete(r)
 }
@@ -100,19 +100,9 @@ var _ = Describe("Bitcoin node controller", func() {
 				MountPath: shared.PathData(client.HomeDir()),
 			},
 		))
-		// volumes
-		Expect(fetched.Spec.Template.Spec.Volumes).To(ContainElements(
-			[]corev1.Volume{
-				{
-					Name: "data",
-					VolumeSource: corev1.VolumeSource{
-						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-							ClaimName: toCreate.Name,
-						},
-					},
-				},
-			},
-		))
+		// data volume claim template
+		Expect(fetched.Spec.VolumeClaimTemplates).To(HaveLen(1))
+		Expect(fetched.Spec.VolumeClaimTemplates[0].Name).To(Equal("data"))
 	})
 
 	It("Should create allocate correct resources to node statefulset", func() {
@@ -133,7 +123,11 @@ var _ = Describe("Bitcoin node controller", func() {
 
 	It("Should create node data persistent volume with correct resources", func() {
 		fetched := &corev1.PersistentVolumeClaim{}
-		Expect(k8sClient.Get(context.Background(), key, fetched)).To(Succeed())
+		pvcKey := types.NamespacedName{
+			Name:      shared.ReplicaPVCName(&metav1.ObjectMeta{Name: key.Name}, 0),
+			Namespace: key.Namespace,
+		}
+		Expect(k8sClient.Get(context.Background(), pvcKey, fetched)).To(Succeed())
 		Expect(fetched.OwnerReferences).To(ContainElements(nodeOwnerReference))
 		expectedResources := corev1.VolumeResourceRequirements{
 			Requests: corev1.ResourceList{
@@ -83,13 +83,36 @@ var _ = BeforeSuite(func() {
 	// start node reconciler
 	nodeReconciler := &NodeReconciler{
 		Reconciler: shared.Reconciler{
-			Client: k8sManager.GetClient(),
-			Scheme: scheme.Scheme,
+			Client:   k8sManager.GetClient(),
+			Scheme:   scheme.Scheme,
+			Recorder: k8sManager.GetEventRecorderFor("kotal-operator"),
 		},
 	}
 	nodeReconciler.SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
+	// start lightning node reconciler
+	lightningNodeReconciler := &LightningNodeReconciler{
+		Reconciler: shared.Reconciler{
+			Client:   k8sManager.GetClient(),
+			Scheme:   scheme.Scheme,
+			Recorder: k8sManager.GetEventRecorderFor("kotal-operator"),
+		},
+	}
+	err = lightningNodeReconciler.SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	// start electrum server reconciler
+	electrumServerReconciler := &ElectrumServerReconciler{
+		Reconciler: shared.Reconciler{
+			Client:   k8sManager.GetClient(),
+			Scheme:   scheme.Scheme,
+			Recorder: k8sManager.GetEventRecorderFor("kotal-operator"),
+		},
+	}
+	err = electrumServerReconciler.SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
 	go func() {
 		err = k8sManager.Start(ctx)
 		Expect(err).ToNot(HaveOccurred())
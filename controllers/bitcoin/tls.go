@@ -0,0 +1,62 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kotalco/kotal/controllers/shared"
+	"github.com/kotalco/kotal/helpers"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// reconcileTLSSecret reconciles self signed TLS certificate secret and returns PEM encoded certificate
+// certificate is generated once for custom resource service hosts, so the operator can verify its servers
+func reconcileTLSSecret(ctx context.Context, r shared.Reconciler, cr shared.CustomResource, name string) (cert []byte, err error) {
+	var generated bool
+
+	secret := &corev1.Secret{}
+	secret.SetName(name)
+
+	err = r.ReconcileOwned(ctx, cr, secret, func(obj client.Object) error {
+		secret := obj.(*corev1.Secret)
+		secret.ObjectMeta.Labels = cr.GetLabels()
+
+		// secret type is immutable
+		if secret.CreationTimestamp.IsZero() {
+			secret.Type = corev1.SecretTypeTLS
+		}
+
+		if len(secret.Data[corev1.TLSCertKey]) == 0 || len(secret.Data[corev1.TLSPrivateKeyKey]) == 0 {
+			hosts := []string{
+				cr.GetName(),
+				fmt.Sprintf("%s.%s", cr.GetName(), cr.GetNamespace()),
+				fmt.Sprintf("%s.%s.svc", cr.GetName(), cr.GetNamespace()),
+				"localhost",
+				"127.0.0.1",
+			}
+			certPEM, keyPEM, err := helpers.GenerateSelfSignedCertificate("kotal", hosts)
+			if err != nil {
+				return err
+			}
+			secret.Data = map[string][]byte{
+				corev1.TLSCertKey:       certPEM,
+				corev1.TLSPrivateKeyKey: keyPEM,
+			}
+			generated = true
+		}
+
+		cert = secret.Data[corev1.TLSCertKey]
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if generated {
+		r.Recorder.Eventf(cr, corev1.EventTypeNormal, shared.EventReasonKeyGenerated, "Generated TLS certificate secret %s", secret.Name)
+	}
+
+	return
+}
@@ -33,13 +33,20 @@ type WebServerTLS struct {
 type EVM struct {
 	ChainID             string
 	LinkContractAddress string
+	FinalityDepth       uint          `toml:",omitzero"`
+	GasEstimator        *GasEstimator `toml:",omitempty"`
 	Nodes               []Node
 }
 
+type GasEstimator struct {
+	Mode string
+}
+
 type Node struct {
-	Name    string
-	WSURL   string
-	HTTPURL string
+	Name     string
+	WSURL    string `toml:",omitempty"`
+	HTTPURL  string
+	SendOnly bool `toml:",omitempty"`
 }
 
 type P2PV2 struct {
@@ -74,18 +81,29 @@ func ConfigFromSpec(node *chainlinkv1alpha1.Node, homeDir string) (config string
 		Level: string(node.Spec.Logging),
 	}
 
-	c.EVM = []EVM{
-		{
-			ChainID:             fmt.Sprintf("%d", node.Spec.EthereumChainId),
-			LinkContractAddress: node.Spec.LinkContractAddress,
-			Nodes: []Node{
-				{
-					Name:    "node",
-					WSURL:   node.Spec.EthereumWSEndpoint,
-					HTTPURL: strings.Join(node.Spec.EthereumHTTPEndpoints, ","),
-				},
-			},
-		},
+	for _, chain := range node.Spec.Chains() {
+		evm := EVM{
+			ChainID:             fmt.Sprintf("%d", chain.ChainID),
+			LinkContractAddress: chain.LinkContractAddress,
+			FinalityDepth:       chain.FinalityDepth,
+		}
+
+		if chain.GasEstimatorMode != "" {
+			evm.GasEstimator = &GasEstimator{
+				Mode: string(chain.GasEstimatorMode),
+			}
+		}
+
+		for _, n := range chain.Nodes {
+			evm.Nodes = append(evm.Nodes, Node{
+				Name:     n.Name,
+				WSURL:    n.WSURL,
+				HTTPURL:  n.HTTPURL,
+				SendOnly: n.SendOnly,
+			})
+		}
+
+		c.EVM = append(c.EVM, evm)
 	}
 
 	c.P2P = P2P{
@@ -144,12 +162,12 @@ func SecretsFromSpec(node *chainlinkv1alpha1.Node, homeDir string, client client
 	}
 
 	key := types.NamespacedName{
-		Name:      node.Spec.KeystorePasswordSecretName,
+		Name:      node.Spec.KeystorePasswordSecretName.Name,
 		Namespace: node.Namespace,
 	}
 
 	var password string
-	if password, err = shared.GetSecret(context.Background(), client, key, "password"); err != nil {
+	if password, err = shared.GetSecret(context.Background(), client, key, node.Spec.KeystorePasswordSecretName.KeyOr("password")); err != nil {
 		return
 	}
 
@@ -0,0 +1,79 @@
+package controllers
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	ethereumv1alpha1 "github.com/kotalco/kotal/apis/ethereum/v1alpha1"
+	ethereum2v1alpha1 "github.com/kotalco/kotal/apis/ethereum2/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// engineNode returns Ethereum node service referenced by beacon node execution engine endpoint
+// it returns false if endpoint isn't a k8s service in the cluster
+func engineNode(beacon *ethereum2v1alpha1.BeaconNode) (types.NamespacedName, bool) {
+	endpoint, err := url.Parse(beacon.Spec.ExecutionEngineEndpoint)
+	if err != nil {
+		return types.NamespacedName{}, false
+	}
+
+	// service host is name, name.namespace or name.namespace.svc[.cluster.domain]
+	labels := strings.Split(endpoint.Hostname(), ".")
+	switch {
+	case labels[0] == "":
+		return types.NamespacedName{}, false
+	case len(labels) == 1:
+		return types.NamespacedName{Name: labels[0], Namespace: beacon.Namespace}, true
+	case len(labels) == 2 || labels[2] == "svc":
+		return types.NamespacedName{Name: labels[0], Namespace: labels[1]}, true
+	}
+
+	return types.NamespacedName{}, false
+}
+
+// enginePeers returns network policy peers of beacon nodes using the node as execution engine
+func (r *NodeReconciler) enginePeers(ctx context.Context, node *ethereumv1alpha1.Node) (peers []networkingv1.NetworkPolicyPeer, err error) {
+	beacons := &ethereum2v1alpha1.BeaconNodeList{}
+	if err = r.Client.List(ctx, beacons); err != nil {
+		return
+	}
+
+	for i := range beacons.Items {
+		beacon := &beacons.Items[i]
+		if name, ok := engineNode(beacon); !ok || name != client.ObjectKeyFromObject(node) {
+			continue
+		}
+
+		peers = append(peers, networkingv1.NetworkPolicyPeer{
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					corev1.LabelMetadataName: beacon.Namespace,
+				},
+			},
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"app.kubernetes.io/component": "ethereum2-beaconnode",
+					"app.kubernetes.io/instance":  beacon.Name,
+				},
+			},
+		})
+	}
+
+	return
+}
+
+// enqueueEngineNode enqueues Ethereum node used by beacon node as execution engine
+func enqueueEngineNode(ctx context.Context, obj client.Object) []reconcile.Request {
+	name, ok := engineNode(obj.(*ethereum2v1alpha1.BeaconNode))
+	if !ok {
+		return nil
+	}
+
+	return []reconcile.Request{{NamespacedName: name}}
+}
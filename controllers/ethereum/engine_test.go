@@ -0,0 +1,36 @@
+package controllers
+
+import (
+	"testing"
+
+	ethereum2v1alpha1 "github.com/kotalco/kotal/apis/ethereum2/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestEngineNode(t *testing.T) {
+	cases := []struct {
+		endpoint string
+		name     types.NamespacedName
+		ok       bool
+	}{
+		{"http://my-node:8551", types.NamespacedName{Name: "my-node", Namespace: "beacons"}, true},
+		{"http://my-node.ethereum:8551", types.NamespacedName{Name: "my-node", Namespace: "ethereum"}, true},
+		{"http://my-node.ethereum.svc:8551", types.NamespacedName{Name: "my-node", Namespace: "ethereum"}, true},
+		{"http://my-node.ethereum.svc.cluster.local:8551", types.NamespacedName{Name: "my-node", Namespace: "ethereum"}, true},
+		{"https://engine.example.com:8551", types.NamespacedName{}, false},
+		{"http://10.0.0.1:8551", types.NamespacedName{}, false},
+		{"", types.NamespacedName{}, false},
+	}
+
+	for _, c := range cases {
+		beacon := &ethereum2v1alpha1.BeaconNode{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-beacon", Namespace: "beacons"},
+			Spec:       ethereum2v1alpha1.BeaconNodeSpec{ExecutionEngineEndpoint: c.endpoint},
+		}
+		name, ok := engineNode(beacon)
+		if ok != c.ok || name != c.name {
+			t.Errorf("%s: expected %v %t, got %v %t", c.endpoint, c.name, c.ok, name, ok)
+		}
+	}
+}
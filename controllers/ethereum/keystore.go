@@ -31,3 +31,18 @@ func KeyStoreFromPrivateKey(key, password string) (content []byte, err error) {
 
 	return
 }
+
+// KeyStoreMatches returns true if key store content decrypts with password into private key (hex without 0x)
+func KeyStoreMatches(content []byte, key, password string) bool {
+	privateKey, err := crypto.HexToECDSA(key)
+	if err != nil {
+		return false
+	}
+
+	decrypted, err := keystore.DecryptKey(content, password)
+	if err != nil {
+		return false
+	}
+
+	return decrypted.PrivateKey.Equal(privateKey)
+}
@@ -0,0 +1,29 @@
+package controllers
+
+import "testing"
+
+func TestKeyStoreMatches(t *testing.T) {
+	key := "5df5eff7ef9e4e82739b68a34c6b23608d79ee8daf3b598a01ffb0dd7aa3a2fd"
+	password := "secret"
+
+	content, err := KeyStoreFromPrivateKey(key, password)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !KeyStoreMatches(content, key, password) {
+		t.Error("expected key store to match the key and password it's generated from")
+	}
+
+	if KeyStoreMatches(content, key, "another-secret") {
+		t.Error("expected key store not to match another password")
+	}
+
+	if KeyStoreMatches(content, "2f2d6ee1e7f8f4f7a3de5bd7e7e1d7b9cb2a3f6f5e1c8d1e5b0c0d5b8e9a7f31", password) {
+		t.Error("expected key store not to match another key")
+	}
+
+	if KeyStoreMatches(nil, key, password) {
+		t.Error("expected missing key store not to match")
+	}
+}
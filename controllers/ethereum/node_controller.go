@@ -5,20 +5,26 @@ import (
 	_ "embed"
 	"fmt"
 	"strings"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
-	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	ethereumv1alpha1 "github.com/kotalco/kotal/apis/ethereum/v1alpha1"
+	ethereum2v1alpha1 "github.com/kotalco/kotal/apis/ethereum2/v1alpha1"
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
 	ethereumClients "github.com/kotalco/kotal/clients/ethereum"
 	"github.com/kotalco/kotal/controllers/shared"
 	"github.com/kotalco/kotal/helpers"
@@ -26,8 +32,7 @@ import (
 
 // NodeReconciler reconciles a Node object
 type NodeReconciler struct {
-	client.Client
-	Scheme *runtime.Scheme
+	shared.Reconciler
 }
 
 const (
@@ -47,6 +52,7 @@ var (
 
 // +kubebuilder:rbac:groups=ethereum.kotal.io,resources=nodes,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=ethereum.kotal.io,resources=nodes/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=ethereum2.kotal.io,resources=beaconnodes,verbs=get;list;watch
 // +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=watch;get;list;create;update;delete
 // +kubebuilder:rbac:groups=core,resources=secrets;services;configmaps;persistentvolumeclaims,verbs=watch;get;create;update;list;delete
 
@@ -55,12 +61,24 @@ func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (resul
 	defer shared.IgnoreConflicts(&err)
 
 	var node ethereumv1alpha1.Node
+	defer r.ObserveReconcile(&node, req.NamespacedName, &err)
 
 	if err = r.Client.Get(ctx, req.NamespacedName, &node); err != nil {
 		err = client.IgnoreNotFound(err)
 		return
 	}
 
+	var deleting bool
+	if deleting, result, err = r.ReconcileDeletion(ctx, &node, node.Spec.Deletion); deleting || err != nil {
+		return
+	}
+
+	// owned objects are left as is while node is paused
+	if shared.IsPaused(&node) {
+		err = r.ReconcilePaused(ctx, &node, &node.Status.Mode)
+		return
+	}
+
 	// default the node if webhooks are disabled
 	if !shared.IsWebhookEnabled() {
 		node.Default()
@@ -83,7 +101,40 @@ func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (resul
 		return
 	}
 
-	if err = r.reconcileStatefulSet(ctx, &node); err != nil {
+	var nodeKeys []map[string]string
+	if nodeKeys, err = r.reconcileNodeKeys(ctx, &node); err != nil {
+		return
+	}
+
+	var sts *appsv1.StatefulSet
+	if sts, err = r.reconcileStatefulSet(ctx, &node); err != nil {
+		return
+	}
+
+	// reconcile pod disruption budget
+	if err = r.ReconcilePodDisruptionBudget(ctx, &node, node.Spec.Disruption, *node.Spec.Replicas); err != nil {
+		return
+	}
+
+	// reconcile network policy
+	// engine port is only accessible by beacon nodes paired with the node by default
+	var enginePeers []networkingv1.NetworkPolicyPeer
+	if enginePeers, err = r.enginePeers(ctx, &node); err != nil {
+		return
+	}
+	defaults := map[string][]networkingv1.NetworkPolicyPeer{
+		"engine": enginePeers,
+	}
+	if err = r.ReconcileNetworkPolicy(ctx, &node, node.Spec.Access, &sts.Spec.Template.Spec, defaults); err != nil {
+		return
+	}
+
+	// reconcile prometheus service monitor
+	endpoint := shared.MetricsEndpoint{Port: "metrics", Path: "/metrics"}
+	if node.Spec.Client == ethereumv1alpha1.GethClient {
+		endpoint.Path = "/debug/metrics/prometheus"
+	}
+	if err = r.ReconcileServiceMonitor(ctx, &node, node.Spec.Metrics, endpoint); err != nil {
 		return
 	}
 
@@ -94,13 +145,82 @@ func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (resul
 
 	enodeURL := fmt.Sprintf("enode://%s@%s:%d", publicKey, ip, node.Spec.P2PPort)
 
-	if err = r.updateStatus(ctx, &node, enodeURL); err != nil {
+	var replicas []sharedAPI.ReplicaStatus
+	if replicas, err = r.replicasStatus(ctx, &node, nodeKeys); err != nil {
 		return
 	}
 
+	// replicas have different node keys, service enode URL is replaced by first replica enode URL
+	if len(replicas) != 0 {
+		enodeURL = replicas[0].EnodeURL
+	}
+
+	if err = r.updateStatus(ctx, &node, enodeURL, replicas); err != nil {
+		return
+	}
+
+	// replica pods haven't been assigned IP addresses yet
+	for _, replica := range replicas {
+		if replica.EnodeURL == "" {
+			return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+		}
+	}
+
 	return ctrl.Result{}, nil
 }
 
+// reconcileNodeKeys reconciles node key of every replica if node has multiple replicas
+// first replica uses node private key if provided
+func (r *NodeReconciler) reconcileNodeKeys(ctx context.Context, node *ethereumv1alpha1.Node) ([]map[string]string, error) {
+	if !shared.HasReplicaKeys(node.Spec.Replicas) {
+		return nil, nil
+	}
+
+	var primary map[string]string
+	if node.Spec.NodePrivateKeySecretName.Name != "" {
+		key := types.NamespacedName{
+			Name:      node.Spec.NodePrivateKeySecretName.Name,
+			Namespace: node.Namespace,
+		}
+
+		nodekey, err := r.GetSecret(ctx, node, key, node.Spec.NodePrivateKeySecretName.KeyOr("key"))
+		if err != nil {
+			return nil, err
+		}
+
+		primary = map[string]string{"nodekey": nodekey}
+	}
+
+	return r.ReconcileNodeKeys(ctx, node, *node.Spec.Replicas, primary, func() (map[string]string, error) {
+		nodekey, err := helpers.GenerateNodePrivateKey()
+		return map[string]string{"nodekey": nodekey}, err
+	})
+}
+
+// replicasStatus returns public key and enode URL of every replica
+// enode URL is empty if replica pod hasn't been assigned an IP address yet
+func (r *NodeReconciler) replicasStatus(ctx context.Context, node *ethereumv1alpha1.Node, nodeKeys []map[string]string) (replicas []sharedAPI.ReplicaStatus, err error) {
+	for ordinal, files := range nodeKeys {
+		var publicKey string
+		if publicKey, err = helpers.DerivePublicKey(files["nodekey"]); err != nil {
+			return
+		}
+
+		replica := sharedAPI.ReplicaStatus{
+			Name: shared.ReplicaName(node, uint(ordinal)),
+			ID:   publicKey,
+		}
+
+		if ip := r.ReplicaPodIP(ctx, node, uint(ordinal)); ip != "" {
+			replica.EnodeURL = fmt.Sprintf("enode://%s@%s:%d", publicKey, ip, node.Spec.P2PPort)
+		}
+
+		replicas = append(replicas, replica)
+	}
+
+	return
+}
+
 // getEnodeURL fetch enodeURL from enode that has the format of node.namespace
 // name is the node name, and namespace is the node namespace
 func (r *NodeReconciler) getEnodeURL(ctx context.Context, enode, ns string) (string, error) {
@@ -139,6 +259,7 @@ func (r *NodeReconciler) updateStaticNodes(ctx context.Context, node *ethereumv1
 				// don't return the error, node maybe not up and running yet
 				node.Spec.StaticNodes = append(node.Spec.StaticNodes[:i], node.Spec.StaticNodes[i+1:]...)
 				log.Error(err, "failed to get static node")
+				r.Recorder.Eventf(node, corev1.EventTypeWarning, shared.EventReasonMissingReference, "Static node %s is not available: %s", enode, err)
 				continue
 			}
 			log.Info("static node enodeURL", string(enode), enodeURL)
@@ -164,6 +285,7 @@ func (r *NodeReconciler) updateBootnodes(ctx context.Context, node *ethereumv1al
 				// don't return the error, node maybe not up and running yet
 				node.Spec.Bootnodes = append(node.Spec.Bootnodes[:i], node.Spec.Bootnodes[i+1:]...)
 				log.Error(err, "failed to get bootnode")
+				r.Recorder.Eventf(node, corev1.EventTypeWarning, shared.EventReasonMissingReference, "Bootnode %s is not available: %s", enode, err)
 				continue
 			}
 			log.Info("bootnode enodeURL", string(enode), enodeURL)
@@ -178,11 +300,26 @@ func (r *NodeReconciler) updateBootnodes(ctx context.Context, node *ethereumv1al
 	}
 }
 
+// references returns secrets and config maps referenced by Ethereum node
+func references(node *ethereumv1alpha1.Node) shared.References {
+	refs := shared.PodExtrasReferences(node.Spec.PodExtras)
+	// keys loaded from secret store aren't kubernetes secrets
+	if node.Spec.SecretStore != nil {
+		return refs
+	}
+	refs.Add(node.Spec.NodePrivateKeySecretName.Name, node.Spec.JWTSecretName.Name)
+	if node.Spec.Import != nil {
+		refs.Add(node.Spec.Import.PrivateKeySecretName.Name, node.Spec.Import.PasswordSecretName.Name)
+	}
+	return refs
+}
+
 // updateStatus updates network status
-func (r *NodeReconciler) updateStatus(ctx context.Context, node *ethereumv1alpha1.Node, enodeURL string) error {
+func (r *NodeReconciler) updateStatus(ctx context.Context, node *ethereumv1alpha1.Node, enodeURL string, replicas []sharedAPI.ReplicaStatus) error {
 	var consensus, network string
 
 	log := log.FromContext(ctx)
+	previous := node.Status
 
 	if node.Spec.Genesis == nil {
 		switch node.Spec.Network {
@@ -212,7 +349,8 @@ func (r *NodeReconciler) updateStatus(ctx context.Context, node *ethereumv1alpha
 
 	node.Status.Network = network
 
-	if node.Spec.NodePrivateKeySecretName == "" {
+	// node public key is unknown if node private key is loaded from secret store
+	if (node.Spec.NodePrivateKeySecretName.Name == "" || node.Spec.SecretStore != nil) && len(replicas) == 0 {
 		switch node.Spec.Client {
 		case ethereumv1alpha1.BesuClient:
 			enodeURL = "call net_enode JSON-RPC method"
@@ -224,12 +362,20 @@ func (r *NodeReconciler) updateStatus(ctx context.Context, node *ethereumv1alpha
 	}
 
 	node.Status.EnodeURL = enodeURL
+	node.Status.Replicas = replicas
+
+	node.Status.Mode = shared.Mode(node, node.Spec.Suspend)
+	if err := r.UpdateResyncStatus(ctx, node, &node.Status.ResyncStatus, node.Spec.DataResync); err != nil {
+		return err
+	}
 
 	if err := r.Status().Update(ctx, node); err != nil {
 		log.Error(err, "unable to update node status")
 		return err
 	}
 
+	r.RecordStatusTransition(node, previous, node.Status)
+
 	return nil
 }
 
@@ -349,25 +495,11 @@ func (r *NodeReconciler) specPVC(node *ethereumv1alpha1.Node, pvc *corev1.Persis
 	}
 }
 
-// reconcilePVC creates node data pvc if it doesn't exist
+// reconcilePVC creates node data pvcs if they don't exist, update them if they exist
 func (r *NodeReconciler) reconcilePVC(ctx context.Context, node *ethereumv1alpha1.Node) error {
-
-	pvc := &corev1.PersistentVolumeClaim{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      node.Name,
-			Namespace: node.Namespace,
-		},
-	}
-
-	_, err := ctrl.CreateOrUpdate(ctx, r.Client, pvc, func() error {
-		if err := ctrl.SetControllerReference(node, pvc, r.Scheme); err != nil {
-			return err
-		}
+	return r.ReconcilePVCs(ctx, node, *node.Spec.Replicas, func(pvc *corev1.PersistentVolumeClaim) {
 		r.specPVC(node, pvc)
-		return nil
 	})
-
-	return err
 }
 
 // createNodeVolumes creates all the required volumes for the node
@@ -377,15 +509,15 @@ func (r *NodeReconciler) createNodeVolumes(node *ethereumv1alpha1.Node) []corev1
 	projections := []corev1.VolumeProjection{}
 
 	// authenticated APIs jwt secret
-	if node.Spec.JWTSecretName != "" {
+	if node.Spec.JWTSecretName.Name != "" {
 		jwtSecretProjection := corev1.VolumeProjection{
 			Secret: &corev1.SecretProjection{
 				LocalObjectReference: corev1.LocalObjectReference{
-					Name: node.Spec.JWTSecretName,
+					Name: node.Spec.JWTSecretName.Name,
 				},
 				Items: []corev1.KeyToPath{
 					{
-						Key:  "secret",
+						Key:  node.Spec.JWTSecretName.KeyOr("secret"),
 						Path: "jwt.secret",
 					},
 				},
@@ -395,15 +527,15 @@ func (r *NodeReconciler) createNodeVolumes(node *ethereumv1alpha1.Node) []corev1
 	}
 
 	// nodekey (node private key) projection
-	if node.Spec.NodePrivateKeySecretName != "" {
+	if node.Spec.NodePrivateKeySecretName.Name != "" {
 		nodekeyProjection := corev1.VolumeProjection{
 			Secret: &corev1.SecretProjection{
 				LocalObjectReference: corev1.LocalObjectReference{
-					Name: node.Spec.NodePrivateKeySecretName,
+					Name: node.Spec.NodePrivateKeySecretName.Name,
 				},
 				Items: []corev1.KeyToPath{
 					{
-						Key:  "key",
+						Key:  node.Spec.NodePrivateKeySecretName.KeyOr("key"),
 						Path: "nodekey",
 					},
 				},
@@ -418,11 +550,11 @@ func (r *NodeReconciler) createNodeVolumes(node *ethereumv1alpha1.Node) []corev1
 		privateKeyProjection := corev1.VolumeProjection{
 			Secret: &corev1.SecretProjection{
 				LocalObjectReference: corev1.LocalObjectReference{
-					Name: node.Spec.Import.PrivateKeySecretName,
+					Name: node.Spec.Import.PrivateKeySecretName.Name,
 				},
 				Items: []corev1.KeyToPath{
 					{
-						Key:  "key",
+						Key:  node.Spec.Import.PrivateKeySecretName.KeyOr("key"),
 						Path: "account.key",
 					},
 				},
@@ -434,11 +566,11 @@ func (r *NodeReconciler) createNodeVolumes(node *ethereumv1alpha1.Node) []corev1
 		passwordProjection := corev1.VolumeProjection{
 			Secret: &corev1.SecretProjection{
 				LocalObjectReference: corev1.LocalObjectReference{
-					Name: node.Spec.Import.PasswordSecretName,
+					Name: node.Spec.Import.PasswordSecretName.Name,
 				},
 				Items: []corev1.KeyToPath{
 					{
-						Key:  "password",
+						Key:  node.Spec.Import.PasswordSecretName.KeyOr("password"),
 						Path: "account.password",
 					},
 				},
@@ -459,6 +591,11 @@ func (r *NodeReconciler) createNodeVolumes(node *ethereumv1alpha1.Node) []corev1
 		}
 	}
 
+	// replicas node keys
+	if shared.HasReplicaKeys(node.Spec.Replicas) {
+		volumes = append(volumes, shared.NodeKeysVolume(node, *node.Spec.Replicas, "nodekey"))
+	}
+
 	if len(projections) != 0 {
 		secretsVolume := corev1.Volume{
 			Name: "secrets",
@@ -483,16 +620,6 @@ func (r *NodeReconciler) createNodeVolumes(node *ethereumv1alpha1.Node) []corev1
 	}
 	volumes = append(volumes, configVolume)
 
-	dataVolume := corev1.Volume{
-		Name: "data",
-		VolumeSource: corev1.VolumeSource{
-			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-				ClaimName: node.Name,
-			},
-		},
-	}
-	volumes = append(volumes, dataVolume)
-
 	return volumes
 }
 
@@ -501,7 +628,7 @@ func (r *NodeReconciler) createNodeVolumeMounts(node *ethereumv1alpha1.Node, hom
 
 	volumeMounts := []corev1.VolumeMount{}
 
-	if node.Spec.NodePrivateKeySecretName != "" || node.Spec.Import != nil || node.Spec.JWTSecretName != "" {
+	if node.Spec.NodePrivateKeySecretName.Name != "" || node.Spec.Import != nil || node.Spec.JWTSecretName.Name != "" {
 		secretsMount := corev1.VolumeMount{
 			Name:      "secrets",
 			MountPath: shared.PathSecrets(homedir),
@@ -510,6 +637,10 @@ func (r *NodeReconciler) createNodeVolumeMounts(node *ethereumv1alpha1.Node, hom
 		volumeMounts = append(volumeMounts, secretsMount)
 	}
 
+	if shared.HasReplicaKeys(node.Spec.Replicas) {
+		volumeMounts = append(volumeMounts, shared.NodeKeysVolumeMount(homedir))
+	}
+
 	configMount := corev1.VolumeMount{
 		Name:      "config",
 		MountPath: shared.PathConfig(homedir),
@@ -527,7 +658,7 @@ func (r *NodeReconciler) createNodeVolumeMounts(node *ethereumv1alpha1.Node, hom
 }
 
 // specStatefulset updates node statefulset spec
-func (r *NodeReconciler) specStatefulset(node *ethereumv1alpha1.Node, sts *appsv1.StatefulSet, homedir string, args []string, volumes []corev1.Volume, volumeMounts []corev1.VolumeMount) {
+func (r *NodeReconciler) specStatefulset(node *ethereumv1alpha1.Node, sts *appsv1.StatefulSet, homedir string, args []string, volumes []corev1.Volume, volumeMounts []corev1.VolumeMount) error {
 	labels := node.GetLabels()
 	// used by geth to init genesis and import account(s)
 	initContainers := []corev1.Container{}
@@ -545,6 +676,13 @@ func (r *NodeReconciler) specStatefulset(node *ethereumv1alpha1.Node, sts *appsv
 		},
 	}
 
+	if node.Spec.Metrics.Enabled {
+		ports = append(ports, corev1.ContainerPort{
+			Name:          "metrics",
+			ContainerPort: int32(node.Spec.Metrics.Port),
+		})
+	}
+
 	if node.Spec.RPC {
 		ports = append(ports, corev1.ContainerPort{
 			Name:          "rpc",
@@ -639,7 +777,11 @@ func (r *NodeReconciler) specStatefulset(node *ethereumv1alpha1.Node, sts *appsv
 		}
 
 	} else if node.Spec.Client == ethereumv1alpha1.NethermindClient {
-		if node.Spec.NodePrivateKeySecretName != "" {
+		if node.Spec.NodePrivateKeySecretName.Name != "" || shared.HasReplicaKeys(node.Spec.Replicas) {
+			secretsPath := shared.PathSecrets(homedir)
+			if shared.HasReplicaKeys(node.Spec.Replicas) {
+				secretsPath = shared.PathNodeKeys(homedir)
+			}
 			convertEnodePrivateKey := corev1.Container{
 				Name:  "convert-enode-privatekey",
 				Image: shared.BusyboxImage,
@@ -650,7 +792,7 @@ func (r *NodeReconciler) specStatefulset(node *ethereumv1alpha1.Node, sts *appsv
 					},
 					{
 						Name:  shared.EnvSecretsPath,
-						Value: shared.PathSecrets(homedir),
+						Value: secretsPath,
 					},
 				},
 				Command:      []string{"/bin/sh"},
@@ -686,27 +828,48 @@ func (r *NodeReconciler) specStatefulset(node *ethereumv1alpha1.Node, sts *appsv
 		}
 	}
 
+	// replica node keys volume is mounted by pod name
+	if shared.HasReplicaKeys(node.Spec.Replicas) {
+		nodeContainer.Env = append(nodeContainer.Env, shared.PodNameEnv())
+		for i := range initContainers {
+			initContainers[i].Env = append(initContainers[i].Env, shared.PodNameEnv())
+		}
+	}
+
 	sts.ObjectMeta.Labels = labels
 	if sts.Spec.Selector == nil {
 		sts.Spec.Selector = &metav1.LabelSelector{}
 	}
 
-	replicas := int32(*node.Spec.Replicas)
+	replicas := shared.StatefulSetReplicas(*node.Spec.Replicas, node.Spec.Suspend)
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	r.specPVC(node, pvc)
+	dataVolumes, volumeClaimTemplates := shared.SpecDataStorage(node, sts, pvc)
 
 	sts.Spec.Replicas = &replicas
 	sts.Spec.ServiceName = node.Name
 	sts.Spec.Selector.MatchLabels = labels
 	sts.Spec.Template.ObjectMeta.Labels = labels
+	sts.Spec.VolumeClaimTemplates = volumeClaimTemplates
 	sts.Spec.Template.Spec = corev1.PodSpec{
 		SecurityContext: shared.SecurityContext(),
-		Volumes:         volumes,
+		Volumes:         append(dataVolumes, volumes...),
 		InitContainers:  initContainers,
 		Containers:      []corev1.Container{nodeContainer},
 	}
+
+	shared.SpecResync(node.Spec.DataResync, &sts.Spec.Template.Spec, homedir)
+	if err := shared.SpecPodExtras(node.Spec.PodExtras, &sts.Spec.Template.Spec); err != nil {
+		return err
+	}
+	shared.SpecSecretStore(node.Spec.SecretStore, &sts.Spec.Template, "secrets")
+
+	return nil
 }
 
 // reconcileStatefulSet creates node statefulset if it doesn't exist, update it if it does exist
-func (r *NodeReconciler) reconcileStatefulSet(ctx context.Context, node *ethereumv1alpha1.Node) error {
+func (r *NodeReconciler) reconcileStatefulSet(ctx context.Context, node *ethereumv1alpha1.Node) (*appsv1.StatefulSet, error) {
 
 	sts := &appsv1.StatefulSet{
 		ObjectMeta: metav1.ObjectMeta{
@@ -717,11 +880,11 @@ func (r *NodeReconciler) reconcileStatefulSet(ctx context.Context, node *ethereu
 
 	client, err := ethereumClients.NewClient(node)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	homedir := client.HomeDir()
 	args := client.Args()
-	args = append(args, node.Spec.ExtraArgs.Encode(false)...)
+	args = node.Spec.ExtraArgs.Merge(args, node.Spec.RemoveArgs, false)
 	volumes := r.createNodeVolumes(node)
 	mounts := r.createNodeVolumeMounts(node, homedir)
 
@@ -729,11 +892,14 @@ func (r *NodeReconciler) reconcileStatefulSet(ctx context.Context, node *ethereu
 		if err := ctrl.SetControllerReference(node, sts, r.Scheme); err != nil {
 			return err
 		}
-		r.specStatefulset(node, sts, homedir, args, volumes, mounts)
-		return nil
+		if err := r.specStatefulset(node, sts, homedir, args, volumes, mounts); err != nil {
+			return err
+		}
+		shared.SpecShutdown(node.Spec.Shutdown, client, &sts.Spec.Template.Spec)
+		return r.SpecContentHash(ctx, node, sts, references(node))
 	})
 
-	return err
+	return sts, err
 }
 
 // specSecret creates keystore from account private key for nethermind client
@@ -742,25 +908,30 @@ func (r *NodeReconciler) specSecret(ctx context.Context, node *ethereumv1alpha1.
 
 	if node.Spec.Import != nil && node.Spec.Client == ethereumv1alpha1.NethermindClient {
 		key := types.NamespacedName{
-			Name:      node.Spec.Import.PrivateKeySecretName,
+			Name:      node.Spec.Import.PrivateKeySecretName.Name,
 			Namespace: node.Namespace,
 		}
 
-		privateKey, err := shared.GetSecret(ctx, r.Client, key, "key")
+		privateKey, err := r.GetSecret(ctx, node, key, node.Spec.Import.PrivateKeySecretName.KeyOr("key"))
 		if err != nil {
 			return err
 		}
 
 		key = types.NamespacedName{
-			Name:      node.Spec.Import.PasswordSecretName,
+			Name:      node.Spec.Import.PasswordSecretName.Name,
 			Namespace: node.Namespace,
 		}
 
-		password, err := shared.GetSecret(ctx, r.Client, key, "password")
+		password, err := r.GetSecret(ctx, node, key, node.Spec.Import.PasswordSecretName.KeyOr("password"))
 		if err != nil {
 			return err
 		}
 
+		// key store is encrypted with random salt, it's generated again only if key or password changed
+		if KeyStoreMatches(secret.Data["account"], privateKey, password) {
+			return nil
+		}
+
 		account, err := KeyStoreFromPrivateKey(privateKey, password)
 		if err != nil {
 			return err
@@ -787,14 +958,15 @@ func (r *NodeReconciler) reconcileSecret(ctx context.Context, node *ethereumv1al
 	// pubkey is required by the caller
 	// 1. read the private key secret content
 	// 2. derive public key from the private key
-	if node.Spec.NodePrivateKeySecretName != "" {
+	// node private key loaded from secret store can't be read
+	if node.Spec.NodePrivateKeySecretName.Name != "" && node.Spec.SecretStore == nil {
 		key := types.NamespacedName{
-			Name:      node.Spec.NodePrivateKeySecretName,
+			Name:      node.Spec.NodePrivateKeySecretName.Name,
 			Namespace: node.Namespace,
 		}
 
 		var nodekey string
-		nodekey, err = shared.GetSecret(ctx, r.Client, key, "key")
+		nodekey, err = r.GetSecret(ctx, node, key, node.Spec.NodePrivateKeySecretName.KeyOr("key"))
 		if err != nil {
 			return
 		}
@@ -806,7 +978,8 @@ func (r *NodeReconciler) reconcileSecret(ctx context.Context, node *ethereumv1al
 		}
 	}
 
-	_, err = ctrl.CreateOrUpdate(ctx, r.Client, secret, func() error {
+	var op controllerutil.OperationResult
+	op, err = ctrl.CreateOrUpdate(ctx, r.Client, secret, func() error {
 		if err := ctrl.SetControllerReference(node, secret, r.Scheme); err != nil {
 			return err
 		}
@@ -814,6 +987,10 @@ func (r *NodeReconciler) reconcileSecret(ctx context.Context, node *ethereumv1al
 		return r.specSecret(ctx, node, secret)
 	})
 
+	if err == nil && op != controllerutil.OperationResultNone && len(secret.Data["account"]) != 0 {
+		r.Recorder.Event(node, corev1.EventTypeNormal, shared.EventReasonKeyGenerated, "Generated account keystore from imported private key")
+	}
+
 	return
 }
 
@@ -868,6 +1045,14 @@ func (r *NodeReconciler) specService(node *ethereumv1alpha1.Node, svc *corev1.Se
 		})
 	}
 
+	if node.Spec.Metrics.Enabled {
+		svc.Spec.Ports = append(svc.Spec.Ports, corev1.ServicePort{
+			Name:       "metrics",
+			Port:       int32(node.Spec.Metrics.Port),
+			TargetPort: intstr.FromString("metrics"),
+		})
+	}
+
 	svc.Spec.Selector = labels
 }
 
@@ -902,14 +1087,25 @@ func (r *NodeReconciler) reconcileService(ctx context.Context, node *ethereumv1a
 
 // SetupWithManager adds reconciler to the manager
 func (r *NodeReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	pred := predicate.GenerationChangedPredicate{}
+	if err := shared.IndexReferences(mgr, &ethereumv1alpha1.Node{}, func(obj client.Object) shared.References {
+		return references(obj.(*ethereumv1alpha1.Node))
+	}); err != nil {
+		return err
+	}
+
+	// owned objects updates are filtered, referenced objects are watched without predicate so their updates always pass
+	pred := builder.WithPredicates(shared.GenerationOrContentChangedPredicate())
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&ethereumv1alpha1.Node{}).
-		WithEventFilter(pred).
-		Owns(&appsv1.StatefulSet{}).
-		Owns(&corev1.Service{}).
-		Owns(&corev1.Secret{}).
-		Owns(&corev1.PersistentVolumeClaim{}).
-		Owns(&corev1.ConfigMap{}).
+		For(&ethereumv1alpha1.Node{}, pred).
+		Owns(&appsv1.StatefulSet{}, pred).
+		Owns(&policyv1.PodDisruptionBudget{}, pred).
+		Owns(&networkingv1.NetworkPolicy{}, pred).
+		Owns(&corev1.Service{}, pred).
+		Owns(&corev1.Secret{}, pred).
+		Owns(&corev1.PersistentVolumeClaim{}, pred).
+		Owns(&corev1.ConfigMap{}, pred).
+		Watches(&corev1.Secret{}, r.EnqueueReferencing(&ethereumv1alpha1.NodeList{}, shared.IndexSecrets)).
+		Watches(&corev1.ConfigMap{}, r.EnqueueReferencing(&ethereumv1alpha1.NodeList{}, shared.IndexConfigMaps)).
+		Watches(&ethereum2v1alpha1.BeaconNode{}, handler.EnqueueRequestsFromMapFunc(enqueueEngineNode), pred).
 		Complete(r)
 }
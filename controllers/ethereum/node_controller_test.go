@@ -53,7 +53,7 @@ var _ = Describe("Ethereum network controller", func() {
 		spec := ethereumv1alpha1.NodeSpec{
 			Client:                   ethereumv1alpha1.BesuClient,
 			Network:                  "mainnet",
-			NodePrivateKeySecretName: "nodekey",
+			NodePrivateKeySecretName: sharedAPI.SecretKeySelector{Name: "nodekey"},
 			SyncMode:                 ethereumv1alpha1.FullSynchronization,
 			Logging:                  sharedAPI.NoLogs,
 		}
@@ -170,7 +170,11 @@ var _ = Describe("Ethereum network controller", func() {
 					corev1.ResourceStorage: resource.MustParse(ethereumv1alpha1.DefaultMainNetworkFullNodeStorageRequest),
 				},
 			}
-			Expect(k8sClient.Get(context.Background(), key, pvc)).To(Succeed())
+			pvcKey := types.NamespacedName{
+				Name:      shared.ReplicaPVCName(&metav1.ObjectMeta{Name: key.Name}, 0),
+				Namespace: key.Namespace,
+			}
+			Expect(k8sClient.Get(context.Background(), pvcKey, pvc)).To(Succeed())
 			Expect(pvc.GetOwnerReferences()).To(ContainElement(nodeOwnerReference))
 			Expect(pvc.Spec.Resources).To(Equal(expectedResources))
 		})
@@ -218,7 +222,7 @@ var _ = Describe("Ethereum network controller", func() {
 		spec := ethereumv1alpha1.NodeSpec{
 			Client:                   ethereumv1alpha1.BesuClient,
 			Network:                  ethereumv1alpha1.GoerliNetwork,
-			NodePrivateKeySecretName: "nodekey",
+			NodePrivateKeySecretName: sharedAPI.SecretKeySelector{Name: "nodekey"},
 			Logging:                  sharedAPI.FatalLogs,
 		}
 
@@ -356,7 +360,11 @@ var _ = Describe("Ethereum network controller", func() {
 					corev1.ResourceStorage: resource.MustParse(ethereumv1alpha1.DefaultTestNetworkStorageRequest),
 				},
 			}
-			Expect(k8sClient.Get(context.Background(), key, nodePVC)).To(Succeed())
+			pvcKey := types.NamespacedName{
+				Name:      shared.ReplicaPVCName(&metav1.ObjectMeta{Name: key.Name}, 0),
+				Namespace: key.Namespace,
+			}
+			Expect(k8sClient.Get(context.Background(), pvcKey, nodePVC)).To(Succeed())
 			Expect(nodePVC.GetOwnerReferences()).To(ContainElement(nodeOwnerReference))
 			Expect(nodePVC.Spec.Resources).To(Equal(expectedResources))
 		})
@@ -418,7 +426,7 @@ var _ = Describe("Ethereum network controller", func() {
 				},
 			},
 			Client:                   ethereumv1alpha1.BesuClient,
-			NodePrivateKeySecretName: "nodekey",
+			NodePrivateKeySecretName: sharedAPI.SecretKeySelector{Name: "nodekey"},
 		}
 
 		toCreate := &ethereumv1alpha1.Node{
@@ -557,7 +565,11 @@ var _ = Describe("Ethereum network controller", func() {
 					corev1.ResourceStorage: resource.MustParse(ethereumv1alpha1.DefaultPrivateNetworkNodeStorageRequest),
 				},
 			}
-			Expect(k8sClient.Get(context.Background(), key, nodePVC)).To(Succeed())
+			pvcKey := types.NamespacedName{
+				Name:      shared.ReplicaPVCName(&metav1.ObjectMeta{Name: key.Name}, 0),
+				Namespace: key.Namespace,
+			}
+			Expect(k8sClient.Get(context.Background(), pvcKey, nodePVC)).To(Succeed())
 			Expect(nodePVC.GetOwnerReferences()).To(ContainElement(nodeOwnerReference))
 			Expect(nodePVC.Spec.Resources).To(Equal(expectedResources))
 		})
@@ -618,7 +630,7 @@ var _ = Describe("Ethereum network controller", func() {
 				Ethash:    &ethereumv1alpha1.Ethash{},
 			},
 			Client:                   ethereumv1alpha1.BesuClient,
-			NodePrivateKeySecretName: "nodekey",
+			NodePrivateKeySecretName: sharedAPI.SecretKeySelector{Name: "nodekey"},
 			Logging:                  sharedAPI.TraceLogs,
 		}
 
@@ -757,7 +769,11 @@ var _ = Describe("Ethereum network controller", func() {
 					corev1.ResourceStorage: resource.MustParse(ethereumv1alpha1.DefaultPrivateNetworkNodeStorageRequest),
 				},
 			}
-			Expect(k8sClient.Get(context.Background(), key, pvc)).To(Succeed())
+			pvcKey := types.NamespacedName{
+				Name:      shared.ReplicaPVCName(&metav1.ObjectMeta{Name: key.Name}, 0),
+				Namespace: key.Namespace,
+			}
+			Expect(k8sClient.Get(context.Background(), pvcKey, pvc)).To(Succeed())
 			Expect(pvc.GetOwnerReferences()).To(ContainElement(nodeOwnerReference))
 			Expect(pvc.Spec.Resources).To(Equal(expectedResources))
 		})
@@ -824,7 +840,7 @@ var _ = Describe("Ethereum network controller", func() {
 				},
 			},
 			Client:                   ethereumv1alpha1.BesuClient,
-			NodePrivateKeySecretName: "nodekey",
+			NodePrivateKeySecretName: sharedAPI.SecretKeySelector{Name: "nodekey"},
 			Logging:                  sharedAPI.WarnLogs,
 		}
 
@@ -941,7 +957,11 @@ var _ = Describe("Ethereum network controller", func() {
 					corev1.ResourceStorage: resource.MustParse(ethereumv1alpha1.DefaultPrivateNetworkNodeStorageRequest),
 				},
 			}
-			Expect(k8sClient.Get(context.Background(), key, nodePVC)).To(Succeed())
+			pvcKey := types.NamespacedName{
+				Name:      shared.ReplicaPVCName(&metav1.ObjectMeta{Name: key.Name}, 0),
+				Namespace: key.Namespace,
+			}
+			Expect(k8sClient.Get(context.Background(), pvcKey, nodePVC)).To(Succeed())
 			Expect(nodePVC.GetOwnerReferences()).To(ContainElement(nodeOwnerReference))
 			Expect(nodePVC.Spec.Resources).To(Equal(expectedResources))
 		})
@@ -18,6 +18,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
 	ethereumv1alpha1 "github.com/kotalco/kotal/apis/ethereum/v1alpha1"
+	ethereum2v1alpha1 "github.com/kotalco/kotal/apis/ethereum2/v1alpha1"
 	"github.com/kotalco/kotal/controllers/shared"
 	// +kubebuilder:scaffold:imports
 )
@@ -69,6 +70,9 @@ var _ = BeforeSuite(func() {
 	err = ethereumv1alpha1.AddToScheme(scheme.Scheme)
 	Expect(err).NotTo(HaveOccurred())
 
+	err = ethereum2v1alpha1.AddToScheme(scheme.Scheme)
+	Expect(err).NotTo(HaveOccurred())
+
 	// +kubebuilder:scaffold:scheme
 
 	// create new controller manager
@@ -85,8 +89,11 @@ var _ = BeforeSuite(func() {
 
 	// start node reconciler
 	nodeReconciler = &NodeReconciler{
-		Client: k8sManager.GetClient(),
-		Scheme: scheme.Scheme,
+		Reconciler: shared.Reconciler{
+			Client:   k8sManager.GetClient(),
+			Scheme:   scheme.Scheme,
+			Recorder: k8sManager.GetEventRecorderFor("kotal-operator"),
+		},
 	}
 	nodeReconciler.SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
@@ -6,11 +6,14 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	ethereum2v1alpha1 "github.com/kotalco/kotal/apis/ethereum2/v1alpha1"
 	ethereum2Clients "github.com/kotalco/kotal/clients/ethereum2"
@@ -32,12 +35,24 @@ func (r *BeaconNodeReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	defer shared.IgnoreConflicts(&err)
 
 	var node ethereum2v1alpha1.BeaconNode
+	defer r.ObserveReconcile(&node, req.NamespacedName, &err)
 
 	if err = r.Client.Get(ctx, req.NamespacedName, &node); err != nil {
 		err = client.IgnoreNotFound(err)
 		return
 	}
 
+	var deleting bool
+	if deleting, result, err = r.ReconcileDeletion(ctx, &node, node.Spec.Deletion); deleting || err != nil {
+		return
+	}
+
+	// owned objects are left as is while node is paused
+	if shared.IsPaused(&node) {
+		err = r.ReconcilePaused(ctx, &node, &node.Status.Mode)
+		return
+	}
+
 	// default the beacon node if webhooks are disabled
 	if !shared.IsWebhookEnabled() {
 		node.Default()
@@ -46,9 +61,8 @@ func (r *BeaconNodeReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	shared.UpdateLabels(&node, string(node.Spec.Client), node.Spec.Network)
 
 	// reconcile persistent volume clain
-	if err = r.ReconcileOwned(ctx, &node, &corev1.PersistentVolumeClaim{}, func(obj client.Object) error {
-		r.specPVC(&node, obj.(*corev1.PersistentVolumeClaim))
-		return nil
+	if err = r.ReconcilePVCs(ctx, &node, *node.Spec.Replicas, func(pvc *corev1.PersistentVolumeClaim) {
+		r.specPVC(&node, pvc)
 	}); err != nil {
 		return
 	}
@@ -62,7 +76,8 @@ func (r *BeaconNodeReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	}
 
 	// reconcile service
-	if err = r.ReconcileOwned(ctx, &node, &appsv1.StatefulSet{}, func(obj client.Object) error {
+	sts := &appsv1.StatefulSet{}
+	if err = r.ReconcileOwned(ctx, &node, sts, func(obj client.Object) error {
 		client, err := ethereum2Clients.NewClient(&node)
 		if err != nil {
 			return err
@@ -71,19 +86,62 @@ func (r *BeaconNodeReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		args := client.Args()
 		// encode extra arguments as key=value only if client is numbus
 		kv := node.Spec.Client == ethereum2v1alpha1.NimbusClient
-		args = append(args, node.Spec.ExtraArgs.Encode(kv)...)
+		args = node.Spec.ExtraArgs.Merge(args, node.Spec.RemoveArgs, kv)
 		command := client.Command()
 		homeDir := client.HomeDir()
 
-		r.specStatefulset(&node, obj.(*appsv1.StatefulSet), args, command, homeDir)
-		return nil
+		sts := obj.(*appsv1.StatefulSet)
+		if err := r.specStatefulset(&node, sts, args, command, homeDir); err != nil {
+			return err
+		}
+		shared.SpecShutdown(node.Spec.Shutdown, client, &sts.Spec.Template.Spec)
+		return r.SpecContentHash(ctx, &node, sts, beaconNodeReferences(&node))
 	}); err != nil {
 		return
 	}
 
+	// reconcile pod disruption budget
+	if err = r.ReconcilePodDisruptionBudget(ctx, &node, node.Spec.Disruption, *node.Spec.Replicas); err != nil {
+		return
+	}
+
+	// reconcile network policy
+	if err = r.ReconcileNetworkPolicy(ctx, &node, node.Spec.Access, &sts.Spec.Template.Spec, nil); err != nil {
+		return
+	}
+
+	// reconcile prometheus service monitor
+	endpoint := shared.MetricsEndpoint{Port: "metrics", Path: "/metrics"}
+	if err = r.ReconcileServiceMonitor(ctx, &node, node.Spec.Metrics, endpoint); err != nil {
+		return
+	}
+
+	if err = r.updateStatus(ctx, &node); err != nil {
+		return
+	}
+
 	return
 }
 
+// updateStatus updates Ethereum 2.0 beacon node status
+func (r *BeaconNodeReconciler) updateStatus(ctx context.Context, node *ethereum2v1alpha1.BeaconNode) error {
+	previous := node.Status
+
+	node.Status.Mode = shared.Mode(node, node.Spec.Suspend)
+	if err := r.UpdateResyncStatus(ctx, node, &node.Status.ResyncStatus, node.Spec.DataResync); err != nil {
+		return err
+	}
+
+	if err := r.Status().Update(ctx, node); err != nil {
+		log.FromContext(ctx).Error(err, "unable to update beacon node status")
+		return err
+	}
+
+	r.RecordStatusTransition(node, previous, node.Status)
+
+	return nil
+}
+
 func (r *BeaconNodeReconciler) specService(node *ethereum2v1alpha1.BeaconNode, svc *corev1.Service) {
 	labels := node.GetLabels()
 
@@ -126,9 +184,28 @@ func (r *BeaconNodeReconciler) specService(node *ethereum2v1alpha1.BeaconNode, s
 		})
 	}
 
+	if node.Spec.Metrics.Enabled {
+		svc.Spec.Ports = append(svc.Spec.Ports, corev1.ServicePort{
+			Name:       "metrics",
+			Port:       int32(node.Spec.Metrics.Port),
+			TargetPort: intstr.FromString("metrics"),
+		})
+	}
+
 	svc.Spec.Selector = labels
 }
 
+// beaconNodeReferences returns secrets and config maps referenced by beacon node
+func beaconNodeReferences(node *ethereum2v1alpha1.BeaconNode) shared.References {
+	refs := shared.PodExtrasReferences(node.Spec.PodExtras)
+	refs.Add(node.Spec.CertSecretName)
+	// jwt secret loaded from secret store isn't kubernetes secret
+	if node.Spec.SecretStore == nil {
+		refs.Add(node.Spec.JWTSecretName.Name)
+	}
+	return refs
+}
+
 // specPVC updates beacon node persistent volume claim spec
 func (r *BeaconNodeReconciler) specPVC(node *ethereum2v1alpha1.BeaconNode, pvc *corev1.PersistentVolumeClaim) {
 
@@ -157,26 +234,16 @@ func (r *BeaconNodeReconciler) specPVC(node *ethereum2v1alpha1.BeaconNode, pvc *
 
 // nodeVolumes returns node volumes
 func (r *BeaconNodeReconciler) nodeVolumes(node *ethereum2v1alpha1.BeaconNode) (volumes []corev1.Volume) {
-	dataVolume := corev1.Volume{
-		Name: "data",
-		VolumeSource: corev1.VolumeSource{
-			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-				ClaimName: node.Name,
-			},
-		},
-	}
-	volumes = append(volumes, dataVolume)
-
 	// projected volume sources
 	volumeProjections := []corev1.VolumeProjection{
 		{
 			Secret: &corev1.SecretProjection{
 				LocalObjectReference: corev1.LocalObjectReference{
-					Name: node.Spec.JWTSecretName,
+					Name: node.Spec.JWTSecretName.Name,
 				},
 				Items: []corev1.KeyToPath{
 					{
-						Key:  "secret",
+						Key:  node.Spec.JWTSecretName.KeyOr("secret"),
 						Path: "jwt.secret",
 					},
 				},
@@ -234,7 +301,7 @@ func (r *BeaconNodeReconciler) nodeVolumeMounts(node *ethereum2v1alpha1.BeaconNo
 }
 
 // specStatefulset updates beacon node statefulset spec
-func (r *BeaconNodeReconciler) specStatefulset(node *ethereum2v1alpha1.BeaconNode, sts *appsv1.StatefulSet, args, command []string, homeDir string) {
+func (r *BeaconNodeReconciler) specStatefulset(node *ethereum2v1alpha1.BeaconNode, sts *appsv1.StatefulSet, args, command []string, homeDir string) error {
 
 	sts.Labels = node.GetLabels()
 
@@ -292,6 +359,13 @@ func (r *BeaconNodeReconciler) specStatefulset(node *ethereum2v1alpha1.BeaconNod
 		},
 	}
 
+	if node.Spec.Metrics.Enabled {
+		ports = append(ports, corev1.ContainerPort{
+			Name:          "metrics",
+			ContainerPort: int32(node.Spec.Metrics.Port),
+		})
+	}
+
 	if node.Spec.RPC {
 		ports = append(ports, corev1.ContainerPort{
 			Name:          "rpc",
@@ -313,7 +387,11 @@ func (r *BeaconNodeReconciler) specStatefulset(node *ethereum2v1alpha1.BeaconNod
 		})
 	}
 
-	replicas := int32(*node.Spec.Replicas)
+	replicas := shared.StatefulSetReplicas(*node.Spec.Replicas, node.Spec.Suspend)
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	r.specPVC(node, pvc)
+	dataVolumes, volumeClaimTemplates := shared.SpecDataStorage(node, sts, pvc)
 
 	sts.Spec = appsv1.StatefulSetSpec{
 		Selector: &metav1.LabelSelector{
@@ -347,18 +425,37 @@ func (r *BeaconNodeReconciler) specStatefulset(node *ethereum2v1alpha1.BeaconNod
 						},
 					},
 				},
-				Volumes: volumes,
+				Volumes: append(dataVolumes, volumes...),
 			},
 		},
+		VolumeClaimTemplates: volumeClaimTemplates,
 	}
+
+	shared.SpecResync(node.Spec.DataResync, &sts.Spec.Template.Spec, homeDir)
+	if err := shared.SpecPodExtras(node.Spec.PodExtras, &sts.Spec.Template.Spec); err != nil {
+		return err
+	}
+	shared.SpecSecretStore(node.Spec.SecretStore, &sts.Spec.Template, "secrets")
+
+	return nil
 }
 
 // SetupWithManager adds reconciler to the manager
 func (r *BeaconNodeReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := shared.IndexReferences(mgr, &ethereum2v1alpha1.BeaconNode{}, func(obj client.Object) shared.References {
+		return beaconNodeReferences(obj.(*ethereum2v1alpha1.BeaconNode))
+	}); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&ethereum2v1alpha1.BeaconNode{}).
 		Owns(&appsv1.StatefulSet{}).
+		Owns(&policyv1.PodDisruptionBudget{}).
+		Owns(&networkingv1.NetworkPolicy{}).
 		Owns(&corev1.Service{}).
 		Owns(&corev1.PersistentVolumeClaim{}).
+		Watches(&corev1.Secret{}, r.EnqueueReferencing(&ethereum2v1alpha1.BeaconNodeList{}, shared.IndexSecrets)).
+		Watches(&corev1.ConfigMap{}, r.EnqueueReferencing(&ethereum2v1alpha1.BeaconNodeList{}, shared.IndexConfigMaps)).
 		Complete(r)
 }
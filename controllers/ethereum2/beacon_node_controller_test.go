@@ -118,7 +118,11 @@ var _ = Describe("Ethereum 2.0 beacon node", func() {
 					corev1.ResourceStorage: resource.MustParse(ethereum2v1alpha1.DefaultStorage),
 				},
 			}
-			Expect(k8sClient.Get(context.Background(), key, nodePVC)).To(Succeed())
+			pvcKey := types.NamespacedName{
+				Name:      shared.ReplicaPVCName(&metav1.ObjectMeta{Name: key.Name}, 0),
+				Namespace: key.Namespace,
+			}
+			Expect(k8sClient.Get(context.Background(), pvcKey, nodePVC)).To(Succeed())
 			Expect(nodePVC.GetOwnerReferences()).To(ContainElement(nodeOwnerReference))
 			Expect(nodePVC.Spec.Resources).To(Equal(expectedResources))
 		})
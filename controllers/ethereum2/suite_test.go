@@ -85,8 +85,9 @@ var _ = BeforeSuite(func() {
 	// start beacon node reconciler
 	beaconNodeReconciler := &BeaconNodeReconciler{
 		Reconciler: shared.Reconciler{
-			Client: k8sManager.GetClient(),
-			Scheme: scheme.Scheme,
+			Client:   k8sManager.GetClient(),
+			Scheme:   scheme.Scheme,
+			Recorder: k8sManager.GetEventRecorderFor("kotal-operator"),
 		},
 	}
 	beaconNodeReconciler.SetupWithManager(k8sManager)
@@ -95,8 +96,9 @@ var _ = BeforeSuite(func() {
 	// start validator reconciler
 	validatorReconciler := &ValidatorReconciler{
 		Reconciler: shared.Reconciler{
-			Client: k8sManager.GetClient(),
-			Scheme: scheme.Scheme,
+			Client:   k8sManager.GetClient(),
+			Scheme:   scheme.Scheme,
+			Recorder: k8sManager.GetEventRecorderFor("kotal-operator"),
 		},
 	}
 	validatorReconciler.SetupWithManager(k8sManager)
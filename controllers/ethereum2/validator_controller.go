@@ -7,10 +7,14 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	ethereum2v1alpha1 "github.com/kotalco/kotal/apis/ethereum2/v1alpha1"
 	ethereum2Clients "github.com/kotalco/kotal/clients/ethereum2"
@@ -41,19 +45,31 @@ var (
 // +kubebuilder:rbac:groups=ethereum2.kotal.io,resources=validators,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=ethereum2.kotal.io,resources=validators/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=watch;get;list;create;update;delete
-// +kubebuilder:rbac:groups=core,resources=configmaps;persistentvolumeclaims,verbs=watch;get;create;update;list;delete
+// +kubebuilder:rbac:groups=core,resources=services;configmaps;persistentvolumeclaims,verbs=watch;get;create;update;list;delete
 
 // Reconcile reconciles Ethereum 2.0 validator client
 func (r *ValidatorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 	defer shared.IgnoreConflicts(&err)
 
 	var validator ethereum2v1alpha1.Validator
+	defer r.ObserveReconcile(&validator, req.NamespacedName, &err)
 
 	if err = r.Client.Get(ctx, req.NamespacedName, &validator); err != nil {
 		err = client.IgnoreNotFound(err)
 		return
 	}
 
+	var deleting bool
+	if deleting, result, err = r.ReconcileDeletion(ctx, &validator, validator.Spec.Deletion); deleting || err != nil {
+		return
+	}
+
+	// owned objects are left as is while validator is paused
+	if shared.IsPaused(&validator) {
+		err = r.ReconcilePaused(ctx, &validator, &validator.Status.Mode)
+		return
+	}
+
 	// default the peer if webhooks are disabled
 	if !shared.IsWebhookEnabled() {
 		validator.Default()
@@ -69,16 +85,16 @@ func (r *ValidatorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return
 	}
 
-	// reconcile persistent volume claim
-	if err = r.ReconcileOwned(ctx, &validator, &corev1.PersistentVolumeClaim{}, func(obj client.Object) error {
-		r.specPVC(&validator, obj.(*corev1.PersistentVolumeClaim))
-		return nil
+	// reconcile persistent volume claims
+	if err = r.ReconcilePVCs(ctx, &validator, *validator.Spec.Replicas, func(pvc *corev1.PersistentVolumeClaim) {
+		r.specPVC(&validator, pvc)
 	}); err != nil {
 		return
 	}
 
 	// reconcile stateful set
-	if err = r.ReconcileOwned(ctx, &validator, &appsv1.StatefulSet{}, func(obj client.Object) error {
+	sts := &appsv1.StatefulSet{}
+	if err = r.ReconcileOwned(ctx, &validator, sts, func(obj client.Object) error {
 		client, err := ethereum2Clients.NewClient(&validator)
 		if err != nil {
 			return err
@@ -88,18 +104,117 @@ func (r *ValidatorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		args := client.Args()
 		// encode extra arguments as key=value only if client is numbus
 		kv := validator.Spec.Client == ethereum2v1alpha1.NimbusClient
-		args = append(args, validator.Spec.ExtraArgs.Encode(kv)...)
+		args = validator.Spec.ExtraArgs.Merge(args, validator.Spec.RemoveArgs, kv)
 		homeDir := client.HomeDir()
 
-		r.specStatefulset(&validator, obj.(*appsv1.StatefulSet), command, args, homeDir)
-		return nil
+		sts := obj.(*appsv1.StatefulSet)
+		if err := r.specStatefulset(&validator, sts, command, args, homeDir); err != nil {
+			return err
+		}
+		shared.SpecShutdown(validator.Spec.Shutdown, client, &sts.Spec.Template.Spec)
+		return r.SpecContentHash(ctx, &validator, sts, validatorReferences(&validator))
 	}); err != nil {
 		return
 	}
 
+	// reconcile metrics service
+	if err = r.reconcileService(ctx, &validator); err != nil {
+		return
+	}
+
+	// reconcile pod disruption budget
+	if err = r.ReconcilePodDisruptionBudget(ctx, &validator, validator.Spec.Disruption, *validator.Spec.Replicas); err != nil {
+		return
+	}
+
+	// reconcile network policy
+	if err = r.ReconcileNetworkPolicy(ctx, &validator, validator.Spec.Access, &sts.Spec.Template.Spec, nil); err != nil {
+		return
+	}
+
+	// reconcile prometheus service monitor
+	endpoint := shared.MetricsEndpoint{Port: "metrics", Path: "/metrics"}
+	if err = r.ReconcileServiceMonitor(ctx, &validator, validator.Spec.Metrics, endpoint); err != nil {
+		return
+	}
+
+	if err = r.updateStatus(ctx, &validator); err != nil {
+		return
+	}
+
 	return
 }
 
+// updateStatus updates Ethereum 2.0 validator client status
+func (r *ValidatorReconciler) updateStatus(ctx context.Context, validator *ethereum2v1alpha1.Validator) error {
+	previous := validator.Status
+
+	validator.Status.Mode = shared.Mode(validator, validator.Spec.Suspend)
+	if err := r.UpdateResyncStatus(ctx, validator, &validator.Status.ResyncStatus, validator.Spec.DataResync); err != nil {
+		return err
+	}
+
+	if err := r.Status().Update(ctx, validator); err != nil {
+		log.FromContext(ctx).Error(err, "unable to update validator status")
+		return err
+	}
+
+	r.RecordStatusTransition(validator, previous, validator.Status)
+
+	return nil
+}
+
+// validatorReferences returns secrets and config maps referenced by validator client
+func validatorReferences(validator *ethereum2v1alpha1.Validator) shared.References {
+	refs := shared.PodExtrasReferences(validator.Spec.PodExtras)
+	refs.Add(validator.Spec.CertSecretName)
+	// keys loaded from secret store aren't kubernetes secrets
+	if validator.Spec.SecretStore != nil {
+		return refs
+	}
+	refs.Add(validator.Spec.WalletPasswordSecret.Name)
+	for _, keystore := range validator.Spec.Keystores {
+		refs.Add(keystore.SecretName)
+	}
+	return refs
+}
+
+// reconcileService reconciles validator service
+// validator client doesn't serve any API, service is used to expose metrics only
+func (r *ValidatorReconciler) reconcileService(ctx context.Context, validator *ethereum2v1alpha1.Validator) error {
+	if !validator.Spec.Metrics.Enabled {
+		svc := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      validator.Name,
+				Namespace: validator.Namespace,
+			},
+		}
+		return client.IgnoreNotFound(r.Client.Delete(ctx, svc))
+	}
+
+	return r.ReconcileOwned(ctx, validator, &corev1.Service{}, func(obj client.Object) error {
+		r.specService(validator, obj.(*corev1.Service))
+		return nil
+	})
+}
+
+// specService updates validator service spec
+func (r *ValidatorReconciler) specService(validator *ethereum2v1alpha1.Validator, svc *corev1.Service) {
+	labels := validator.GetLabels()
+
+	svc.ObjectMeta.Labels = labels
+
+	svc.Spec.Ports = []corev1.ServicePort{
+		{
+			Name:       "metrics",
+			Port:       int32(validator.Spec.Metrics.Port),
+			TargetPort: intstr.FromString("metrics"),
+		},
+	}
+
+	svc.Spec.Selector = labels
+}
+
 // specPVC updates validator persistent volume claim spec
 func (r *ValidatorReconciler) specPVC(validator *ethereum2v1alpha1.Validator, pvc *corev1.PersistentVolumeClaim) {
 
@@ -131,16 +246,6 @@ func (r *ValidatorReconciler) createValidatorVolumes(validator *ethereum2v1alpha
 
 	var volumeProjections []corev1.VolumeProjection
 
-	dataVolume := corev1.Volume{
-		Name: "data",
-		VolumeSource: corev1.VolumeSource{
-			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-				ClaimName: validator.Name,
-			},
-		},
-	}
-	volumes = append(volumes, dataVolume)
-
 	configVolume := corev1.Volume{
 		Name: "config",
 		VolumeSource: corev1.VolumeSource{
@@ -234,13 +339,13 @@ func (r *ValidatorReconciler) createValidatorVolumes(validator *ethereum2v1alpha
 	if validator.Spec.Client == ethereum2v1alpha1.PrysmClient {
 		walletPasswordVolume := corev1.Volume{
 			// TODO: rename volume name to prysm-wallet-password
-			Name: validator.Spec.WalletPasswordSecret,
+			Name: validator.Spec.WalletPasswordSecret.Name,
 			VolumeSource: corev1.VolumeSource{
 				Secret: &corev1.SecretVolumeSource{
-					SecretName: validator.Spec.WalletPasswordSecret,
+					SecretName: validator.Spec.WalletPasswordSecret.Name,
 					Items: []corev1.KeyToPath{
 						{
-							Key:  "password",
+							Key:  validator.Spec.WalletPasswordSecret.KeyOr("password"),
 							Path: "prysm-wallet-password.txt",
 						},
 					},
@@ -302,7 +407,7 @@ func (r *ValidatorReconciler) createValidatorVolumeMounts(validator *ethereum2v1
 	// prysm wallet password
 	if validator.Spec.Client == ethereum2v1alpha1.PrysmClient {
 		walletPasswordMount := corev1.VolumeMount{
-			Name:      validator.Spec.WalletPasswordSecret,
+			Name:      validator.Spec.WalletPasswordSecret.Name,
 			ReadOnly:  true,
 			MountPath: fmt.Sprintf("%s/prysm-wallet", shared.PathSecrets(homeDir)),
 		}
@@ -331,7 +436,7 @@ func (r *ValidatorReconciler) createValidatorVolumeMounts(validator *ethereum2v1
 }
 
 // specStatefulset updates vvalidator statefulset spec
-func (r *ValidatorReconciler) specStatefulset(validator *ethereum2v1alpha1.Validator, sts *appsv1.StatefulSet, command, args []string, homeDir string) {
+func (r *ValidatorReconciler) specStatefulset(validator *ethereum2v1alpha1.Validator, sts *appsv1.StatefulSet, command, args []string, homeDir string) error {
 
 	sts.Labels = validator.GetLabels()
 
@@ -435,7 +540,20 @@ func (r *ValidatorReconciler) specStatefulset(validator *ethereum2v1alpha1.Valid
 		initContainers = append(initContainers, copyValidators)
 	}
 
-	replicas := int32(*validator.Spec.Replicas)
+	var ports []corev1.ContainerPort
+
+	if validator.Spec.Metrics.Enabled {
+		ports = append(ports, corev1.ContainerPort{
+			Name:          "metrics",
+			ContainerPort: int32(validator.Spec.Metrics.Port),
+		})
+	}
+
+	replicas := shared.StatefulSetReplicas(*validator.Spec.Replicas, validator.Spec.Suspend)
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	r.specPVC(validator, pvc)
+	dataVolumes, volumeClaimTemplates := shared.SpecDataStorage(validator, sts, pvc)
 
 	sts.Spec = appsv1.StatefulSetSpec{
 		Selector: &metav1.LabelSelector{
@@ -454,6 +572,7 @@ func (r *ValidatorReconciler) specStatefulset(validator *ethereum2v1alpha1.Valid
 						Image:        validator.Spec.Image,
 						Command:      command,
 						Args:         args,
+						Ports:        ports,
 						VolumeMounts: mounts,
 						Resources: corev1.ResourceRequirements{
 							Requests: corev1.ResourceList{
@@ -468,10 +587,25 @@ func (r *ValidatorReconciler) specStatefulset(validator *ethereum2v1alpha1.Valid
 					},
 				},
 				InitContainers: initContainers,
-				Volumes:        r.createValidatorVolumes(validator),
+				Volumes:        append(dataVolumes, r.createValidatorVolumes(validator)...),
 			},
 		},
+		VolumeClaimTemplates: volumeClaimTemplates,
 	}
+
+	shared.SpecResync(validator.Spec.DataResync, &sts.Spec.Template.Spec, homeDir)
+	if err := shared.SpecPodExtras(validator.Spec.PodExtras, &sts.Spec.Template.Spec); err != nil {
+		return err
+	}
+
+	// keystores and wallet password volumes
+	keys := []string{"validator-secrets", validator.Spec.WalletPasswordSecret.Name}
+	for _, keystore := range validator.Spec.Keystores {
+		keys = append(keys, keystore.SecretName)
+	}
+	shared.SpecSecretStore(validator.Spec.SecretStore, &sts.Spec.Template, keys...)
+
+	return nil
 }
 
 // specConfigmap updates validator configmap spec
@@ -493,10 +627,21 @@ func (r *ValidatorReconciler) specConfigmap(validator *ethereum2v1alpha1.Validat
 
 // SetupWithManager adds reconciler to the manager
 func (r *ValidatorReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := shared.IndexReferences(mgr, &ethereum2v1alpha1.Validator{}, func(obj client.Object) shared.References {
+		return validatorReferences(obj.(*ethereum2v1alpha1.Validator))
+	}); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&ethereum2v1alpha1.Validator{}).
 		Owns(&appsv1.StatefulSet{}).
+		Owns(&policyv1.PodDisruptionBudget{}).
+		Owns(&networkingv1.NetworkPolicy{}).
 		Owns(&corev1.ConfigMap{}).
 		Owns(&corev1.PersistentVolumeClaim{}).
+		Owns(&corev1.Service{}).
+		Watches(&corev1.Secret{}, r.EnqueueReferencing(&ethereum2v1alpha1.ValidatorList{}, shared.IndexSecrets)).
+		Watches(&corev1.ConfigMap{}, r.EnqueueReferencing(&ethereum2v1alpha1.ValidatorList{}, shared.IndexConfigMaps)).
 		Complete(r)
 }
@@ -3,6 +3,7 @@ package controllers
 import (
 	"context"
 	"fmt"
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
 	"os"
 	"time"
 
@@ -115,17 +116,12 @@ var _ = Describe("Ethereum 2.0 validator client", func() {
 					MountPath: fmt.Sprintf("%s/validator-keys/%s", shared.PathSecrets(ethereum2Clients.TekuHomeDir), "my-validator"),
 				},
 			))
+			// data volume claim template
+			Expect(validatorSts.Spec.VolumeClaimTemplates).To(HaveLen(1))
+			Expect(validatorSts.Spec.VolumeClaimTemplates[0].Name).To(Equal("data"))
 			// container volume
 			mode := corev1.ConfigMapVolumeSourceDefaultMode
 			Expect(validatorSts.Spec.Template.Spec.Volumes).To(ContainElements(
-				corev1.Volume{
-					Name: "data",
-					VolumeSource: corev1.VolumeSource{
-						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-							ClaimName: validatorSts.Name,
-						},
-					},
-				},
 				corev1.Volume{
 					Name: "config",
 					VolumeSource: corev1.VolumeSource{
@@ -187,7 +183,11 @@ var _ = Describe("Ethereum 2.0 validator client", func() {
 					corev1.ResourceStorage: resource.MustParse(ethereum2v1alpha1.DefaultStorage),
 				},
 			}
-			Expect(k8sClient.Get(context.Background(), key, validatorPVC)).To(Succeed())
+			pvcKey := types.NamespacedName{
+				Name:      shared.ReplicaPVCName(&metav1.ObjectMeta{Name: key.Name}, 0),
+				Namespace: key.Namespace,
+			}
+			Expect(k8sClient.Get(context.Background(), pvcKey, validatorPVC)).To(Succeed())
 			Expect(validatorPVC.GetOwnerReferences()).To(ContainElement(validatorOwnerReference))
 			Expect(validatorPVC.Spec.Resources).To(Equal(expectedResources))
 		})
@@ -218,7 +218,7 @@ var _ = Describe("Ethereum 2.0 validator client", func() {
 			Client:               ethereum2v1alpha1.PrysmClient,
 			BeaconEndpoints:      []string{"http://10.96.130.88:9999"},
 			Graffiti:             "testing Kotal validator controller",
-			WalletPasswordSecret: "my-wallet-password",
+			WalletPasswordSecret: sharedAPI.SecretKeySelector{Name: "my-wallet-password"},
 			Keystores: []ethereum2v1alpha1.Keystore{
 				{
 					SecretName: "my-validator",
@@ -301,17 +301,12 @@ var _ = Describe("Ethereum 2.0 validator client", func() {
 					MountPath: fmt.Sprintf("%s/cert", shared.PathSecrets(ethereum2Clients.PrysmHomeDir)),
 				},
 			))
+			// data volume claim template
+			Expect(validatorSts.Spec.VolumeClaimTemplates).To(HaveLen(1))
+			Expect(validatorSts.Spec.VolumeClaimTemplates[0].Name).To(Equal("data"))
 			// container volume
 			mode := corev1.ConfigMapVolumeSourceDefaultMode
 			Expect(validatorSts.Spec.Template.Spec.Volumes).To(ContainElements(
-				corev1.Volume{
-					Name: "data",
-					VolumeSource: corev1.VolumeSource{
-						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-							ClaimName: validatorSts.Name,
-						},
-					},
-				},
 				corev1.Volume{
 					Name: "config",
 					VolumeSource: corev1.VolumeSource{
@@ -445,7 +440,11 @@ var _ = Describe("Ethereum 2.0 validator client", func() {
 					corev1.ResourceStorage: resource.MustParse(ethereum2v1alpha1.DefaultStorage),
 				},
 			}
-			Expect(k8sClient.Get(context.Background(), key, validatorPVC)).To(Succeed())
+			pvcKey := types.NamespacedName{
+				Name:      shared.ReplicaPVCName(&metav1.ObjectMeta{Name: key.Name}, 0),
+				Namespace: key.Namespace,
+			}
+			Expect(k8sClient.Get(context.Background(), pvcKey, validatorPVC)).To(Succeed())
 			Expect(validatorPVC.GetOwnerReferences()).To(ContainElement(validatorOwnerReference))
 			Expect(validatorPVC.Spec.Resources).To(Equal(expectedResources))
 		})
@@ -476,7 +475,7 @@ var _ = Describe("Ethereum 2.0 validator client", func() {
 			Client:               ethereum2v1alpha1.LighthouseClient,
 			BeaconEndpoints:      []string{"http://10.96.130.88:9999"},
 			Graffiti:             "testing Kotal validator controller",
-			WalletPasswordSecret: "my-wallet-password",
+			WalletPasswordSecret: sharedAPI.SecretKeySelector{Name: "my-wallet-password"},
 			Keystores: []ethereum2v1alpha1.Keystore{
 				{
 					SecretName: "my-validator",
@@ -549,17 +548,12 @@ var _ = Describe("Ethereum 2.0 validator client", func() {
 					MountPath: fmt.Sprintf("%s/validator-keys/%s", shared.PathSecrets(ethereum2Clients.LighthouseHomeDir), "my-validator"),
 				},
 			))
+			// data volume claim template
+			Expect(validatorSts.Spec.VolumeClaimTemplates).To(HaveLen(1))
+			Expect(validatorSts.Spec.VolumeClaimTemplates[0].Name).To(Equal("data"))
 			// container volume
 			mode := corev1.ConfigMapVolumeSourceDefaultMode
 			Expect(validatorSts.Spec.Template.Spec.Volumes).To(ContainElements(
-				corev1.Volume{
-					Name: "data",
-					VolumeSource: corev1.VolumeSource{
-						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-							ClaimName: validatorSts.Name,
-						},
-					},
-				},
 				corev1.Volume{
 					Name: "config",
 					VolumeSource: corev1.VolumeSource{
@@ -660,7 +654,11 @@ var _ = Describe("Ethereum 2.0 validator client", func() {
 					corev1.ResourceStorage: resource.MustParse(ethereum2v1alpha1.DefaultStorage),
 				},
 			}
-			Expect(k8sClient.Get(context.Background(), key, validatorPVC)).To(Succeed())
+			pvcKey := types.NamespacedName{
+				Name:      shared.ReplicaPVCName(&metav1.ObjectMeta{Name: key.Name}, 0),
+				Namespace: key.Namespace,
+			}
+			Expect(k8sClient.Get(context.Background(), pvcKey, validatorPVC)).To(Succeed())
 			Expect(validatorPVC.GetOwnerReferences()).To(ContainElement(validatorOwnerReference))
 			Expect(validatorPVC.Spec.Resources).To(Equal(expectedResources))
 		})
@@ -691,7 +689,7 @@ var _ = Describe("Ethereum 2.0 validator client", func() {
 			Client:               ethereum2v1alpha1.NimbusClient,
 			BeaconEndpoints:      []string{"http://10.96.130.88:9999"},
 			Graffiti:             "testing Kotal validator controller",
-			WalletPasswordSecret: "my-wallet-password",
+			WalletPasswordSecret: sharedAPI.SecretKeySelector{Name: "my-wallet-password"},
 			Keystores: []ethereum2v1alpha1.Keystore{
 				{
 					SecretName: "my-validator",
@@ -767,18 +765,13 @@ var _ = Describe("Ethereum 2.0 validator client", func() {
 					MountPath: fmt.Sprintf("%s/validator-secrets", shared.PathSecrets(ethereum2Clients.NimbusHomeDir)),
 				},
 			))
+			// data volume claim template
+			Expect(validatorSts.Spec.VolumeClaimTemplates).To(HaveLen(1))
+			Expect(validatorSts.Spec.VolumeClaimTemplates[0].Name).To(Equal("data"))
 			// container volume
 			mode := corev1.ConfigMapVolumeSourceDefaultMode
 			fmt.Sprintln(validatorSts.Spec.Template.Spec.Volumes)
 			Expect(validatorSts.Spec.Template.Spec.Volumes).To(ContainElements(
-				corev1.Volume{
-					Name: "data",
-					VolumeSource: corev1.VolumeSource{
-						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-							ClaimName: validatorSts.Name,
-						},
-					},
-				},
 				corev1.Volume{
 					Name: "config",
 					VolumeSource: corev1.VolumeSource{
@@ -890,7 +883,11 @@ var _ = Describe("Ethereum 2.0 validator client", func() {
 					corev1.ResourceStorage: resource.MustParse(ethereum2v1alpha1.DefaultStorage),
 				},
 			}
-			Expect(k8sClient.Get(context.Background(), key, validatorPVC)).To(Succeed())
+			pvcKey := types.NamespacedName{
+				Name:      shared.ReplicaPVCName(&metav1.ObjectMeta{Name: key.Name}, 0),
+				Namespace: key.Namespace,
+			}
+			Expect(k8sClient.Get(context.Background(), pvcKey, validatorPVC)).To(Succeed())
 			Expect(validatorPVC.GetOwnerReferences()).To(ContainElement(validatorOwnerReference))
 			Expect(validatorPVC.Spec.Resources).To(Equal(expectedResources))
 		})
@@ -10,13 +10,15 @@ import (
 	"github.com/kotalco/kotal/controllers/shared"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
-	"sigs.k8s.io/controller-runtime/pkg/predicate"
 )
 
 // NodeReconciler reconciles a Node object
@@ -39,12 +41,24 @@ func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (resul
 	defer shared.IgnoreConflicts(&err)
 
 	var node filecoinv1alpha1.Node
+	defer r.ObserveReconcile(&node, req.NamespacedName, &err)
 
 	if err = r.Client.Get(ctx, req.NamespacedName, &node); err != nil {
 		err = client.IgnoreNotFound(err)
 		return
 	}
 
+	var deleting bool
+	if deleting, result, err = r.ReconcileDeletion(ctx, &node, node.Spec.Deletion); deleting || err != nil {
+		return
+	}
+
+	// owned objects are left as is while node is paused
+	if shared.IsPaused(&node) {
+		err = r.ReconcilePaused(ctx, &node, &node.Status.Mode)
+		return
+	}
+
 	// default the node if webhooks are disabled
 	if !shared.IsWebhookEnabled() {
 		node.Default()
@@ -73,27 +87,48 @@ func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (resul
 		return
 	}
 
-	// reconcile persistent volume claim
-	if err = r.ReconcileOwned(ctx, &node, &corev1.PersistentVolumeClaim{}, func(obj client.Object) error {
-		r.specPVC(&node, obj.(*corev1.PersistentVolumeClaim))
-		return nil
+	// reconcile persistent volume claims
+	if err = r.ReconcilePVCs(ctx, &node, *node.Spec.Replicas, func(pvc *corev1.PersistentVolumeClaim) {
+		r.specPVC(&node, pvc)
 	}); err != nil {
 		return
 	}
 
 	// reconcile stateful set
-	if err = r.ReconcileOwned(ctx, &node, &appsv1.StatefulSet{}, func(obj client.Object) error {
+	sts := &appsv1.StatefulSet{}
+	if err = r.ReconcileOwned(ctx, &node, sts, func(obj client.Object) error {
 		client := filecoinClients.NewClient(&node)
 		args := client.Args()
-		args = append(args, node.Spec.ExtraArgs.Encode(false)...)
+		args = node.Spec.ExtraArgs.Merge(args, node.Spec.RemoveArgs, false)
 		env := client.Env()
 		cmd := client.Command()
 		homeDir := client.HomeDir()
-		return r.specStatefulSet(&node, obj.(*appsv1.StatefulSet), homeDir, cmd, args, env)
+		sts := obj.(*appsv1.StatefulSet)
+		if err := r.specStatefulSet(&node, sts, homeDir, cmd, args, env); err != nil {
+			return err
+		}
+		shared.SpecShutdown(node.Spec.Shutdown, client, &sts.Spec.Template.Spec)
+		return r.SpecContentHash(ctx, &node, sts, references(&node))
 	}); err != nil {
 		return
 	}
 
+	// reconcile pod disruption budget
+	if err = r.ReconcilePodDisruptionBudget(ctx, &node, node.Spec.Disruption, *node.Spec.Replicas); err != nil {
+		return
+	}
+
+	// reconcile network policy
+	if err = r.ReconcileNetworkPolicy(ctx, &node, node.Spec.Access, &sts.Spec.Template.Spec, nil); err != nil {
+		return
+	}
+
+	// reconcile prometheus service monitor
+	endpoint := shared.MetricsEndpoint{Port: "api", Path: "/debug/metrics"}
+	if err = r.ReconcileServiceMonitor(ctx, &node, node.Spec.Metrics, endpoint); err != nil {
+		return
+	}
+
 	if err = r.updateStatus(ctx, &node); err != nil {
 		return
 	}
@@ -101,15 +136,30 @@ func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (resul
 	return
 }
 
+// references returns secrets and config maps referenced by filecoin node
+func references(node *filecoinv1alpha1.Node) shared.References {
+	refs := shared.PodExtrasReferences(node.Spec.PodExtras)
+	return refs
+}
+
 // updateStatus updates filecoin node status
 func (r *NodeReconciler) updateStatus(ctx context.Context, node *filecoinv1alpha1.Node) error {
+	previous := node.Status
+
 	node.Status.Client = "lotus"
 
+	node.Status.Mode = shared.Mode(node, node.Spec.Suspend)
+	if err := r.UpdateResyncStatus(ctx, node, &node.Status.ResyncStatus, node.Spec.DataResync); err != nil {
+		return err
+	}
+
 	if err := r.Status().Update(ctx, node); err != nil {
 		log.FromContext(ctx).Error(err, "unable to update filecoin node status")
 		return err
 	}
 
+	r.RecordStatusTransition(node, previous, node.Status)
+
 	return nil
 }
 
@@ -196,7 +246,11 @@ func (r *NodeReconciler) specStatefulSet(node *filecoinv1alpha1.Node, sts *appsv
 		})
 	}
 
-	replicas := int32(*node.Spec.Replicas)
+	replicas := shared.StatefulSetReplicas(*node.Spec.Replicas, node.Spec.Suspend)
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	r.specPVC(node, pvc)
+	dataVolumes, volumeClaimTemplates := shared.SpecDataStorage(node, sts, pvc)
 
 	sts.Spec = appsv1.StatefulSetSpec{
 		Selector: &metav1.LabelSelector{
@@ -268,15 +322,7 @@ func (r *NodeReconciler) specStatefulSet(node *filecoinv1alpha1.Node, sts *appsv
 						},
 					},
 				},
-				Volumes: []corev1.Volume{
-					{
-						Name: "data",
-						VolumeSource: corev1.VolumeSource{
-							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-								ClaimName: node.Name,
-							},
-						},
-					},
+				Volumes: append(dataVolumes, []corev1.Volume{
 					{
 						Name: "config",
 						VolumeSource: corev1.VolumeSource{
@@ -293,9 +339,15 @@ func (r *NodeReconciler) specStatefulSet(node *filecoinv1alpha1.Node, sts *appsv
 							EmptyDir: &corev1.EmptyDirVolumeSource{},
 						},
 					},
-				},
+				}...),
 			},
 		},
+		VolumeClaimTemplates: volumeClaimTemplates,
+	}
+
+	shared.SpecResync(node.Spec.DataResync, &sts.Spec.Template.Spec, homeDir)
+	if err := shared.SpecPodExtras(node.Spec.PodExtras, &sts.Spec.Template.Spec); err != nil {
+		return err
 	}
 
 	return nil
@@ -303,13 +355,23 @@ func (r *NodeReconciler) specStatefulSet(node *filecoinv1alpha1.Node, sts *appsv
 
 // SetupWithManager adds reconciler to the manager
 func (r *NodeReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	pred := predicate.GenerationChangedPredicate{}
+	if err := shared.IndexReferences(mgr, &filecoinv1alpha1.Node{}, func(obj client.Object) shared.References {
+		return references(obj.(*filecoinv1alpha1.Node))
+	}); err != nil {
+		return err
+	}
+
+	// owned objects updates are filtered, referenced objects are watched without predicate so their updates always pass
+	pred := builder.WithPredicates(shared.GenerationOrContentChangedPredicate())
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&filecoinv1alpha1.Node{}).
-		WithEventFilter(pred).
-		Owns(&appsv1.StatefulSet{}).
-		Owns(&corev1.ConfigMap{}).
-		Owns(&corev1.Service{}).
-		Owns(&corev1.PersistentVolumeClaim{}).
+		For(&filecoinv1alpha1.Node{}, pred).
+		Owns(&appsv1.StatefulSet{}, pred).
+		Owns(&policyv1.PodDisruptionBudget{}, pred).
+		Owns(&networkingv1.NetworkPolicy{}, pred).
+		Owns(&corev1.ConfigMap{}, pred).
+		Owns(&corev1.Service{}, pred).
+		Owns(&corev1.PersistentVolumeClaim{}, pred).
+		Watches(&corev1.Secret{}, r.EnqueueReferencing(&filecoinv1alpha1.NodeList{}, shared.IndexSecrets)).
+		Watches(&corev1.ConfigMap{}, r.EnqueueReferencing(&filecoinv1alpha1.NodeList{}, shared.IndexConfigMaps)).
 		Complete(r)
 }
@@ -2,23 +2,30 @@ package controllers
 
 import (
 	"context"
+	"crypto/ed25519"
 	_ "embed"
+	"encoding/base64"
 	"fmt"
 	"strings"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
-	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	ipfsv1alpha1 "github.com/kotalco/kotal/apis/ipfs/v1alpha1"
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
 	ipfsClients "github.com/kotalco/kotal/clients/ipfs"
 	"github.com/kotalco/kotal/controllers/shared"
+	"github.com/kotalco/kotal/helpers"
 )
 
 // ClusterPeerReconciler reconciles a ClusterPeer object
@@ -34,18 +41,30 @@ var (
 // +kubebuilder:rbac:groups=ipfs.kotal.io,resources=clusterpeers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=ipfs.kotal.io,resources=clusterpeers/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=watch;get;list;create;update;delete
-// +kubebuilder:rbac:groups=core,resources=configmaps;services;persistentvolumeclaims,verbs=watch;get;create;update;list;delete
+// +kubebuilder:rbac:groups=core,resources=configmaps;secrets;services;persistentvolumeclaims,verbs=watch;get;create;update;list;delete
 
 func (r *ClusterPeerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 	defer shared.IgnoreConflicts(&err)
 
 	var peer ipfsv1alpha1.ClusterPeer
+	defer r.ObserveReconcile(&peer, req.NamespacedName, &err)
 
 	if err = r.Client.Get(ctx, req.NamespacedName, &peer); err != nil {
 		err = client.IgnoreNotFound(err)
 		return
 	}
 
+	var deleting bool
+	if deleting, result, err = r.ReconcileDeletion(ctx, &peer, peer.Spec.Deletion); deleting || err != nil {
+		return
+	}
+
+	// owned objects are left as is while peer is paused
+	if shared.IsPaused(&peer) {
+		err = r.ReconcilePaused(ctx, &peer, &peer.Status.Mode)
+		return
+	}
+
 	// default the cluster peer if webhooks are disabled
 	if !shared.IsWebhookEnabled() {
 		peer.Default()
@@ -61,10 +80,9 @@ func (r *ClusterPeerReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return
 	}
 
-	// reconcile persistent volume claim
-	if err = r.ReconcileOwned(ctx, &peer, &corev1.PersistentVolumeClaim{}, func(obj client.Object) error {
-		r.specPVC(&peer, obj.(*corev1.PersistentVolumeClaim))
-		return nil
+	// reconcile persistent volume claims
+	if err = r.ReconcilePVCs(ctx, &peer, *peer.Spec.Replicas, func(pvc *corev1.PersistentVolumeClaim) {
+		r.specPVC(&peer, pvc)
 	}); err != nil {
 		return
 	}
@@ -77,8 +95,15 @@ func (r *ClusterPeerReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return
 	}
 
+	// reconcile replicas identities
+	var nodeKeys []map[string]string
+	if nodeKeys, err = r.reconcileNodeKeys(ctx, &peer); err != nil {
+		return
+	}
+
 	// reconcile stateful set
-	if err = r.ReconcileOwned(ctx, &peer, &appsv1.StatefulSet{}, func(obj client.Object) error {
+	sts := &appsv1.StatefulSet{}
+	if err = r.ReconcileOwned(ctx, &peer, sts, func(obj client.Object) error {
 		client, err := ipfsClients.NewClient(&peer)
 		if err != nil {
 			return err
@@ -86,33 +111,114 @@ func (r *ClusterPeerReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 		command := client.Command()
 		args := client.Args()
-		args = append(args, peer.Spec.ExtraArgs.Encode(false)...)
+		args = peer.Spec.ExtraArgs.Merge(args, peer.Spec.RemoveArgs, false)
 		env := client.Env()
 		homeDir := client.HomeDir()
 
-		r.specStatefulset(&peer, obj.(*appsv1.StatefulSet), homeDir, env, command, args)
-		return nil
+		sts := obj.(*appsv1.StatefulSet)
+		if err := r.specStatefulset(&peer, sts, homeDir, env, command, args); err != nil {
+			return err
+		}
+		shared.SpecShutdown(peer.Spec.Shutdown, client, &sts.Spec.Template.Spec)
+		return r.SpecContentHash(ctx, &peer, sts, clusterPeerReferences(&peer))
 	}); err != nil {
 		return
 	}
 
-	if err = r.updateStatus(ctx, &peer); err != nil {
+	// reconcile pod disruption budget
+	if err = r.ReconcilePodDisruptionBudget(ctx, &peer, peer.Spec.Disruption, *peer.Spec.Replicas); err != nil {
+		return
+	}
+
+	// reconcile network policy
+	if err = r.ReconcileNetworkPolicy(ctx, &peer, peer.Spec.Access, &sts.Spec.Template.Spec, nil); err != nil {
+		return
+	}
+
+	// reconcile prometheus service monitor
+	endpoint := shared.MetricsEndpoint{Port: "metrics", Path: "/metrics"}
+	if err = r.ReconcileServiceMonitor(ctx, &peer, peer.Spec.Metrics, endpoint); err != nil {
+		return
+	}
+
+	if err = r.updateStatus(ctx, &peer, nodeKeys); err != nil {
 		return
 	}
 
 	return
 }
 
+// reconcileNodeKeys reconciles cluster peer id and private key of every replica if cluster peer has multiple replicas
+// first replica uses cluster peer id and private key if provided
+func (r *ClusterPeerReconciler) reconcileNodeKeys(ctx context.Context, peer *ipfsv1alpha1.ClusterPeer) ([]map[string]string, error) {
+	if !shared.HasReplicaKeys(peer.Spec.Replicas) {
+		return nil, nil
+	}
+
+	var primary map[string]string
+	if peer.Spec.ID != "" {
+		key := types.NamespacedName{
+			Name:      peer.Spec.PrivateKeySecretName.Name,
+			Namespace: peer.Namespace,
+		}
+
+		privateKey, err := r.GetSecret(ctx, peer, key, peer.Spec.PrivateKeySecretName.KeyOr("key"))
+		if err != nil {
+			return nil, err
+		}
+
+		primary = map[string]string{"id": peer.Spec.ID, "key": privateKey}
+	}
+
+	return r.ReconcileNodeKeys(ctx, peer, *peer.Spec.Replicas, primary, func() (map[string]string, error) {
+		publicKey, privateKey, err := ed25519.GenerateKey(nil)
+		if err != nil {
+			return nil, err
+		}
+
+		return map[string]string{
+			"id":  helpers.Libp2pPeerID(publicKey),
+			"key": base64.StdEncoding.EncodeToString(helpers.Libp2pPrivateKey(privateKey)),
+		}, nil
+	})
+}
+
+// clusterPeerReferences returns secrets and config maps referenced by ipfs cluster peer
+func clusterPeerReferences(peer *ipfsv1alpha1.ClusterPeer) shared.References {
+	refs := shared.PodExtrasReferences(peer.Spec.PodExtras)
+	refs.Add(peer.Spec.PrivateKeySecretName.Name, peer.Spec.ClusterSecretName.Name)
+	return refs
+}
+
 // updateStatus updates ipfs cluster peer status
-func (r *ClusterPeerReconciler) updateStatus(ctx context.Context, peer *ipfsv1alpha1.ClusterPeer) error {
+func (r *ClusterPeerReconciler) updateStatus(ctx context.Context, peer *ipfsv1alpha1.ClusterPeer, nodeKeys []map[string]string) error {
+	previous := peer.Status
+
 	// TODO: update after multi-client support
 	peer.Status.Client = "ipfs-cluster-service"
 
+	var replicas []sharedAPI.ReplicaStatus
+	for ordinal, files := range nodeKeys {
+		replicas = append(replicas, sharedAPI.ReplicaStatus{
+			Name: shared.ReplicaName(peer, uint(ordinal)),
+			ID:   files["id"],
+		})
+	}
+
+	peer.Status.Replicas = replicas
+
+	peer.Status.Mode = shared.Mode(peer, peer.Spec.Suspend)
+	if err := r.UpdateResyncStatus(ctx, peer, &peer.Status.ResyncStatus, peer.Spec.DataResync); err != nil {
+		return err
+	}
+
 	if err := r.Status().Update(ctx, peer); err != nil {
 		log.FromContext(ctx).Error(err, "unable to update cluster peer status")
 		return err
 	}
 
+	r.RecordStatusTransition(peer, previous, peer.Status)
+
 	return nil
 }
 
@@ -157,7 +263,7 @@ func (r *ClusterPeerReconciler) specService(peer *ipfsv1alpha1.ClusterPeer, svc
 		},
 		{
 			Name:       "metrics",
-			Port:       8888,
+			Port:       int32(peer.Spec.Metrics.Port),
 			TargetPort: intstr.FromString("metrics"),
 		},
 		{
@@ -206,7 +312,7 @@ func (r *ClusterPeerReconciler) specPVC(peer *ipfsv1alpha1.ClusterPeer, pvc *cor
 }
 
 // specStatefulset updates IPFS cluster peer statefulset
-func (r *ClusterPeerReconciler) specStatefulset(peer *ipfsv1alpha1.ClusterPeer, sts *appsv1.StatefulSet, homeDir string, env []corev1.EnvVar, command, args []string) {
+func (r *ClusterPeerReconciler) specStatefulset(peer *ipfsv1alpha1.ClusterPeer, sts *appsv1.StatefulSet, homeDir string, env []corev1.EnvVar, command, args []string) error {
 	labels := peer.Labels
 
 	sts.Labels = labels
@@ -230,9 +336,9 @@ func (r *ClusterPeerReconciler) specStatefulset(peer *ipfsv1alpha1.ClusterPeer,
 			ValueFrom: &corev1.EnvVarSource{
 				SecretKeyRef: &corev1.SecretKeySelector{
 					LocalObjectReference: corev1.LocalObjectReference{
-						Name: peer.Spec.ClusterSecretName,
+						Name: peer.Spec.ClusterSecretName.Name,
 					},
-					Key: "secret",
+					Key: peer.Spec.ClusterSecretName.KeyOr("secret"),
 				},
 			},
 		},
@@ -242,9 +348,42 @@ func (r *ClusterPeerReconciler) specStatefulset(peer *ipfsv1alpha1.ClusterPeer,
 		},
 	}
 
-	// if cluster peer ID (which implies private key) is provided
-	// append cluster id and private key environment variables
-	if peer.Spec.ID != "" {
+	initClusterPeerMounts := []corev1.VolumeMount{
+		{
+			Name:      "data",
+			MountPath: shared.PathData(homeDir),
+		},
+		{
+			Name:      "config",
+			MountPath: shared.PathConfig(homeDir),
+		},
+	}
+
+	volumes := []corev1.Volume{
+		{
+			Name: "config",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: peer.Name,
+					},
+				},
+			},
+		},
+	}
+
+	// every replica has its own cluster peer id and private key
+	// init script reads them from replica node keys directory
+	if shared.HasReplicaKeys(peer.Spec.Replicas) {
+		initClusterPeerENV = append(initClusterPeerENV, shared.PodNameEnv(), corev1.EnvVar{
+			Name:  shared.EnvNodeKeysPath,
+			Value: shared.PathNodeKeys(homeDir),
+		})
+		initClusterPeerMounts = append(initClusterPeerMounts, shared.NodeKeysVolumeMount(homeDir))
+		volumes = append(volumes, shared.NodeKeysVolume(peer, *peer.Spec.Replicas, "id", "key"))
+	} else if peer.Spec.ID != "" {
+		// if cluster peer ID (which implies private key) is provided
+		// append cluster id and private key environment variables
 		// cluster id
 		initClusterPeerENV = append(initClusterPeerENV, corev1.EnvVar{
 			Name:  ipfsClients.EnvIPFSClusterId,
@@ -256,9 +395,9 @@ func (r *ClusterPeerReconciler) specStatefulset(peer *ipfsv1alpha1.ClusterPeer,
 			ValueFrom: &corev1.EnvVarSource{
 				SecretKeyRef: &corev1.SecretKeySelector{
 					LocalObjectReference: corev1.LocalObjectReference{
-						Name: peer.Spec.PrivateKeySecretName,
+						Name: peer.Spec.PrivateKeySecretName.Name,
 					},
-					Key: "key",
+					Key: peer.Spec.PrivateKeySecretName.KeyOr("key"),
 				},
 			},
 		})
@@ -288,7 +427,7 @@ func (r *ClusterPeerReconciler) specStatefulset(peer *ipfsv1alpha1.ClusterPeer,
 		},
 		{
 			Name:          "metrics",
-			ContainerPort: 8888,
+			ContainerPort: int32(peer.Spec.Metrics.Port),
 		},
 		{
 			Name:          "tracing",
@@ -296,7 +435,11 @@ func (r *ClusterPeerReconciler) specStatefulset(peer *ipfsv1alpha1.ClusterPeer,
 		},
 	}
 
-	replicas := int32(*peer.Spec.Replicas)
+	replicas := shared.StatefulSetReplicas(*peer.Spec.Replicas, peer.Spec.Suspend)
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	r.specPVC(peer, pvc)
+	dataVolumes, volumeClaimTemplates := shared.SpecDataStorage(peer, sts, pvc)
 
 	sts.Spec = appsv1.StatefulSetSpec{
 		Selector: &metav1.LabelSelector{
@@ -318,16 +461,7 @@ func (r *ClusterPeerReconciler) specStatefulset(peer *ipfsv1alpha1.ClusterPeer,
 						Args: []string{
 							fmt.Sprintf("%s/init_ipfs_cluster_config.sh", shared.PathConfig(homeDir)),
 						},
-						VolumeMounts: []corev1.VolumeMount{
-							{
-								Name:      "data",
-								MountPath: shared.PathData(homeDir),
-							},
-							{
-								Name:      "config",
-								MountPath: shared.PathConfig(homeDir),
-							},
-						},
+						VolumeMounts: initClusterPeerMounts,
 					},
 				},
 				Containers: []corev1.Container{
@@ -356,39 +490,38 @@ func (r *ClusterPeerReconciler) specStatefulset(peer *ipfsv1alpha1.ClusterPeer,
 						},
 					},
 				},
-				Volumes: []corev1.Volume{
-					{
-						Name: "data",
-						VolumeSource: corev1.VolumeSource{
-							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-								ClaimName: peer.Name,
-							},
-						},
-					},
-					{
-						Name: "config",
-						VolumeSource: corev1.VolumeSource{
-							ConfigMap: &corev1.ConfigMapVolumeSource{
-								LocalObjectReference: corev1.LocalObjectReference{
-									Name: peer.Name,
-								},
-							},
-						},
-					},
-				},
+				Volumes: append(dataVolumes, volumes...),
 			},
 		},
+		VolumeClaimTemplates: volumeClaimTemplates,
+	}
+
+	shared.SpecResync(peer.Spec.DataResync, &sts.Spec.Template.Spec, homeDir)
+	if err := shared.SpecPodExtras(peer.Spec.PodExtras, &sts.Spec.Template.Spec); err != nil {
+		return err
 	}
+
+	return nil
 }
 
 func (r *ClusterPeerReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	pred := predicate.GenerationChangedPredicate{}
+	if err := shared.IndexReferences(mgr, &ipfsv1alpha1.ClusterPeer{}, func(obj client.Object) shared.References {
+		return clusterPeerReferences(obj.(*ipfsv1alpha1.ClusterPeer))
+	}); err != nil {
+		return err
+	}
+
+	// owned objects updates are filtered, referenced objects are watched without predicate so their updates always pass
+	pred := builder.WithPredicates(shared.GenerationOrContentChangedPredicate())
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&ipfsv1alpha1.ClusterPeer{}).
-		WithEventFilter(pred).
-		Owns(&appsv1.StatefulSet{}).
-		Owns(&corev1.PersistentVolumeClaim{}).
-		Owns(&corev1.Service{}).
-		Owns(&corev1.ConfigMap{}).
+		For(&ipfsv1alpha1.ClusterPeer{}, pred).
+		Owns(&appsv1.StatefulSet{}, pred).
+		Owns(&policyv1.PodDisruptionBudget{}, pred).
+		Owns(&networkingv1.NetworkPolicy{}, pred).
+		Owns(&corev1.PersistentVolumeClaim{}, pred).
+		Owns(&corev1.Service{}, pred).
+		Owns(&corev1.ConfigMap{}, pred).
+		Watches(&corev1.Secret{}, r.EnqueueReferencing(&ipfsv1alpha1.ClusterPeerList{}, shared.IndexSecrets)).
+		Watches(&corev1.ConfigMap{}, r.EnqueueReferencing(&ipfsv1alpha1.ClusterPeerList{}, shared.IndexConfigMaps)).
 		Complete(r)
 }
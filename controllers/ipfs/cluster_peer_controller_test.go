@@ -3,6 +3,7 @@ package controllers
 import (
 	"context"
 	"fmt"
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
 	"os"
 	"strings"
 	"time"
@@ -40,8 +41,8 @@ var _ = Describe("IPFS cluster peer controller", func() {
 	spec := ipfsv1alpha1.ClusterPeerSpec{
 		Image:                image,
 		ID:                   "12D3KooWBcEtY8GH4mNkri9kM3haeWhEXtQV7mi81ErWrqLYGuiq",
-		PrivateKeySecretName: "cluster-privatekey",
-		ClusterSecretName:    "cluster-secret",
+		PrivateKeySecretName: sharedAPI.SecretKeySelector{Name: "cluster-privatekey"},
+		ClusterSecretName:    sharedAPI.SecretKeySelector{Name: "cluster-secret"},
 		Consensus:            ipfsv1alpha1.CRDT,
 		TrustedPeers: []string{
 			"12D3KooWBcEtY8GH4mNkri9kM3haeWhEXtQV7mi81ErWrqLYGuiq",
@@ -165,9 +166,9 @@ var _ = Describe("IPFS cluster peer controller", func() {
 				ValueFrom: &corev1.EnvVarSource{
 					SecretKeyRef: &corev1.SecretKeySelector{
 						LocalObjectReference: corev1.LocalObjectReference{
-							Name: toCreate.Spec.ClusterSecretName,
+							Name: toCreate.Spec.ClusterSecretName.Name,
 						},
-						Key: "secret",
+						Key: toCreate.Spec.ClusterSecretName.KeyOr("secret"),
 					},
 				},
 			},
@@ -184,9 +185,9 @@ var _ = Describe("IPFS cluster peer controller", func() {
 				ValueFrom: &corev1.EnvVarSource{
 					SecretKeyRef: &corev1.SecretKeySelector{
 						LocalObjectReference: corev1.LocalObjectReference{
-							Name: toCreate.Spec.PrivateKeySecretName,
+							Name: toCreate.Spec.PrivateKeySecretName.Name,
 						},
-						Key: "key",
+						Key: toCreate.Spec.PrivateKeySecretName.KeyOr("key"),
 					},
 				},
 			},
@@ -218,7 +219,11 @@ var _ = Describe("IPFS cluster peer controller", func() {
 
 	It("Should create peer data persistent volume with correct resources", func() {
 		fetched := &corev1.PersistentVolumeClaim{}
-		Expect(k8sClient.Get(context.Background(), key, fetched)).To(Succeed())
+		pvcKey := types.NamespacedName{
+			Name:      shared.ReplicaPVCName(&metav1.ObjectMeta{Name: key.Name}, 0),
+			Namespace: key.Namespace,
+		}
+		Expect(k8sClient.Get(context.Background(), pvcKey, fetched)).To(Succeed())
 		Expect(fetched.OwnerReferences).To(ContainElements(peerOwnerReference))
 
 		expectedResources := corev1.VolumeResourceRequirements{
@@ -2,6 +2,13 @@
 
 set -e
 
+# replica own cluster peer identity
+if [ -n "$KOTAL_NODE_KEYS_PATH" ] && [ -e $KOTAL_NODE_KEYS_PATH/id ]
+then
+	export CLUSTER_ID=$(cat $KOTAL_NODE_KEYS_PATH/id)
+	export CLUSTER_PRIVATEKEY=$(cat $KOTAL_NODE_KEYS_PATH/key)
+fi
+
 if [ -e $IPFS_CLUSTER_PATH/service.json ]
 then
 	echo "ipfs cluster config has already been initialized"
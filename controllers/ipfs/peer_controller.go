@@ -8,13 +8,15 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
-	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	ipfsv1alpha1 "github.com/kotalco/kotal/apis/ipfs/v1alpha1"
 	ipfsClients "github.com/kotalco/kotal/clients/ipfs"
@@ -44,12 +46,24 @@ func (r *PeerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (resul
 	defer shared.IgnoreConflicts(&err)
 
 	var peer ipfsv1alpha1.Peer
+	defer r.ObserveReconcile(&peer, req.NamespacedName, &err)
 
 	if err = r.Client.Get(ctx, req.NamespacedName, &peer); err != nil {
 		err = client.IgnoreNotFound(err)
 		return
 	}
 
+	var deleting bool
+	if deleting, result, err = r.ReconcileDeletion(ctx, &peer, peer.Spec.Deletion); deleting || err != nil {
+		return
+	}
+
+	// owned objects are left as is while peer is paused
+	if shared.IsPaused(&peer) {
+		err = r.ReconcilePaused(ctx, &peer, &peer.Status.Mode)
+		return
+	}
+
 	// default the peer if webhooks are disabled
 	if !shared.IsWebhookEnabled() {
 		peer.Default()
@@ -73,16 +87,16 @@ func (r *PeerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (resul
 		return
 	}
 
-	// reconcile persistent volume claim
-	if err = r.ReconcileOwned(ctx, &peer, &corev1.PersistentVolumeClaim{}, func(obj client.Object) error {
-		r.specPVC(&peer, obj.(*corev1.PersistentVolumeClaim))
-		return nil
+	// reconcile persistent volume claims
+	if err = r.ReconcilePVCs(ctx, &peer, *peer.Spec.Replicas, func(pvc *corev1.PersistentVolumeClaim) {
+		r.specPVC(&peer, pvc)
 	}); err != nil {
 		return
 	}
 
 	// reconcile stateful set
-	if err = r.ReconcileOwned(ctx, &peer, &appsv1.StatefulSet{}, func(obj client.Object) error {
+	sts := &appsv1.StatefulSet{}
+	if err = r.ReconcileOwned(ctx, &peer, sts, func(obj client.Object) error {
 		client, err := ipfsClients.NewClient(&peer)
 		if err != nil {
 			return err
@@ -91,15 +105,35 @@ func (r *PeerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (resul
 		command := client.Command()
 		env := client.Env()
 		args := client.Args()
-		args = append(args, peer.Spec.ExtraArgs.Encode(false)...)
+		args = peer.Spec.ExtraArgs.Merge(args, peer.Spec.RemoveArgs, false)
 		homeDir := client.HomeDir()
 
-		r.specStatefulSet(&peer, obj.(*appsv1.StatefulSet), homeDir, env, command, args)
-		return nil
+		sts := obj.(*appsv1.StatefulSet)
+		if err := r.specStatefulSet(&peer, sts, homeDir, env, command, args); err != nil {
+			return err
+		}
+		shared.SpecShutdown(peer.Spec.Shutdown, client, &sts.Spec.Template.Spec)
+		return r.SpecContentHash(ctx, &peer, sts, peerReferences(&peer))
 	}); err != nil {
 		return
 	}
 
+	// reconcile pod disruption budget
+	if err = r.ReconcilePodDisruptionBudget(ctx, &peer, peer.Spec.Disruption, *peer.Spec.Replicas); err != nil {
+		return
+	}
+
+	// reconcile network policy
+	if err = r.ReconcileNetworkPolicy(ctx, &peer, peer.Spec.Access, &sts.Spec.Template.Spec, nil); err != nil {
+		return
+	}
+
+	// reconcile prometheus service monitor
+	endpoint := shared.MetricsEndpoint{Port: "api", Path: "/debug/metrics/prometheus"}
+	if err = r.ReconcileServiceMonitor(ctx, &peer, peer.Spec.Metrics, endpoint); err != nil {
+		return
+	}
+
 	if err = r.updateStatus(ctx, &peer); err != nil {
 		return
 	}
@@ -107,16 +141,32 @@ func (r *PeerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (resul
 	return
 }
 
+// peerReferences returns secrets and config maps referenced by ipfs peer
+func peerReferences(peer *ipfsv1alpha1.Peer) shared.References {
+	refs := shared.PodExtrasReferences(peer.Spec.PodExtras)
+	refs.Add(peer.Spec.SwarmKeySecretName.Name)
+	return refs
+}
+
 // updateStatus updates ipfs peer status
 func (r *PeerReconciler) updateStatus(ctx context.Context, peer *ipfsv1alpha1.Peer) error {
+	previous := peer.Status
+
 	// TODO: update after multi-client support
 	peer.Status.Client = "kubo"
 
+	peer.Status.Mode = shared.Mode(peer, peer.Spec.Suspend)
+	if err := r.UpdateResyncStatus(ctx, peer, &peer.Status.ResyncStatus, peer.Spec.DataResync); err != nil {
+		return err
+	}
+
 	if err := r.Status().Update(ctx, peer); err != nil {
 		log.FromContext(ctx).Error(err, "unable to update peer status")
 		return err
 	}
 
+	r.RecordStatusTransition(peer, previous, peer.Status)
+
 	return nil
 }
 
@@ -197,20 +247,12 @@ func (r *PeerReconciler) specPVC(peer *ipfsv1alpha1.Peer, pvc *corev1.Persistent
 }
 
 // specStatefulSet updates ipfs peer statefulset spec
-func (r *PeerReconciler) specStatefulSet(peer *ipfsv1alpha1.Peer, sts *appsv1.StatefulSet, homeDir string, env []corev1.EnvVar, command, args []string) {
+func (r *PeerReconciler) specStatefulSet(peer *ipfsv1alpha1.Peer, sts *appsv1.StatefulSet, homeDir string, env []corev1.EnvVar, command, args []string) error {
 	labels := peer.Labels
 
 	sts.ObjectMeta.Labels = labels
 
 	volumes := []corev1.Volume{
-		{
-			Name: "data",
-			VolumeSource: corev1.VolumeSource{
-				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-					ClaimName: peer.Name,
-				},
-			},
-		},
 		{
 			Name: "config",
 			VolumeSource: corev1.VolumeSource{
@@ -237,12 +279,18 @@ func (r *PeerReconciler) specStatefulSet(peer *ipfsv1alpha1.Peer, sts *appsv1.St
 	initContainers := []corev1.Container{}
 
 	// copy swarm key before init ipfs
-	if peer.Spec.SwarmKeySecretName != "" {
+	if peer.Spec.SwarmKeySecretName.Name != "" {
 		volumes = append(volumes, corev1.Volume{
 			Name: "swarm-key",
 			VolumeSource: corev1.VolumeSource{
 				Secret: &corev1.SecretVolumeSource{
-					SecretName: peer.Spec.SwarmKeySecretName,
+					SecretName: peer.Spec.SwarmKeySecretName.Name,
+					Items: []corev1.KeyToPath{
+						{
+							Key:  peer.Spec.SwarmKeySecretName.KeyOr("swarm.key"),
+							Path: "swarm.key",
+						},
+					},
 				},
 			},
 		})
@@ -367,7 +415,11 @@ func (r *PeerReconciler) specStatefulSet(peer *ipfsv1alpha1.Peer, sts *appsv1.St
 		})
 	}
 
-	replicas := int32(*peer.Spec.Replicas)
+	replicas := shared.StatefulSetReplicas(*peer.Spec.Replicas, peer.Spec.Suspend)
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	r.specPVC(peer, pvc)
+	dataVolumes, volumeClaimTemplates := shared.SpecDataStorage(peer, sts, pvc)
 
 	sts.Spec = appsv1.StatefulSetSpec{
 		Selector: &metav1.LabelSelector{
@@ -402,21 +454,39 @@ func (r *PeerReconciler) specStatefulSet(peer *ipfsv1alpha1.Peer, sts *appsv1.St
 						},
 					},
 				},
-				Volumes: volumes,
+				Volumes: append(dataVolumes, volumes...),
 			},
 		},
+		VolumeClaimTemplates: volumeClaimTemplates,
 	}
+
+	shared.SpecResync(peer.Spec.DataResync, &sts.Spec.Template.Spec, homeDir)
+	if err := shared.SpecPodExtras(peer.Spec.PodExtras, &sts.Spec.Template.Spec); err != nil {
+		return err
+	}
+
+	return nil
 }
 
 // SetupWithManager registers the controller to be started with the given manager
 func (r *PeerReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	pred := predicate.GenerationChangedPredicate{}
+	if err := shared.IndexReferences(mgr, &ipfsv1alpha1.Peer{}, func(obj client.Object) shared.References {
+		return peerReferences(obj.(*ipfsv1alpha1.Peer))
+	}); err != nil {
+		return err
+	}
+
+	// owned objects updates are filtered, referenced objects are watched without predicate so their updates always pass
+	pred := builder.WithPredicates(shared.GenerationOrContentChangedPredicate())
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&ipfsv1alpha1.Peer{}).
-		WithEventFilter(pred).
-		Owns(&appsv1.StatefulSet{}).
-		Owns(&corev1.PersistentVolumeClaim{}).
-		Owns(&corev1.ConfigMap{}).
-		Owns(&corev1.Service{}).
+		For(&ipfsv1alpha1.Peer{}, pred).
+		Owns(&appsv1.StatefulSet{}, pred).
+		Owns(&policyv1.PodDisruptionBudget{}, pred).
+		Owns(&networkingv1.NetworkPolicy{}, pred).
+		Owns(&corev1.PersistentVolumeClaim{}, pred).
+		Owns(&corev1.ConfigMap{}, pred).
+		Owns(&corev1.Service{}, pred).
+		Watches(&corev1.Secret{}, r.EnqueueReferencing(&ipfsv1alpha1.PeerList{}, shared.IndexSecrets)).
+		Watches(&corev1.ConfigMap{}, r.EnqueueReferencing(&ipfsv1alpha1.PeerList{}, shared.IndexConfigMaps)).
 		Complete(r)
 }
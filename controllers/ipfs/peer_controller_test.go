@@ -3,6 +3,7 @@ package controllers
 import (
 	"context"
 	"fmt"
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
 	"os"
 	"time"
 
@@ -42,7 +43,7 @@ var _ = Describe("IPFS peer controller", func() {
 		Gateway:            true,
 		GatewayPort:        4444,
 		Routing:            ipfsv1alpha1.DHTClientRouting,
-		SwarmKeySecretName: "my-swarm",
+		SwarmKeySecretName: sharedAPI.SecretKeySelector{Name: "my-swarm"},
 	}
 
 	toCreate := &ipfsv1alpha1.Peer{
@@ -122,7 +123,11 @@ var _ = Describe("IPFS peer controller", func() {
 
 	It("Should create peer data persistent volume with correct resources", func() {
 		fetched := &corev1.PersistentVolumeClaim{}
-		Expect(k8sClient.Get(context.Background(), key, fetched)).To(Succeed())
+		pvcKey := types.NamespacedName{
+			Name:      shared.ReplicaPVCName(&metav1.ObjectMeta{Name: key.Name}, 0),
+			Namespace: key.Namespace,
+		}
+		Expect(k8sClient.Get(context.Background(), pvcKey, fetched)).To(Succeed())
 		Expect(fetched.OwnerReferences).To(ContainElements(peerOwnerReference))
 		expectedResources := corev1.VolumeResourceRequirements{
 			Requests: corev1.ResourceList{
@@ -86,8 +86,9 @@ var _ = BeforeSuite(func() {
 	// start peer reconciler
 	peerReconciler := &PeerReconciler{
 		Reconciler: shared.Reconciler{
-			Client: k8sManager.GetClient(),
-			Scheme: scheme.Scheme,
+			Client:   k8sManager.GetClient(),
+			Scheme:   scheme.Scheme,
+			Recorder: k8sManager.GetEventRecorderFor("kotal-operator"),
 		},
 	}
 	peerReconciler.SetupWithManager(k8sManager)
@@ -96,8 +97,9 @@ var _ = BeforeSuite(func() {
 	// start cluster peer reconciler
 	clusterPeerReconciler := &ClusterPeerReconciler{
 		Reconciler: shared.Reconciler{
-			Client: k8sManager.GetClient(),
-			Scheme: scheme.Scheme,
+			Client:   k8sManager.GetClient(),
+			Scheme:   scheme.Scheme,
+			Recorder: k8sManager.GetEventRecorderFor("kotal-operator"),
 		},
 	}
 	clusterPeerReconciler.SetupWithManager(k8sManager)
@@ -2,21 +2,28 @@ package controllers
 
 import (
 	"context"
+	"crypto/ed25519"
 	_ "embed"
+	"encoding/json"
 	"fmt"
 
 	nearv1alpha1 "github.com/kotalco/kotal/apis/near/v1alpha1"
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
 	nearClients "github.com/kotalco/kotal/clients/near"
 	"github.com/kotalco/kotal/controllers/shared"
+	"github.com/kotalco/kotal/helpers"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
-	"sigs.k8s.io/controller-runtime/pkg/predicate"
 )
 
 // NodeReconciler reconciles a Node object
@@ -28,6 +35,13 @@ const (
 	envNetwork = "KOTAL_NEAR_NETWORK"
 )
 
+// nodeKey is NEAR node key file content
+type nodeKey struct {
+	AccountID string `json:"account_id"`
+	PublicKey string `json:"public_key"`
+	SecretKey string `json:"secret_key"`
+}
+
 var (
 	//go:embed init_near_node.sh
 	InitNearNode string
@@ -40,18 +54,30 @@ var (
 // +kubebuilder:rbac:groups=near.kotal.io,resources=nodes,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=near.kotal.io,resources=nodes/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=watch;get;list;create;update;delete
-// +kubebuilder:rbac:groups=core,resources=configmaps;persistentvolumeclaims;services,verbs=watch;get;create;update;list;delete
+// +kubebuilder:rbac:groups=core,resources=configmaps;secrets;persistentvolumeclaims;services,verbs=watch;get;create;update;list;delete
 
 func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 	defer shared.IgnoreConflicts(&err)
 
 	var node nearv1alpha1.Node
+	defer r.ObserveReconcile(&node, req.NamespacedName, &err)
 
 	if err = r.Client.Get(ctx, req.NamespacedName, &node); err != nil {
 		err = client.IgnoreNotFound(err)
 		return
 	}
 
+	var deleting bool
+	if deleting, result, err = r.ReconcileDeletion(ctx, &node, node.Spec.Deletion); deleting || err != nil {
+		return
+	}
+
+	// owned objects are left as is while node is paused
+	if shared.IsPaused(&node) {
+		err = r.ReconcilePaused(ctx, &node, &node.Status.Mode)
+		return
+	}
+
 	// default the node if webhooks are disabled
 	if !shared.IsWebhookEnabled() {
 		node.Default()
@@ -59,10 +85,9 @@ func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (resul
 
 	shared.UpdateLabels(&node, "nearcore", node.Spec.Network)
 
-	// reconcile persistent volume claim
-	if err = r.ReconcileOwned(ctx, &node, &corev1.PersistentVolumeClaim{}, func(obj client.Object) error {
-		r.specPVC(&node, obj.(*corev1.PersistentVolumeClaim))
-		return nil
+	// reconcile persistent volume claims
+	if err = r.ReconcilePVCs(ctx, &node, *node.Spec.Replicas, func(pvc *corev1.PersistentVolumeClaim) {
+		r.specPVC(&node, pvc)
 	}); err != nil {
 		return
 	}
@@ -83,35 +108,137 @@ func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (resul
 		return
 	}
 
+	// reconcile replicas node keys
+	var nodeKeys []map[string]string
+	if nodeKeys, err = r.reconcileNodeKeys(ctx, &node); err != nil {
+		return
+	}
+
 	// reconcile stateful set
-	if err = r.ReconcileOwned(ctx, &node, &appsv1.StatefulSet{}, func(obj client.Object) error {
+	sts := &appsv1.StatefulSet{}
+	if err = r.ReconcileOwned(ctx, &node, sts, func(obj client.Object) error {
 		client := nearClients.NewClient(&node)
 		homeDir := client.HomeDir()
 		args := client.Args()
-		args = append(args, node.Spec.ExtraArgs.Encode(false)...)
-
-		r.specStatefulSet(&node, obj.(*appsv1.StatefulSet), homeDir, args)
-		return nil
+		args = node.Spec.ExtraArgs.Merge(args, node.Spec.RemoveArgs, false)
+
+		sts := obj.(*appsv1.StatefulSet)
+		if err := r.specStatefulSet(&node, sts, homeDir, args); err != nil {
+			return err
+		}
+		shared.SpecShutdown(node.Spec.Shutdown, client, &sts.Spec.Template.Spec)
+		return r.SpecContentHash(ctx, &node, sts, references(&node))
 	}); err != nil {
 		return
 	}
 
-	if err = r.updateStatus(ctx, &node); err != nil {
+	// reconcile pod disruption budget
+	if err = r.ReconcilePodDisruptionBudget(ctx, &node, node.Spec.Disruption, *node.Spec.Replicas); err != nil {
+		return
+	}
+
+	// reconcile network policy
+	if err = r.ReconcileNetworkPolicy(ctx, &node, node.Spec.Access, &sts.Spec.Template.Spec, nil); err != nil {
+		return
+	}
+
+	// reconcile prometheus service monitor
+	endpoint := shared.MetricsEndpoint{Port: "prometheus", Path: "/metrics"}
+	if err = r.ReconcileServiceMonitor(ctx, &node, node.Spec.Metrics, endpoint); err != nil {
+		return
+	}
+
+	if err = r.updateStatus(ctx, &node, nodeKeys); err != nil {
 		return
 	}
 
 	return
 }
 
+// reconcileNodeKeys reconciles node key of every replica if node has multiple replicas
+// first replica uses node private key if provided
+func (r *NodeReconciler) reconcileNodeKeys(ctx context.Context, node *nearv1alpha1.Node) ([]map[string]string, error) {
+	if !shared.HasReplicaKeys(node.Spec.Replicas) {
+		return nil, nil
+	}
+
+	var primary map[string]string
+	if node.Spec.NodePrivateKeySecretName.Name != "" {
+		key := types.NamespacedName{
+			Name:      node.Spec.NodePrivateKeySecretName.Name,
+			Namespace: node.Namespace,
+		}
+
+		content, err := r.GetSecret(ctx, node, key, node.Spec.NodePrivateKeySecretName.KeyOr("key"))
+		if err != nil {
+			return nil, err
+		}
+
+		primary = map[string]string{"node_key.json": content}
+	}
+
+	return r.ReconcileNodeKeys(ctx, node, *node.Spec.Replicas, primary, func() (map[string]string, error) {
+		publicKey, privateKey, err := ed25519.GenerateKey(nil)
+		if err != nil {
+			return nil, err
+		}
+
+		content, err := json.Marshal(nodeKey{
+			AccountID: "node",
+			PublicKey: "ed25519:" + helpers.Base58Encode(publicKey),
+			SecretKey: "ed25519:" + helpers.Base58Encode(privateKey),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return map[string]string{"node_key.json": string(content)}, nil
+	})
+}
+
+// references returns secrets and config maps referenced by NEAR node
+func references(node *nearv1alpha1.Node) shared.References {
+	refs := shared.PodExtrasReferences(node.Spec.PodExtras)
+	// keys loaded from secret store aren't kubernetes secrets
+	if node.Spec.SecretStore == nil {
+		refs.Add(node.Spec.NodePrivateKeySecretName.Name, node.Spec.ValidatorSecretName.Name)
+	}
+	return refs
+}
+
 // updateStatus updates NEAR node status
-func (r *NodeReconciler) updateStatus(ctx context.Context, peer *nearv1alpha1.Node) error {
+func (r *NodeReconciler) updateStatus(ctx context.Context, peer *nearv1alpha1.Node, nodeKeys []map[string]string) error {
+	previous := peer.Status
+
 	peer.Status.Client = "nearcore"
 
+	var replicas []sharedAPI.ReplicaStatus
+	for ordinal, files := range nodeKeys {
+		key := nodeKey{}
+		name := shared.ReplicaName(peer, uint(ordinal))
+		if err := json.Unmarshal([]byte(files["node_key.json"]), &key); err != nil {
+			return fmt.Errorf("invalid node key of replica %s: %w", name, err)
+		}
+		replicas = append(replicas, sharedAPI.ReplicaStatus{
+			Name: name,
+			ID:   key.PublicKey,
+		})
+	}
+
+	peer.Status.Replicas = replicas
+
+	peer.Status.Mode = shared.Mode(peer, peer.Spec.Suspend)
+	if err := r.UpdateResyncStatus(ctx, peer, &peer.Status.ResyncStatus, peer.Spec.DataResync); err != nil {
+		return err
+	}
+
 	if err := r.Status().Update(ctx, peer); err != nil {
 		log.FromContext(ctx).Error(err, "unable to update node status")
 		return err
 	}
 
+	r.RecordStatusTransition(peer, previous, peer.Status)
+
 	return nil
 }
 
@@ -143,7 +270,7 @@ func (r *NodeReconciler) specService(node *nearv1alpha1.Node, svc *corev1.Servic
 		})
 		svc.Spec.Ports = append(svc.Spec.Ports, corev1.ServicePort{
 			Name:       "prometheus",
-			Port:       int32(node.Spec.PrometheusPort),
+			Port:       int32(node.Spec.Metrics.Port),
 			TargetPort: intstr.FromString("prometheus"),
 		})
 	}
@@ -194,14 +321,6 @@ func (r *NodeReconciler) createVolumes(node *nearv1alpha1.Node) []corev1.Volume
 	var volumeProjections []corev1.VolumeProjection
 
 	volumes := []corev1.Volume{
-		{
-			Name: "data",
-			VolumeSource: corev1.VolumeSource{
-				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-					ClaimName: node.Name,
-				},
-			},
-		},
 		{
 			Name: "config",
 			VolumeSource: corev1.VolumeSource{
@@ -214,15 +333,15 @@ func (r *NodeReconciler) createVolumes(node *nearv1alpha1.Node) []corev1.Volume
 		},
 	}
 
-	if node.Spec.NodePrivateKeySecretName != "" {
+	if node.Spec.NodePrivateKeySecretName.Name != "" {
 		volumeProjections = append(volumeProjections, corev1.VolumeProjection{
 			Secret: &corev1.SecretProjection{
 				LocalObjectReference: corev1.LocalObjectReference{
-					Name: node.Spec.NodePrivateKeySecretName,
+					Name: node.Spec.NodePrivateKeySecretName.Name,
 				},
 				Items: []corev1.KeyToPath{
 					{
-						Key:  "key",
+						Key:  node.Spec.NodePrivateKeySecretName.KeyOr("key"),
 						Path: "node_key.json",
 					},
 				},
@@ -230,15 +349,15 @@ func (r *NodeReconciler) createVolumes(node *nearv1alpha1.Node) []corev1.Volume
 		})
 	}
 
-	if node.Spec.ValidatorSecretName != "" {
+	if node.Spec.ValidatorSecretName.Name != "" {
 		volumeProjections = append(volumeProjections, corev1.VolumeProjection{
 			Secret: &corev1.SecretProjection{
 				LocalObjectReference: corev1.LocalObjectReference{
-					Name: node.Spec.ValidatorSecretName,
+					Name: node.Spec.ValidatorSecretName.Name,
 				},
 				Items: []corev1.KeyToPath{
 					{
-						Key:  "key",
+						Key:  node.Spec.ValidatorSecretName.KeyOr("key"),
 						Path: "validator_key.json",
 					},
 				},
@@ -256,6 +375,11 @@ func (r *NodeReconciler) createVolumes(node *nearv1alpha1.Node) []corev1.Volume
 	}
 	volumes = append(volumes, secretsVolume)
 
+	// replicas node keys
+	if shared.HasReplicaKeys(node.Spec.Replicas) {
+		volumes = append(volumes, shared.NodeKeysVolume(node, *node.Spec.Replicas, "node_key.json"))
+	}
+
 	return volumes
 }
 
@@ -271,26 +395,40 @@ func (r *NodeReconciler) createVolumeMounts(node *nearv1alpha1.Node, homeDir str
 		},
 	}
 
-	if node.Spec.NodePrivateKeySecretName != "" || node.Spec.ValidatorSecretName != "" {
+	if node.Spec.NodePrivateKeySecretName.Name != "" || node.Spec.ValidatorSecretName.Name != "" {
 		mounts = append(mounts, corev1.VolumeMount{
 			Name:      "secrets",
 			MountPath: shared.PathSecrets(homeDir),
 		})
 	}
 
+	if shared.HasReplicaKeys(node.Spec.Replicas) {
+		mounts = append(mounts, shared.NodeKeysVolumeMount(homeDir))
+	}
+
 	return mounts
 }
 
 // specStatefulSet updates node statefulset spec
-func (r *NodeReconciler) specStatefulSet(node *nearv1alpha1.Node, sts *appsv1.StatefulSet, homeDir string, args []string) {
+func (r *NodeReconciler) specStatefulSet(node *nearv1alpha1.Node, sts *appsv1.StatefulSet, homeDir string, args []string) error {
 
 	sts.ObjectMeta.Labels = node.Labels
 
+	var env []corev1.EnvVar
+
+	nodeKeyPath := shared.PathSecrets(homeDir)
+
+	// replica node keys volume is mounted by pod name
+	if shared.HasReplicaKeys(node.Spec.Replicas) {
+		env = append(env, shared.PodNameEnv())
+		nodeKeyPath = shared.PathNodeKeys(homeDir)
+	}
+
 	initContainers := []corev1.Container{
 		{
 			Name:  "init-near-node",
 			Image: node.Spec.Image,
-			Env: []corev1.EnvVar{
+			Env: append([]corev1.EnvVar{
 				{
 					Name:  shared.EnvDataPath,
 					Value: shared.PathData(homeDir),
@@ -299,39 +437,39 @@ func (r *NodeReconciler) specStatefulSet(node *nearv1alpha1.Node, sts *appsv1.St
 					Name:  envNetwork,
 					Value: node.Spec.Network,
 				},
-			},
+			}, env...),
 			Command:      []string{"/bin/sh"},
 			Args:         []string{fmt.Sprintf("%s/init_near_node.sh", shared.PathConfig(homeDir))},
 			VolumeMounts: r.createVolumeMounts(node, homeDir),
 		},
 	}
 
-	if node.Spec.NodePrivateKeySecretName != "" {
+	if node.Spec.NodePrivateKeySecretName.Name != "" || shared.HasReplicaKeys(node.Spec.Replicas) {
 		initContainers = append(initContainers, corev1.Container{
 			Name:    "copy-node-key",
 			Image:   shared.BusyboxImage,
 			Command: []string{"/bin/sh"},
-			Env: []corev1.EnvVar{
+			Env: append([]corev1.EnvVar{
 				{
 					Name:  shared.EnvDataPath,
 					Value: shared.PathData(homeDir),
 				},
 				{
 					Name:  shared.EnvSecretsPath,
-					Value: shared.PathSecrets(homeDir),
+					Value: nodeKeyPath,
 				},
-			},
+			}, env...),
 			Args:         []string{fmt.Sprintf("%s/copy_node_key.sh", shared.PathConfig(homeDir))},
 			VolumeMounts: r.createVolumeMounts(node, homeDir),
 		})
 	}
 
-	if node.Spec.ValidatorSecretName != "" {
+	if node.Spec.ValidatorSecretName.Name != "" {
 		initContainers = append(initContainers, corev1.Container{
 			Name:    "copy-validator-key",
 			Image:   shared.BusyboxImage,
 			Command: []string{"/bin/sh"},
-			Env: []corev1.EnvVar{
+			Env: append([]corev1.EnvVar{
 				{
 					Name:  shared.EnvDataPath,
 					Value: shared.PathData(homeDir),
@@ -340,7 +478,7 @@ func (r *NodeReconciler) specStatefulSet(node *nearv1alpha1.Node, sts *appsv1.St
 					Name:  shared.EnvSecretsPath,
 					Value: shared.PathSecrets(homeDir),
 				},
-			},
+			}, env...),
 			Args:         []string{fmt.Sprintf("%s/copy_validator_key.sh", shared.PathConfig(homeDir))},
 			VolumeMounts: r.createVolumeMounts(node, homeDir),
 		})
@@ -365,11 +503,15 @@ func (r *NodeReconciler) specStatefulSet(node *nearv1alpha1.Node, sts *appsv1.St
 		})
 		ports = append(ports, corev1.ContainerPort{
 			Name:          "prometheus",
-			ContainerPort: int32(node.Spec.PrometheusPort),
+			ContainerPort: int32(node.Spec.Metrics.Port),
 		})
 	}
 
-	replicas := int32(*node.Spec.Replicas)
+	replicas := shared.StatefulSetReplicas(*node.Spec.Replicas, node.Spec.Suspend)
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	r.specPVC(node, pvc)
+	dataVolumes, volumeClaimTemplates := shared.SpecDataStorage(node, sts, pvc)
 
 	sts.Spec = appsv1.StatefulSetSpec{
 		Selector: &metav1.LabelSelector{
@@ -389,6 +531,7 @@ func (r *NodeReconciler) specStatefulSet(node *nearv1alpha1.Node, sts *appsv1.St
 						Name:         "node",
 						Image:        node.Spec.Image,
 						Args:         args,
+						Env:          env,
 						Ports:        ports,
 						VolumeMounts: r.createVolumeMounts(node, homeDir),
 						Resources: corev1.ResourceRequirements{
@@ -403,21 +546,39 @@ func (r *NodeReconciler) specStatefulSet(node *nearv1alpha1.Node, sts *appsv1.St
 						},
 					},
 				},
-				Volumes: r.createVolumes(node),
+				Volumes: append(dataVolumes, r.createVolumes(node)...),
 			},
 		},
+		VolumeClaimTemplates: volumeClaimTemplates,
 	}
 
+	shared.SpecResync(node.Spec.DataResync, &sts.Spec.Template.Spec, homeDir)
+	if err := shared.SpecPodExtras(node.Spec.PodExtras, &sts.Spec.Template.Spec); err != nil {
+		return err
+	}
+	shared.SpecSecretStore(node.Spec.SecretStore, &sts.Spec.Template, "secrets")
+
+	return nil
 }
 
 func (r *NodeReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	pred := predicate.GenerationChangedPredicate{}
+	if err := shared.IndexReferences(mgr, &nearv1alpha1.Node{}, func(obj client.Object) shared.References {
+		return references(obj.(*nearv1alpha1.Node))
+	}); err != nil {
+		return err
+	}
+
+	// owned objects updates are filtered, referenced objects are watched without predicate so their updates always pass
+	pred := builder.WithPredicates(shared.GenerationOrContentChangedPredicate())
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&nearv1alpha1.Node{}).
-		WithEventFilter(pred).
-		Owns(&appsv1.StatefulSet{}).
-		Owns(&corev1.ConfigMap{}).
-		Owns(&corev1.PersistentVolumeClaim{}).
-		Owns(&corev1.Service{}).
+		For(&nearv1alpha1.Node{}, pred).
+		Owns(&appsv1.StatefulSet{}, pred).
+		Owns(&policyv1.PodDisruptionBudget{}, pred).
+		Owns(&networkingv1.NetworkPolicy{}, pred).
+		Owns(&corev1.ConfigMap{}, pred).
+		Owns(&corev1.PersistentVolumeClaim{}, pred).
+		Owns(&corev1.Service{}, pred).
+		Watches(&corev1.Secret{}, r.EnqueueReferencing(&nearv1alpha1.NodeList{}, shared.IndexSecrets)).
+		Watches(&corev1.ConfigMap{}, r.EnqueueReferencing(&nearv1alpha1.NodeList{}, shared.IndexConfigMaps)).
 		Complete(r)
 }
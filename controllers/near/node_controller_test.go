@@ -3,6 +3,7 @@ package controllers
 import (
 	"context"
 	"fmt"
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
 	"os"
 	"time"
 
@@ -39,8 +40,8 @@ var _ = Describe("NEAR node controller", func() {
 		Network:                  "mainnet",
 		RPC:                      true,
 		Archive:                  true, // test volume storage size
-		NodePrivateKeySecretName: "my-node-key",
-		ValidatorSecretName:      "validator-key",
+		NodePrivateKeySecretName: sharedAPI.SecretKeySelector{Name: "my-node-key"},
+		ValidatorSecretName:      sharedAPI.SecretKeySelector{Name: "validator-key"},
 	}
 
 	toCreate := &nearv1alpha1.Node{
@@ -204,18 +205,13 @@ var _ = Describe("NEAR node controller", func() {
 				MountPath: shared.PathSecrets(client.HomeDir()),
 			},
 		))
+		// data volume claim template
+		Expect(fetched.Spec.VolumeClaimTemplates).To(HaveLen(1))
+		Expect(fetched.Spec.VolumeClaimTemplates[0].Name).To(Equal("data"))
 		// volumes
 		mode := corev1.ConfigMapVolumeSourceDefaultMode
 		Expect(fetched.Spec.Template.Spec.Volumes).To(ContainElements(
 			[]corev1.Volume{
-				{
-					Name: "data",
-					VolumeSource: corev1.VolumeSource{
-						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-							ClaimName: toCreate.Name,
-						},
-					},
-				},
 				{
 					Name: "config",
 					VolumeSource: corev1.VolumeSource{
@@ -235,11 +231,11 @@ var _ = Describe("NEAR node controller", func() {
 								{
 									Secret: &corev1.SecretProjection{
 										LocalObjectReference: corev1.LocalObjectReference{
-											Name: toCreate.Spec.NodePrivateKeySecretName,
+											Name: toCreate.Spec.NodePrivateKeySecretName.Name,
 										},
 										Items: []corev1.KeyToPath{
 											{
-												Key:  "key",
+												Key:  toCreate.Spec.NodePrivateKeySecretName.KeyOr("key"),
 												Path: "node_key.json",
 											},
 										},
@@ -248,11 +244,11 @@ var _ = Describe("NEAR node controller", func() {
 								{
 									Secret: &corev1.SecretProjection{
 										LocalObjectReference: corev1.LocalObjectReference{
-											Name: toCreate.Spec.ValidatorSecretName,
+											Name: toCreate.Spec.ValidatorSecretName.Name,
 										},
 										Items: []corev1.KeyToPath{
 											{
-												Key:  "key",
+												Key:  toCreate.Spec.ValidatorSecretName.KeyOr("key"),
 												Path: "validator_key.json",
 											},
 										},
@@ -294,7 +290,11 @@ var _ = Describe("NEAR node controller", func() {
 
 	It("Should create node data persistent volume with correct resources", func() {
 		fetched := &corev1.PersistentVolumeClaim{}
-		Expect(k8sClient.Get(context.Background(), key, fetched)).To(Succeed())
+		pvcKey := types.NamespacedName{
+			Name:      shared.ReplicaPVCName(&metav1.ObjectMeta{Name: key.Name}, 0),
+			Namespace: key.Namespace,
+		}
+		Expect(k8sClient.Get(context.Background(), pvcKey, fetched)).To(Succeed())
 		Expect(fetched.OwnerReferences).To(ContainElements(nodeOwnerReference))
 		expectedResources := corev1.VolumeResourceRequirements{
 			Requests: corev1.ResourceList{
@@ -86,8 +86,9 @@ var _ = BeforeSuite(func() {
 	// start node reconciler
 	nodeReconciler := &NodeReconciler{
 		Reconciler: shared.Reconciler{
-			Client: k8sManager.GetClient(),
-			Scheme: scheme.Scheme,
+			Client:   k8sManager.GetClient(),
+			Scheme:   scheme.Scheme,
+			Recorder: k8sManager.GetEventRecorderFor("kotal-operator"),
 		},
 	}
 	nodeReconciler.SetupWithManager(k8sManager)
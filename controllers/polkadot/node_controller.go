@@ -2,19 +2,28 @@ package controllers
 
 import (
 	"context"
+	"crypto/ed25519"
 	_ "embed"
+	"encoding/hex"
 	"fmt"
+	"strings"
 
 	polkadotv1alpha1 "github.com/kotalco/kotal/apis/polkadot/v1alpha1"
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
 	polkadotClients "github.com/kotalco/kotal/clients/polkadot"
 	"github.com/kotalco/kotal/controllers/shared"
+	"github.com/kotalco/kotal/helpers"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 // NodeReconciler reconciles a Node object
@@ -30,18 +39,30 @@ var (
 // +kubebuilder:rbac:groups=polkadot.kotal.io,resources=nodes,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=polkadot.kotal.io,resources=nodes/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=watch;get;list;create;update;delete
-// +kubebuilder:rbac:groups=core,resources=services;configmaps;persistentvolumeclaims,verbs=watch;get;create;update;list;delete
+// +kubebuilder:rbac:groups=core,resources=services;configmaps;secrets;persistentvolumeclaims,verbs=watch;get;create;update;list;delete
 
 func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 	defer shared.IgnoreConflicts(&err)
 
 	var node polkadotv1alpha1.Node
+	defer r.ObserveReconcile(&node, req.NamespacedName, &err)
 
 	if err = r.Client.Get(ctx, req.NamespacedName, &node); err != nil {
 		err = client.IgnoreNotFound(err)
 		return
 	}
 
+	var deleting bool
+	if deleting, result, err = r.ReconcileDeletion(ctx, &node, node.Spec.Deletion); deleting || err != nil {
+		return
+	}
+
+	// owned objects are left as is while node is paused
+	if shared.IsPaused(&node) {
+		err = r.ReconcilePaused(ctx, &node, &node.Status.Mode)
+		return
+	}
+
 	// default the node if webhooks are disabled
 	if !shared.IsWebhookEnabled() {
 		node.Default()
@@ -57,10 +78,9 @@ func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (resul
 		return
 	}
 
-	// reconcile persistent volume claim
-	if err = r.ReconcileOwned(ctx, &node, &corev1.PersistentVolumeClaim{}, func(obj client.Object) error {
-		r.specPVC(&node, obj.(*corev1.PersistentVolumeClaim))
-		return nil
+	// reconcile persistent volume claims
+	if err = r.ReconcilePVCs(ctx, &node, *node.Spec.Replicas, func(pvc *corev1.PersistentVolumeClaim) {
+		r.specPVC(&node, pvc)
 	}); err != nil {
 		return
 	}
@@ -73,21 +93,132 @@ func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (resul
 		return
 	}
 
+	// reconcile replicas node keys
+	var nodeKeys []map[string]string
+	if nodeKeys, err = r.reconcileNodeKeys(ctx, &node); err != nil {
+		return
+	}
+
 	// reconcile stateful set
-	if err = r.ReconcileOwned(ctx, &node, &appsv1.StatefulSet{}, func(obj client.Object) error {
+	sts := &appsv1.StatefulSet{}
+	if err = r.ReconcileOwned(ctx, &node, sts, func(obj client.Object) error {
 		client := polkadotClients.NewClient(&node)
 		args := client.Args()
-		args = append(args, node.Spec.ExtraArgs.Encode(false)...)
+		args = node.Spec.ExtraArgs.Merge(args, node.Spec.RemoveArgs, false)
 		homeDir := client.HomeDir()
 
-		return r.specStatefulSet(&node, obj.(*appsv1.StatefulSet), homeDir, args)
+		sts := obj.(*appsv1.StatefulSet)
+		if err := r.specStatefulSet(&node, sts, homeDir, args); err != nil {
+			return err
+		}
+		shared.SpecShutdown(node.Spec.Shutdown, client, &sts.Spec.Template.Spec)
+		return r.SpecContentHash(ctx, &node, sts, references(&node))
 	}); err != nil {
 		return
 	}
 
+	// reconcile pod disruption budget
+	if err = r.ReconcilePodDisruptionBudget(ctx, &node, node.Spec.Disruption, *node.Spec.Replicas); err != nil {
+		return
+	}
+
+	// reconcile network policy
+	if err = r.ReconcileNetworkPolicy(ctx, &node, node.Spec.Access, &sts.Spec.Template.Spec, nil); err != nil {
+		return
+	}
+
+	// reconcile prometheus service monitor
+	endpoint := shared.MetricsEndpoint{Port: "prometheus", Path: "/metrics"}
+	if err = r.ReconcileServiceMonitor(ctx, &node, node.Spec.Metrics, endpoint); err != nil {
+		return
+	}
+
+	if err = r.updateStatus(ctx, &node, nodeKeys); err != nil {
+		return
+	}
+
 	return
 }
 
+// reconcileNodeKeys reconciles node key of every replica if node has multiple replicas
+// first replica uses node private key if provided
+func (r *NodeReconciler) reconcileNodeKeys(ctx context.Context, node *polkadotv1alpha1.Node) ([]map[string]string, error) {
+	if !shared.HasReplicaKeys(node.Spec.Replicas) {
+		return nil, nil
+	}
+
+	var primary map[string]string
+	if node.Spec.NodePrivateKeySecretName.Name != "" {
+		key := types.NamespacedName{
+			Name:      node.Spec.NodePrivateKeySecretName.Name,
+			Namespace: node.Namespace,
+		}
+
+		nodekey, err := r.GetSecret(ctx, node, key, node.Spec.NodePrivateKeySecretName.KeyOr("key"))
+		if err != nil {
+			return nil, err
+		}
+
+		primary = map[string]string{"nodekey": nodekey}
+	}
+
+	return r.ReconcileNodeKeys(ctx, node, *node.Spec.Replicas, primary, func() (map[string]string, error) {
+		_, privateKey, err := ed25519.GenerateKey(nil)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string{"nodekey": hex.EncodeToString(privateKey.Seed())}, nil
+	})
+}
+
+// references returns secrets and config maps referenced by polkadot node
+func references(node *polkadotv1alpha1.Node) shared.References {
+	refs := shared.PodExtrasReferences(node.Spec.PodExtras)
+	// node private key loaded from secret store isn't kubernetes secret
+	if node.Spec.SecretStore == nil {
+		refs.Add(node.Spec.NodePrivateKeySecretName.Name)
+	}
+	return refs
+}
+
+// updateStatus updates polkadot node status
+func (r *NodeReconciler) updateStatus(ctx context.Context, node *polkadotv1alpha1.Node, nodeKeys []map[string]string) error {
+	previous := node.Status
+
+	var replicas []sharedAPI.ReplicaStatus
+	for ordinal, files := range nodeKeys {
+		name := shared.ReplicaName(node, uint(ordinal))
+
+		// node key is Ed25519 private key seed in hex format
+		seed, err := hex.DecodeString(strings.TrimPrefix(files["nodekey"], "0x"))
+		if err != nil || len(seed) != ed25519.SeedSize {
+			return fmt.Errorf("invalid node key of replica %s", name)
+		}
+		publicKey := ed25519.NewKeyFromSeed(seed).Public().(ed25519.PublicKey)
+
+		replicas = append(replicas, sharedAPI.ReplicaStatus{
+			Name: name,
+			ID:   helpers.Libp2pPeerID(publicKey),
+		})
+	}
+
+	node.Status.Replicas = replicas
+
+	node.Status.Mode = shared.Mode(node, node.Spec.Suspend)
+	if err := r.UpdateResyncStatus(ctx, node, &node.Status.ResyncStatus, node.Spec.DataResync); err != nil {
+		return err
+	}
+
+	if err := r.Status().Update(ctx, node); err != nil {
+		log.FromContext(ctx).Error(err, "unable to update node status")
+		return err
+	}
+
+	r.RecordStatusTransition(node, previous, node.Status)
+
+	return nil
+}
+
 // specConfigmap updates polkadot node configmap spec
 func (r *NodeReconciler) specConfigmap(node *polkadotv1alpha1.Node, config *corev1.ConfigMap) {
 	config.ObjectMeta.Labels = node.Labels
@@ -113,10 +244,10 @@ func (r *NodeReconciler) specService(node *polkadotv1alpha1.Node, svc *corev1.Se
 		},
 	}
 
-	if node.Spec.Prometheus {
+	if node.Spec.Prometheus || node.Spec.Metrics.Enabled {
 		svc.Spec.Ports = append(svc.Spec.Ports, corev1.ServicePort{
 			Name:       "prometheus",
-			Port:       int32(node.Spec.PrometheusPort),
+			Port:       int32(node.Spec.Metrics.Port),
 			TargetPort: intstr.FromString("prometheus"),
 		})
 	}
@@ -142,16 +273,6 @@ func (r *NodeReconciler) specService(node *polkadotv1alpha1.Node, svc *corev1.Se
 
 // nodeVolumes returns node volumes
 func (r *NodeReconciler) nodeVolumes(node *polkadotv1alpha1.Node) (volumes []corev1.Volume) {
-	dataVolume := corev1.Volume{
-		Name: "data",
-		VolumeSource: corev1.VolumeSource{
-			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-				ClaimName: node.Name,
-			},
-		},
-	}
-	volumes = append(volumes, dataVolume)
-
 	configVolume := corev1.Volume{
 		Name: "config",
 		VolumeSource: corev1.VolumeSource{
@@ -164,15 +285,15 @@ func (r *NodeReconciler) nodeVolumes(node *polkadotv1alpha1.Node) (volumes []cor
 	}
 	volumes = append(volumes, configVolume)
 
-	if node.Spec.NodePrivateKeySecretName != "" {
+	if node.Spec.NodePrivateKeySecretName.Name != "" {
 		secretVolume := corev1.Volume{
 			Name: "secret",
 			VolumeSource: corev1.VolumeSource{
 				Secret: &corev1.SecretVolumeSource{
-					SecretName: node.Spec.NodePrivateKeySecretName,
+					SecretName: node.Spec.NodePrivateKeySecretName.Name,
 					Items: []corev1.KeyToPath{
 						{
-							Key:  "key",
+							Key:  node.Spec.NodePrivateKeySecretName.KeyOr("key"),
 							Path: "nodekey",
 						},
 					},
@@ -182,6 +303,11 @@ func (r *NodeReconciler) nodeVolumes(node *polkadotv1alpha1.Node) (volumes []cor
 		volumes = append(volumes, secretVolume)
 	}
 
+	// replicas node keys
+	if shared.HasReplicaKeys(node.Spec.Replicas) {
+		volumes = append(volumes, shared.NodeKeysVolume(node, *node.Spec.Replicas, "nodekey"))
+	}
+
 	return
 }
 
@@ -199,13 +325,18 @@ func (r *NodeReconciler) nodeVolumeMounts(node *polkadotv1alpha1.Node, homeDir s
 	}
 	mounts = append(mounts, configMount)
 
-	if node.Spec.NodePrivateKeySecretName != "" {
+	if node.Spec.NodePrivateKeySecretName.Name != "" {
 		secretMount := corev1.VolumeMount{
 			Name:      "secret",
 			MountPath: shared.PathSecrets(homeDir),
 		}
 		mounts = append(mounts, secretMount)
 	}
+
+	if shared.HasReplicaKeys(node.Spec.Replicas) {
+		mounts = append(mounts, shared.NodeKeysVolumeMount(homeDir))
+	}
+
 	return
 }
 
@@ -215,21 +346,30 @@ func (r *NodeReconciler) specStatefulSet(node *polkadotv1alpha1.Node, sts *appsv
 	sts.ObjectMeta.Labels = node.Labels
 
 	var initContainers []corev1.Container
+	var env []corev1.EnvVar
+
+	secretsPath := shared.PathSecrets(homeDir)
 
-	if node.Spec.NodePrivateKeySecretName != "" {
+	// replica node keys volume is mounted by pod name
+	if shared.HasReplicaKeys(node.Spec.Replicas) {
+		env = append(env, shared.PodNameEnv())
+		secretsPath = shared.PathNodeKeys(homeDir)
+	}
+
+	if node.Spec.NodePrivateKeySecretName.Name != "" || shared.HasReplicaKeys(node.Spec.Replicas) {
 		convertEnodePrivateKey := corev1.Container{
 			Name:  "convert-node-private-key",
 			Image: shared.BusyboxImage,
-			Env: []corev1.EnvVar{
+			Env: append([]corev1.EnvVar{
 				{
 					Name:  shared.EnvDataPath,
 					Value: shared.PathData(homeDir),
 				},
 				{
 					Name:  shared.EnvSecretsPath,
-					Value: shared.PathSecrets(homeDir),
+					Value: secretsPath,
 				},
-			},
+			}, env...),
 			Command:      []string{"/bin/sh"},
 			Args:         []string{fmt.Sprintf("%s/convert_node_private_key.sh", shared.PathConfig(homeDir))},
 			VolumeMounts: r.nodeVolumeMounts(node, homeDir),
@@ -244,10 +384,10 @@ func (r *NodeReconciler) specStatefulSet(node *polkadotv1alpha1.Node, sts *appsv
 		},
 	}
 
-	if node.Spec.Prometheus {
+	if node.Spec.Prometheus || node.Spec.Metrics.Enabled {
 		ports = append(ports, corev1.ContainerPort{
 			Name:          "prometheus",
-			ContainerPort: int32(node.Spec.PrometheusPort),
+			ContainerPort: int32(node.Spec.Metrics.Port),
 		})
 	}
 
@@ -265,7 +405,11 @@ func (r *NodeReconciler) specStatefulSet(node *polkadotv1alpha1.Node, sts *appsv
 		})
 	}
 
-	replicas := int32(*node.Spec.Replicas)
+	replicas := shared.StatefulSetReplicas(*node.Spec.Replicas, node.Spec.Suspend)
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	r.specPVC(node, pvc)
+	dataVolumes, volumeClaimTemplates := shared.SpecDataStorage(node, sts, pvc)
 
 	sts.Spec = appsv1.StatefulSetSpec{
 		Selector: &metav1.LabelSelector{
@@ -285,6 +429,7 @@ func (r *NodeReconciler) specStatefulSet(node *polkadotv1alpha1.Node, sts *appsv
 						Name:         "node",
 						Image:        node.Spec.Image,
 						Args:         args,
+						Env:          env,
 						Ports:        ports,
 						VolumeMounts: r.nodeVolumeMounts(node, homeDir),
 						Resources: corev1.ResourceRequirements{
@@ -299,11 +444,18 @@ func (r *NodeReconciler) specStatefulSet(node *polkadotv1alpha1.Node, sts *appsv
 						},
 					},
 				},
-				Volumes: r.nodeVolumes(node),
+				Volumes: append(dataVolumes, r.nodeVolumes(node)...),
 			},
 		},
+		VolumeClaimTemplates: volumeClaimTemplates,
 	}
 
+	shared.SpecResync(node.Spec.DataResync, &sts.Spec.Template.Spec, homeDir)
+	if err := shared.SpecPodExtras(node.Spec.PodExtras, &sts.Spec.Template.Spec); err != nil {
+		return err
+	}
+	shared.SpecSecretStore(node.Spec.SecretStore, &sts.Spec.Template, "secret")
+
 	return nil
 }
 
@@ -332,11 +484,21 @@ func (r *NodeReconciler) specPVC(node *polkadotv1alpha1.Node, pvc *corev1.Persis
 }
 
 func (r *NodeReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := shared.IndexReferences(mgr, &polkadotv1alpha1.Node{}, func(obj client.Object) shared.References {
+		return references(obj.(*polkadotv1alpha1.Node))
+	}); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&polkadotv1alpha1.Node{}).
 		Owns(&corev1.PersistentVolumeClaim{}).
 		Owns(&corev1.Service{}).
 		Owns(&corev1.ConfigMap{}).
 		Owns(&appsv1.StatefulSet{}).
+		Owns(&policyv1.PodDisruptionBudget{}).
+		Owns(&networkingv1.NetworkPolicy{}).
+		Watches(&corev1.Secret{}, r.EnqueueReferencing(&polkadotv1alpha1.NodeList{}, shared.IndexSecrets)).
+		Watches(&corev1.ConfigMap{}, r.EnqueueReferencing(&polkadotv1alpha1.NodeList{}, shared.IndexConfigMaps)).
 		Complete(r)
 }
@@ -0,0 +1,76 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	polkadotv1alpha1 "github.com/kotalco/kotal/apis/polkadot/v1alpha1"
+	"github.com/kotalco/kotal/controllers/shared"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReconcileReplicas(t *testing.T) {
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = polkadotv1alpha1.AddToScheme(scheme)
+
+	r := &NodeReconciler{
+		Reconciler: shared.Reconciler{
+			Client:   fake.NewClientBuilder().WithScheme(scheme).Build(),
+			Scheme:   scheme,
+			Recorder: record.NewFakeRecorder(20),
+		},
+	}
+
+	var replicas uint = 3
+	node := &polkadotv1alpha1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-node", Namespace: "default", UID: "my-node-uid"},
+		Spec: polkadotv1alpha1.NodeSpec{
+			Network:  "polkadot",
+			Replicas: &replicas,
+		},
+	}
+	node.Default()
+
+	keys, err := r.reconcileNodeKeys(ctx, node)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 3 {
+		t.Fatalf("expected 3 replica node keys, got %d", len(keys))
+	}
+	distinct := map[string]bool{}
+	for _, files := range keys {
+		distinct[files["nodekey"]] = true
+	}
+	if len(distinct) != 3 {
+		t.Errorf("expected 3 distinct node keys, got %v", keys)
+	}
+
+	if err := r.ReconcilePVCs(ctx, node, replicas, func(pvc *corev1.PersistentVolumeClaim) {
+		r.specPVC(node, pvc)
+	}); err != nil {
+		t.Fatal(err)
+	}
+	pvcs := &corev1.PersistentVolumeClaimList{}
+	if err := r.Client.List(ctx, pvcs, client.InNamespace(node.Namespace)); err != nil {
+		t.Fatal(err)
+	}
+	if len(pvcs.Items) != 3 {
+		t.Fatalf("expected 3 persistent volume claims, got %d", len(pvcs.Items))
+	}
+	for ordinal := uint(0); ordinal < replicas; ordinal++ {
+		name := shared.ReplicaPVCName(node, ordinal)
+		if err := r.Client.Get(ctx, client.ObjectKey{Name: name, Namespace: node.Namespace}, &corev1.PersistentVolumeClaim{}); err != nil {
+			t.Errorf("expected persistent volume claim %s, got %s", name, err)
+		}
+	}
+}
@@ -85,8 +85,9 @@ var _ = BeforeSuite(func() {
 	// start node reconciler
 	peerReconciler := &NodeReconciler{
 		Reconciler: shared.Reconciler{
-			Client: k8sManager.GetClient(),
-			Scheme: scheme.Scheme,
+			Client:   k8sManager.GetClient(),
+			Scheme:   scheme.Scheme,
+			Recorder: k8sManager.GetEventRecorderFor("kotal-operator"),
 		},
 	}
 	peerReconciler.SetupWithManager(k8sManager)
@@ -0,0 +1,192 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// +kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshots,verbs=get;list;watch;create
+
+// DeletionFinalizer is custom resources finalizer applying deletion policy
+const DeletionFinalizer = "kotal.io/deletion-policy"
+
+// VolumeSnapshotGVK is volume snapshot group, version and kind
+var VolumeSnapshotGVK = schema.GroupVersionKind{
+	Group:   "snapshot.storage.k8s.io",
+	Version: "v1",
+	Kind:    "VolumeSnapshot",
+}
+
+// ReconcileDeletion adds deletion finalizer to custom resource, and applies deletion policy once it's deleted
+// it returns true if custom resource is being deleted, and reconciliation must stop
+func (r Reconciler) ReconcileDeletion(ctx context.Context, cr CustomResource, deletion sharedAPI.Deletion) (deleting bool, result ctrl.Result, err error) {
+	if cr.GetDeletionTimestamp().IsZero() {
+		if controllerutil.ContainsFinalizer(cr, DeletionFinalizer) {
+			return
+		}
+		patch := client.MergeFrom(cr.DeepCopyObject().(client.Object))
+		controllerutil.AddFinalizer(cr, DeletionFinalizer)
+		err = r.Client.Patch(ctx, cr, patch)
+		return
+	}
+
+	deleting = true
+
+	if !controllerutil.ContainsFinalizer(cr, DeletionFinalizer) {
+		return
+	}
+
+	switch deletion.Policy() {
+	case sharedAPI.DeletionPolicyRetain:
+		err = r.orphanOwned(ctx, cr)
+	case sharedAPI.DeletionPolicySnapshot:
+		// volume snapshot CRDs aren't installed, retain data instead of blocking deletion forever
+		if _, err = r.Client.RESTMapper().RESTMapping(VolumeSnapshotGVK.GroupKind(), VolumeSnapshotGVK.Version); meta.IsNoMatchError(err) {
+			r.Recorder.Event(cr, corev1.EventTypeWarning, EventReasonSnapshotUnsupported, "VolumeSnapshot CRD is not installed, retaining persistent volume claims and secrets instead")
+			err = r.orphanOwned(ctx, cr)
+			break
+		} else if err != nil {
+			break
+		}
+		var ready bool
+		if ready, err = r.snapshotPVCs(ctx, cr, deletion.VolumeSnapshotClassName); err == nil && !ready {
+			result.RequeueAfter = 10 * time.Second
+			return
+		}
+	}
+
+	if err != nil {
+		r.Recorder.Eventf(cr, corev1.EventTypeWarning, EventReasonReconcileFailed, "Failed to apply %s deletion policy: %s", deletion.Policy(), err)
+		return
+	}
+
+	patch := client.MergeFrom(cr.DeepCopyObject().(client.Object))
+	controllerutil.RemoveFinalizer(cr, DeletionFinalizer)
+	err = r.Client.Patch(ctx, cr, patch)
+
+	return
+}
+
+// ownedPVCs returns persistent volume claims controlled by custom resource
+func (r Reconciler) ownedPVCs(ctx context.Context, cr CustomResource) (pvcs []corev1.PersistentVolumeClaim, err error) {
+	list := &corev1.PersistentVolumeClaimList{}
+	if err = r.Client.List(ctx, list, client.InNamespace(cr.GetNamespace())); err != nil {
+		return
+	}
+
+	for _, pvc := range list.Items {
+		if metav1.IsControlledBy(&pvc, cr) {
+			pvcs = append(pvcs, pvc)
+		}
+	}
+
+	return
+}
+
+// orphanOwned removes custom resource owner reference from persistent volume claims and generated secrets
+// so they're not garbage collected after custom resource is deleted
+func (r Reconciler) orphanOwned(ctx context.Context, cr CustomResource) error {
+	pvcs, err := r.ownedPVCs(ctx, cr)
+	if err != nil {
+		return err
+	}
+
+	objects := []client.Object{}
+	for i := range pvcs {
+		objects = append(objects, &pvcs[i])
+	}
+
+	secrets := &corev1.SecretList{}
+	if err := r.Client.List(ctx, secrets, client.InNamespace(cr.GetNamespace())); err != nil {
+		return err
+	}
+	for i := range secrets.Items {
+		if metav1.IsControlledBy(&secrets.Items[i], cr) {
+			objects = append(objects, &secrets.Items[i])
+		}
+	}
+
+	for _, obj := range objects {
+		var refs []metav1.OwnerReference
+		for _, ref := range obj.GetOwnerReferences() {
+			if ref.UID != cr.GetUID() {
+				refs = append(refs, ref)
+			}
+		}
+		obj.SetOwnerReferences(refs)
+
+		if err := r.Client.Update(ctx, obj); err != nil {
+			return err
+		}
+	}
+
+	if len(objects) != 0 {
+		r.Recorder.Eventf(cr, corev1.EventTypeNormal, EventReasonRetained, "Retained %d persistent volume claims and secrets", len(objects))
+	}
+
+	return nil
+}
+
+// VolumeSnapshotName returns name of volume snapshot taken before persistent volume claim is deleted
+func VolumeSnapshotName(pvc string) string {
+	return fmt.Sprintf("%s-final", pvc)
+}
+
+// snapshotPVCs takes volume snapshots of persistent volume claims controlled by custom resource
+// it returns true if all volume snapshots are ready to use
+func (r Reconciler) snapshotPVCs(ctx context.Context, cr CustomResource, class string) (ready bool, err error) {
+	pvcs, err := r.ownedPVCs(ctx, cr)
+	if err != nil {
+		return
+	}
+
+	ready = true
+
+	for _, pvc := range pvcs {
+		snapshot := &unstructured.Unstructured{}
+		snapshot.SetGroupVersionKind(VolumeSnapshotGVK)
+		key := client.ObjectKey{Name: VolumeSnapshotName(pvc.Name), Namespace: pvc.Namespace}
+
+		err = r.Client.Get(ctx, key, snapshot)
+		if client.IgnoreNotFound(err) != nil {
+			return
+		}
+
+		// volume snapshots aren't owned by custom resource, so they outlive it
+		if err != nil {
+			snapshot.SetName(key.Name)
+			snapshot.SetNamespace(key.Namespace)
+			snapshot.SetLabels(cr.GetLabels())
+			source := map[string]interface{}{"persistentVolumeClaimName": pvc.Name}
+			if err = unstructured.SetNestedMap(snapshot.Object, source, "spec", "source"); err != nil {
+				return
+			}
+			if class != "" {
+				if err = unstructured.SetNestedField(snapshot.Object, class, "spec", "volumeSnapshotClassName"); err != nil {
+					return
+				}
+			}
+			if err = r.Client.Create(ctx, snapshot); err != nil {
+				return
+			}
+			r.Recorder.Eventf(cr, corev1.EventTypeNormal, EventReasonCreated, "Created volume snapshot %s", key.Name)
+		}
+
+		if readyToUse, _, _ := unstructured.NestedBool(snapshot.Object, "status", "readyToUse"); !readyToUse {
+			ready = false
+		}
+	}
+
+	return
+}
@@ -0,0 +1,194 @@
+package shared
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	ethereumv1alpha1 "github.com/kotalco/kotal/apis/ethereum/v1alpha1"
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// deletingNode returns reconciler and ethereum node being deleted, owning a persistent volume claim and a secret
+// gvks are kinds known to the reconciler client rest mapper
+func deletingNode(t *testing.T, gvks ...schema.GroupVersionKind) (Reconciler, *ethereumv1alpha1.Node) {
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = ethereumv1alpha1.AddToScheme(scheme)
+
+	mapper := meta.NewDefaultRESTMapper(nil)
+	for _, gvk := range gvks {
+		mapper.Add(gvk, meta.RESTScopeNamespace)
+	}
+
+	r := Reconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithRESTMapper(mapper).Build(),
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(10),
+	}
+
+	node := &ethereumv1alpha1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-node", Namespace: "default"},
+	}
+	if err := r.Client.Create(ctx, node); err != nil {
+		t.Fatal(err)
+	}
+
+	deleting, _, err := r.ReconcileDeletion(ctx, node, sharedAPI.Deletion{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deleting {
+		t.Errorf("expected node not to be deleting")
+	}
+	if err = r.Client.Get(ctx, client.ObjectKeyFromObject(node), node); err != nil {
+		t.Fatal(err)
+	}
+	if !controllerutil.ContainsFinalizer(node, DeletionFinalizer) {
+		t.Fatalf("expected deletion finalizer to be added, got %v", node.Finalizers)
+	}
+
+	for _, obj := range []client.Object{
+		&corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "my-node", Namespace: "default"}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "my-node", Namespace: "default"}},
+	} {
+		if err = controllerutil.SetControllerReference(node, obj, scheme); err != nil {
+			t.Fatal(err)
+		}
+		if err = r.Client.Create(ctx, obj); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err = r.Client.Delete(ctx, node); err != nil {
+		t.Fatal(err)
+	}
+	if err = r.Client.Get(ctx, client.ObjectKeyFromObject(node), node); err != nil {
+		t.Fatal(err)
+	}
+
+	return r, node
+}
+
+func TestReconcileDeletionRetain(t *testing.T) {
+	ctx := context.Background()
+	r, node := deletingNode(t)
+
+	deleting, _, err := r.ReconcileDeletion(ctx, node, sharedAPI.Deletion{DeletionPolicy: sharedAPI.DeletionPolicyRetain})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !deleting {
+		t.Errorf("expected node to be deleting")
+	}
+
+	key := client.ObjectKey{Name: "my-node", Namespace: "default"}
+	for _, obj := range []client.Object{&corev1.PersistentVolumeClaim{}, &corev1.Secret{}} {
+		if err = r.Client.Get(ctx, key, obj); err != nil {
+			t.Fatal(err)
+		}
+		if refs := obj.GetOwnerReferences(); len(refs) != 0 {
+			t.Errorf("expected %T owner references to be removed, got %v", obj, refs)
+		}
+	}
+
+	if err = r.Client.Get(ctx, key, &ethereumv1alpha1.Node{}); client.IgnoreNotFound(err) != nil || err == nil {
+		t.Errorf("expected node to be deleted after deletion finalizer is removed, got %v", err)
+	}
+}
+
+func TestReconcileDeletionSnapshot(t *testing.T) {
+	ctx := context.Background()
+	r, node := deletingNode(t, VolumeSnapshotGVK)
+	deletion := sharedAPI.Deletion{DeletionPolicy: sharedAPI.DeletionPolicySnapshot, VolumeSnapshotClassName: "csi-snapclass"}
+
+	deleting, result, err := r.ReconcileDeletion(ctx, node, deletion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !deleting || result.RequeueAfter == 0 {
+		t.Errorf("expected deletion to be requeued until volume snapshot is ready, got %v", result)
+	}
+
+	snapshot := &unstructured.Unstructured{}
+	snapshot.SetGroupVersionKind(VolumeSnapshotGVK)
+	key := client.ObjectKey{Name: VolumeSnapshotName("my-node"), Namespace: "default"}
+	if err = r.Client.Get(ctx, key, snapshot); err != nil {
+		t.Fatal(err)
+	}
+
+	if pvc, _, _ := unstructured.NestedString(snapshot.Object, "spec", "source", "persistentVolumeClaimName"); pvc != "my-node" {
+		t.Errorf("expected volume snapshot source to be my-node, got %s", pvc)
+	}
+	if class, _, _ := unstructured.NestedString(snapshot.Object, "spec", "volumeSnapshotClassName"); class != "csi-snapclass" {
+		t.Errorf("expected volume snapshot class to be csi-snapclass, got %s", class)
+	}
+
+	if !controllerutil.ContainsFinalizer(node, DeletionFinalizer) {
+		t.Errorf("expected deletion finalizer to be kept until volume snapshot is ready")
+	}
+
+	// volume snapshot becomes ready to use
+	if err = unstructured.SetNestedField(snapshot.Object, true, "status", "readyToUse"); err != nil {
+		t.Fatal(err)
+	}
+	if err = r.Client.Update(ctx, snapshot); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, result, err = r.ReconcileDeletion(ctx, node, deletion); err != nil {
+		t.Fatal(err)
+	}
+	if result.RequeueAfter != 0 {
+		t.Errorf("expected deletion not to be requeued, got %v", result)
+	}
+	if controllerutil.ContainsFinalizer(node, DeletionFinalizer) {
+		t.Errorf("expected deletion finalizer to be removed")
+	}
+}
+
+func TestReconcileDeletionSnapshotUnsupported(t *testing.T) {
+	ctx := context.Background()
+	// volume snapshot CRDs aren't installed
+	r, node := deletingNode(t)
+	deletion := sharedAPI.Deletion{DeletionPolicy: sharedAPI.DeletionPolicySnapshot}
+
+	deleting, result, err := r.ReconcileDeletion(ctx, node, deletion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !deleting || result.RequeueAfter != 0 {
+		t.Errorf("expected deletion not to be requeued, got %v", result)
+	}
+
+	recorder := r.Recorder.(*record.FakeRecorder)
+	if event := <-recorder.Events; !strings.HasPrefix(event, "Warning "+EventReasonSnapshotUnsupported) {
+		t.Errorf("expected snapshot unsupported warning event, got %s", event)
+	}
+
+	key := client.ObjectKey{Name: "my-node", Namespace: "default"}
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err = r.Client.Get(ctx, key, pvc); err != nil {
+		t.Fatal(err)
+	}
+	if refs := pvc.GetOwnerReferences(); len(refs) != 0 {
+		t.Errorf("expected persistent volume claim to be retained, got owner references %v", refs)
+	}
+
+	if err = r.Client.Get(ctx, key, &ethereumv1alpha1.Node{}); client.IgnoreNotFound(err) != nil || err == nil {
+		t.Errorf("expected node to be deleted after deletion finalizer is removed, got %v", err)
+	}
+}
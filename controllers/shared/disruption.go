@@ -0,0 +1,54 @@
+package shared
+
+import (
+	"context"
+
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=watch;get;list;create;update;delete
+
+// ReconcilePodDisruptionBudget creates, updates or deletes custom resource pod disruption budget
+// pod disruption budget is deleted if it's disabled or node has no replicas
+func (r Reconciler) ReconcilePodDisruptionBudget(ctx context.Context, cr CustomResource, disruption sharedAPI.Disruption, replicas uint) error {
+	pdb := &policyv1.PodDisruptionBudget{}
+
+	if disruption.Enabled == nil || !*disruption.Enabled || replicas == 0 {
+		pdb.SetName(cr.GetName())
+		pdb.SetNamespace(cr.GetNamespace())
+		return client.IgnoreNotFound(r.Client.Delete(ctx, pdb))
+	}
+
+	return r.ReconcileOwned(ctx, cr, pdb, func(obj client.Object) error {
+		SpecPodDisruptionBudget(cr, disruption, replicas, obj.(*policyv1.PodDisruptionBudget))
+		return nil
+	})
+}
+
+// SpecPodDisruptionBudget updates pod disruption budget spec
+func SpecPodDisruptionBudget(cr CustomResource, disruption sharedAPI.Disruption, replicas uint, pdb *policyv1.PodDisruptionBudget) {
+	pdb.ObjectMeta.Labels = cr.GetLabels()
+
+	var maxUnavailable uint
+	if disruption.MaxUnavailable != nil {
+		maxUnavailable = *disruption.MaxUnavailable
+	}
+
+	// keep at least one pod available
+	if replicas > 1 && maxUnavailable >= replicas {
+		maxUnavailable = replicas - 1
+	}
+
+	max := intstr.FromInt32(int32(maxUnavailable))
+
+	pdb.Spec = policyv1.PodDisruptionBudgetSpec{
+		MaxUnavailable: &max,
+		Selector: &metav1.LabelSelector{
+			MatchLabels: cr.GetLabels(),
+		},
+	}
+}
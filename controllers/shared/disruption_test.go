@@ -0,0 +1,77 @@
+package shared
+
+import (
+	"testing"
+
+	ethereumv1alpha1 "github.com/kotalco/kotal/apis/ethereum/v1alpha1"
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSpecPodDisruptionBudget(t *testing.T) {
+
+	node := ethereumv1alpha1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-node",
+			Namespace: "default",
+			Labels: map[string]string{
+				"app.kubernetes.io/instance": "my-node",
+			},
+		},
+	}
+
+	enabled := true
+
+	cases := []struct {
+		title          string
+		maxUnavailable uint
+		replicas       uint
+		expected       int
+	}{
+		{
+			title:          "signing node",
+			maxUnavailable: 0,
+			replicas:       1,
+			expected:       0,
+		},
+		{
+			title:          "single replica node",
+			maxUnavailable: 1,
+			replicas:       1,
+			expected:       1,
+		},
+		{
+			title:          "multiple replicas node",
+			maxUnavailable: 1,
+			replicas:       3,
+			expected:       1,
+		},
+		{
+			title:          "max unavailable exceeding replicas",
+			maxUnavailable: 5,
+			replicas:       3,
+			expected:       2,
+		},
+	}
+
+	for _, c := range cases {
+		maxUnavailable := c.maxUnavailable
+		disruption := sharedAPI.Disruption{
+			Enabled:        &enabled,
+			MaxUnavailable: &maxUnavailable,
+		}
+
+		pdb := &policyv1.PodDisruptionBudget{}
+		SpecPodDisruptionBudget(&node, disruption, c.replicas, pdb)
+
+		if got := pdb.Spec.MaxUnavailable.IntValue(); got != c.expected {
+			t.Errorf("%s: expecting max unavailable to be %d, but got %d", c.title, c.expected, got)
+		}
+
+		if pdb.Spec.Selector.MatchLabels["app.kubernetes.io/instance"] != "my-node" {
+			t.Errorf("%s: expecting selector to match node pods", c.title)
+		}
+	}
+
+}
@@ -4,5 +4,8 @@ const (
 	EnvDataPath           = "KOTAL_DATA_PATH"
 	EnvConfigPath         = "KOTAL_CONFIG_PATH"
 	EnvSecretsPath        = "KOTAL_SECRETS_PATH"
+	EnvNodeKeysPath       = "KOTAL_NODE_KEYS_PATH"
+	EnvPodName            = "KOTAL_POD_NAME"
+	EnvResync             = "KOTAL_RESYNC"
 	EnvUseExistingCluster = "USE_EXISTING_CLUSTER"
 )
@@ -0,0 +1,153 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+
+const (
+	// EventReasonCreated is the reason of owned object creation events
+	EventReasonCreated = "Created"
+	// EventReasonUpdated is the reason of owned object update events
+	EventReasonUpdated = "Updated"
+	// EventReasonReconcileFailed is the reason of owned object reconciliation failure events
+	EventReasonReconcileFailed = "ReconcileFailed"
+	// EventReasonResizeRequested is the reason of persistent volume claim resize events
+	EventReasonResizeRequested = "ResizeRequested"
+	// EventReasonImageChanged is the reason of container image change events
+	EventReasonImageChanged = "ImageChanged"
+	// EventReasonMissingReference is the reason of missing referenced object events
+	EventReasonMissingReference = "MissingReference"
+	// EventReasonKeyGenerated is the reason of generated key events
+	EventReasonKeyGenerated = "KeyGenerated"
+	// EventReasonStatusChanged is the reason of status transition events
+	EventReasonStatusChanged = "StatusChanged"
+	// EventReasonRetained is the reason of retained persistent volume claims and secrets events
+	EventReasonRetained = "Retained"
+	// EventReasonSnapshotUnsupported is the reason of volume snapshot CRDs not installed events
+	EventReasonSnapshotUnsupported = "SnapshotUnsupported"
+	// EventReasonPaused is the reason of paused reconciliation events
+	EventReasonPaused = "Paused"
+)
+
+// event is a pending event to be recorded
+type event struct {
+	eventtype string
+	reason    string
+	message   string
+}
+
+// changeEvents returns events describing notable changes to existing owned object
+func changeEvents(before, after client.Object) (events []event) {
+
+	switch current := after.(type) {
+	case *corev1.PersistentVolumeClaim:
+		previous := before.(*corev1.PersistentVolumeClaim)
+		from := previous.Spec.Resources.Requests[corev1.ResourceStorage]
+		to := current.Spec.Resources.Requests[corev1.ResourceStorage]
+		if !from.IsZero() && from.Cmp(to) != 0 {
+			events = append(events, event{
+				eventtype: corev1.EventTypeNormal,
+				reason:    EventReasonResizeRequested,
+				message:   fmt.Sprintf("Requested resize of PersistentVolumeClaim %s from %s to %s", current.Name, from.String(), to.String()),
+			})
+		}
+	case *appsv1.StatefulSet:
+		previous := before.(*appsv1.StatefulSet)
+		images := map[string]string{}
+		for _, container := range previous.Spec.Template.Spec.Containers {
+			images[container.Name] = container.Image
+		}
+		for _, container := range current.Spec.Template.Spec.Containers {
+			if image, ok := images[container.Name]; ok && image != container.Image {
+				events = append(events, event{
+					eventtype: corev1.EventTypeNormal,
+					reason:    EventReasonImageChanged,
+					message:   fmt.Sprintf("Changed container %s image from %s to %s", container.Name, image, container.Image),
+				})
+			}
+		}
+	}
+
+	return
+}
+
+// GetSecret returns k8s secret stored at key
+// a warning event is recorded on the custom resource if the secret doesn't exist
+func (r Reconciler) GetSecret(ctx context.Context, cr runtime.Object, name types.NamespacedName, key string) (string, error) {
+	value, err := GetSecret(ctx, r.Client, name, key)
+	if apierrors.IsNotFound(err) {
+		r.Recorder.Eventf(cr, corev1.EventTypeWarning, EventReasonMissingReference, "Secret %s not found", name.Name)
+	}
+	return value, err
+}
+
+// statusTransitionFields are custom resource status fields whose transitions are recorded as events
+// counters like block heights and progress change frequently and aren't recorded
+var statusTransitionFields = []struct {
+	path  []string
+	title string
+}{
+	{[]string{"Mode"}, "mode"},
+	{[]string{"SyncedToChain"}, "synced to chain"},
+	{[]string{"Resync"}, "data resync"},
+	{[]string{"AssumeUTXO", "Phase"}, "UTXO snapshot phase"},
+}
+
+// statusField returns status field value at path, or nil if status doesn't have the field
+// nil pointers along the path resolve to the zero value of the field
+func statusField(status interface{}, path []string) interface{} {
+	v := reflect.ValueOf(status)
+	for _, name := range path {
+		for v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v = reflect.New(v.Type().Elem())
+			}
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Struct {
+			return nil
+		}
+		if v = v.FieldByName(name); !v.IsValid() {
+			return nil
+		}
+	}
+	return v.Interface()
+}
+
+// statusTransitions returns messages describing transitions of notable status fields
+func statusTransitions(previous, current interface{}) (messages []string) {
+	for _, field := range statusTransitionFields {
+		from, to := statusField(previous, field.path), statusField(current, field.path)
+		if to == nil || reflect.DeepEqual(from, to) {
+			continue
+		}
+		messages = append(messages, fmt.Sprintf("Status %s changed from %s to %s", field.title, statusValue(from), statusValue(to)))
+	}
+	return
+}
+
+// statusValue returns printable status field value
+func statusValue(value interface{}) string {
+	if s := fmt.Sprintf("%v", value); s != "" {
+		return s
+	}
+	return "none"
+}
+
+// RecordStatusTransition records an event for every notable custom resource status field transition
+func (r Reconciler) RecordStatusTransition(cr runtime.Object, previous, current interface{}) {
+	for _, message := range statusTransitions(previous, current) {
+		r.Recorder.Event(cr, corev1.EventTypeNormal, EventReasonStatusChanged, message)
+	}
+}
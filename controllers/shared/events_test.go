@@ -0,0 +1,100 @@
+package shared
+
+import (
+	"reflect"
+	"testing"
+
+	bitcoinv1alpha1 "github.com/kotalco/kotal/apis/bitcoin/v1alpha1"
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestChangeEvents(t *testing.T) {
+
+	pvc := &corev1.PersistentVolumeClaim{
+		Spec: corev1.PersistentVolumeClaimSpec{
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("100Gi"),
+				},
+			},
+		},
+	}
+
+	resized := pvc.DeepCopy()
+	resized.Spec.Resources.Requests[corev1.ResourceStorage] = resource.MustParse("200Gi")
+
+	events := changeEvents(pvc, resized)
+	if len(events) != 1 || events[0].reason != EventReasonResizeRequested {
+		t.Errorf("Expecting resize requested event, but got %v", events)
+	}
+
+	if events := changeEvents(pvc, pvc.DeepCopy()); len(events) != 0 {
+		t.Errorf("Expecting no events, but got %v", events)
+	}
+
+	sts := &appsv1.StatefulSet{
+		Spec: appsv1.StatefulSetSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "node", Image: "kotalco/geth:v1.13.10"},
+					},
+				},
+			},
+		},
+	}
+
+	upgraded := sts.DeepCopy()
+	upgraded.Spec.Template.Spec.Containers[0].Image = "kotalco/geth:v1.13.11"
+
+	events = changeEvents(sts, upgraded)
+	if len(events) != 1 || events[0].reason != EventReasonImageChanged {
+		t.Errorf("Expecting image changed event, but got %v", events)
+	}
+
+}
+
+func TestStatusTransitions(t *testing.T) {
+
+	previous := bitcoinv1alpha1.NodeStatus{
+		Mode: sharedAPI.ModeRunning,
+	}
+
+	current := previous
+	current.Mode = sharedAPI.ModePaused
+	current.Resync = 1
+	current.AssumeUTXO = &bitcoinv1alpha1.AssumeUTXOStatus{
+		Phase:              bitcoinv1alpha1.AssumeUTXOLoading,
+		ValidationProgress: "10.00%",
+	}
+
+	expected := []string{
+		"Status mode changed from Running to Paused",
+		"Status data resync changed from 0 to 1",
+		"Status UTXO snapshot phase changed from none to Loading",
+	}
+	if messages := statusTransitions(previous, current); !reflect.DeepEqual(messages, expected) {
+		t.Errorf("Expecting transitions %v, but got %v", expected, messages)
+	}
+
+	// counters aren't status transitions
+	progressed := *current.DeepCopy()
+	progressed.AssumeUTXO.ValidationProgress = "20.00%"
+	progressed.AssumeUTXO.ValidatedHeight = 1000
+	if messages := statusTransitions(current, progressed); len(messages) != 0 {
+		t.Errorf("Expecting no transitions, but got %v", messages)
+	}
+
+	lightning := bitcoinv1alpha1.LightningNodeStatus{BlockHeight: 100}
+	synced := lightning
+	synced.BlockHeight = 101
+	synced.SyncedToChain = true
+	expected = []string{"Status synced to chain changed from false to true"}
+	if messages := statusTransitions(lightning, synced); !reflect.DeepEqual(messages, expected) {
+		t.Errorf("Expecting transitions %v, but got %v", expected, messages)
+	}
+
+}
@@ -5,11 +5,13 @@ import (
 	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 type CustomResource interface {
 	metav1.Object
+	runtime.Object
 	GroupVersionKind() schema.GroupVersionKind
 }
 
@@ -0,0 +1,200 @@
+package shared
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	// metricsNamespace is the prefix of all operator metrics
+	metricsNamespace = "kotal"
+)
+
+// resourceLabelNames are labels identifying a single custom resource
+var resourceLabelNames = []string{"protocol", "kind", "namespace", "name"}
+
+var (
+	// reconcileErrors counts failed reconciliations per custom resource
+	reconcileErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "reconcile_errors_total",
+		Help:      "Total number of failed reconciliations per custom resource",
+	}, resourceLabelNames)
+
+	// lastSuccessfulReconcile is the last successful reconciliation time per custom resource
+	lastSuccessfulReconcile = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "last_successful_reconcile_timestamp_seconds",
+		Help:      "Unix timestamp of the last successful reconciliation per custom resource",
+	}, resourceLabelNames)
+
+	// syncHeight is the latest block height of the node as learned by the operator
+	syncHeight = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "node_sync_height",
+		Help:      "Latest block height of the node as learned by the operator",
+	}, resourceLabelNames)
+
+	// peers is the number of connected peers of the node as learned by the operator
+	peers = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "node_peers",
+		Help:      "Number of connected peers of the node as learned by the operator",
+	}, resourceLabelNames)
+
+	// resources is the collector of managed custom resources
+	resources = newResourcesCollector()
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		reconcileErrors,
+		lastSuccessfulReconcile,
+		syncHeight,
+		peers,
+		resources,
+	)
+}
+
+// resourceKey identifies a managed custom resource
+type resourceKey struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+// resourceInfo is managed custom resource labels used for grouping
+type resourceInfo struct {
+	protocol string
+	kind     string
+	client   string
+	network  string
+}
+
+// resourcesCollector counts managed custom resources by protocol, kind, client and network
+type resourcesCollector struct {
+	mu        sync.RWMutex
+	resources map[resourceKey]resourceInfo
+	desc      *prometheus.Desc
+}
+
+// newResourcesCollector creates a new managed custom resources collector
+func newResourcesCollector() *resourcesCollector {
+	return &resourcesCollector{
+		resources: map[resourceKey]resourceInfo{},
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "", "resources"),
+			"Number of managed custom resources by protocol, kind, client and network",
+			[]string{"protocol", "kind", "client", "network"},
+			nil,
+		),
+	}
+}
+
+// track adds or updates managed custom resource
+func (c *resourcesCollector) track(key resourceKey, info resourceInfo) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.resources[key] = info
+}
+
+// forget removes managed custom resource
+func (c *resourcesCollector) forget(key resourceKey) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.resources, key)
+}
+
+// Describe implements prometheus.Collector
+func (c *resourcesCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+// Collect implements prometheus.Collector
+func (c *resourcesCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.RLock()
+	counts := map[resourceInfo]float64{}
+	for _, info := range c.resources {
+		counts[info]++
+	}
+	c.mu.RUnlock()
+
+	for info, count := range counts {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, count, info.protocol, info.kind, info.client, info.network)
+	}
+}
+
+// resourceLabels returns custom resource metric labels
+func (r Reconciler) resourceLabels(obj client.Object, name types.NamespacedName) (prometheus.Labels, bool) {
+	gvk, err := apiutil.GVKForObject(obj, r.Scheme)
+	if err != nil {
+		return nil, false
+	}
+
+	return prometheus.Labels{
+		"protocol":  strings.Replace(gvk.Group, ".kotal.io", "", 1),
+		"kind":      strings.ToLower(gvk.Kind),
+		"namespace": name.Namespace,
+		"name":      name.Name,
+	}, true
+}
+
+// ObserveReconcile records custom resource reconciliation metrics
+// it's deferred by reconcilers before the custom resource is fetched
+// custom resource is forgotten if it has been deleted
+func (r Reconciler) ObserveReconcile(obj client.Object, name types.NamespacedName, err *error) {
+	labels, ok := r.resourceLabels(obj, name)
+	if !ok {
+		return
+	}
+
+	key := resourceKey{
+		kind:      labels["kind"],
+		namespace: name.Namespace,
+		name:      name.Name,
+	}
+
+	switch {
+	case *err != nil:
+		// conflicts are retried and are not reconciliation failures
+		if !apierrors.IsConflict(*err) {
+			reconcileErrors.With(labels).Inc()
+		}
+	case obj.GetUID() == "":
+		// custom resource has been deleted
+		resources.forget(key)
+		for _, vec := range []*prometheus.MetricVec{reconcileErrors.MetricVec, lastSuccessfulReconcile.MetricVec, syncHeight.MetricVec, peers.MetricVec} {
+			vec.Delete(labels)
+		}
+	default:
+		crLabels := obj.GetLabels()
+		resources.track(key, resourceInfo{
+			protocol: labels["protocol"],
+			kind:     labels["kind"],
+			client:   crLabels["app.kubernetes.io/name"],
+			network:  crLabels["kotal.io/network"],
+		})
+		lastSuccessfulReconcile.With(labels).SetToCurrentTime()
+	}
+}
+
+// SetSyncHeight records node latest block height as learned by the controller
+func (r Reconciler) SetSyncHeight(obj client.Object, height uint64) {
+	if labels, ok := r.resourceLabels(obj, client.ObjectKeyFromObject(obj)); ok {
+		syncHeight.With(labels).Set(float64(height))
+	}
+}
+
+// SetPeerCount records node connected peers count as learned by the controller
+func (r Reconciler) SetPeerCount(obj client.Object, count uint) {
+	if labels, ok := r.resourceLabels(obj, client.ObjectKeyFromObject(obj)); ok {
+		peers.With(labels).Set(float64(count))
+	}
+}
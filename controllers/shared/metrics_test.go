@@ -0,0 +1,87 @@
+package shared
+
+import (
+	"errors"
+	"testing"
+
+	ethereumv1alpha1 "github.com/kotalco/kotal/apis/ethereum/v1alpha1"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestObserveReconcile(t *testing.T) {
+
+	scheme := runtime.NewScheme()
+	if err := ethereumv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	r := Reconciler{Scheme: scheme}
+
+	name := types.NamespacedName{Name: "my-node", Namespace: "default"}
+	labels := prometheus.Labels{
+		"protocol":  "ethereum",
+		"kind":      "node",
+		"namespace": "default",
+		"name":      "my-node",
+	}
+
+	node := ethereumv1alpha1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name.Name,
+			Namespace: name.Namespace,
+			UID:       "my-node-uid",
+			Labels: map[string]string{
+				"app.kubernetes.io/name": "geth",
+				"kotal.io/network":       "mainnet",
+			},
+		},
+	}
+
+	var err error
+	r.ObserveReconcile(&node, name, &err)
+
+	if count := testutil.CollectAndCount(resources); count != 1 {
+		t.Errorf("Expecting 1 resources series, but got %d", count)
+	}
+
+	if timestamp := testutil.ToFloat64(lastSuccessfulReconcile.With(labels)); timestamp == 0 {
+		t.Error("Expecting last successful reconcile timestamp to be set")
+	}
+
+	r.SetSyncHeight(&node, 100)
+	r.SetPeerCount(&node, 8)
+
+	if height := testutil.ToFloat64(syncHeight.With(labels)); height != 100 {
+		t.Errorf("Expecting sync height 100, but got %f", height)
+	}
+
+	if count := testutil.ToFloat64(peers.With(labels)); count != 8 {
+		t.Errorf("Expecting 8 peers, but got %f", count)
+	}
+
+	err = errors.New("reconcile failed")
+	r.ObserveReconcile(&node, name, &err)
+
+	if failures := testutil.ToFloat64(reconcileErrors.With(labels)); failures != 1 {
+		t.Errorf("Expecting 1 reconcile error, but got %f", failures)
+	}
+
+	// deleted custom resource is fetched into an empty object
+	err = nil
+	r.ObserveReconcile(&ethereumv1alpha1.Node{}, name, &err)
+
+	if count := testutil.CollectAndCount(resources); count != 0 {
+		t.Errorf("Expecting 0 resources series, but got %d", count)
+	}
+
+	for _, vec := range []*prometheus.GaugeVec{syncHeight, peers} {
+		if count := testutil.CollectAndCount(vec); count != 0 {
+			t.Errorf("Expecting 0 node series, but got %d", count)
+		}
+	}
+
+}
@@ -0,0 +1,51 @@
+package shared
+
+import (
+	"context"
+
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// IsPaused returns true if custom resource is annotated to pause reconciliation of owned objects
+func IsPaused(cr CustomResource) bool {
+	return cr.GetAnnotations()[sharedAPI.PausedAnnotation] == "true"
+}
+
+// Mode returns custom resource reconciliation mode
+func Mode(cr CustomResource, suspend bool) sharedAPI.Mode {
+	switch {
+	case IsPaused(cr):
+		return sharedAPI.ModePaused
+	case suspend:
+		return sharedAPI.ModeSuspended
+	default:
+		return sharedAPI.ModeRunning
+	}
+}
+
+// StatefulSetReplicas returns statefulset replicas, suspended nodes are scaled to zero
+func StatefulSetReplicas(replicas uint, suspend bool) int32 {
+	if suspend {
+		return 0
+	}
+	return int32(replicas)
+}
+
+// ReconcilePaused updates status mode of paused custom resource, owned objects are left as is
+func (r Reconciler) ReconcilePaused(ctx context.Context, cr CustomResource, mode *sharedAPI.Mode) error {
+	if *mode == sharedAPI.ModePaused {
+		return nil
+	}
+
+	patch := client.MergeFrom(cr.DeepCopyObject().(client.Object))
+	*mode = sharedAPI.ModePaused
+	if err := r.Client.Status().Patch(ctx, cr, patch); err != nil {
+		return err
+	}
+
+	r.Recorder.Eventf(cr, corev1.EventTypeNormal, EventReasonPaused, "Paused reconciliation, remove %s annotation to resume", sharedAPI.PausedAnnotation)
+
+	return nil
+}
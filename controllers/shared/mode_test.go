@@ -0,0 +1,85 @@
+package shared
+
+import (
+	"context"
+	"testing"
+
+	ethereumv1alpha1 "github.com/kotalco/kotal/apis/ethereum/v1alpha1"
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestMode(t *testing.T) {
+	node := &ethereumv1alpha1.Node{}
+
+	if mode := Mode(node, false); mode != sharedAPI.ModeRunning {
+		t.Errorf("expected mode to be Running, got %s", mode)
+	}
+
+	if mode := Mode(node, true); mode != sharedAPI.ModeSuspended {
+		t.Errorf("expected mode to be Suspended, got %s", mode)
+	}
+
+	node.Annotations = map[string]string{sharedAPI.PausedAnnotation: "true"}
+	if mode := Mode(node, true); mode != sharedAPI.ModePaused {
+		t.Errorf("expected mode to be Paused, got %s", mode)
+	}
+}
+
+func TestStatefulSetReplicas(t *testing.T) {
+	if replicas := StatefulSetReplicas(3, false); replicas != 3 {
+		t.Errorf("expected 3 replicas, got %d", replicas)
+	}
+
+	if replicas := StatefulSetReplicas(3, true); replicas != 0 {
+		t.Errorf("expected suspended node to be scaled to 0 replicas, got %d", replicas)
+	}
+}
+
+func TestReconcilePaused(t *testing.T) {
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = ethereumv1alpha1.AddToScheme(scheme)
+
+	node := &ethereumv1alpha1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "my-node",
+			Namespace:   "default",
+			Annotations: map[string]string{sharedAPI.PausedAnnotation: "true"},
+		},
+	}
+
+	recorder := record.NewFakeRecorder(10)
+	r := Reconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(node).WithStatusSubresource(node).Build(),
+		Scheme:   scheme,
+		Recorder: recorder,
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := r.ReconcilePaused(ctx, node, &node.Status.Mode); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	fetched := &ethereumv1alpha1.Node{}
+	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(node), fetched); err != nil {
+		t.Fatal(err)
+	}
+
+	if fetched.Status.Mode != sharedAPI.ModePaused {
+		t.Errorf("expected status mode to be Paused, got %s", fetched.Status.Mode)
+	}
+
+	// event is recorded only once the node is paused
+	if len(recorder.Events) != 1 {
+		t.Errorf("expected 1 paused event, got %d", len(recorder.Events))
+	}
+}
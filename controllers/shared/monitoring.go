@@ -0,0 +1,83 @@
+package shared
+
+import (
+	"context"
+
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ServiceMonitorGVK is prometheus operator ServiceMonitor group version kind
+var ServiceMonitorGVK = schema.GroupVersionKind{
+	Group:   "monitoring.coreos.com",
+	Version: "v1",
+	Kind:    "ServiceMonitor",
+}
+
+// MetricsEndpoint is the service endpoint to be scraped by prometheus
+type MetricsEndpoint struct {
+	// Port is service port name
+	Port string
+	// Path is HTTP metrics path
+	Path string
+}
+
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=watch;get;list;create;update;delete
+
+// ReconcileServiceMonitor creates, updates or deletes custom resource ServiceMonitor
+// it does nothing if prometheus operator CRDs are not installed
+func (r Reconciler) ReconcileServiceMonitor(ctx context.Context, cr CustomResource, metrics sharedAPI.Metrics, endpoint MetricsEndpoint) error {
+
+	if _, err := r.Client.RESTMapper().RESTMapping(ServiceMonitorGVK.GroupKind(), ServiceMonitorGVK.Version); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil
+		}
+		return err
+	}
+
+	monitor := &unstructured.Unstructured{}
+	monitor.SetGroupVersionKind(ServiceMonitorGVK)
+
+	if !metrics.Enabled {
+		monitor.SetName(cr.GetName())
+		monitor.SetNamespace(cr.GetNamespace())
+		return client.IgnoreNotFound(r.Client.Delete(ctx, monitor))
+	}
+
+	return r.ReconcileOwned(ctx, cr, monitor, func(obj client.Object) error {
+		return SpecServiceMonitor(cr, metrics, endpoint, obj.(*unstructured.Unstructured))
+	})
+}
+
+// SpecServiceMonitor updates ServiceMonitor spec
+func SpecServiceMonitor(cr CustomResource, metrics sharedAPI.Metrics, endpoint MetricsEndpoint, monitor *unstructured.Unstructured) error {
+	monitor.SetLabels(cr.GetLabels())
+
+	scrapeEndpoint := map[string]interface{}{
+		"port": endpoint.Port,
+		"path": endpoint.Path,
+	}
+
+	if metrics.Interval != "" {
+		scrapeEndpoint["interval"] = metrics.Interval
+	}
+
+	matchLabels := map[string]interface{}{}
+	for k, v := range cr.GetLabels() {
+		matchLabels[k] = v
+	}
+
+	spec := map[string]interface{}{
+		"selector": map[string]interface{}{
+			"matchLabels": matchLabels,
+		},
+		"endpoints": []interface{}{
+			scrapeEndpoint,
+		},
+	}
+
+	return unstructured.SetNestedField(monitor.Object, spec, "spec")
+}
@@ -0,0 +1,69 @@
+package shared
+
+import (
+	"testing"
+
+	ethereumv1alpha1 "github.com/kotalco/kotal/apis/ethereum/v1alpha1"
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestSpecServiceMonitor(t *testing.T) {
+
+	node := ethereumv1alpha1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-node",
+			Namespace: "default",
+			Labels: map[string]string{
+				"app.kubernetes.io/instance": "my-node",
+			},
+		},
+	}
+
+	metrics := sharedAPI.Metrics{
+		Enabled:  true,
+		Port:     6060,
+		Interval: "15s",
+	}
+
+	endpoint := MetricsEndpoint{
+		Port: "metrics",
+		Path: "/debug/metrics/prometheus",
+	}
+
+	monitor := &unstructured.Unstructured{}
+	monitor.SetGroupVersionKind(ServiceMonitorGVK)
+
+	if err := SpecServiceMonitor(&node, metrics, endpoint, monitor); err != nil {
+		t.Fatal(err)
+	}
+
+	endpoints, _, err := unstructured.NestedSlice(monitor.Object, "spec", "endpoints")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(endpoints) != 1 {
+		t.Fatalf("Expecting 1 endpoint, but got %d", len(endpoints))
+	}
+
+	expected := map[string]string{
+		"port":     "metrics",
+		"path":     "/debug/metrics/prometheus",
+		"interval": "15s",
+	}
+
+	scrapeEndpoint := endpoints[0].(map[string]interface{})
+	for k, v := range expected {
+		if scrapeEndpoint[k] != v {
+			t.Errorf("Expecting endpoint %s to be %s, but got %v", k, v, scrapeEndpoint[k])
+		}
+	}
+
+	instance, _, _ := unstructured.NestedString(monitor.Object, "spec", "selector", "matchLabels", "app.kubernetes.io/instance")
+	if instance != "my-node" {
+		t.Errorf("Expecting selector to match instance label my-node, but got %s", instance)
+	}
+
+}
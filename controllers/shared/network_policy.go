@@ -0,0 +1,161 @@
+package shared
+
+import (
+	"context"
+	"sort"
+
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=watch;get;list;create;update;delete
+
+// publicPorts is names of P2P ports that are always accessible
+var publicPorts = map[string]bool{
+	"p2p":       true,
+	"discovery": true,
+	"swarm":     true,
+	"swarm-udp": true,
+}
+
+// ReconcileNetworkPolicy creates, updates or deletes custom resource network policy
+// restricted endpoints are the named container ports of custom resource pod spec generated in the same reconciliation
+// defaults are allowed peers of endpoints without access rules, keyed by endpoint port name
+// endpoints with empty defaults are not accessible unless access rules are given
+func (r Reconciler) ReconcileNetworkPolicy(ctx context.Context, cr CustomResource, access sharedAPI.Access, pod *corev1.PodSpec, defaults map[string][]networkingv1.NetworkPolicyPeer) error {
+	policy := &networkingv1.NetworkPolicy{}
+
+	if !access.Enabled {
+		policy.SetName(cr.GetName())
+		policy.SetNamespace(cr.GetNamespace())
+		return client.IgnoreNotFound(r.Client.Delete(ctx, policy))
+	}
+
+	var ports []corev1.ContainerPort
+	for _, container := range pod.Containers {
+		ports = append(ports, container.Ports...)
+	}
+
+	return r.ReconcileOwned(ctx, cr, policy, func(obj client.Object) error {
+		SpecNetworkPolicy(cr, access, ports, defaults, obj.(*networkingv1.NetworkPolicy))
+		return nil
+	})
+}
+
+// accessPeers returns network policy peers from allowed endpoint peers
+func accessPeers(peers sharedAPI.AccessPeers) (policyPeers []networkingv1.NetworkPolicyPeer) {
+	for _, namespace := range peers.Namespaces {
+		policyPeers = append(policyPeers, networkingv1.NetworkPolicyPeer{
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					corev1.LabelMetadataName: namespace,
+				},
+			},
+		})
+	}
+
+	if len(peers.PodSelector) != 0 {
+		policyPeers = append(policyPeers, networkingv1.NetworkPolicyPeer{
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: peers.PodSelector,
+			},
+		})
+	}
+
+	for _, cidr := range peers.CIDRs {
+		policyPeers = append(policyPeers, networkingv1.NetworkPolicyPeer{
+			IPBlock: &networkingv1.IPBlock{
+				CIDR: cidr,
+			},
+		})
+	}
+
+	return
+}
+
+// SpecNetworkPolicy updates network policy spec
+// P2P ports are open, other ports are only accessible by allowed peers
+func SpecNetworkPolicy(cr CustomResource, access sharedAPI.Access, ports []corev1.ContainerPort, defaults map[string][]networkingv1.NetworkPolicyPeer, policy *networkingv1.NetworkPolicy) {
+	policy.ObjectMeta.Labels = cr.GetLabels()
+
+	var public []networkingv1.NetworkPolicyPort
+	restricted := map[string][]networkingv1.NetworkPolicyPort{}
+
+	for _, port := range ports {
+		// unnamed ports can't be classified into endpoints
+		if port.Name == "" {
+			continue
+		}
+		protocol := port.Protocol
+		if protocol == "" {
+			protocol = corev1.ProtocolTCP
+		}
+		name := intstr.FromString(port.Name)
+		policyPort := networkingv1.NetworkPolicyPort{
+			Protocol: &protocol,
+			Port:     &name,
+		}
+		if publicPorts[port.Name] {
+			public = append(public, policyPort)
+		} else {
+			restricted[port.Name] = append(restricted[port.Name], policyPort)
+		}
+	}
+
+	ingress := []networkingv1.NetworkPolicyIngressRule{}
+
+	if len(public) != 0 {
+		ingress = append(ingress, networkingv1.NetworkPolicyIngressRule{
+			Ports: public,
+		})
+	}
+
+	// sort endpoints to generate the same spec every reconciliation
+	endpoints := []string{}
+	for endpoint := range restricted {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Strings(endpoints)
+
+	for _, endpoint := range endpoints {
+		var from []networkingv1.NetworkPolicyPeer
+
+		if peers, ok := access.Endpoints[endpoint]; ok {
+			from = accessPeers(peers)
+		} else if peers, ok := defaults[endpoint]; ok {
+			// endpoint isn't accessible by any peer
+			if len(peers) == 0 {
+				continue
+			}
+			from = peers
+		}
+
+		// only pods in custom resource namespace are allowed by default
+		if len(from) == 0 {
+			from = []networkingv1.NetworkPolicyPeer{
+				{
+					PodSelector: &metav1.LabelSelector{},
+				},
+			}
+		}
+
+		ingress = append(ingress, networkingv1.NetworkPolicyIngressRule{
+			Ports: restricted[endpoint],
+			From:  from,
+		})
+	}
+
+	policy.Spec = networkingv1.NetworkPolicySpec{
+		PodSelector: metav1.LabelSelector{
+			MatchLabels: cr.GetLabels(),
+		},
+		PolicyTypes: []networkingv1.PolicyType{
+			networkingv1.PolicyTypeIngress,
+		},
+		Ingress: ingress,
+	}
+}
@@ -0,0 +1,161 @@
+package shared
+
+import (
+	"testing"
+
+	ethereumv1alpha1 "github.com/kotalco/kotal/apis/ethereum/v1alpha1"
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSpecNetworkPolicy(t *testing.T) {
+
+	node := ethereumv1alpha1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-node",
+			Namespace: "default",
+			Labels: map[string]string{
+				"app.kubernetes.io/instance": "my-node",
+			},
+		},
+	}
+
+	access := sharedAPI.Access{
+		Enabled: true,
+		Endpoints: map[string]sharedAPI.AccessPeers{
+			"rpc": {
+				Namespaces: []string{"dapps"},
+				CIDRs:      []string{"10.0.0.0/8"},
+			},
+		},
+	}
+
+	ports := []corev1.ContainerPort{
+		{Name: "discovery", ContainerPort: 30303, Protocol: corev1.ProtocolUDP},
+		{Name: "p2p", ContainerPort: 30303},
+		{Name: "rpc", ContainerPort: 8545},
+		{Name: "engine", ContainerPort: 8551},
+		{Name: "metrics", ContainerPort: 6060},
+	}
+
+	beaconNodes := []networkingv1.NetworkPolicyPeer{
+		{
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"app.kubernetes.io/component": "ethereum2-beaconnode",
+				},
+			},
+		},
+	}
+
+	defaults := map[string][]networkingv1.NetworkPolicyPeer{
+		"engine": beaconNodes,
+	}
+
+	policy := &networkingv1.NetworkPolicy{}
+	SpecNetworkPolicy(&node, access, ports, defaults, policy)
+
+	ingress := policy.Spec.Ingress
+	// p2p, engine, metrics and rpc rules
+	if len(ingress) != 4 {
+		t.Fatalf("Expecting 4 ingress rules, but got %d", len(ingress))
+	}
+
+	if len(ingress[0].Ports) != 2 || len(ingress[0].From) != 0 {
+		t.Errorf("Expecting discovery and p2p ports to be open, but got %v", ingress[0])
+	}
+
+	if ingress[1].Ports[0].Port.StrVal != "engine" || ingress[1].From[0].PodSelector.MatchLabels["app.kubernetes.io/component"] != "ethereum2-beaconnode" {
+		t.Errorf("Expecting engine port to be limited to beacon nodes, but got %v", ingress[1])
+	}
+
+	if ingress[2].Ports[0].Port.StrVal != "metrics" || len(ingress[2].From[0].PodSelector.MatchLabels) != 0 {
+		t.Errorf("Expecting metrics port to be limited to node namespace, but got %v", ingress[2])
+	}
+
+	rpc := ingress[3]
+	if rpc.Ports[0].Port.StrVal != "rpc" || len(rpc.From) != 2 {
+		t.Fatalf("Expecting rpc port to be limited to allowed peers, but got %v", rpc)
+	}
+
+	if rpc.From[0].NamespaceSelector.MatchLabels[corev1.LabelMetadataName] != "dapps" {
+		t.Errorf("Expecting rpc port to be accessible from dapps namespace")
+	}
+
+	if rpc.From[1].IPBlock.CIDR != "10.0.0.0/8" {
+		t.Errorf("Expecting rpc port to be accessible from 10.0.0.0/8")
+	}
+
+}
+
+func TestSpecNetworkPolicySwarmPorts(t *testing.T) {
+
+	node := ethereumv1alpha1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-peer",
+			Namespace: "default",
+		},
+	}
+
+	access := sharedAPI.Access{Enabled: true}
+
+	ports := []corev1.ContainerPort{
+		{Name: "swarm", ContainerPort: 4001},
+		{Name: "swarm-udp", ContainerPort: 4001, Protocol: corev1.ProtocolUDP},
+		{Name: "api", ContainerPort: 5001},
+	}
+
+	policy := &networkingv1.NetworkPolicy{}
+	SpecNetworkPolicy(&node, access, ports, nil, policy)
+
+	ingress := policy.Spec.Ingress
+	// swarm and api rules
+	if len(ingress) != 2 {
+		t.Fatalf("Expecting 2 ingress rules, but got %d", len(ingress))
+	}
+
+	public := ingress[0]
+	if len(public.Ports) != 2 || len(public.From) != 0 {
+		t.Fatalf("Expecting swarm and swarm-udp ports to be open, but got %v", public)
+	}
+
+	if public.Ports[1].Port.StrVal != "swarm-udp" || *public.Ports[1].Protocol != corev1.ProtocolUDP {
+		t.Errorf("Expecting swarm-udp port to be open over UDP, but got %v", public.Ports[1])
+	}
+
+}
+
+func TestSpecNetworkPolicyDeniedEndpoints(t *testing.T) {
+
+	node := ethereumv1alpha1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-node",
+			Namespace: "default",
+		},
+	}
+
+	access := sharedAPI.Access{Enabled: true}
+
+	ports := []corev1.ContainerPort{
+		{Name: "engine", ContainerPort: 8551},
+		{Name: "rpc", ContainerPort: 8545},
+		// unnamed sidecar port
+		{ContainerPort: 9000},
+	}
+
+	// no peers are allowed to access engine port
+	defaults := map[string][]networkingv1.NetworkPolicyPeer{
+		"engine": nil,
+	}
+
+	policy := &networkingv1.NetworkPolicy{}
+	SpecNetworkPolicy(&node, access, ports, defaults, policy)
+
+	ingress := policy.Spec.Ingress
+	if len(ingress) != 1 || ingress[0].Ports[0].Port.StrVal != "rpc" {
+		t.Errorf("Expecting rpc rule only, but got %v", ingress)
+	}
+
+}
@@ -0,0 +1,160 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+
+// NodeKeysVolumeName is the name of replica node keys volume
+const NodeKeysVolumeName = "node-keys"
+
+// NodeKeyGenerator generates node key files content keyed by file name
+type NodeKeyGenerator func() (map[string]string, error)
+
+// HasReplicaKeys returns true if every replica has its own node key managed by the operator
+func HasReplicaKeys(replicas *uint) bool {
+	return replicas != nil && *replicas > 1
+}
+
+// NodeKeysSecretName returns replica node keys secret name
+func NodeKeysSecretName(cr metav1.Object) string {
+	return fmt.Sprintf("%s-node-keys", cr.GetName())
+}
+
+// nodeKeyName returns secret key of replica node key file
+func nodeKeyName(replica, file string) string {
+	return fmt.Sprintf("%s.%s", replica, file)
+}
+
+// ReconcileNodeKeys reconciles replica node keys secret and returns node key files of every replica
+// first replica uses primary node key files if provided, other replicas use generated node keys
+// generated node keys are kept if node is scaled down, so replicas keep their identity if scaled up again
+func (r Reconciler) ReconcileNodeKeys(ctx context.Context, cr CustomResource, replicas uint, primary map[string]string, generate NodeKeyGenerator) (keys []map[string]string, err error) {
+	var generated []string
+
+	secret := &corev1.Secret{}
+	secret.SetName(NodeKeysSecretName(cr))
+
+	err = r.ReconcileOwned(ctx, cr, secret, func(obj client.Object) error {
+		secret := obj.(*corev1.Secret)
+		secret.ObjectMeta.Labels = cr.GetLabels()
+
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+
+		keys = make([]map[string]string, replicas)
+		generated = nil
+
+		for ordinal := uint(0); ordinal < replicas; ordinal++ {
+			replica := ReplicaName(cr, ordinal)
+
+			files := primary
+			if ordinal != 0 || primary == nil {
+				files = map[string]string{}
+				for key, value := range secret.Data {
+					if file, ok := strings.CutPrefix(key, replica+"."); ok {
+						files[file] = string(value)
+					}
+				}
+			}
+
+			if len(files) == 0 {
+				var err error
+				if files, err = generate(); err != nil {
+					return err
+				}
+				generated = append(generated, replica)
+			}
+
+			for file, value := range files {
+				secret.Data[nodeKeyName(replica, file)] = []byte(value)
+			}
+
+			keys[ordinal] = files
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, replica := range generated {
+		r.Recorder.Eventf(cr, corev1.EventTypeNormal, EventReasonKeyGenerated, "Generated node key for replica %s", replica)
+	}
+
+	return
+}
+
+// NodeKeysVolume returns replica node keys volume
+// node key files of every replica are projected into a directory named after the replica
+func NodeKeysVolume(cr metav1.Object, replicas uint, files ...string) corev1.Volume {
+	items := []corev1.KeyToPath{}
+
+	for ordinal := uint(0); ordinal < replicas; ordinal++ {
+		replica := ReplicaName(cr, ordinal)
+		for _, file := range files {
+			items = append(items, corev1.KeyToPath{
+				Key:  nodeKeyName(replica, file),
+				Path: fmt.Sprintf("%s/%s", replica, file),
+			})
+		}
+	}
+
+	return corev1.Volume{
+		Name: NodeKeysVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: NodeKeysSecretName(cr),
+				Items:      items,
+			},
+		},
+	}
+}
+
+// NodeKeysVolumeMount mounts replica own node keys directory
+// containers mounting it must have pod name environment variable
+func NodeKeysVolumeMount(homeDir string) corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:        NodeKeysVolumeName,
+		MountPath:   PathNodeKeys(homeDir),
+		SubPathExpr: fmt.Sprintf("$(%s)", EnvPodName),
+		ReadOnly:    true,
+	}
+}
+
+// PodNameEnv returns pod name environment variable
+func PodNameEnv() corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: EnvPodName,
+		ValueFrom: &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{
+				FieldPath: "metadata.name",
+			},
+		},
+	}
+}
+
+// ReplicaPodIP returns replica pod IP, it's empty if pod hasn't been scheduled yet
+func (r Reconciler) ReplicaPodIP(ctx context.Context, cr metav1.Object, ordinal uint) string {
+	pod := &corev1.Pod{}
+	key := client.ObjectKey{
+		Name:      ReplicaName(cr, ordinal),
+		Namespace: cr.GetNamespace(),
+	}
+
+	if err := r.Client.Get(ctx, key, pod); err != nil {
+		return ""
+	}
+
+	return pod.Status.PodIP
+}
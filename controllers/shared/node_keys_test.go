@@ -0,0 +1,69 @@
+package shared
+
+import (
+	"testing"
+
+	ethereumv1alpha1 "github.com/kotalco/kotal/apis/ethereum/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestHasReplicaKeys(t *testing.T) {
+	one, three := uint(1), uint(3)
+
+	if HasReplicaKeys(nil) {
+		t.Error("expecting node without replicas to have no replica keys")
+	}
+	if HasReplicaKeys(&one) {
+		t.Error("expecting single replica node to have no replica keys")
+	}
+	if !HasReplicaKeys(&three) {
+		t.Error("expecting multiple replicas node to have replica keys")
+	}
+}
+
+func TestNodeKeysVolume(t *testing.T) {
+	node := &ethereumv1alpha1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-node",
+		},
+	}
+
+	volume := NodeKeysVolume(node, 2, "id", "key")
+
+	if volume.Name != NodeKeysVolumeName {
+		t.Errorf("expecting volume name to be %s, got %s", NodeKeysVolumeName, volume.Name)
+	}
+
+	if volume.Secret == nil || volume.Secret.SecretName != "my-node-node-keys" {
+		t.Fatalf("expecting volume source to be my-node-node-keys secret, got %v", volume.VolumeSource)
+	}
+
+	expected := map[string]string{
+		"my-node-0.id":  "my-node-0/id",
+		"my-node-0.key": "my-node-0/key",
+		"my-node-1.id":  "my-node-1/id",
+		"my-node-1.key": "my-node-1/key",
+	}
+
+	if len(volume.Secret.Items) != len(expected) {
+		t.Fatalf("expecting %d items, got %d", len(expected), len(volume.Secret.Items))
+	}
+
+	for _, item := range volume.Secret.Items {
+		if expected[item.Key] != item.Path {
+			t.Errorf("expecting key %s to be projected to %s, got %s", item.Key, expected[item.Key], item.Path)
+		}
+	}
+}
+
+func TestNodeKeysVolumeMount(t *testing.T) {
+	mount := NodeKeysVolumeMount("/home/kotal")
+
+	if mount.MountPath != "/home/kotal/.kotal-node-keys" {
+		t.Errorf("expecting mount path to be /home/kotal/.kotal-node-keys, got %s", mount.MountPath)
+	}
+
+	if mount.SubPathExpr != "$(KOTAL_POD_NAME)" {
+		t.Errorf("expecting sub path to be replica pod name, got %s", mount.SubPathExpr)
+	}
+}
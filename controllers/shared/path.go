@@ -9,6 +9,8 @@ const (
 	SecretsSubDir = ".kotal-secrets"
 	// ConfigSubDir is the configuration sub directory
 	ConfigSubDir = "kotal-config"
+	// NodeKeysSubDir is the replica node keys sub directory
+	NodeKeysSubDir = ".kotal-node-keys"
 )
 
 // PathData returns blockchain data directory
@@ -25,3 +27,8 @@ func PathSecrets(homeDir string) string {
 func PathConfig(homeDir string) string {
 	return fmt.Sprintf("%s/%s", homeDir, ConfigSubDir)
 }
+
+// PathNodeKeys returns replica node keys directory
+func PathNodeKeys(homeDir string) string {
+	return fmt.Sprintf("%s/%s", homeDir, NodeKeysSubDir)
+}
@@ -32,3 +32,12 @@ func TestPathSecrets(t *testing.T) {
 		t.Errorf("expected secrets directory to be %s, got %s", expected, got)
 	}
 }
+
+func TestPathNodeKeys(t *testing.T) {
+	expected := "/users/test/.kotal-node-keys"
+	got := PathNodeKeys(testHomeDir)
+
+	if got != expected {
+		t.Errorf("expected node keys directory to be %s, got %s", expected, got)
+	}
+}
@@ -0,0 +1,63 @@
+package shared
+
+import (
+	"fmt"
+
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// SpecPodExtras injects extra environment variables, volumes and containers into pod spec
+// extra environment variables and volume mounts are added to generated containers only
+// it must be called after generated containers and volumes are set
+// extra containers and volumes named after generated ones are rejected instead of shadowing them
+func SpecPodExtras(extras sharedAPI.PodExtras, pod *corev1.PodSpec) error {
+	containers := map[string]bool{}
+	for _, generated := range [][]corev1.Container{pod.InitContainers, pod.Containers} {
+		for _, container := range generated {
+			containers[container.Name] = true
+		}
+	}
+	for _, extra := range [][]corev1.Container{extras.InitContainers, extras.Sidecars} {
+		for _, container := range extra {
+			if containers[container.Name] {
+				return fmt.Errorf("container %s is reserved for containers generated by the operator", container.Name)
+			}
+		}
+	}
+
+	volumes := map[string]bool{}
+	for _, volume := range pod.Volumes {
+		volumes[volume.Name] = true
+	}
+	for _, volume := range extras.ExtraVolumes {
+		if volumes[volume.Name] {
+			return fmt.Errorf("volume %s is reserved for volumes generated by the operator", volume.Name)
+		}
+	}
+
+	for i := range pod.Containers {
+		container := &pod.Containers[i]
+		for _, extra := range extras.ExtraEnv {
+			container.Env = mergeEnv(container.Env, extra)
+		}
+		container.VolumeMounts = append(container.VolumeMounts, extras.ExtraVolumeMounts...)
+	}
+
+	pod.Volumes = append(pod.Volumes, extras.ExtraVolumes...)
+	pod.InitContainers = append(pod.InitContainers, extras.InitContainers...)
+	pod.Containers = append(pod.Containers, extras.Sidecars...)
+
+	return nil
+}
+
+// mergeEnv overrides environment variable with the same name, or appends it
+func mergeEnv(env []corev1.EnvVar, extra corev1.EnvVar) []corev1.EnvVar {
+	for i := range env {
+		if env[i].Name == extra.Name {
+			env[i] = extra
+			return env
+		}
+	}
+	return append(env, extra)
+}
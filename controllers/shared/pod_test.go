@@ -0,0 +1,99 @@
+package shared
+
+import (
+	"testing"
+
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestSpecPodExtras(t *testing.T) {
+	pod := &corev1.PodSpec{
+		InitContainers: []corev1.Container{{Name: "copy-config"}},
+		Containers: []corev1.Container{
+			{
+				Name: "node",
+				Env:  []corev1.EnvVar{{Name: "GOMEMLIMIT", Value: "1GiB"}},
+			},
+		},
+		Volumes: []corev1.Volume{{Name: "data"}},
+	}
+
+	extras := sharedAPI.PodExtras{
+		ExtraEnv: []corev1.EnvVar{
+			{Name: "GOMEMLIMIT", Value: "2GiB"},
+			{Name: "HTTPS_PROXY", Value: "http://proxy:3128"},
+		},
+		ExtraVolumes:      []corev1.Volume{{Name: "ca-bundle"}},
+		ExtraVolumeMounts: []corev1.VolumeMount{{Name: "ca-bundle", MountPath: "/etc/ssl/certs"}},
+		Sidecars:          []corev1.Container{{Name: "log-shipper"}},
+		InitContainers:    []corev1.Container{{Name: "wait-for-peer"}},
+	}
+
+	if err := SpecPodExtras(extras, pod); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(pod.Containers) != 2 || pod.Containers[1].Name != "log-shipper" {
+		t.Fatalf("expected sidecar to be appended to containers, got %v", pod.Containers)
+	}
+
+	node := pod.Containers[0]
+	if len(node.Env) != 2 || node.Env[0].Value != "2GiB" || node.Env[1].Name != "HTTPS_PROXY" {
+		t.Errorf("expected extra env to override and extend generated env, got %v", node.Env)
+	}
+
+	if len(node.VolumeMounts) != 1 || node.VolumeMounts[0].Name != "ca-bundle" {
+		t.Errorf("expected extra volume mount to be added to node container, got %v", node.VolumeMounts)
+	}
+
+	if len(pod.Containers[1].Env) != 0 || len(pod.Containers[1].VolumeMounts) != 0 {
+		t.Errorf("expected sidecar to be left as is, got %v", pod.Containers[1])
+	}
+
+	if len(pod.InitContainers) != 2 || pod.InitContainers[1].Name != "wait-for-peer" {
+		t.Errorf("expected init container to run after generated init containers, got %v", pod.InitContainers)
+	}
+
+	if len(pod.Volumes) != 2 || pod.Volumes[1].Name != "ca-bundle" {
+		t.Errorf("expected extra volume to be appended to volumes, got %v", pod.Volumes)
+	}
+}
+
+func TestSpecPodExtrasReserved(t *testing.T) {
+	generated := func() *corev1.PodSpec {
+		return &corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: "import-keystore-my-keystore"}},
+			Containers:     []corev1.Container{{Name: "validator"}},
+			Volumes:        []corev1.Volume{{Name: "data"}},
+		}
+	}
+
+	cases := []struct {
+		extras sharedAPI.PodExtras
+		err    string
+	}{
+		{
+			extras: sharedAPI.PodExtras{Sidecars: []corev1.Container{{Name: "validator"}}},
+			err:    "container validator is reserved for containers generated by the operator",
+		},
+		{
+			extras: sharedAPI.PodExtras{InitContainers: []corev1.Container{{Name: "import-keystore-my-keystore"}}},
+			err:    "container import-keystore-my-keystore is reserved for containers generated by the operator",
+		},
+		{
+			extras: sharedAPI.PodExtras{ExtraVolumes: []corev1.Volume{{Name: "data"}}},
+			err:    "volume data is reserved for volumes generated by the operator",
+		},
+	}
+
+	for _, c := range cases {
+		pod := generated()
+		if err := SpecPodExtras(c.extras, pod); err == nil || err.Error() != c.err {
+			t.Errorf("expected error %q, got %v", c.err, err)
+		}
+		if len(pod.Containers) != 1 || len(pod.InitContainers) != 1 || len(pod.Volumes) != 1 {
+			t.Errorf("expected generated pod spec to be left as is, got %v", pod)
+		}
+	}
+}
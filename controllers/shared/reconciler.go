@@ -3,14 +3,19 @@ package shared
 import (
 	"context"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 type Reconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
 }
 
 func (r *Reconciler) GetClient() client.Client {
@@ -22,20 +27,62 @@ func (r *Reconciler) GetScheme() *runtime.Scheme {
 }
 
 // ReconcileOwned reconciles k8s object according to custom resource spec
+// events are recorded on the custom resource if the object is created, updated or failed to be updated
 func (r Reconciler) ReconcileOwned(ctx context.Context, cr CustomResource, obj client.Object, updateFn func(client.Object) error) error {
 
-	obj.SetName(cr.GetName())
+	// owned objects are named after the custom resource unless named by the caller
+	if obj.GetName() == "" {
+		obj.SetName(cr.GetName())
+	}
 	obj.SetNamespace(cr.GetNamespace())
 
-	_, err := ctrl.CreateOrUpdate(ctx, r.GetClient(), obj, func() error {
+	kind := "object"
+	if gvk, err := apiutil.GVKForObject(obj, r.GetScheme()); err == nil {
+		kind = gvk.Kind
+	}
+
+	var events []event
+	var updateFailed bool
+
+	op, err := ctrl.CreateOrUpdate(ctx, r.GetClient(), obj, func() error {
 		if err := ctrl.SetControllerReference(cr, obj, r.GetScheme()); err != nil {
 			return err
 		}
 
-		updateFn(obj)
+		before := obj.DeepCopyObject().(client.Object)
+
+		if err := updateFn(obj); err != nil {
+			updateFailed = true
+			return err
+		}
+
+		if creationTimestamp := obj.GetCreationTimestamp(); !creationTimestamp.IsZero() {
+			events = append(events, changeEvents(before, obj)...)
+		}
 
 		return nil
 	})
 
-	return err
+	if updateFailed {
+		r.Recorder.Eventf(cr, corev1.EventTypeWarning, EventReasonReconcileFailed, "Failed to update %s %s: %s", kind, obj.GetName(), err)
+		return err
+	}
+
+	if err != nil {
+		r.Recorder.Eventf(cr, corev1.EventTypeWarning, EventReasonReconcileFailed, "Failed to reconcile %s %s: %s", kind, obj.GetName(), err)
+		return err
+	}
+
+	for _, e := range events {
+		r.Recorder.Event(cr, e.eventtype, e.reason, e.message)
+	}
+
+	switch op {
+	case controllerutil.OperationResultCreated:
+		r.Recorder.Eventf(cr, corev1.EventTypeNormal, EventReasonCreated, "Created %s %s", kind, obj.GetName())
+	case controllerutil.OperationResultUpdated:
+		r.Recorder.Eventf(cr, corev1.EventTypeNormal, EventReasonUpdated, "Updated %s %s", kind, obj.GetName())
+	}
+
+	return nil
 }
@@ -0,0 +1,64 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	ethereumv1alpha1 "github.com/kotalco/kotal/apis/ethereum/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReconcileOwned(t *testing.T) {
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = ethereumv1alpha1.AddToScheme(scheme)
+
+	recorder := record.NewFakeRecorder(10)
+	r := Reconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).Build(),
+		Scheme:   scheme,
+		Recorder: recorder,
+	}
+
+	node := &ethereumv1alpha1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-node", Namespace: "default", UID: "my-node-uid"},
+	}
+
+	// failed update isn't written and is returned to the caller
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "my-node-keys"}}
+	err := r.ReconcileOwned(ctx, node, secret, func(obj client.Object) error {
+		obj.(*corev1.Secret).StringData = map[string]string{"key": "half-built"}
+		return errors.New("missing key")
+	})
+	if err == nil || err.Error() != "missing key" {
+		t.Errorf("expected update error to be returned, got %v", err)
+	}
+	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(secret), &corev1.Secret{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected secret not to be created, got %v", err)
+	}
+	if e := <-recorder.Events; e != "Warning ReconcileFailed Failed to update Secret my-node-keys: missing key" {
+		t.Errorf("unexpected event %q", e)
+	}
+
+	// events name the owned object
+	secret = &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "my-node-keys"}}
+	if err := r.ReconcileOwned(ctx, node, secret, func(obj client.Object) error {
+		obj.(*corev1.Secret).StringData = map[string]string{"key": "value"}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if e := <-recorder.Events; e != "Normal Created Created Secret my-node-keys" {
+		t.Errorf("unexpected event %q", e)
+	}
+}
@@ -0,0 +1,258 @@
+package shared
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlevent "sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// +kubebuilder:rbac:groups=core,resources=secrets;configmaps,verbs=get;list;watch
+
+const (
+	// ContentHashAnnotation is pod template annotation holding content hash of referenced secrets and config maps
+	ContentHashAnnotation = "kotal.io/content-hash"
+	// IndexSecrets is custom resources field index of referenced secrets names
+	IndexSecrets = ".kotal.references.secrets"
+	// IndexConfigMaps is custom resources field index of referenced config maps names
+	IndexConfigMaps = ".kotal.references.configmaps"
+)
+
+// References is names of secrets and config maps referenced by custom resource
+type References struct {
+	Secrets    []string
+	ConfigMaps []string
+}
+
+// Add adds non empty secrets names to references
+func (refs *References) Add(secrets ...string) {
+	for _, secret := range secrets {
+		if secret != "" {
+			refs.Secrets = append(refs.Secrets, secret)
+		}
+	}
+}
+
+// addEnv adds secrets and config maps referenced by environment variables
+func (refs *References) addEnv(env []corev1.EnvVar, envFrom []corev1.EnvFromSource) {
+	for _, e := range env {
+		if e.ValueFrom == nil {
+			continue
+		}
+		if ref := e.ValueFrom.SecretKeyRef; ref != nil {
+			refs.Add(ref.Name)
+		}
+		if ref := e.ValueFrom.ConfigMapKeyRef; ref != nil && ref.Name != "" {
+			refs.ConfigMaps = append(refs.ConfigMaps, ref.Name)
+		}
+	}
+
+	for _, source := range envFrom {
+		if source.SecretRef != nil {
+			refs.Add(source.SecretRef.Name)
+		}
+		if source.ConfigMapRef != nil && source.ConfigMapRef.Name != "" {
+			refs.ConfigMaps = append(refs.ConfigMaps, source.ConfigMapRef.Name)
+		}
+	}
+}
+
+// addVolumes adds secrets and config maps mounted as volumes
+func (refs *References) addVolumes(volumes []corev1.Volume) {
+	for _, volume := range volumes {
+		if volume.Secret != nil {
+			refs.Add(volume.Secret.SecretName)
+		}
+		if volume.ConfigMap != nil && volume.ConfigMap.Name != "" {
+			refs.ConfigMaps = append(refs.ConfigMaps, volume.ConfigMap.Name)
+		}
+		if volume.Projected == nil {
+			continue
+		}
+		for _, source := range volume.Projected.Sources {
+			if source.Secret != nil {
+				refs.Add(source.Secret.Name)
+			}
+			if source.ConfigMap != nil && source.ConfigMap.Name != "" {
+				refs.ConfigMaps = append(refs.ConfigMaps, source.ConfigMap.Name)
+			}
+		}
+	}
+}
+
+// PodExtrasReferences returns secrets and config maps referenced by extra environment variables, volumes and containers
+func PodExtrasReferences(extras sharedAPI.PodExtras) (refs References) {
+	refs.addEnv(extras.ExtraEnv, nil)
+	refs.addVolumes(extras.ExtraVolumes)
+
+	for _, containers := range [][]corev1.Container{extras.Sidecars, extras.InitContainers} {
+		for _, container := range containers {
+			refs.addEnv(container.Env, container.EnvFrom)
+		}
+	}
+
+	return
+}
+
+// unique returns sorted unique names
+func unique(names []string) (result []string) {
+	set := map[string]bool{}
+	for _, name := range names {
+		if !set[name] {
+			set[name] = true
+			result = append(result, name)
+		}
+	}
+	sort.Strings(result)
+	return
+}
+
+// ContentHash returns hash of referenced secrets and config maps content
+// missing secrets and config maps are hashed by name only, so pods are rolled out once they're created
+func (r Reconciler) ContentHash(ctx context.Context, namespace string, refs References) (string, error) {
+	hash := sha256.New()
+
+	write := func(kind, name string, data map[string][]byte) {
+		hash.Write([]byte(kind + "/" + name + "\n"))
+		keys := make([]string, 0, len(data))
+		for key := range data {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			hash.Write([]byte(key + "="))
+			hash.Write(data[key])
+			hash.Write([]byte("\n"))
+		}
+	}
+
+	for _, name := range unique(refs.Secrets) {
+		secret := &corev1.Secret{}
+		if err := r.Client.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, secret); client.IgnoreNotFound(err) != nil {
+			return "", err
+		}
+		write("secret", name, secret.Data)
+	}
+
+	for _, name := range unique(refs.ConfigMaps) {
+		configmap := &corev1.ConfigMap{}
+		if err := r.Client.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, configmap); client.IgnoreNotFound(err) != nil {
+			return "", err
+		}
+		data := configmap.BinaryData
+		if len(configmap.Data) != 0 {
+			data = map[string][]byte{}
+			for key, value := range configmap.BinaryData {
+				data[key] = value
+			}
+			for key, value := range configmap.Data {
+				data[key] = []byte(value)
+			}
+		}
+		write("configmap", name, data)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// SpecContentHash annotates statefulset pod template with content hash of referenced secrets and config maps
+// generated config map named after the custom resource is hashed too
+// pods are rolled out if any of them changes
+// it must be called after statefulset pod template is set
+func (r Reconciler) SpecContentHash(ctx context.Context, cr CustomResource, sts *appsv1.StatefulSet, refs References) error {
+	refs.ConfigMaps = append(refs.ConfigMaps, cr.GetName())
+
+	hash, err := r.ContentHash(ctx, cr.GetNamespace(), refs)
+	if err != nil {
+		return err
+	}
+
+	if sts.Spec.Template.Annotations == nil {
+		sts.Spec.Template.Annotations = map[string]string{}
+	}
+	sts.Spec.Template.Annotations[ContentHashAnnotation] = hash
+
+	return nil
+}
+
+// IndexReferences indexes custom resources by names of secrets and config maps they reference
+func IndexReferences(mgr ctrl.Manager, cr client.Object, references func(client.Object) References) error {
+	indexer := mgr.GetFieldIndexer()
+
+	if err := indexer.IndexField(context.Background(), cr, IndexSecrets, func(obj client.Object) []string {
+		return unique(references(obj).Secrets)
+	}); err != nil {
+		return err
+	}
+
+	return indexer.IndexField(context.Background(), cr, IndexConfigMaps, func(obj client.Object) []string {
+		return unique(references(obj).ConfigMaps)
+	})
+}
+
+// EnqueueReferencing enqueues custom resources referencing the secret or config map using field index
+func (r Reconciler) EnqueueReferencing(list client.ObjectList, index string) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) (requests []reconcile.Request) {
+		crs := list.DeepCopyObject().(client.ObjectList)
+		if err := r.Client.List(ctx, crs, client.InNamespace(obj.GetNamespace()), client.MatchingFields{index: obj.GetName()}); err != nil {
+			return
+		}
+
+		items, err := meta.ExtractList(crs)
+		if err != nil {
+			return
+		}
+
+		for _, item := range items {
+			if cr, ok := item.(client.Object); ok {
+				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cr)})
+			}
+		}
+
+		return
+	})
+}
+
+// GenerationOrContentChangedPredicate filters out updates that don't change generation
+// it's applied to custom resource and owned objects watches only, owned secrets and config maps updates are filtered out
+// referenced secrets and config maps are watched without it, so their content changes always pass
+// paused annotation changes pass, so reconciliation is paused and resumed right away
+// stateful set rollout progress passes, so rolled out data resync is recorded in status
+func GenerationOrContentChangedPredicate() predicate.Predicate {
+	return predicate.Or(
+		predicate.GenerationChangedPredicate{},
+		predicate.Funcs{
+			UpdateFunc: func(e ctrlevent.UpdateEvent) bool {
+				if e.ObjectOld == nil || e.ObjectNew == nil {
+					return false
+				}
+				return e.ObjectOld.GetAnnotations()[sharedAPI.PausedAnnotation] != e.ObjectNew.GetAnnotations()[sharedAPI.PausedAnnotation]
+			},
+		},
+		predicate.Funcs{
+			UpdateFunc: func(e ctrlevent.UpdateEvent) bool {
+				oldSts, ok := e.ObjectOld.(*appsv1.StatefulSet)
+				if !ok {
+					return false
+				}
+				newSts, ok := e.ObjectNew.(*appsv1.StatefulSet)
+				if !ok {
+					return false
+				}
+				return oldSts.Status.ObservedGeneration != newSts.Status.ObservedGeneration ||
+					oldSts.Status.UpdatedReplicas != newSts.Status.UpdatedReplicas
+			},
+		},
+	)
+}
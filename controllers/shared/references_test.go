@@ -0,0 +1,153 @@
+package shared
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	ethereumv1alpha1 "github.com/kotalco/kotal/apis/ethereum/v1alpha1"
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	ctrlevent "sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestPodExtrasReferences(t *testing.T) {
+	extras := sharedAPI.PodExtras{
+		ExtraEnv: []corev1.EnvVar{
+			{
+				Name: "API_TOKEN",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "api-token"},
+						Key:                  "token",
+					},
+				},
+			},
+		},
+		ExtraVolumes: []corev1.Volume{
+			{
+				Name: "ca-bundle",
+				VolumeSource: corev1.VolumeSource{
+					ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "ca-bundle"},
+					},
+				},
+			},
+		},
+		Sidecars: []corev1.Container{
+			{
+				Name: "log-shipper",
+				EnvFrom: []corev1.EnvFromSource{
+					{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "shipper"}}},
+				},
+			},
+		},
+	}
+
+	refs := PodExtrasReferences(extras)
+
+	if !reflect.DeepEqual(refs.Secrets, []string{"api-token", "shipper"}) {
+		t.Errorf("expected referenced secrets to be api-token and shipper, got %v", refs.Secrets)
+	}
+
+	if !reflect.DeepEqual(refs.ConfigMaps, []string{"ca-bundle"}) {
+		t.Errorf("expected referenced config maps to be ca-bundle, got %v", refs.ConfigMaps)
+	}
+}
+
+func TestContentHash(t *testing.T) {
+	ctx := context.Background()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "jwt", Namespace: "default"},
+		Data:       map[string][]byte{"secret": []byte("old")},
+	}
+
+	r := Reconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(secret).Build(),
+	}
+
+	refs := References{Secrets: []string{"jwt", "missing"}}
+
+	before, err := r.ContentHash(ctx, "default", refs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// order of references doesn't change the hash
+	again, _ := r.ContentHash(ctx, "default", References{Secrets: []string{"missing", "jwt", "jwt"}})
+	if again != before {
+		t.Errorf("expected hash to be %s, got %s", before, again)
+	}
+
+	secret.Data["secret"] = []byte("new")
+	if err := r.Client.Update(ctx, secret); err != nil {
+		t.Fatal(err)
+	}
+
+	after, _ := r.ContentHash(ctx, "default", refs)
+	if after == before {
+		t.Error("expected hash to change after secret is updated")
+	}
+}
+
+func TestGenerationOrContentChangedPredicate(t *testing.T) {
+	p := GenerationOrContentChangedPredicate()
+
+	old := &ethereumv1alpha1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-node", Namespace: "default", Generation: 1},
+	}
+
+	// labels changed only
+	labeled := old.DeepCopy()
+	labeled.Labels = map[string]string{"team": "infra"}
+	if p.Update(ctrlevent.UpdateEvent{ObjectOld: old, ObjectNew: labeled}) {
+		t.Errorf("expected update not changing generation to be filtered out")
+	}
+
+	// node is paused
+	paused := old.DeepCopy()
+	paused.Annotations = map[string]string{sharedAPI.PausedAnnotation: "true"}
+	if !p.Update(ctrlevent.UpdateEvent{ObjectOld: old, ObjectNew: paused}) {
+		t.Errorf("expected pausing node to be reconciled")
+	}
+
+	// node is resumed
+	if !p.Update(ctrlevent.UpdateEvent{ObjectOld: paused, ObjectNew: old}) {
+		t.Errorf("expected resuming node to be reconciled")
+	}
+
+	// spec changed
+	updated := old.DeepCopy()
+	updated.Generation = 2
+	if !p.Update(ctrlevent.UpdateEvent{ObjectOld: old, ObjectNew: updated}) {
+		t.Errorf("expected update changing generation to be reconciled")
+	}
+
+	// stateful set rollout progressed
+	sts := &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "my-node", Namespace: "default", Generation: 2}}
+	rolled := sts.DeepCopy()
+	rolled.Status.UpdatedReplicas = 1
+	if !p.Update(ctrlevent.UpdateEvent{ObjectOld: sts, ObjectNew: rolled}) {
+		t.Errorf("expected stateful set rollout progress to be reconciled")
+	}
+
+	// stateful set ready replicas changed only
+	ready := rolled.DeepCopy()
+	ready.Status.ReadyReplicas = 1
+	if p.Update(ctrlevent.UpdateEvent{ObjectOld: rolled, ObjectNew: ready}) {
+		t.Errorf("expected stateful set status update not progressing rollout to be filtered out")
+	}
+
+	// owned secrets updates don't trigger reconciliation
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "my-secret", Namespace: "default"}}
+	keystore := secret.DeepCopy()
+	keystore.Data = map[string][]byte{"keystore": []byte("{}")}
+	if p.Update(ctrlevent.UpdateEvent{ObjectOld: secret, ObjectNew: keystore}) {
+		t.Errorf("expected owned secret updates to be filtered out")
+	}
+}
@@ -0,0 +1,118 @@
+package shared
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	//go:embed resync_data.sh
+	resyncDataScript string
+)
+
+// resyncContainerName is name of init container wiping node data
+const resyncContainerName = "resync-data"
+
+// SpecResync prepends init container wiping node data once per data resync generation
+// keys in secrets directory are kept, they're not stored in data volume
+// changing data resync generation rolls out node pods, restarting the client
+func SpecResync(resync sharedAPI.DataResync, pod *corev1.PodSpec, homeDir string) {
+	if resync.Resync == 0 {
+		return
+	}
+
+	var dataMount *corev1.VolumeMount
+	for _, container := range pod.Containers {
+		for i := range container.VolumeMounts {
+			if container.VolumeMounts[i].Name == DataVolumeName {
+				dataMount = &container.VolumeMounts[i]
+				break
+			}
+		}
+		if dataMount != nil {
+			break
+		}
+	}
+
+	if dataMount == nil {
+		return
+	}
+
+	wipe := corev1.Container{
+		Name:    resyncContainerName,
+		Image:   BusyboxImage,
+		Command: []string{"/bin/sh", "-c", resyncDataScript},
+		Env: []corev1.EnvVar{
+			{
+				Name:  EnvDataPath,
+				Value: PathData(homeDir),
+			},
+			{
+				Name:  EnvResync,
+				Value: fmt.Sprintf("%d", resync.Resync),
+			},
+		},
+		VolumeMounts: []corev1.VolumeMount{*dataMount},
+	}
+
+	pod.InitContainers = append([]corev1.Container{wipe}, pod.InitContainers...)
+}
+
+// UpdateResyncStatus records data resync generation once it's rolled out
+// it's rolled out once all stateful set pods are updated to pod template running the resync init container
+func (r Reconciler) UpdateResyncStatus(ctx context.Context, cr CustomResource, status *sharedAPI.ResyncStatus, resync sharedAPI.DataResync) error {
+	if status.Resync == resync.Resync {
+		return nil
+	}
+
+	sts := &appsv1.StatefulSet{}
+	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(cr), sts); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	if !ResyncRolledOut(sts, resync.Resync) {
+		return nil
+	}
+
+	now := metav1.Now()
+	status.Resync = resync.Resync
+	status.LastResyncTime = &now
+
+	return nil
+}
+
+// ResyncRolledOut returns true if stateful set pod template runs data resync generation
+// and all stateful set pods are updated to it
+func ResyncRolledOut(sts *appsv1.StatefulSet, resync uint) bool {
+	if sts.Status.ObservedGeneration != sts.Generation {
+		return false
+	}
+
+	replicas := int32(1)
+	if sts.Spec.Replicas != nil {
+		replicas = *sts.Spec.Replicas
+	}
+	if sts.Status.UpdatedReplicas != replicas {
+		return false
+	}
+
+	for _, container := range sts.Spec.Template.Spec.InitContainers {
+		if container.Name != resyncContainerName {
+			continue
+		}
+		for _, env := range container.Env {
+			if env.Name == EnvResync {
+				return env.Value == fmt.Sprintf("%d", resync)
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,13 @@
+#!/bin/sh
+
+set -e
+
+marker="$KOTAL_DATA_PATH/.kotal-resync"
+
+# data is wiped once per data resync generation
+if [ "$(cat $marker 2>/dev/null)" != "$KOTAL_RESYNC" ]; then
+	echo "Wiping $KOTAL_DATA_PATH for data resync $KOTAL_RESYNC"
+	mkdir -p $KOTAL_DATA_PATH
+	find $KOTAL_DATA_PATH -mindepth 1 -maxdepth 1 -exec rm -rf {} +
+	echo "$KOTAL_RESYNC" > $marker
+fi
@@ -0,0 +1,140 @@
+package shared
+
+import (
+	"context"
+	"testing"
+
+	ethereumv1alpha1 "github.com/kotalco/kotal/apis/ethereum/v1alpha1"
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// dataPodSpec returns pod spec mounting data volume at home directory
+func dataPodSpec() *corev1.PodSpec {
+	return &corev1.PodSpec{
+		InitContainers: []corev1.Container{{Name: "init-genesis"}},
+		Containers: []corev1.Container{
+			{
+				Name: "node",
+				VolumeMounts: []corev1.VolumeMount{
+					{Name: "secrets", MountPath: PathSecrets("/home/kotal")},
+					{Name: DataVolumeName, MountPath: "/home/kotal"},
+				},
+			},
+		},
+	}
+}
+
+func TestSpecResync(t *testing.T) {
+	pod := dataPodSpec()
+
+	SpecResync(sharedAPI.DataResync{Resync: 2}, pod, "/home/kotal")
+
+	if len(pod.InitContainers) != 2 || pod.InitContainers[1].Name != "init-genesis" {
+		t.Fatalf("expected resync init container to run first, got %v", pod.InitContainers)
+	}
+
+	wipe := pod.InitContainers[0]
+	if wipe.Name != "resync-data" || wipe.Image != BusyboxImage {
+		t.Errorf("expected resync-data busybox container, got %s %s", wipe.Name, wipe.Image)
+	}
+
+	env := map[string]string{}
+	for _, e := range wipe.Env {
+		env[e.Name] = e.Value
+	}
+	if env[EnvDataPath] != "/home/kotal/kotal-data" || env[EnvResync] != "2" {
+		t.Errorf("expected data path and resync generation env, got %v", env)
+	}
+
+	// secrets volume isn't mounted, so keys are kept
+	if len(wipe.VolumeMounts) != 1 || wipe.VolumeMounts[0].Name != DataVolumeName || wipe.VolumeMounts[0].MountPath != "/home/kotal" {
+		t.Errorf("expected only data volume to be mounted, got %v", wipe.VolumeMounts)
+	}
+}
+
+func TestSpecResyncWithoutResync(t *testing.T) {
+	pod := dataPodSpec()
+
+	SpecResync(sharedAPI.DataResync{}, pod, "/home/kotal")
+
+	if len(pod.InitContainers) != 1 {
+		t.Errorf("expected no resync init container, got %v", pod.InitContainers)
+	}
+}
+
+func TestUpdateResyncStatus(t *testing.T) {
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = ethereumv1alpha1.AddToScheme(scheme)
+
+	node := &ethereumv1alpha1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-node", Namespace: "default"},
+	}
+
+	replicas := int32(2)
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-node", Namespace: "default", Generation: 2},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: &replicas,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "node", VolumeMounts: []corev1.VolumeMount{{Name: DataVolumeName}}}}},
+			},
+		},
+		Status: appsv1.StatefulSetStatus{ObservedGeneration: 2, UpdatedReplicas: 1},
+	}
+	SpecResync(sharedAPI.DataResync{Resync: 1}, &sts.Spec.Template.Spec, "/home/kotal")
+
+	r := Reconciler{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(sts).Build()}
+	status := sharedAPI.ResyncStatus{}
+
+	if err := r.UpdateResyncStatus(ctx, node, &status, sharedAPI.DataResync{}); err != nil {
+		t.Fatal(err)
+	}
+	if status.LastResyncTime != nil {
+		t.Errorf("expected no resync time, got %v", status.LastResyncTime)
+	}
+
+	// one of two replicas is updated
+	if err := r.UpdateResyncStatus(ctx, node, &status, sharedAPI.DataResync{Resync: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if status.Resync != 0 || status.LastResyncTime != nil {
+		t.Fatalf("expected resync 1 not to be recorded before it's rolled out, got %+v", status)
+	}
+
+	// all replicas are updated
+	sts.Status.UpdatedReplicas = 2
+	if err := r.Client.Status().Update(ctx, sts); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.UpdateResyncStatus(ctx, node, &status, sharedAPI.DataResync{Resync: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if status.Resync != 1 || status.LastResyncTime == nil {
+		t.Fatalf("expected resync 1 to be recorded, got %+v", status)
+	}
+
+	last := *status.LastResyncTime
+	if err := r.UpdateResyncStatus(ctx, node, &status, sharedAPI.DataResync{Resync: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if !status.LastResyncTime.Equal(&last) {
+		t.Errorf("expected resync time not to change, got %v", status.LastResyncTime)
+	}
+
+	// stateful set pod template doesn't run resync 2 yet
+	if err := r.UpdateResyncStatus(ctx, node, &status, sharedAPI.DataResync{Resync: 2}); err != nil {
+		t.Fatal(err)
+	}
+	if status.Resync != 1 {
+		t.Errorf("expected resync 2 not to be recorded before it's in pod template, got %d", status.Resync)
+	}
+}
@@ -0,0 +1,163 @@
+package shared
+
+import (
+	"fmt"
+	"path"
+
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// SecretsStoreCSIDriver is Secrets Store CSI driver name
+	SecretsStoreCSIDriver = "secrets-store.csi.k8s.io"
+	// vaultAnnotationPrefix is Vault agent injector annotations prefix
+	vaultAnnotationPrefix = "vault.hashicorp.com/"
+)
+
+// secretItem is a secret key mounted as a file
+type secretItem struct {
+	secret string
+	key    string
+	path   string
+}
+
+// secretItems returns secret keys mounted by secret or projected volume
+func secretItems(volume corev1.Volume) (items []secretItem) {
+	if source := volume.Secret; source != nil {
+		for _, item := range source.Items {
+			items = append(items, secretItem{source.SecretName, item.Key, item.Path})
+		}
+	}
+
+	if volume.Projected != nil {
+		for _, source := range volume.Projected.Sources {
+			if source.Secret == nil {
+				continue
+			}
+			for _, item := range source.Secret.Items {
+				items = append(items, secretItem{source.Secret.Name, item.Key, item.Path})
+			}
+		}
+	}
+
+	return
+}
+
+// SpecSecretStore loads keys mounted by generated secret volumes from external secret store
+// secrets of these volumes are secret store entries, and they're removed from pod template
+// CSI provider mounts every key from its secret provider class volume
+// Vault provider injects every key using Vault agent annotations
+// it must be called after statefulset pod template is set
+func SpecSecretStore(store *sharedAPI.SecretStore, template *corev1.PodTemplateSpec, volumes ...string) {
+	if store == nil {
+		return
+	}
+
+	pod := &template.Spec
+	containers := []*corev1.Container{}
+	for i := range pod.InitContainers {
+		containers = append(containers, &pod.InitContainers[i])
+	}
+	for i := range pod.Containers {
+		containers = append(containers, &pod.Containers[i])
+	}
+
+	// secret provider classes volumes names
+	classes := map[string]string{}
+	// files of keys injected by Vault agent
+	injected := map[string]bool{}
+
+	for _, name := range volumes {
+		var items []secretItem
+		var kept []corev1.Volume
+		for _, volume := range pod.Volumes {
+			if volume.Name == name {
+				items = secretItems(volume)
+				continue
+			}
+			kept = append(kept, volume)
+		}
+		pod.Volumes = kept
+
+		for _, container := range containers {
+			var mounts []corev1.VolumeMount
+			for _, mount := range container.VolumeMounts {
+				if mount.Name != name {
+					mounts = append(mounts, mount)
+					continue
+				}
+
+				for _, item := range items {
+					file := path.Join(mount.MountPath, item.path)
+
+					if store.Provider == sharedAPI.VaultSecretStore {
+						if !injected[file] {
+							specVaultSecret(store, template, fmt.Sprintf("kotal-%d", len(injected)), item, file)
+							injected[file] = true
+						}
+						continue
+					}
+
+					volume, ok := classes[item.secret]
+					if !ok {
+						volume = fmt.Sprintf("secret-store-%d", len(classes))
+						classes[item.secret] = volume
+						pod.Volumes = append(pod.Volumes, secretProviderClassVolume(volume, item.secret))
+					}
+
+					mounts = append(mounts, corev1.VolumeMount{
+						Name:      volume,
+						MountPath: file,
+						SubPath:   item.key,
+						ReadOnly:  true,
+					})
+				}
+			}
+			container.VolumeMounts = mounts
+		}
+	}
+
+	if len(injected) != 0 {
+		template.Annotations[vaultAnnotationPrefix+"agent-inject"] = "true"
+		template.Annotations[vaultAnnotationPrefix+"role"] = store.VaultRole
+		// keys must be available to generated init containers
+		template.Annotations[vaultAnnotationPrefix+"agent-init-first"] = "true"
+		template.Annotations[vaultAnnotationPrefix+"agent-pre-populate-only"] = "true"
+	}
+}
+
+// secretProviderClassVolume returns Secrets Store CSI driver volume mounting secret provider class
+func secretProviderClassVolume(name, class string) corev1.Volume {
+	readOnly := true
+	return corev1.Volume{
+		Name: name,
+		VolumeSource: corev1.VolumeSource{
+			CSI: &corev1.CSIVolumeSource{
+				Driver:   SecretsStoreCSIDriver,
+				ReadOnly: &readOnly,
+				VolumeAttributes: map[string]string{
+					"secretProviderClass": class,
+				},
+			},
+		},
+	}
+}
+
+// specVaultSecret annotates pod template to inject secret key into the file using Vault agent
+// secret is read from Vault KV v2 secrets engine
+func specVaultSecret(store *sharedAPI.SecretStore, template *corev1.PodTemplateSpec, id string, item secretItem, file string) {
+	if template.Annotations == nil {
+		template.Annotations = map[string]string{}
+	}
+
+	secret := item.secret
+	if store.VaultPath != "" {
+		secret = path.Join(store.VaultPath, item.secret)
+	}
+
+	template.Annotations[vaultAnnotationPrefix+"agent-inject-secret-"+id] = secret
+	template.Annotations[vaultAnnotationPrefix+"agent-inject-template-"+id] = fmt.Sprintf(`{{- with secret %q -}}{{ index .Data.data %q }}{{- end -}}`, secret, item.key)
+	template.Annotations[vaultAnnotationPrefix+"agent-inject-file-"+id] = path.Base(file)
+	template.Annotations[vaultAnnotationPrefix+"secret-volume-path-"+id] = path.Dir(file)
+}
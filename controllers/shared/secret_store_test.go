@@ -0,0 +1,152 @@
+package shared
+
+import (
+	"testing"
+
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// secretsPodTemplate returns pod template mounting node key and jwt secret from secrets volume
+func secretsPodTemplate() *corev1.PodTemplateSpec {
+	return &corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{
+				{
+					Name:         "convert-node-key",
+					VolumeMounts: []corev1.VolumeMount{{Name: "secrets", MountPath: "/home/kotal/secrets"}},
+				},
+			},
+			Containers: []corev1.Container{
+				{
+					Name: "node",
+					VolumeMounts: []corev1.VolumeMount{
+						{Name: "data", MountPath: "/home/kotal/data"},
+						{Name: "secrets", MountPath: "/home/kotal/secrets"},
+					},
+				},
+			},
+			Volumes: []corev1.Volume{
+				{Name: "data"},
+				{
+					Name: "secrets",
+					VolumeSource: corev1.VolumeSource{
+						Projected: &corev1.ProjectedVolumeSource{
+							Sources: []corev1.VolumeProjection{
+								{
+									Secret: &corev1.SecretProjection{
+										LocalObjectReference: corev1.LocalObjectReference{Name: "node-keys"},
+										Items:                []corev1.KeyToPath{{Key: "nodekey", Path: "nodekey"}},
+									},
+								},
+								{
+									Secret: &corev1.SecretProjection{
+										LocalObjectReference: corev1.LocalObjectReference{Name: "jwt"},
+										Items:                []corev1.KeyToPath{{Key: "secret", Path: "jwt.secret"}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestSpecSecretStoreWithoutStore(t *testing.T) {
+	template := secretsPodTemplate()
+
+	SpecSecretStore(nil, template, "secrets")
+
+	if len(template.Spec.Volumes) != 2 || template.Spec.Volumes[1].Projected == nil {
+		t.Errorf("expected secrets volume to be left as is, got %v", template.Spec.Volumes)
+	}
+}
+
+func TestSpecSecretStoreCSI(t *testing.T) {
+	template := secretsPodTemplate()
+
+	SpecSecretStore(&sharedAPI.SecretStore{Provider: sharedAPI.CSISecretStore}, template, "secrets")
+
+	volumes := template.Spec.Volumes
+	if len(volumes) != 3 || volumes[0].Name != "data" {
+		t.Fatalf("expected secrets volume to be replaced by secret provider classes volumes, got %v", volumes)
+	}
+
+	for i, class := range []string{"node-keys", "jwt"} {
+		csi := volumes[i+1].CSI
+		if csi == nil || csi.Driver != SecretsStoreCSIDriver || csi.VolumeAttributes["secretProviderClass"] != class {
+			t.Errorf("expected volume %d to mount secret provider class %s, got %v", i+1, class, volumes[i+1])
+		}
+	}
+
+	node := template.Spec.Containers[0]
+	if len(node.VolumeMounts) != 3 || node.VolumeMounts[0].Name != "data" {
+		t.Fatalf("expected secrets volume mount to be replaced by keys mounts, got %v", node.VolumeMounts)
+	}
+
+	if mount := node.VolumeMounts[1]; mount.Name != "secret-store-0" || mount.MountPath != "/home/kotal/secrets/nodekey" || mount.SubPath != "nodekey" {
+		t.Errorf("expected node key to be mounted from its secret provider class, got %v", mount)
+	}
+
+	if mount := node.VolumeMounts[2]; mount.Name != "secret-store-1" || mount.MountPath != "/home/kotal/secrets/jwt.secret" || mount.SubPath != "secret" {
+		t.Errorf("expected jwt secret to be mounted from its secret provider class, got %v", mount)
+	}
+
+	if mounts := template.Spec.InitContainers[0].VolumeMounts; len(mounts) != 2 || mounts[0].Name != "secret-store-0" {
+		t.Errorf("expected init container keys to be mounted from secret provider classes, got %v", mounts)
+	}
+
+	if len(template.Annotations) != 0 {
+		t.Errorf("expected no vault annotations, got %v", template.Annotations)
+	}
+}
+
+func TestSpecSecretStoreVault(t *testing.T) {
+	template := secretsPodTemplate()
+	store := &sharedAPI.SecretStore{
+		Provider:  sharedAPI.VaultSecretStore,
+		VaultRole: "ethereum",
+		VaultPath: "secret/data/kotal",
+	}
+
+	SpecSecretStore(store, template, "secrets")
+
+	if len(template.Spec.Volumes) != 1 || template.Spec.Volumes[0].Name != "data" {
+		t.Errorf("expected secrets volume to be removed, got %v", template.Spec.Volumes)
+	}
+
+	if mounts := template.Spec.Containers[0].VolumeMounts; len(mounts) != 1 || mounts[0].Name != "data" {
+		t.Errorf("expected secrets volume mount to be removed, got %v", mounts)
+	}
+
+	if mounts := template.Spec.InitContainers[0].VolumeMounts; len(mounts) != 0 {
+		t.Errorf("expected init container secrets volume mount to be removed, got %v", mounts)
+	}
+
+	expected := map[string]string{
+		"vault.hashicorp.com/agent-inject":                  "true",
+		"vault.hashicorp.com/role":                          "ethereum",
+		"vault.hashicorp.com/agent-init-first":              "true",
+		"vault.hashicorp.com/agent-pre-populate-only":       "true",
+		"vault.hashicorp.com/agent-inject-secret-kotal-0":   "secret/data/kotal/node-keys",
+		"vault.hashicorp.com/agent-inject-template-kotal-0": `{{- with secret "secret/data/kotal/node-keys" -}}{{ index .Data.data "nodekey" }}{{- end -}}`,
+		"vault.hashicorp.com/agent-inject-file-kotal-0":     "nodekey",
+		"vault.hashicorp.com/secret-volume-path-kotal-0":    "/home/kotal/secrets",
+		"vault.hashicorp.com/agent-inject-secret-kotal-1":   "secret/data/kotal/jwt",
+		"vault.hashicorp.com/agent-inject-template-kotal-1": `{{- with secret "secret/data/kotal/jwt" -}}{{ index .Data.data "secret" }}{{- end -}}`,
+		"vault.hashicorp.com/agent-inject-file-kotal-1":     "jwt.secret",
+		"vault.hashicorp.com/secret-volume-path-kotal-1":    "/home/kotal/secrets",
+	}
+
+	if len(template.Annotations) != len(expected) {
+		t.Errorf("expected %d vault annotations, got %v", len(expected), template.Annotations)
+	}
+
+	for annotation, value := range expected {
+		if template.Annotations[annotation] != value {
+			t.Errorf("expected annotation %s to be %q, got %q", annotation, value, template.Annotations[annotation])
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package shared
+
+import (
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+	"github.com/kotalco/kotal/clients"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// SpecShutdown sets pod termination grace period and client container pre-stop hook
+// shutdown spec overrides client recommended settings
+// client container is the first pod container
+func SpecShutdown(shutdown sharedAPI.Shutdown, client clients.Interface, pod *corev1.PodSpec) {
+	gracePeriod := shutdown.TerminationGracePeriodSeconds
+	preStop := shutdown.PreStop
+
+	if recommended, ok := client.(clients.ShutdownInterface); ok {
+		if gracePeriod == nil {
+			seconds := recommended.TerminationGracePeriod()
+			gracePeriod = &seconds
+		}
+		if preStop == nil {
+			preStop = recommended.PreStop()
+		}
+	}
+
+	pod.TerminationGracePeriodSeconds = gracePeriod
+
+	if len(preStop) == 0 || len(pod.Containers) == 0 {
+		return
+	}
+
+	container := &pod.Containers[0]
+	if container.Lifecycle == nil {
+		container.Lifecycle = &corev1.Lifecycle{}
+	}
+	container.Lifecycle.PreStop = &corev1.LifecycleHandler{
+		Exec: &corev1.ExecAction{
+			Command: preStop,
+		},
+	}
+}
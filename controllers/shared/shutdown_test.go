@@ -0,0 +1,77 @@
+package shared
+
+import (
+	"reflect"
+	"testing"
+
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// plainClient is a client without graceful shutdown recommendations
+type plainClient struct{}
+
+func (c plainClient) Args() []string       { return nil }
+func (c plainClient) Command() []string    { return nil }
+func (c plainClient) Env() []corev1.EnvVar { return nil }
+func (c plainClient) HomeDir() string      { return "/home/kotal" }
+
+// shutdownClient is a client recommending graceful shutdown settings
+type shutdownClient struct{ plainClient }
+
+func (c shutdownClient) TerminationGracePeriod() int64 { return 300 }
+func (c shutdownClient) PreStop() []string             { return []string{"client", "stop"} }
+
+func shutdownPodSpec() *corev1.PodSpec {
+	return &corev1.PodSpec{
+		Containers: []corev1.Container{{Name: "node"}, {Name: "exporter"}},
+	}
+}
+
+func TestSpecShutdownRecommended(t *testing.T) {
+	pod := shutdownPodSpec()
+
+	SpecShutdown(sharedAPI.Shutdown{}, shutdownClient{}, pod)
+
+	if pod.TerminationGracePeriodSeconds == nil || *pod.TerminationGracePeriodSeconds != 300 {
+		t.Errorf("expected recommended grace period to be used, got %v", pod.TerminationGracePeriodSeconds)
+	}
+
+	lifecycle := pod.Containers[0].Lifecycle
+	if lifecycle == nil || !reflect.DeepEqual(lifecycle.PreStop.Exec.Command, []string{"client", "stop"}) {
+		t.Errorf("expected recommended pre-stop command to be used, got %v", lifecycle)
+	}
+
+	if pod.Containers[1].Lifecycle != nil {
+		t.Errorf("expected sidecar containers to be left as is")
+	}
+}
+
+func TestSpecShutdownOverride(t *testing.T) {
+	pod := shutdownPodSpec()
+	var gracePeriod int64 = 60
+	shutdown := sharedAPI.Shutdown{
+		TerminationGracePeriodSeconds: &gracePeriod,
+		PreStop:                       []string{"sleep", "5"},
+	}
+
+	SpecShutdown(shutdown, shutdownClient{}, pod)
+
+	if *pod.TerminationGracePeriodSeconds != 60 {
+		t.Errorf("expected grace period to be overridden, got %d", *pod.TerminationGracePeriodSeconds)
+	}
+
+	if command := pod.Containers[0].Lifecycle.PreStop.Exec.Command; !reflect.DeepEqual(command, []string{"sleep", "5"}) {
+		t.Errorf("expected pre-stop command to be overridden, got %v", command)
+	}
+}
+
+func TestSpecShutdownWithoutRecommendations(t *testing.T) {
+	pod := shutdownPodSpec()
+
+	SpecShutdown(sharedAPI.Shutdown{}, plainClient{}, pod)
+
+	if pod.TerminationGracePeriodSeconds != nil || pod.Containers[0].Lifecycle != nil {
+		t.Errorf("expected kubernetes defaults to be used, got %v %v", pod.TerminationGracePeriodSeconds, pod.Containers[0].Lifecycle)
+	}
+}
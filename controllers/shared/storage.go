@@ -0,0 +1,94 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DataVolumeName is the name of node data volume and volume claim template
+const DataVolumeName = "data"
+
+// ReplicaName returns custom resource replica pod name
+func ReplicaName(cr metav1.Object, ordinal uint) string {
+	return fmt.Sprintf("%s-%d", cr.GetName(), ordinal)
+}
+
+// ReplicaPVCName returns replica persistent volume claim name created from statefulset volume claim template
+func ReplicaPVCName(cr metav1.Object, ordinal uint) string {
+	return fmt.Sprintf("%s-%s", DataVolumeName, ReplicaName(cr, ordinal))
+}
+
+// isSharedStorage returns true if statefulset was created with a single persistent volume claim shared by all replicas
+func isSharedStorage(sts *appsv1.StatefulSet) bool {
+	return !sts.CreationTimestamp.IsZero() && len(sts.Spec.VolumeClaimTemplates) == 0
+}
+
+// ReconcilePVCs reconciles custom resource data persistent volume claims
+// every replica has its own persistent volume claim, it's reconciled to support volume expansion
+// statefulsets created with a single persistent volume claim keep using it, because volume claim templates are immutable
+func (r Reconciler) ReconcilePVCs(ctx context.Context, cr CustomResource, replicas uint, updateFn func(*corev1.PersistentVolumeClaim)) error {
+	sts := &appsv1.StatefulSet{}
+	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(cr), sts); client.IgnoreNotFound(err) != nil {
+		return err
+	}
+
+	specPVC := func(obj client.Object) error {
+		updateFn(obj.(*corev1.PersistentVolumeClaim))
+		return nil
+	}
+
+	if isSharedStorage(sts) {
+		return r.ReconcileOwned(ctx, cr, &corev1.PersistentVolumeClaim{}, specPVC)
+	}
+
+	for ordinal := uint(0); ordinal < replicas; ordinal++ {
+		pvc := &corev1.PersistentVolumeClaim{}
+		pvc.SetName(ReplicaPVCName(cr, ordinal))
+		if err := r.ReconcileOwned(ctx, cr, pvc, specPVC); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SpecDataStorage returns statefulset data volumes and volume claim templates
+// it must be called before statefulset spec is updated
+func SpecDataStorage(cr CustomResource, sts *appsv1.StatefulSet, pvc *corev1.PersistentVolumeClaim) (volumes []corev1.Volume, templates []corev1.PersistentVolumeClaim) {
+	if isSharedStorage(sts) {
+		volumes = []corev1.Volume{
+			{
+				Name: DataVolumeName,
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: cr.GetName(),
+					},
+				},
+			},
+		}
+		return
+	}
+
+	// volume claim templates are immutable
+	if !sts.CreationTimestamp.IsZero() {
+		templates = sts.Spec.VolumeClaimTemplates
+		return
+	}
+
+	templates = []corev1.PersistentVolumeClaim{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   DataVolumeName,
+				Labels: cr.GetLabels(),
+			},
+			Spec: pvc.Spec,
+		},
+	}
+
+	return
+}
@@ -0,0 +1,78 @@
+package shared
+
+import (
+	"testing"
+
+	ethereumv1alpha1 "github.com/kotalco/kotal/apis/ethereum/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestReplicaPVCName(t *testing.T) {
+	node := &ethereumv1alpha1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-node",
+		},
+	}
+
+	if name := ReplicaPVCName(node, 2); name != "data-my-node-2" {
+		t.Errorf("expecting replica pvc name to be data-my-node-2, got %s", name)
+	}
+}
+
+func TestSpecDataStorage(t *testing.T) {
+	node := &ethereumv1alpha1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-node",
+			Labels: map[string]string{
+				"app.kubernetes.io/instance": "my-node",
+			},
+		},
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{
+		Spec: corev1.PersistentVolumeClaimSpec{
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10Gi"),
+				},
+			},
+		},
+	}
+
+	// new statefulset
+	sts := &appsv1.StatefulSet{}
+	volumes, templates := SpecDataStorage(node, sts, pvc)
+	if len(volumes) != 0 {
+		t.Errorf("expecting no data volumes for new statefulset, got %d", len(volumes))
+	}
+	if len(templates) != 1 || templates[0].Name != DataVolumeName {
+		t.Fatalf("expecting data volume claim template for new statefulset, got %v", templates)
+	}
+	if templates[0].Labels["app.kubernetes.io/instance"] != "my-node" {
+		t.Errorf("expecting data volume claim template to have node labels, got %v", templates[0].Labels)
+	}
+
+	// statefulset created with volume claim templates
+	sts.CreationTimestamp = metav1.Now()
+	sts.Spec.VolumeClaimTemplates = templates
+	volumes, templates = SpecDataStorage(node, sts, &corev1.PersistentVolumeClaim{})
+	if len(volumes) != 0 {
+		t.Errorf("expecting no data volumes for existing statefulset, got %d", len(volumes))
+	}
+	if len(templates) != 1 || !templates[0].Spec.Resources.Requests.Storage().Equal(resource.MustParse("10Gi")) {
+		t.Errorf("expecting existing volume claim templates to be kept, got %v", templates)
+	}
+
+	// statefulset created with a single shared persistent volume claim
+	sts.Spec.VolumeClaimTemplates = nil
+	volumes, templates = SpecDataStorage(node, sts, pvc)
+	if len(templates) != 0 {
+		t.Errorf("expecting no volume claim templates for shared storage, got %d", len(templates))
+	}
+	if len(volumes) != 1 || volumes[0].PersistentVolumeClaim == nil || volumes[0].PersistentVolumeClaim.ClaimName != "my-node" {
+		t.Errorf("expecting shared persistent volume claim volume, got %v", volumes)
+	}
+}
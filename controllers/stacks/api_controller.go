@@ -0,0 +1,287 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	stacksv1alpha1 "github.com/kotalco/kotal/apis/stacks/v1alpha1"
+	stacksClients "github.com/kotalco/kotal/clients/stacks"
+	"github.com/kotalco/kotal/controllers/shared"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// APIReconciler reconciles a API object
+type APIReconciler struct {
+	shared.Reconciler
+}
+
+const (
+	// indexStacksNode is field index of referenced Stacks node name
+	indexStacksNode = ".spec.stacksNode"
+)
+
+// +kubebuilder:rbac:groups=stacks.kotal.io,resources=apis,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=stacks.kotal.io,resources=apis/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=stacks.kotal.io,resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=watch;get;list;create;update;delete
+// +kubebuilder:rbac:groups=core,resources=services,verbs=watch;get;create;update;list;delete
+
+// Reconcile Stacks blockchain API
+func (r *APIReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	defer shared.IgnoreConflicts(&err)
+
+	var api stacksv1alpha1.API
+	defer r.ObserveReconcile(&api, req.NamespacedName, &err)
+
+	if err = r.Client.Get(ctx, req.NamespacedName, &api); err != nil {
+		err = client.IgnoreNotFound(err)
+		return
+	}
+
+	var deleting bool
+	if deleting, result, err = r.ReconcileDeletion(ctx, &api, api.Spec.Deletion); deleting || err != nil {
+		return
+	}
+
+	// owned objects are left as is while api is paused
+	if shared.IsPaused(&api) {
+		err = r.ReconcilePaused(ctx, &api, &api.Status.Mode)
+		return
+	}
+
+	// default the api if webhooks are disabled
+	if !shared.IsWebhookEnabled() {
+		api.Default()
+	}
+
+	var node *stacksv1alpha1.Node
+	if node, err = r.getStacksNode(ctx, &api); err != nil {
+		return
+	}
+
+	shared.UpdateLabels(&api, "stacks-blockchain-api", string(node.Spec.Network))
+
+	// reconcile service
+	if err = r.ReconcileOwned(ctx, &api, &corev1.Service{}, func(obj client.Object) error {
+		r.specService(&api, obj.(*corev1.Service))
+		return nil
+	}); err != nil {
+		return
+	}
+
+	// reconcile statefulset
+	sts := &appsv1.StatefulSet{}
+	if err = r.ReconcileOwned(ctx, &api, sts, func(obj client.Object) error {
+		client := stacksClients.NewAPIClient(&api, node)
+		sts := obj.(*appsv1.StatefulSet)
+		if err := r.specStatefulSet(&api, sts, client.Env(), client.Command(), client.Args()); err != nil {
+			return err
+		}
+		shared.SpecShutdown(api.Spec.Shutdown, client, &sts.Spec.Template.Spec)
+		return r.SpecContentHash(ctx, &api, sts, apiReferences(&api))
+	}); err != nil {
+		return
+	}
+
+	// reconcile pod disruption budget
+	if err = r.ReconcilePodDisruptionBudget(ctx, &api, api.Spec.Disruption, *api.Spec.Replicas); err != nil {
+		return
+	}
+
+	// reconcile network policy
+	// events port is only accessible by the referenced Stacks node by default
+	defaults := map[string][]networkingv1.NetworkPolicyPeer{
+		"events": {
+			{
+				PodSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						"app.kubernetes.io/component": "stacks-node",
+						"app.kubernetes.io/instance":  node.Name,
+					},
+				},
+			},
+		},
+	}
+	if err = r.ReconcileNetworkPolicy(ctx, &api, api.Spec.Access, &sts.Spec.Template.Spec, defaults); err != nil {
+		return
+	}
+
+	err = r.updateStatus(ctx, &api)
+
+	return
+}
+
+// getStacksNode returns Stacks node the API receives events from
+// Stacks node must enable JSON-RPC server, which is proxied by the API
+func (r *APIReconciler) getStacksNode(ctx context.Context, api *stacksv1alpha1.API) (*stacksv1alpha1.Node, error) {
+	node := &stacksv1alpha1.Node{}
+	name := types.NamespacedName{Name: api.Spec.StacksNode, Namespace: api.Namespace}
+
+	if err := r.Client.Get(ctx, name, node); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.Recorder.Eventf(api, corev1.EventTypeWarning, shared.EventReasonMissingReference, "Stacks node %s not found", name.Name)
+		}
+		return nil, err
+	}
+
+	// default the node if webhooks are disabled
+	if !shared.IsWebhookEnabled() {
+		node.Default()
+	}
+
+	if !node.Spec.RPC {
+		err := fmt.Errorf("stacks node %s must enable rpc", name.Name)
+		r.Recorder.Event(api, corev1.EventTypeWarning, shared.EventReasonReconcileFailed, err.Error())
+		return nil, err
+	}
+
+	return node, nil
+}
+
+// updateStatus updates Stacks blockchain API status
+func (r *APIReconciler) updateStatus(ctx context.Context, api *stacksv1alpha1.API) error {
+	previous := api.Status
+
+	api.Status.Client = "stacks-blockchain-api"
+
+	api.Status.Mode = shared.Mode(api, api.Spec.Suspend)
+
+	if err := r.Status().Update(ctx, api); err != nil {
+		log.FromContext(ctx).Error(err, "unable to update api status")
+		return err
+	}
+
+	r.RecordStatusTransition(api, previous, api.Status)
+
+	return nil
+}
+
+// apiReferences returns secrets and config maps referenced by Stacks blockchain API
+func apiReferences(api *stacksv1alpha1.API) shared.References {
+	refs := shared.PodExtrasReferences(api.Spec.PodExtras)
+	refs.Add(api.Spec.Postgres.PasswordSecretName.Name)
+	return refs
+}
+
+// apiPorts returns Stacks blockchain API container ports
+func apiPorts(api *stacksv1alpha1.API) []corev1.ContainerPort {
+	return []corev1.ContainerPort{
+		{
+			Name:          "api",
+			ContainerPort: int32(api.Spec.Port),
+		},
+		{
+			Name:          "events",
+			ContainerPort: int32(api.Spec.EventPort),
+		},
+	}
+}
+
+// specService updates Stacks blockchain API service spec
+// REST and WebSocket servers share the api port
+func (r *APIReconciler) specService(api *stacksv1alpha1.API, svc *corev1.Service) {
+	labels := api.Labels
+
+	svc.ObjectMeta.Labels = labels
+
+	svc.Spec.Ports = nil
+	for _, port := range apiPorts(api) {
+		svc.Spec.Ports = append(svc.Spec.Ports, corev1.ServicePort{
+			Name:       port.Name,
+			Port:       port.ContainerPort,
+			TargetPort: intstr.FromString(port.Name),
+		})
+	}
+
+	svc.Spec.Selector = labels
+}
+
+// specStatefulSet updates Stacks blockchain API statefulset spec
+// API data is kept in PostgreSQL database, so no volumes are claimed
+func (r *APIReconciler) specStatefulSet(api *stacksv1alpha1.API, sts *appsv1.StatefulSet, env []corev1.EnvVar, cmd, args []string) error {
+
+	sts.ObjectMeta.Labels = api.Labels
+
+	replicas := shared.StatefulSetReplicas(*api.Spec.Replicas, api.Spec.Suspend)
+
+	sts.Spec = appsv1.StatefulSetSpec{
+		Selector: &metav1.LabelSelector{
+			MatchLabels: api.Labels,
+		},
+		Replicas:    &replicas,
+		ServiceName: api.Name,
+		Template: corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: api.Labels,
+			},
+			Spec: corev1.PodSpec{
+				SecurityContext: shared.SecurityContext(),
+				Containers: []corev1.Container{
+					{
+						Name:    "api",
+						Image:   api.Spec.Image,
+						Command: cmd,
+						Args:    args,
+						Env:     env,
+						Ports:   apiPorts(api),
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse(api.Spec.CPU),
+								corev1.ResourceMemory: resource.MustParse(api.Spec.Memory),
+							},
+							Limits: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse(api.Spec.CPULimit),
+								corev1.ResourceMemory: resource.MustParse(api.Spec.MemoryLimit),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if err := shared.SpecPodExtras(api.Spec.PodExtras, &sts.Spec.Template.Spec); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *APIReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := shared.IndexReferences(mgr, &stacksv1alpha1.API{}, func(obj client.Object) shared.References {
+		return apiReferences(obj.(*stacksv1alpha1.API))
+	}); err != nil {
+		return err
+	}
+
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &stacksv1alpha1.API{}, indexStacksNode, func(obj client.Object) []string {
+		return []string{obj.(*stacksv1alpha1.API).Spec.StacksNode}
+	}); err != nil {
+		return err
+	}
+
+	// owned objects updates are filtered, referenced objects are watched without predicate so their updates always pass
+	pred := builder.WithPredicates(shared.GenerationOrContentChangedPredicate())
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&stacksv1alpha1.API{}, pred).
+		Owns(&appsv1.StatefulSet{}, pred).
+		Owns(&policyv1.PodDisruptionBudget{}, pred).
+		Owns(&networkingv1.NetworkPolicy{}, pred).
+		Owns(&corev1.Service{}, pred).
+		Watches(&stacksv1alpha1.Node{}, r.EnqueueReferencing(&stacksv1alpha1.APIList{}, indexStacksNode)).
+		Watches(&corev1.Secret{}, r.EnqueueReferencing(&stacksv1alpha1.APIList{}, shared.IndexSecrets)).
+		Watches(&corev1.ConfigMap{}, r.EnqueueReferencing(&stacksv1alpha1.APIList{}, shared.IndexConfigMaps)).
+		Complete(r)
+}
@@ -0,0 +1,160 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+	stacksv1alpha1 "github.com/kotalco/kotal/apis/stacks/v1alpha1"
+	stacksClients "github.com/kotalco/kotal/clients/stacks"
+	"github.com/kotalco/kotal/controllers/shared"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+var _ = Describe("Stacks API controller", func() {
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "stacks-api",
+		},
+	}
+
+	key := types.NamespacedName{
+		Name:      "stacks-api",
+		Namespace: ns.Name,
+	}
+
+	rpcPassword := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "bitcoin-node-rpc-password",
+			Namespace: ns.Name,
+		},
+		StringData: map[string]string{
+			"password": "blockstacksystem",
+		},
+	}
+
+	node := &stacksv1alpha1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "stacks-node",
+			Namespace: ns.Name,
+		},
+		Spec: stacksv1alpha1.NodeSpec{
+			Network: stacksv1alpha1.Mainnet,
+			RPC:     true,
+			BitcoinNode: &stacksv1alpha1.BitcoinNode{
+				Endpoint:              "bitcoin.blockstack.com",
+				P2pPort:               8333,
+				RpcPort:               8332,
+				RpcUsername:           "blockstack",
+				RpcPasswordSecretName: sharedAPI.SecretKeySelector{Name: rpcPassword.Name},
+			},
+		},
+	}
+
+	testImage := "hirosystems/stacks-blockchain-api:controller-test"
+
+	toCreate := &stacksv1alpha1.API{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+		},
+		Spec: stacksv1alpha1.APISpec{
+			Image:      testImage,
+			StacksNode: node.Name,
+			Postgres: stacksv1alpha1.Postgres{
+				Host:               "postgres",
+				Username:           "stacks",
+				PasswordSecretName: sharedAPI.SecretKeySelector{Name: "postgres-password"},
+			},
+		},
+	}
+
+	t := true
+
+	apiOwnerReference := metav1.OwnerReference{
+		APIVersion:         "stacks.kotal.io/v1alpha1",
+		Kind:               "API",
+		Name:               toCreate.Name,
+		Controller:         &t,
+		BlockOwnerDeletion: &t,
+	}
+
+	It(fmt.Sprintf("Should create %s namespace", ns.Name), func() {
+		Expect(k8sClient.Create(context.TODO(), ns)).To(Succeed())
+	})
+
+	It("Should create referenced Stacks node", func() {
+		Expect(k8sClient.Create(context.Background(), rpcPassword)).Should(Succeed())
+		if os.Getenv(shared.EnvUseExistingCluster) != "true" {
+			node.Default()
+		}
+		Expect(k8sClient.Create(context.Background(), node)).Should(Succeed())
+	})
+
+	It("Should create Stacks API", func() {
+		if os.Getenv(shared.EnvUseExistingCluster) != "true" {
+			toCreate.Default()
+		}
+		Expect(k8sClient.Create(context.Background(), toCreate)).Should(Succeed())
+	})
+
+	It("Should get Stacks API", func() {
+		fetched := &stacksv1alpha1.API{}
+		Expect(k8sClient.Get(context.Background(), key, fetched)).To(Succeed())
+		Expect(fetched.Spec).To(Equal(toCreate.Spec))
+		apiOwnerReference.UID = fetched.UID
+		time.Sleep(5 * time.Second)
+	})
+
+	It("Should create Stacks API statefulset", func() {
+		client := stacksClients.NewAPIClient(toCreate, node)
+
+		fetched := &appsv1.StatefulSet{}
+		Expect(k8sClient.Get(context.Background(), key, fetched)).To(Succeed())
+		Expect(fetched.OwnerReferences).To(ContainElements(apiOwnerReference))
+		Expect(fetched.Spec.VolumeClaimTemplates).To(BeEmpty())
+		Expect(fetched.Spec.Template.Spec.Containers[0].Name).To(Equal("api"))
+		Expect(fetched.Spec.Template.Spec.Containers[0].Image).To(Equal(testImage))
+		Expect(fetched.Spec.Template.Spec.Containers[0].Env).To(Equal(client.Env()))
+	})
+
+	It("Should create Stacks API service", func() {
+		fetched := &corev1.Service{}
+		Expect(k8sClient.Get(context.Background(), key, fetched)).To(Succeed())
+		Expect(fetched.OwnerReferences).To(ContainElements(apiOwnerReference))
+		Expect(fetched.Spec.Ports).To(ContainElements(
+			corev1.ServicePort{
+				Name:       "api",
+				Port:       int32(stacksv1alpha1.DefaultAPIPort),
+				TargetPort: intstr.FromString("api"),
+				Protocol:   corev1.ProtocolTCP,
+			},
+			corev1.ServicePort{
+				Name:       "events",
+				Port:       int32(stacksv1alpha1.DefaultAPIEventPort),
+				TargetPort: intstr.FromString("events"),
+				Protocol:   corev1.ProtocolTCP,
+			},
+		))
+	})
+
+	It("Should register Stacks API as events observer of Stacks node", func() {
+		config := &corev1.ConfigMap{}
+		nodeKey := types.NamespacedName{Name: node.Name, Namespace: ns.Name}
+		Expect(k8sClient.Get(context.Background(), nodeKey, config)).To(Succeed())
+		Expect(config.Data["config.toml"]).To(ContainSubstring("[[events_observer]]"))
+		Expect(config.Data["config.toml"]).To(ContainSubstring(fmt.Sprintf("endpoint = \"stacks-api.%s.svc:%d\"", ns.Name, stacksv1alpha1.DefaultAPIEventPort)))
+	})
+
+	It(fmt.Sprintf("Should delete %s namespace", ns.Name), func() {
+		Expect(k8sClient.Delete(context.Background(), ns)).To(Succeed())
+	})
+})
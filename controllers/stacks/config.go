@@ -4,8 +4,11 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"sort"
 
 	"github.com/BurntSushi/toml"
+	bitcoinv1alpha1 "github.com/kotalco/kotal/apis/bitcoin/v1alpha1"
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
 	stacksv1alpha1 "github.com/kotalco/kotal/apis/stacks/v1alpha1"
 	stacksClients "github.com/kotalco/kotal/clients/stacks"
 	"github.com/kotalco/kotal/controllers/shared"
@@ -31,11 +34,189 @@ type Node struct {
 	LocalPeerSeed   string `toml:"local_peer_seed"`
 	Miner           bool   `toml:"miner"`
 	MineMicroblocks bool   `toml:"mine_microblocks,omitempty"`
+	PrometheusBind  string `toml:"prometheus_bind,omitempty"`
+}
+
+type EventsObserver struct {
+	Endpoint   string   `toml:"endpoint"`
+	EventsKeys []string `toml:"events_keys"`
+}
+
+type ConnectionOptions struct {
+	AuthToken string `toml:"auth_token"`
 }
 
 type Config struct {
-	Node      Node      `toml:"node"`
-	BurnChain BurnChain `toml:"burnchain"`
+	Node              Node               `toml:"node"`
+	BurnChain         BurnChain          `toml:"burnchain"`
+	ConnectionOptions *ConnectionOptions `toml:"connection_options,omitempty"`
+	EventsObservers   []EventsObserver   `toml:"events_observer,omitempty"`
+}
+
+// signerEventsKeys is keys of events sent to Stacks signers
+var signerEventsKeys = []string{"stackerdb", "block_proposal", "burn_blocks"}
+
+// privateKey returns private key stored in the secret
+// private key loaded from secret store is replaced by a placeholder, rendered by init container from file
+func privateKey(node *stacksv1alpha1.Node, client client.Client, secret sharedAPI.SecretKeySelector, file string) (string, error) {
+	if node.Spec.SecretStore != nil {
+		return fmt.Sprintf("@%s@", file), nil
+	}
+
+	name := types.NamespacedName{
+		Name:      secret.Name,
+		Namespace: node.Namespace,
+	}
+
+	return shared.GetSecret(context.Background(), client, name, secret.KeyOr("key"))
+}
+
+// bitcoinNetworks is Bitcoin networks Stacks networks are anchored to
+var bitcoinNetworks = map[stacksv1alpha1.StacksNetwork]bitcoinv1alpha1.BitcoinNetwork{
+	stacksv1alpha1.Mainnet: bitcoinv1alpha1.Mainnet,
+	stacksv1alpha1.Testnet: bitcoinv1alpha1.Testnet,
+	stacksv1alpha1.Xenon:   bitcoinv1alpha1.Testnet,
+}
+
+// resolveBitcoinNode returns Bitcoin node the Stacks node is anchored to
+// referenced Kotal Bitcoin node is resolved into its service endpoint, ports and first rpc user credentials
+// referenced Kotal Bitcoin node must be on the network Stacks network is anchored to, and enable rpc
+func resolveBitcoinNode(node *stacksv1alpha1.Node, client client.Client) (*stacksv1alpha1.BitcoinNode, error) {
+	if node.Spec.BitcoinNodeRef == "" {
+		return node.Spec.BitcoinNode, nil
+	}
+
+	bitcoinNode := &bitcoinv1alpha1.Node{}
+	name := types.NamespacedName{
+		Name:      node.Spec.BitcoinNodeRef,
+		Namespace: node.Namespace,
+	}
+	if err := client.Get(context.Background(), name, bitcoinNode); err != nil {
+		return nil, err
+	}
+
+	if expected := bitcoinNetworks[node.Spec.Network]; bitcoinNode.Spec.Network != expected {
+		return nil, fmt.Errorf("bitcoin node %s network %s doesn't match %s network %s", bitcoinNode.Name, bitcoinNode.Spec.Network, node.Spec.Network, expected)
+	}
+
+	if !bitcoinNode.Spec.RPC || len(bitcoinNode.Spec.RPCUsers) == 0 {
+		return nil, fmt.Errorf("bitcoin node %s must enable rpc and have at least one rpc user", bitcoinNode.Name)
+	}
+
+	user := bitcoinNode.Spec.RPCUsers[0]
+
+	return &stacksv1alpha1.BitcoinNode{
+		Endpoint:              fmt.Sprintf("%s.%s.svc", bitcoinNode.Name, bitcoinNode.Namespace),
+		P2pPort:               bitcoinNode.Spec.P2PPort,
+		RpcPort:               bitcoinNode.Spec.RPCPort,
+		RpcUsername:           user.Username,
+		RpcPasswordSecretName: user.PasswordSecretName,
+	}, nil
+}
+
+// nodeSigners returns Stacks signers referencing the node, sorted by name
+func nodeSigners(node *stacksv1alpha1.Node, c client.Client) (signers []stacksv1alpha1.Signer, err error) {
+	list := &stacksv1alpha1.SignerList{}
+	if err = c.List(context.Background(), list, client.InNamespace(node.Namespace)); err != nil {
+		return
+	}
+
+	for _, signer := range list.Items {
+		if signer.Spec.StacksNode != node.Name || !signer.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		// default the signer if webhooks are disabled
+		if !shared.IsWebhookEnabled() {
+			signer.Default()
+		}
+
+		signers = append(signers, signer)
+	}
+
+	sort.Slice(signers, func(i, j int) bool {
+		return signers[i].Name < signers[j].Name
+	})
+
+	return
+}
+
+// authTokenPlaceholder is auth token placeholder in config file, rendered by init container from signers auth token secret
+const authTokenPlaceholder = "@auth_token@"
+
+// authTokenSecret returns auth token secret of node signers, or nil if node has no signers
+// all signers of the node must share the same auth token
+func authTokenSecret(node *stacksv1alpha1.Node, signers []stacksv1alpha1.Signer) (*sharedAPI.SecretKeySelector, error) {
+	if len(signers) == 0 {
+		return nil, nil
+	}
+
+	secret := signers[0].Spec.AuthTokenSecretName
+	for _, signer := range signers[1:] {
+		if signer.Spec.AuthTokenSecretName != secret {
+			return nil, fmt.Errorf("signers %s and %s of stacks node %s must share auth token", signers[0].Name, signer.Name, node.Name)
+		}
+	}
+
+	return &secret, nil
+}
+
+// connectionOptions returns connection options holding auth token placeholder of node signers
+// auth token isn't written into config map, it's rendered into config file by init container
+func connectionOptions(node *stacksv1alpha1.Node, signers []stacksv1alpha1.Signer) (*ConnectionOptions, error) {
+	secret, err := authTokenSecret(node, signers)
+	if err != nil || secret == nil {
+		return nil, err
+	}
+
+	return &ConnectionOptions{AuthToken: authTokenPlaceholder}, nil
+}
+
+// eventsObservers returns events observers of the node
+// Stacks APIs and signers referencing the node are appended to observers given in node spec
+func eventsObservers(node *stacksv1alpha1.Node, c client.Client, signers []stacksv1alpha1.Signer) (observers []EventsObserver, err error) {
+	for _, observer := range node.Spec.EventObservers {
+		observers = append(observers, EventsObserver{
+			Endpoint:   observer.Endpoint,
+			EventsKeys: observer.EventsKeys,
+		})
+	}
+
+	apis := &stacksv1alpha1.APIList{}
+	if err = c.List(context.Background(), apis, client.InNamespace(node.Namespace)); err != nil {
+		return
+	}
+
+	// keep config stable regardless of listing order
+	sort.Slice(apis.Items, func(i, j int) bool {
+		return apis.Items[i].Name < apis.Items[j].Name
+	})
+
+	for i := range apis.Items {
+		api := &apis.Items[i]
+		if api.Spec.StacksNode != node.Name || !api.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		// default the api if webhooks are disabled
+		if !shared.IsWebhookEnabled() {
+			api.Default()
+		}
+
+		observers = append(observers, EventsObserver{
+			Endpoint:   fmt.Sprintf("%s.%s.svc:%d", api.Name, api.Namespace, api.Spec.EventPort),
+			EventsKeys: []string{stacksv1alpha1.DefaultEventsKey},
+		})
+	}
+
+	for _, signer := range signers {
+		observers = append(observers, EventsObserver{
+			Endpoint:   fmt.Sprintf("%s.%s.svc:%d", signer.Name, signer.Namespace, signer.Spec.Port),
+			EventsKeys: signerEventsKeys,
+		})
+	}
+
+	return
 }
 
 // ConfigFromSpec generates config.toml file from node spec
@@ -49,13 +230,13 @@ func ConfigFromSpec(node *stacksv1alpha1.Node, client client.Client) (config str
 		Miner:      node.Spec.Miner,
 	}
 
+	if node.Spec.Metrics.Enabled {
+		c.Node.PrometheusBind = fmt.Sprintf("%s:%d", shared.Host(true), node.Spec.Metrics.Port)
+	}
+
 	if node.Spec.Miner {
 		var seedPrivateKey string
-		name := types.NamespacedName{
-			Name:      node.Spec.SeedPrivateKeySecretName,
-			Namespace: node.Namespace,
-		}
-		seedPrivateKey, err = shared.GetSecret(context.Background(), client, name, "key")
+		seedPrivateKey, err = privateKey(node, client, node.Spec.SeedPrivateKeySecretName, "seed_private_key")
 		if err != nil {
 			return
 		}
@@ -64,13 +245,9 @@ func ConfigFromSpec(node *stacksv1alpha1.Node, client client.Client) (config str
 		c.Node.MineMicroblocks = node.Spec.MineMicroblocks
 	}
 
-	if node.Spec.NodePrivateKeySecretName != "" {
+	if node.Spec.NodePrivateKeySecretName.Name != "" {
 		var nodePrivateKey string
-		name := types.NamespacedName{
-			Name:      node.Spec.NodePrivateKeySecretName,
-			Namespace: node.Namespace,
-		}
-		nodePrivateKey, err = shared.GetSecret(context.Background(), client, name, "key")
+		nodePrivateKey, err = privateKey(node, client, node.Spec.NodePrivateKeySecretName, "node_private_key")
 		if err != nil {
 			return
 		}
@@ -78,11 +255,16 @@ func ConfigFromSpec(node *stacksv1alpha1.Node, client client.Client) (config str
 		c.Node.LocalPeerSeed = nodePrivateKey
 	}
 
+	bitcoinNode, err := resolveBitcoinNode(node, client)
+	if err != nil {
+		return
+	}
+
 	name := types.NamespacedName{
-		Name:      node.Spec.BitcoinNode.RpcPasswordSecretName,
+		Name:      bitcoinNode.RpcPasswordSecretName.Name,
 		Namespace: node.Namespace,
 	}
-	password, err := shared.GetSecret(context.Background(), client, name, "password")
+	password, err := shared.GetSecret(context.Background(), client, name, bitcoinNode.RpcPasswordSecretName.KeyOr("password"))
 	if err != nil {
 		return
 	}
@@ -90,11 +272,26 @@ func ConfigFromSpec(node *stacksv1alpha1.Node, client client.Client) (config str
 	c.BurnChain = BurnChain{
 		Chain:    "bitcoin",
 		Mode:     string(node.Spec.Network),
-		PeerHost: node.Spec.BitcoinNode.Endpoint,
-		Username: node.Spec.BitcoinNode.RpcUsername,
+		PeerHost: bitcoinNode.Endpoint,
+		Username: bitcoinNode.RpcUsername,
 		Password: password,
-		RPCPort:  node.Spec.BitcoinNode.RpcPort,
-		PeerPort: node.Spec.BitcoinNode.P2pPort,
+		RPCPort:  bitcoinNode.RpcPort,
+		PeerPort: bitcoinNode.P2pPort,
+	}
+
+	signers, err := nodeSigners(node, client)
+	if err != nil {
+		return
+	}
+
+	c.ConnectionOptions, err = connectionOptions(node, signers)
+	if err != nil {
+		return
+	}
+
+	c.EventsObservers, err = eventsObservers(node, client, signers)
+	if err != nil {
+		return
 	}
 
 	var buff bytes.Buffer
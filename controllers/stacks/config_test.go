@@ -0,0 +1,187 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	bitcoinv1alpha1 "github.com/kotalco/kotal/apis/bitcoin/v1alpha1"
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+	stacksv1alpha1 "github.com/kotalco/kotal/apis/stacks/v1alpha1"
+	"github.com/kotalco/kotal/controllers/shared"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// bitcoinNodesClient returns fake client holding testnet Bitcoin node with rpc and mainnet Bitcoin node without rpc
+func bitcoinNodesClient(objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = bitcoinv1alpha1.AddToScheme(scheme)
+	_ = stacksv1alpha1.AddToScheme(scheme)
+
+	objs = append(objs,
+		&bitcoinv1alpha1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "bitcoin-testnet", Namespace: "default"},
+			Spec: bitcoinv1alpha1.NodeSpec{
+				Network: bitcoinv1alpha1.Testnet,
+				RPC:     true,
+				RPCUsers: []bitcoinv1alpha1.RPCUser{
+					{Username: "stacks", PasswordSecretName: sharedAPI.SecretKeySelector{Name: "bitcoin-rpc-password"}},
+				},
+			},
+		},
+		&bitcoinv1alpha1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "bitcoin-no-rpc", Namespace: "default"},
+			Spec: bitcoinv1alpha1.NodeSpec{
+				Network: bitcoinv1alpha1.Mainnet,
+			},
+		},
+	)
+
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithIndex(&stacksv1alpha1.Node{}, indexBitcoinNodeRef, func(obj client.Object) []string {
+			return []string{obj.(*stacksv1alpha1.Node).Spec.BitcoinNodeRef}
+		}).
+		WithIndex(&stacksv1alpha1.Signer{}, indexSignerAuthToken, func(obj client.Object) []string {
+			return []string{obj.(*stacksv1alpha1.Signer).Spec.AuthTokenSecretName.Name}
+		}).
+		Build()
+}
+
+func TestResolveBitcoinNode(t *testing.T) {
+	c := bitcoinNodesClient()
+
+	cases := []struct {
+		title   string
+		network stacksv1alpha1.StacksNetwork
+		ref     string
+		err     string
+	}{
+		{
+			title:   "bitcoin node on anchored network",
+			network: stacksv1alpha1.Testnet,
+			ref:     "bitcoin-testnet",
+		},
+		{
+			title:   "bitcoin node on different network",
+			network: stacksv1alpha1.Mainnet,
+			ref:     "bitcoin-testnet",
+			err:     "bitcoin node bitcoin-testnet network testnet doesn't match mainnet network mainnet",
+		},
+		{
+			title:   "bitcoin node without rpc",
+			network: stacksv1alpha1.Mainnet,
+			ref:     "bitcoin-no-rpc",
+			err:     "bitcoin node bitcoin-no-rpc must enable rpc and have at least one rpc user",
+		},
+	}
+
+	for _, tt := range cases {
+		node := &stacksv1alpha1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-node", Namespace: "default"},
+			Spec:       stacksv1alpha1.NodeSpec{Network: tt.network, BitcoinNodeRef: tt.ref},
+		}
+
+		bitcoinNode, err := resolveBitcoinNode(node, c)
+		if tt.err != "" {
+			if err == nil || err.Error() != tt.err {
+				t.Errorf("%s: expected error %q, got %v", tt.title, tt.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: %s", tt.title, err)
+		}
+		if bitcoinNode.Endpoint != "bitcoin-testnet.default.svc" || bitcoinNode.RpcUsername != "stacks" {
+			t.Errorf("%s: unexpected resolved bitcoin node %+v", tt.title, bitcoinNode)
+		}
+	}
+}
+
+func TestEnqueueBitcoinRPCSecretReferencing(t *testing.T) {
+	c := bitcoinNodesClient(
+		&stacksv1alpha1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "stacks-testnet", Namespace: "default"},
+			Spec:       stacksv1alpha1.NodeSpec{Network: stacksv1alpha1.Testnet, BitcoinNodeRef: "bitcoin-testnet"},
+		},
+		&stacksv1alpha1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "stacks-mainnet", Namespace: "default"},
+			Spec:       stacksv1alpha1.NodeSpec{Network: stacksv1alpha1.Mainnet, BitcoinNodeRef: "bitcoin-no-rpc"},
+		},
+	)
+	r := &NodeReconciler{Reconciler: shared.Reconciler{Client: c}}
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "bitcoin-rpc-password", Namespace: "default"}}
+	requests := r.enqueueBitcoinRPCSecretReferencing(context.Background(), secret)
+	if len(requests) != 1 || requests[0].Name != "stacks-testnet" {
+		t.Errorf("expected stacks-testnet to be enqueued, got %v", requests)
+	}
+
+	other := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "other-secret", Namespace: "default"}}
+	if requests = r.enqueueBitcoinRPCSecretReferencing(context.Background(), other); len(requests) != 0 {
+		t.Errorf("expected no stacks nodes to be enqueued, got %v", requests)
+	}
+}
+
+func TestAuthTokenSecret(t *testing.T) {
+	node := &stacksv1alpha1.Node{ObjectMeta: metav1.ObjectMeta{Name: "my-node", Namespace: "default"}}
+
+	signer := func(name, token string) stacksv1alpha1.Signer {
+		return stacksv1alpha1.Signer{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: stacksv1alpha1.SignerSpec{
+				StacksNode:          node.Name,
+				AuthTokenSecretName: sharedAPI.SecretKeySelector{Name: token},
+			},
+		}
+	}
+
+	if secret, err := authTokenSecret(node, nil); err != nil || secret != nil {
+		t.Errorf("expected no auth token secret without signers, got %v, %v", secret, err)
+	}
+
+	secret, err := authTokenSecret(node, []stacksv1alpha1.Signer{signer("signer-1", "token"), signer("signer-2", "token")})
+	if err != nil || secret == nil || secret.Name != "token" {
+		t.Errorf("expected token auth token secret, got %v, %v", secret, err)
+	}
+
+	options, err := connectionOptions(node, []stacksv1alpha1.Signer{signer("signer-1", "token")})
+	if err != nil || options == nil || options.AuthToken != authTokenPlaceholder {
+		t.Errorf("expected auth token placeholder in connection options, got %v, %v", options, err)
+	}
+
+	_, err = authTokenSecret(node, []stacksv1alpha1.Signer{signer("signer-1", "token"), signer("signer-2", "other-token")})
+	if expected := "signers signer-1 and signer-2 of stacks node my-node must share auth token"; err == nil || err.Error() != expected {
+		t.Errorf("expected error %q, got %v", expected, err)
+	}
+}
+
+func TestEnqueueSignerAuthTokenReferencing(t *testing.T) {
+	c := bitcoinNodesClient(
+		&stacksv1alpha1.Signer{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-signer", Namespace: "default"},
+			Spec: stacksv1alpha1.SignerSpec{
+				StacksNode:          "stacks-testnet",
+				AuthTokenSecretName: sharedAPI.SecretKeySelector{Name: "signer-auth-token"},
+			},
+		},
+	)
+	r := &NodeReconciler{Reconciler: shared.Reconciler{Client: c}}
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "signer-auth-token", Namespace: "default"}}
+	requests := r.enqueueSignerAuthTokenReferencing(context.Background(), secret)
+	if len(requests) != 1 || requests[0].Name != "stacks-testnet" {
+		t.Errorf("expected stacks-testnet to be enqueued, got %v", requests)
+	}
+
+	other := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "other-secret", Namespace: "default"}}
+	if requests = r.enqueueSignerAuthTokenReferencing(context.Background(), other); len(requests) != 0 {
+		t.Errorf("expected no stacks nodes to be enqueued, got %v", requests)
+	}
+}
@@ -2,29 +2,52 @@ package controllers
 
 import (
 	"context"
+	_ "embed"
+	"fmt"
 
+	bitcoinv1alpha1 "github.com/kotalco/kotal/apis/bitcoin/v1alpha1"
 	stacksClients "github.com/kotalco/kotal/clients/stacks"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
-	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
 	stacksv1alpha1 "github.com/kotalco/kotal/apis/stacks/v1alpha1"
 	"github.com/kotalco/kotal/controllers/shared"
 )
 
+const (
+	// indexBitcoinNodeRef is field index of referenced Bitcoin node name
+	indexBitcoinNodeRef = ".spec.bitcoinNodeRef"
+	// indexSignerAuthToken is field index of signer auth token secret name
+	indexSignerAuthToken = ".spec.authTokenSecretName"
+)
+
 // NodeReconciler reconciles a Node object
 type NodeReconciler struct {
 	shared.Reconciler
 }
 
+var (
+	//go:embed render_config.sh
+	renderConfig string
+)
+
 // +kubebuilder:rbac:groups=stacks.kotal.io,resources=nodes,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=stacks.kotal.io,resources=nodes/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=stacks.kotal.io,resources=apis;signers,verbs=get;list;watch
+// +kubebuilder:rbac:groups=bitcoin.kotal.io,resources=nodes,verbs=get;list;watch
 // +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=watch;get;list;create;update;delete
 // +kubebuilder:rbac:groups=core,resources=services;configmaps,verbs=watch;get;create;update;list;delete
 
@@ -32,12 +55,24 @@ func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (resul
 	defer shared.IgnoreConflicts(&err)
 
 	var node stacksv1alpha1.Node
+	defer r.ObserveReconcile(&node, req.NamespacedName, &err)
 
 	if err = r.Client.Get(ctx, req.NamespacedName, &node); err != nil {
 		err = client.IgnoreNotFound(err)
 		return
 	}
 
+	var deleting bool
+	if deleting, result, err = r.ReconcileDeletion(ctx, &node, node.Spec.Deletion); deleting || err != nil {
+		return
+	}
+
+	// owned objects are left as is while node is paused
+	if shared.IsPaused(&node) {
+		err = r.ReconcilePaused(ctx, &node, &node.Status.Mode)
+		return
+	}
+
 	// default the node if webhooks are disabled
 	if !shared.IsWebhookEnabled() {
 		node.Default()
@@ -57,10 +92,9 @@ func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (resul
 		return
 	}
 
-	// reconcile persistent volume claim
-	if err = r.ReconcileOwned(ctx, &node, &corev1.PersistentVolumeClaim{}, func(obj client.Object) error {
-		r.specPVC(&node, obj.(*corev1.PersistentVolumeClaim))
-		return nil
+	// reconcile persistent volume claims
+	if err = r.ReconcilePVCs(ctx, &node, *node.Spec.Replicas, func(pvc *corev1.PersistentVolumeClaim) {
+		r.specPVC(&node, pvc)
 	}); err != nil {
 		return
 	}
@@ -74,20 +108,60 @@ func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (resul
 	}
 
 	// reconcile stateful set
-	if err = r.ReconcileOwned(ctx, &node, &appsv1.StatefulSet{}, func(obj client.Object) error {
+	sts := &appsv1.StatefulSet{}
+	if err = r.ReconcileOwned(ctx, &node, sts, func(obj client.Object) error {
 		client := stacksClients.NewClient(&node)
 
 		homeDir := client.HomeDir()
 		cmd := client.Command()
 		args := client.Args()
-		args = append(args, node.Spec.ExtraArgs.Encode(false)...)
+		args = node.Spec.ExtraArgs.Merge(args, node.Spec.RemoveArgs, false)
 		env := client.Env()
 
-		return r.specStatefulSet(&node, obj.(*appsv1.StatefulSet), homeDir, env, cmd, args)
+		signers, err := nodeSigners(&node, r.Client)
+		if err != nil {
+			return err
+		}
+		authToken, err := authTokenSecret(&node, signers)
+		if err != nil {
+			return err
+		}
+
+		sts := obj.(*appsv1.StatefulSet)
+		if err := r.specStatefulSet(&node, sts, homeDir, env, cmd, args, authToken); err != nil {
+			return err
+		}
+		shared.SpecShutdown(node.Spec.Shutdown, client, &sts.Spec.Template.Spec)
+		refs := references(&node)
+		// referenced Bitcoin node rpc user password is rendered into config file
+		if bitcoinNode, err := resolveBitcoinNode(&node, r.Client); err == nil {
+			refs.Add(bitcoinNode.RpcPasswordSecretName.Name)
+		}
+		// signers auth token is rendered into config file on pod start
+		if authToken != nil {
+			refs.Add(authToken.Name)
+		}
+		return r.SpecContentHash(ctx, &node, sts, refs)
 	}); err != nil {
 		return
 	}
 
+	// reconcile pod disruption budget
+	if err = r.ReconcilePodDisruptionBudget(ctx, &node, node.Spec.Disruption, *node.Spec.Replicas); err != nil {
+		return
+	}
+
+	// reconcile network policy
+	if err = r.ReconcileNetworkPolicy(ctx, &node, node.Spec.Access, &sts.Spec.Template.Spec, nil); err != nil {
+		return
+	}
+
+	// reconcile prometheus service monitor
+	endpoint := shared.MetricsEndpoint{Port: "metrics", Path: "/metrics"}
+	if err = r.ReconcileServiceMonitor(ctx, &node, node.Spec.Metrics, endpoint); err != nil {
+		return
+	}
+
 	if err = r.updateStatus(ctx, &node); err != nil {
 		return
 	}
@@ -95,15 +169,37 @@ func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (resul
 	return
 }
 
+// references returns secrets and config maps referenced by Stacks node
+func references(node *stacksv1alpha1.Node) shared.References {
+	refs := shared.PodExtrasReferences(node.Spec.PodExtras)
+	if node.Spec.BitcoinNode != nil {
+		refs.Add(node.Spec.BitcoinNode.RpcPasswordSecretName.Name)
+	}
+	// private keys loaded from secret store aren't kubernetes secrets
+	if node.Spec.SecretStore == nil {
+		refs.Add(node.Spec.SeedPrivateKeySecretName.Name, node.Spec.NodePrivateKeySecretName.Name)
+	}
+	return refs
+}
+
 // updateStatus updates Stacks node status
 func (r *NodeReconciler) updateStatus(ctx context.Context, node *stacksv1alpha1.Node) error {
+	previous := node.Status
+
 	node.Status.Client = "stacks"
 
+	node.Status.Mode = shared.Mode(node, node.Spec.Suspend)
+	if err := r.UpdateResyncStatus(ctx, node, &node.Status.ResyncStatus, node.Spec.DataResync); err != nil {
+		return err
+	}
+
 	if err := r.Status().Update(ctx, node); err != nil {
 		log.FromContext(ctx).Error(err, "unable to update node status")
 		return err
 	}
 
+	r.RecordStatusTransition(node, previous, node.Status)
+
 	return nil
 }
 
@@ -116,6 +212,7 @@ func (r *NodeReconciler) specConfigmap(node *stacksv1alpha1.Node, configmap *cor
 	}
 
 	configmap.Data["config.toml"] = configToml
+	configmap.Data["render_config.sh"] = renderConfig
 
 }
 
@@ -161,11 +258,19 @@ func (r *NodeReconciler) specService(node *stacksv1alpha1.Node, svc *corev1.Serv
 		},
 	}
 
+	if node.Spec.Metrics.Enabled {
+		svc.Spec.Ports = append(svc.Spec.Ports, corev1.ServicePort{
+			Name:       "metrics",
+			Port:       int32(node.Spec.Metrics.Port),
+			TargetPort: intstr.FromString("metrics"),
+		})
+	}
+
 	svc.Spec.Selector = labels
 }
 
 // specStatefulSet updates node statefulset spec
-func (r *NodeReconciler) specStatefulSet(node *stacksv1alpha1.Node, sts *appsv1.StatefulSet, homeDir string, env []corev1.EnvVar, cmd, args []string) error {
+func (r *NodeReconciler) specStatefulSet(node *stacksv1alpha1.Node, sts *appsv1.StatefulSet, homeDir string, env []corev1.EnvVar, cmd, args []string, authToken *sharedAPI.SecretKeySelector) error {
 
 	sts.ObjectMeta.Labels = node.Labels
 
@@ -180,7 +285,163 @@ func (r *NodeReconciler) specStatefulSet(node *stacksv1alpha1.Node, sts *appsv1.
 		},
 	}
 
-	replicas := int32(*node.Spec.Replicas)
+	if node.Spec.Metrics.Enabled {
+		ports = append(ports, corev1.ContainerPort{
+			Name:          "metrics",
+			ContainerPort: int32(node.Spec.Metrics.Port),
+		})
+	}
+
+	mounts := []corev1.VolumeMount{
+		{
+			Name:      "data",
+			MountPath: shared.PathData(homeDir),
+		},
+		{
+			Name:      "config",
+			ReadOnly:  true,
+			MountPath: shared.PathConfig(homeDir),
+		},
+	}
+
+	configVolume := corev1.VolumeSource{
+		ConfigMap: &corev1.ConfigMapVolumeSource{
+			LocalObjectReference: corev1.LocalObjectReference{
+				Name: node.Name,
+			},
+		},
+	}
+
+	volumes := []corev1.Volume{
+		{
+			Name:         "config",
+			VolumeSource: configVolume,
+		},
+	}
+
+	var initContainers []corev1.Container
+
+	// private keys loaded from secret store and signers auth token are rendered into config file
+	if node.Spec.SecretStore != nil || authToken != nil {
+		templatePath := fmt.Sprintf("%s/kotal-config-template", homeDir)
+
+		renderEnv := []corev1.EnvVar{
+			{
+				Name:  "KOTAL_CONFIG_TEMPLATE_PATH",
+				Value: templatePath,
+			},
+			{
+				Name:  shared.EnvConfigPath,
+				Value: shared.PathConfig(homeDir),
+			},
+			{
+				Name:  shared.EnvSecretsPath,
+				Value: shared.PathSecrets(homeDir),
+			},
+		}
+
+		if authToken != nil {
+			renderEnv = append(renderEnv, corev1.EnvVar{
+				Name: "KOTAL_AUTH_TOKEN",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: authToken.Name,
+						},
+						Key: authToken.KeyOr("token"),
+					},
+				},
+			})
+		}
+
+		renderMounts := []corev1.VolumeMount{
+			{
+				Name:      "config-template",
+				ReadOnly:  true,
+				MountPath: templatePath,
+			},
+			{
+				Name:      "config",
+				MountPath: shared.PathConfig(homeDir),
+			},
+		}
+
+		// rendered config file is kept in memory
+		volumes = []corev1.Volume{
+			{
+				Name: "config",
+				VolumeSource: corev1.VolumeSource{
+					EmptyDir: &corev1.EmptyDirVolumeSource{
+						Medium: corev1.StorageMediumMemory,
+					},
+				},
+			},
+			{
+				Name:         "config-template",
+				VolumeSource: configVolume,
+			},
+		}
+
+		if node.Spec.SecretStore != nil {
+			keys := []struct {
+				file   string
+				secret sharedAPI.SecretKeySelector
+			}{
+				{"seed_private_key", node.Spec.SeedPrivateKeySecretName},
+				{"node_private_key", node.Spec.NodePrivateKeySecretName},
+			}
+
+			var sources []corev1.VolumeProjection
+			for _, key := range keys {
+				if key.secret.Name == "" {
+					continue
+				}
+				sources = append(sources, corev1.VolumeProjection{
+					Secret: &corev1.SecretProjection{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: key.secret.Name,
+						},
+						Items: []corev1.KeyToPath{
+							{
+								Key:  key.secret.KeyOr("key"),
+								Path: key.file,
+							},
+						},
+					},
+				})
+			}
+
+			renderMounts = append(renderMounts, corev1.VolumeMount{
+				Name:      "secrets",
+				ReadOnly:  true,
+				MountPath: shared.PathSecrets(homeDir),
+			})
+
+			volumes = append(volumes, corev1.Volume{
+				Name: "secrets",
+				VolumeSource: corev1.VolumeSource{
+					Projected: &corev1.ProjectedVolumeSource{
+						Sources: sources,
+					},
+				},
+			})
+		}
+
+		initContainers = append(initContainers, corev1.Container{
+			Name:         "render-config",
+			Image:        shared.BusyboxImage,
+			Env:          renderEnv,
+			Command:      []string{"/bin/sh"},
+			Args:         []string{fmt.Sprintf("%s/render_config.sh", templatePath)},
+			VolumeMounts: renderMounts,
+		})
+	}
+
+	replicas := shared.StatefulSetReplicas(*node.Spec.Replicas, node.Spec.Suspend)
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	r.specPVC(node, pvc)
+	dataVolumes, volumeClaimTemplates := shared.SpecDataStorage(node, sts, pvc)
 
 	sts.Spec = appsv1.StatefulSetSpec{
 		Selector: &metav1.LabelSelector{
@@ -194,6 +455,7 @@ func (r *NodeReconciler) specStatefulSet(node *stacksv1alpha1.Node, sts *appsv1.
 			},
 			Spec: corev1.PodSpec{
 				SecurityContext: shared.SecurityContext(),
+				InitContainers:  initContainers,
 				Containers: []corev1.Container{
 					{
 						Name:    "node",
@@ -212,53 +474,126 @@ func (r *NodeReconciler) specStatefulSet(node *stacksv1alpha1.Node, sts *appsv1.
 								corev1.ResourceMemory: resource.MustParse(node.Spec.MemoryLimit),
 							},
 						},
-						VolumeMounts: []corev1.VolumeMount{
-							{
-								Name:      "data",
-								MountPath: shared.PathData(homeDir),
-							},
-							{
-								Name:      "config",
-								ReadOnly:  true,
-								MountPath: shared.PathConfig(homeDir),
-							},
-						},
-					},
-				},
-				Volumes: []corev1.Volume{
-					{
-						Name: "config",
-						VolumeSource: corev1.VolumeSource{
-							ConfigMap: &corev1.ConfigMapVolumeSource{
-								LocalObjectReference: corev1.LocalObjectReference{
-									Name: node.Name,
-								},
-							},
-						},
-					},
-					{
-						Name: "data",
-						VolumeSource: corev1.VolumeSource{
-							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-								ClaimName: node.Name,
-							},
-						},
+						VolumeMounts: mounts,
 					},
 				},
+				Volumes: append(dataVolumes, volumes...),
 			},
 		},
+		VolumeClaimTemplates: volumeClaimTemplates,
 	}
 
+	shared.SpecResync(node.Spec.DataResync, &sts.Spec.Template.Spec, homeDir)
+	if err := shared.SpecPodExtras(node.Spec.PodExtras, &sts.Spec.Template.Spec); err != nil {
+		return err
+	}
+	shared.SpecSecretStore(node.Spec.SecretStore, &sts.Spec.Template, "secrets")
+
 	return nil
 }
 
+// enqueueReferencedStacksNode enqueues Stacks node referenced by API or signer
+// APIs and signers are registered as events observers of the node
+func enqueueReferencedStacksNode(ctx context.Context, obj client.Object) []reconcile.Request {
+	var name string
+	switch cr := obj.(type) {
+	case *stacksv1alpha1.API:
+		name = cr.Spec.StacksNode
+	case *stacksv1alpha1.Signer:
+		name = cr.Spec.StacksNode
+	}
+
+	return []reconcile.Request{
+		{
+			NamespacedName: types.NamespacedName{
+				Name:      name,
+				Namespace: obj.GetNamespace(),
+			},
+		},
+	}
+}
+
+// enqueueBitcoinRPCSecretReferencing enqueues Stacks nodes referencing Bitcoin nodes whose first rpc user password is in the secret
+func (r *NodeReconciler) enqueueBitcoinRPCSecretReferencing(ctx context.Context, obj client.Object) (requests []reconcile.Request) {
+	bitcoinNodes := &bitcoinv1alpha1.NodeList{}
+	if err := r.Client.List(ctx, bitcoinNodes, client.InNamespace(obj.GetNamespace())); err != nil {
+		return
+	}
+
+	for _, bitcoinNode := range bitcoinNodes.Items {
+		if len(bitcoinNode.Spec.RPCUsers) == 0 || bitcoinNode.Spec.RPCUsers[0].PasswordSecretName.Name != obj.GetName() {
+			continue
+		}
+
+		nodes := &stacksv1alpha1.NodeList{}
+		if err := r.Client.List(ctx, nodes, client.InNamespace(obj.GetNamespace()), client.MatchingFields{indexBitcoinNodeRef: bitcoinNode.Name}); err != nil {
+			return
+		}
+
+		for _, node := range nodes.Items {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&node)})
+		}
+	}
+
+	return
+}
+
+// enqueueSignerAuthTokenReferencing enqueues Stacks nodes of signers whose auth token is in the secret
+func (r *NodeReconciler) enqueueSignerAuthTokenReferencing(ctx context.Context, obj client.Object) (requests []reconcile.Request) {
+	signers := &stacksv1alpha1.SignerList{}
+	if err := r.Client.List(ctx, signers, client.InNamespace(obj.GetNamespace()), client.MatchingFields{indexSignerAuthToken: obj.GetName()}); err != nil {
+		return
+	}
+
+	for _, signer := range signers.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      signer.Spec.StacksNode,
+				Namespace: signer.Namespace,
+			},
+		})
+	}
+
+	return
+}
+
 func (r *NodeReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	pred := predicate.GenerationChangedPredicate{}
+	if err := shared.IndexReferences(mgr, &stacksv1alpha1.Node{}, func(obj client.Object) shared.References {
+		// referenced Bitcoin node rpc user password secret can't be indexed without fetching Bitcoin node
+		// its changes are mapped through referenced Bitcoin nodes instead
+		return references(obj.(*stacksv1alpha1.Node))
+	}); err != nil {
+		return err
+	}
+
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &stacksv1alpha1.Node{}, indexBitcoinNodeRef, func(obj client.Object) []string {
+		return []string{obj.(*stacksv1alpha1.Node).Spec.BitcoinNodeRef}
+	}); err != nil {
+		return err
+	}
+
+	// signers auth token is rendered into node config file
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &stacksv1alpha1.Signer{}, indexSignerAuthToken, func(obj client.Object) []string {
+		return []string{obj.(*stacksv1alpha1.Signer).Spec.AuthTokenSecretName.Name}
+	}); err != nil {
+		return err
+	}
+
+	// owned objects updates are filtered, referenced objects are watched without predicate so their updates always pass
+	pred := builder.WithPredicates(shared.GenerationOrContentChangedPredicate())
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&stacksv1alpha1.Node{}).
-		WithEventFilter(pred).
-		Owns(&appsv1.StatefulSet{}).
-		Owns(&corev1.ConfigMap{}).
-		Owns(&corev1.Service{}).
+		For(&stacksv1alpha1.Node{}, pred).
+		Owns(&appsv1.StatefulSet{}, pred).
+		Owns(&policyv1.PodDisruptionBudget{}, pred).
+		Owns(&networkingv1.NetworkPolicy{}, pred).
+		Owns(&corev1.ConfigMap{}, pred).
+		Owns(&corev1.Service{}, pred).
+		Watches(&bitcoinv1alpha1.Node{}, r.EnqueueReferencing(&stacksv1alpha1.NodeList{}, indexBitcoinNodeRef)).
+		Watches(&stacksv1alpha1.API{}, handler.EnqueueRequestsFromMapFunc(enqueueReferencedStacksNode), pred).
+		Watches(&stacksv1alpha1.Signer{}, handler.EnqueueRequestsFromMapFunc(enqueueReferencedStacksNode), pred).
+		Watches(&corev1.Secret{}, r.EnqueueReferencing(&stacksv1alpha1.NodeList{}, shared.IndexSecrets)).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.enqueueBitcoinRPCSecretReferencing)).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.enqueueSignerAuthTokenReferencing)).
+		Watches(&corev1.ConfigMap{}, r.EnqueueReferencing(&stacksv1alpha1.NodeList{}, shared.IndexConfigMaps)).
 		Complete(r)
 }
@@ -3,6 +3,7 @@ package controllers
 import (
 	"context"
 	"fmt"
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
 	"os"
 	"time"
 
@@ -37,12 +38,12 @@ var _ = Describe("Stacks node controller", func() {
 	spec := stacksv1alpha1.NodeSpec{
 		Image:   testImage,
 		Network: stacksv1alpha1.Mainnet,
-		BitcoinNode: stacksv1alpha1.BitcoinNode{
+		BitcoinNode: &stacksv1alpha1.BitcoinNode{
 			Endpoint:              "bitcoin.blockstack.com",
 			P2pPort:               8332,
 			RpcPort:               8333,
 			RpcUsername:           "blockstack",
-			RpcPasswordSecretName: "bitcoin-node-rpc-password",
+			RpcPasswordSecretName: sharedAPI.SecretKeySelector{Name: "bitcoin-node-rpc-password"},
 		},
 	}
 
@@ -131,18 +132,13 @@ var _ = Describe("Stacks node controller", func() {
 				MountPath: shared.PathConfig(client.HomeDir()),
 			},
 		))
+		// data volume claim template
+		Expect(fetched.Spec.VolumeClaimTemplates).To(HaveLen(1))
+		Expect(fetched.Spec.VolumeClaimTemplates[0].Name).To(Equal("data"))
 		// volumes
 		mode := corev1.ConfigMapVolumeSourceDefaultMode
 		Expect(fetched.Spec.Template.Spec.Volumes).To(ContainElements(
 			[]corev1.Volume{
-				{
-					Name: "data",
-					VolumeSource: corev1.VolumeSource{
-						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-							ClaimName: toCreate.Name,
-						},
-					},
-				},
 				{
 					Name: "config",
 					VolumeSource: corev1.VolumeSource{
@@ -176,7 +172,11 @@ var _ = Describe("Stacks node controller", func() {
 
 	It("Should create node data persistent volume with correct resources", func() {
 		fetched := &corev1.PersistentVolumeClaim{}
-		Expect(k8sClient.Get(context.Background(), key, fetched)).To(Succeed())
+		pvcKey := types.NamespacedName{
+			Name:      shared.ReplicaPVCName(&metav1.ObjectMeta{Name: key.Name}, 0),
+			Namespace: key.Namespace,
+		}
+		Expect(k8sClient.Get(context.Background(), pvcKey, fetched)).To(Succeed())
 		Expect(fetched.OwnerReferences).To(ContainElements(nodeOwnerReference))
 		expectedResources := corev1.VolumeResourceRequirements{
 			Requests: corev1.ResourceList{
@@ -0,0 +1,18 @@
+#!/bin/sh
+
+set -e
+
+# render config file with private keys loaded from secret store and signers auth token
+cp $KOTAL_CONFIG_TEMPLATE_PATH/config.toml $KOTAL_CONFIG_PATH/config.toml
+
+for key in seed_private_key node_private_key; do
+	if [ -f $KOTAL_SECRETS_PATH/$key ]; then
+		sed -i "s|@$key@|$(cat $KOTAL_SECRETS_PATH/$key)|" $KOTAL_CONFIG_PATH/config.toml
+	fi
+done
+
+# signers auth token is passed as environment variable
+if [ -n "$KOTAL_AUTH_TOKEN" ]; then
+	token=$(printf '%s' "$KOTAL_AUTH_TOKEN" | sed 's/[&|\\]/\\&/g')
+	sed -i "s|@auth_token@|$token|" $KOTAL_CONFIG_PATH/config.toml
+fi
@@ -0,0 +1,50 @@
+package controllers
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/BurntSushi/toml"
+	stacksv1alpha1 "github.com/kotalco/kotal/apis/stacks/v1alpha1"
+	stacksClients "github.com/kotalco/kotal/clients/stacks"
+	"github.com/kotalco/kotal/controllers/shared"
+)
+
+// SignerConfig is Stacks signer signer.toml file
+type SignerConfig struct {
+	StacksPrivateKey string `toml:"stacks_private_key"`
+	NodeHost         string `toml:"node_host"`
+	Endpoint         string `toml:"endpoint"`
+	Network          string `toml:"network"`
+	AuthPassword     string `toml:"auth_password"`
+	DBPath           string `toml:"db_path"`
+}
+
+// SignerConfigFromSpec generates signer.toml file from signer spec
+// Stacks node is connected to using its JSON-RPC server, and authenticated to using auth token
+func SignerConfigFromSpec(signer *stacksv1alpha1.Signer, node *stacksv1alpha1.Node, privateKey, authToken string) (config string, err error) {
+	// signer networks are mainnet and testnet only
+	network := "testnet"
+	if node.Spec.Network == stacksv1alpha1.Mainnet {
+		network = "mainnet"
+	}
+
+	c := &SignerConfig{
+		StacksPrivateKey: privateKey,
+		NodeHost:         fmt.Sprintf("%s.%s.svc:%d", node.Name, node.Namespace, node.Spec.RPCPort),
+		Endpoint:         fmt.Sprintf("%s:%d", shared.Host(true), signer.Spec.Port),
+		Network:          network,
+		AuthPassword:     authToken,
+		DBPath:           fmt.Sprintf("%s/signer.sqlite", shared.PathData(stacksClients.StacksSignerHomeDir)),
+	}
+
+	var buff bytes.Buffer
+	enc := toml.NewEncoder(&buff)
+	if err = enc.Encode(c); err != nil {
+		return
+	}
+
+	config = buff.String()
+
+	return
+}
@@ -0,0 +1,374 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	stacksv1alpha1 "github.com/kotalco/kotal/apis/stacks/v1alpha1"
+	stacksClients "github.com/kotalco/kotal/clients/stacks"
+	"github.com/kotalco/kotal/controllers/shared"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// SignerReconciler reconciles a Signer object
+type SignerReconciler struct {
+	shared.Reconciler
+}
+
+// +kubebuilder:rbac:groups=stacks.kotal.io,resources=signers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=stacks.kotal.io,resources=signers/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=stacks.kotal.io,resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=watch;get;list;create;update;delete
+// +kubebuilder:rbac:groups=core,resources=services;persistentvolumeclaims,verbs=watch;get;create;update;list;delete
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=watch;get;create;update;list;delete
+
+// Reconcile Stacks signer
+func (r *SignerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	defer shared.IgnoreConflicts(&err)
+
+	var signer stacksv1alpha1.Signer
+	defer r.ObserveReconcile(&signer, req.NamespacedName, &err)
+
+	if err = r.Client.Get(ctx, req.NamespacedName, &signer); err != nil {
+		err = client.IgnoreNotFound(err)
+		return
+	}
+
+	var deleting bool
+	if deleting, result, err = r.ReconcileDeletion(ctx, &signer, signer.Spec.Deletion); deleting || err != nil {
+		return
+	}
+
+	// owned objects are left as is while signer is paused
+	if shared.IsPaused(&signer) {
+		err = r.ReconcilePaused(ctx, &signer, &signer.Status.Mode)
+		return
+	}
+
+	// default the signer if webhooks are disabled
+	if !shared.IsWebhookEnabled() {
+		signer.Default()
+	}
+
+	var node *stacksv1alpha1.Node
+	if node, err = r.getStacksNode(ctx, &signer); err != nil {
+		return
+	}
+
+	shared.UpdateLabels(&signer, "stacks-signer", string(node.Spec.Network))
+
+	// reconcile persistent volume claims
+	if err = r.ReconcilePVCs(ctx, &signer, *signer.Spec.Replicas, func(pvc *corev1.PersistentVolumeClaim) {
+		r.specPVC(&signer, pvc)
+	}); err != nil {
+		return
+	}
+
+	// reconcile service
+	if err = r.ReconcileOwned(ctx, &signer, &corev1.Service{}, func(obj client.Object) error {
+		r.specService(&signer, obj.(*corev1.Service))
+		return nil
+	}); err != nil {
+		return
+	}
+
+	// reconcile config secret, config holds signer private key and auth token
+	var privateKey, authToken string
+	privateKeyName := types.NamespacedName{Name: signer.Spec.PrivateKeySecretName.Name, Namespace: signer.Namespace}
+	if privateKey, err = r.GetSecret(ctx, &signer, privateKeyName, signer.Spec.PrivateKeySecretName.KeyOr("key")); err != nil {
+		return
+	}
+	authTokenName := types.NamespacedName{Name: signer.Spec.AuthTokenSecretName.Name, Namespace: signer.Namespace}
+	if authToken, err = r.GetSecret(ctx, &signer, authTokenName, signer.Spec.AuthTokenSecretName.KeyOr("token")); err != nil {
+		return
+	}
+
+	var config string
+	if config, err = SignerConfigFromSpec(&signer, node, privateKey, authToken); err != nil {
+		return
+	}
+
+	if err = r.ReconcileOwned(ctx, &signer, &corev1.Secret{}, func(obj client.Object) error {
+		r.specConfigSecret(&signer, obj.(*corev1.Secret), config)
+		return nil
+	}); err != nil {
+		return
+	}
+
+	// reconcile statefulset
+	sts := &appsv1.StatefulSet{}
+	if err = r.ReconcileOwned(ctx, &signer, sts, func(obj client.Object) error {
+		client := stacksClients.NewSignerClient(&signer)
+		homeDir := client.HomeDir()
+		cmd := client.Command()
+		args := client.Args()
+		args = signer.Spec.ExtraArgs.Merge(args, signer.Spec.RemoveArgs, false)
+		env := client.Env()
+		sts := obj.(*appsv1.StatefulSet)
+		if err := r.specStatefulSet(&signer, sts, homeDir, env, cmd, args); err != nil {
+			return err
+		}
+		shared.SpecShutdown(signer.Spec.Shutdown, client, &sts.Spec.Template.Spec)
+		refs := signerReferences(&signer)
+		// restart signer on config changes
+		refs.Add(signer.Name)
+		return r.SpecContentHash(ctx, &signer, sts, refs)
+	}); err != nil {
+		return
+	}
+
+	// reconcile pod disruption budget
+	if err = r.ReconcilePodDisruptionBudget(ctx, &signer, signer.Spec.Disruption, *signer.Spec.Replicas); err != nil {
+		return
+	}
+
+	// reconcile network policy
+	// events port is only accessible by the referenced Stacks node by default
+	defaults := map[string][]networkingv1.NetworkPolicyPeer{
+		"events": {
+			{
+				PodSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						"app.kubernetes.io/component": "stacks-node",
+						"app.kubernetes.io/instance":  node.Name,
+					},
+				},
+			},
+		},
+	}
+	if err = r.ReconcileNetworkPolicy(ctx, &signer, signer.Spec.Access, &sts.Spec.Template.Spec, defaults); err != nil {
+		return
+	}
+
+	err = r.updateStatus(ctx, &signer)
+
+	return
+}
+
+// getStacksNode returns Stacks node the signer is paired with
+// Stacks node must enable JSON-RPC server, which is used by the signer
+func (r *SignerReconciler) getStacksNode(ctx context.Context, signer *stacksv1alpha1.Signer) (*stacksv1alpha1.Node, error) {
+	node := &stacksv1alpha1.Node{}
+	name := types.NamespacedName{Name: signer.Spec.StacksNode, Namespace: signer.Namespace}
+
+	if err := r.Client.Get(ctx, name, node); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.Recorder.Eventf(signer, corev1.EventTypeWarning, shared.EventReasonMissingReference, "Stacks node %s not found", name.Name)
+		}
+		return nil, err
+	}
+
+	// default the node if webhooks are disabled
+	if !shared.IsWebhookEnabled() {
+		node.Default()
+	}
+
+	if !node.Spec.RPC {
+		err := fmt.Errorf("stacks node %s must enable rpc", name.Name)
+		r.Recorder.Event(signer, corev1.EventTypeWarning, shared.EventReasonReconcileFailed, err.Error())
+		return nil, err
+	}
+
+	return node, nil
+}
+
+// updateStatus updates Stacks signer status
+func (r *SignerReconciler) updateStatus(ctx context.Context, signer *stacksv1alpha1.Signer) error {
+	previous := signer.Status
+
+	signer.Status.Client = "stacks-signer"
+
+	signer.Status.Mode = shared.Mode(signer, signer.Spec.Suspend)
+	if err := r.UpdateResyncStatus(ctx, signer, &signer.Status.ResyncStatus, signer.Spec.DataResync); err != nil {
+		return err
+	}
+
+	if err := r.Status().Update(ctx, signer); err != nil {
+		log.FromContext(ctx).Error(err, "unable to update signer status")
+		return err
+	}
+
+	r.RecordStatusTransition(signer, previous, signer.Status)
+
+	return nil
+}
+
+// signerReferences returns secrets and config maps referenced by Stacks signer
+func signerReferences(signer *stacksv1alpha1.Signer) shared.References {
+	refs := shared.PodExtrasReferences(signer.Spec.PodExtras)
+	refs.Add(signer.Spec.PrivateKeySecretName.Name, signer.Spec.AuthTokenSecretName.Name)
+	return refs
+}
+
+// specConfigSecret updates Stacks signer config secret
+func (r *SignerReconciler) specConfigSecret(signer *stacksv1alpha1.Signer, secret *corev1.Secret, config string) {
+	secret.ObjectMeta.Labels = signer.Labels
+	secret.Data = map[string][]byte{
+		stacksClients.StacksSignerConfigFile: []byte(config),
+	}
+}
+
+// specPVC updates Stacks signer persistent volume claim
+func (r *SignerReconciler) specPVC(signer *stacksv1alpha1.Signer, pvc *corev1.PersistentVolumeClaim) {
+	request := corev1.ResourceList{
+		corev1.ResourceStorage: resource.MustParse(signer.Spec.Storage),
+	}
+
+	// spec is immutable after creation except resources.requests for bound claims
+	if !pvc.CreationTimestamp.IsZero() {
+		pvc.Spec.Resources.Requests = request
+		return
+	}
+
+	pvc.ObjectMeta.Labels = signer.Labels
+	pvc.Spec = corev1.PersistentVolumeClaimSpec{
+		AccessModes: []corev1.PersistentVolumeAccessMode{
+			corev1.ReadWriteOnce,
+		},
+		Resources: corev1.VolumeResourceRequirements{
+			Requests: request,
+		},
+	}
+}
+
+// specService updates Stacks signer service spec
+func (r *SignerReconciler) specService(signer *stacksv1alpha1.Signer, svc *corev1.Service) {
+	labels := signer.Labels
+
+	svc.ObjectMeta.Labels = labels
+
+	svc.Spec.Ports = []corev1.ServicePort{
+		{
+			Name:       "events",
+			Port:       int32(signer.Spec.Port),
+			TargetPort: intstr.FromString("events"),
+		},
+	}
+
+	svc.Spec.Selector = labels
+}
+
+// specStatefulSet updates Stacks signer statefulset spec
+func (r *SignerReconciler) specStatefulSet(signer *stacksv1alpha1.Signer, sts *appsv1.StatefulSet, homeDir string, env []corev1.EnvVar, cmd, args []string) error {
+
+	sts.ObjectMeta.Labels = signer.Labels
+
+	replicas := shared.StatefulSetReplicas(*signer.Spec.Replicas, signer.Spec.Suspend)
+
+	mounts := []corev1.VolumeMount{
+		{
+			Name:      "data",
+			MountPath: shared.PathData(homeDir),
+		},
+		{
+			Name:      "config",
+			MountPath: shared.PathConfig(homeDir),
+			ReadOnly:  true,
+		},
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	r.specPVC(signer, pvc)
+	dataVolumes, volumeClaimTemplates := shared.SpecDataStorage(signer, sts, pvc)
+
+	volumes := append(dataVolumes, corev1.Volume{
+		Name: "config",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: signer.Name,
+			},
+		},
+	})
+
+	sts.Spec = appsv1.StatefulSetSpec{
+		Selector: &metav1.LabelSelector{
+			MatchLabels: signer.Labels,
+		},
+		Replicas:    &replicas,
+		ServiceName: signer.Name,
+		Template: corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: signer.Labels,
+			},
+			Spec: corev1.PodSpec{
+				SecurityContext: shared.SecurityContext(),
+				Containers: []corev1.Container{
+					{
+						Name:    "signer",
+						Image:   signer.Spec.Image,
+						Command: cmd,
+						Args:    args,
+						Env:     env,
+						Ports: []corev1.ContainerPort{
+							{
+								Name:          "events",
+								ContainerPort: int32(signer.Spec.Port),
+							},
+						},
+						VolumeMounts: mounts,
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse(signer.Spec.CPU),
+								corev1.ResourceMemory: resource.MustParse(signer.Spec.Memory),
+							},
+							Limits: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse(signer.Spec.CPULimit),
+								corev1.ResourceMemory: resource.MustParse(signer.Spec.MemoryLimit),
+							},
+						},
+					},
+				},
+				Volumes: volumes,
+			},
+		},
+		VolumeClaimTemplates: volumeClaimTemplates,
+	}
+
+	shared.SpecResync(signer.Spec.DataResync, &sts.Spec.Template.Spec, homeDir)
+	if err := shared.SpecPodExtras(signer.Spec.PodExtras, &sts.Spec.Template.Spec); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *SignerReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := shared.IndexReferences(mgr, &stacksv1alpha1.Signer{}, func(obj client.Object) shared.References {
+		return signerReferences(obj.(*stacksv1alpha1.Signer))
+	}); err != nil {
+		return err
+	}
+
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &stacksv1alpha1.Signer{}, indexStacksNode, func(obj client.Object) []string {
+		return []string{obj.(*stacksv1alpha1.Signer).Spec.StacksNode}
+	}); err != nil {
+		return err
+	}
+
+	// owned objects updates are filtered, referenced objects are watched without predicate so their updates always pass
+	pred := builder.WithPredicates(shared.GenerationOrContentChangedPredicate())
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&stacksv1alpha1.Signer{}, pred).
+		Owns(&corev1.PersistentVolumeClaim{}, pred).
+		Owns(&appsv1.StatefulSet{}, pred).
+		Owns(&policyv1.PodDisruptionBudget{}, pred).
+		Owns(&networkingv1.NetworkPolicy{}, pred).
+		Owns(&corev1.Service{}, pred).
+		Owns(&corev1.Secret{}, pred).
+		Watches(&stacksv1alpha1.Node{}, r.EnqueueReferencing(&stacksv1alpha1.SignerList{}, indexStacksNode)).
+		Watches(&corev1.Secret{}, r.EnqueueReferencing(&stacksv1alpha1.SignerList{}, shared.IndexSecrets)).
+		Watches(&corev1.ConfigMap{}, r.EnqueueReferencing(&stacksv1alpha1.SignerList{}, shared.IndexConfigMaps)).
+		Complete(r)
+}
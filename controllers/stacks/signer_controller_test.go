@@ -0,0 +1,206 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	sharedAPI "github.com/kotalco/kotal/apis/shared"
+	stacksv1alpha1 "github.com/kotalco/kotal/apis/stacks/v1alpha1"
+	stacksClients "github.com/kotalco/kotal/clients/stacks"
+	"github.com/kotalco/kotal/controllers/shared"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+var _ = Describe("Stacks signer controller", func() {
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "stacks-signer",
+		},
+	}
+
+	key := types.NamespacedName{
+		Name:      "stacks-signer",
+		Namespace: ns.Name,
+	}
+
+	rpcPassword := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "bitcoin-node-rpc-password",
+			Namespace: ns.Name,
+		},
+		StringData: map[string]string{
+			"password": "blockstacksystem",
+		},
+	}
+
+	privateKey := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "signer-private-key",
+			Namespace: ns.Name,
+		},
+		StringData: map[string]string{
+			"key": "8d2ab5f2e3e4d0b0b8b0f0e1b5b1e7a7d2b0b4f2c0a8e6e2b8c0a0e4b6f8d0a2c01",
+		},
+	}
+
+	authToken := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "signer-auth-token",
+			Namespace: ns.Name,
+		},
+		StringData: map[string]string{
+			"token": "12345",
+		},
+	}
+
+	node := &stacksv1alpha1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "stacks-node",
+			Namespace: ns.Name,
+		},
+		Spec: stacksv1alpha1.NodeSpec{
+			Network: stacksv1alpha1.Mainnet,
+			RPC:     true,
+			BitcoinNode: &stacksv1alpha1.BitcoinNode{
+				Endpoint:              "bitcoin.blockstack.com",
+				P2pPort:               8333,
+				RpcPort:               8332,
+				RpcUsername:           "blockstack",
+				RpcPasswordSecretName: sharedAPI.SecretKeySelector{Name: rpcPassword.Name},
+			},
+		},
+	}
+
+	testImage := "blockstack/stacks-signer:controller-test"
+
+	toCreate := &stacksv1alpha1.Signer{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+		},
+		Spec: stacksv1alpha1.SignerSpec{
+			Image:                testImage,
+			StacksNode:           node.Name,
+			PrivateKeySecretName: sharedAPI.SecretKeySelector{Name: privateKey.Name},
+			AuthTokenSecretName:  sharedAPI.SecretKeySelector{Name: authToken.Name},
+		},
+	}
+
+	t := true
+
+	signerOwnerReference := metav1.OwnerReference{
+		APIVersion:         "stacks.kotal.io/v1alpha1",
+		Kind:               "Signer",
+		Name:               toCreate.Name,
+		Controller:         &t,
+		BlockOwnerDeletion: &t,
+	}
+
+	It(fmt.Sprintf("Should create %s namespace", ns.Name), func() {
+		Expect(k8sClient.Create(context.TODO(), ns)).To(Succeed())
+	})
+
+	It("Should create referenced secrets and Stacks node", func() {
+		Expect(k8sClient.Create(context.Background(), rpcPassword)).Should(Succeed())
+		Expect(k8sClient.Create(context.Background(), privateKey)).Should(Succeed())
+		Expect(k8sClient.Create(context.Background(), authToken)).Should(Succeed())
+		if os.Getenv(shared.EnvUseExistingCluster) != "true" {
+			node.Default()
+		}
+		Expect(k8sClient.Create(context.Background(), node)).Should(Succeed())
+	})
+
+	It("Should create Stacks signer", func() {
+		if os.Getenv(shared.EnvUseExistingCluster) != "true" {
+			toCreate.Default()
+		}
+		Expect(k8sClient.Create(context.Background(), toCreate)).Should(Succeed())
+	})
+
+	It("Should get Stacks signer", func() {
+		fetched := &stacksv1alpha1.Signer{}
+		Expect(k8sClient.Get(context.Background(), key, fetched)).To(Succeed())
+		Expect(fetched.Spec).To(Equal(toCreate.Spec))
+		signerOwnerReference.UID = fetched.UID
+		time.Sleep(5 * time.Second)
+	})
+
+	It("Should create Stacks signer config secret", func() {
+		fetched := &corev1.Secret{}
+		Expect(k8sClient.Get(context.Background(), key, fetched)).To(Succeed())
+		Expect(fetched.OwnerReferences).To(ContainElements(signerOwnerReference))
+		config := string(fetched.Data[stacksClients.StacksSignerConfigFile])
+		Expect(config).To(ContainSubstring(fmt.Sprintf("node_host = \"stacks-node.%s.svc:%d\"", ns.Name, stacksv1alpha1.DefaultRPCPort)))
+		Expect(config).To(ContainSubstring("auth_password = \"12345\""))
+		Expect(config).To(ContainSubstring("network = \"mainnet\""))
+	})
+
+	It("Should create Stacks signer statefulset", func() {
+		client := stacksClients.NewSignerClient(toCreate)
+
+		fetched := &appsv1.StatefulSet{}
+		Expect(k8sClient.Get(context.Background(), key, fetched)).To(Succeed())
+		Expect(fetched.OwnerReferences).To(ContainElements(signerOwnerReference))
+		Expect(fetched.Spec.Template.Spec.Containers[0].Name).To(Equal("signer"))
+		Expect(fetched.Spec.Template.Spec.Containers[0].Image).To(Equal(testImage))
+		Expect(fetched.Spec.Template.Spec.Containers[0].Command).To(Equal(client.Command()))
+		Expect(fetched.Spec.Template.Spec.Containers[0].Args).To(Equal(client.Args()))
+	})
+
+	It("Should create Stacks signer service", func() {
+		fetched := &corev1.Service{}
+		Expect(k8sClient.Get(context.Background(), key, fetched)).To(Succeed())
+		Expect(fetched.OwnerReferences).To(ContainElements(signerOwnerReference))
+		Expect(fetched.Spec.Ports).To(ContainElements(
+			corev1.ServicePort{
+				Name:       "events",
+				Port:       int32(stacksv1alpha1.DefaultSignerPort),
+				TargetPort: intstr.FromString("events"),
+				Protocol:   corev1.ProtocolTCP,
+			},
+		))
+	})
+
+	It("Should register Stacks signer as events observer of Stacks node", func() {
+		config := &corev1.ConfigMap{}
+		nodeKey := types.NamespacedName{Name: node.Name, Namespace: ns.Name}
+		Expect(k8sClient.Get(context.Background(), nodeKey, config)).To(Succeed())
+		Expect(config.Data["config.toml"]).To(ContainSubstring("[connection_options]"))
+		Expect(config.Data["config.toml"]).To(ContainSubstring("auth_token = \"@auth_token@\""))
+		Expect(config.Data["config.toml"]).NotTo(ContainSubstring("12345"))
+		Expect(config.Data["config.toml"]).To(ContainSubstring(fmt.Sprintf("endpoint = \"stacks-signer.%s.svc:%d\"", ns.Name, stacksv1alpha1.DefaultSignerPort)))
+	})
+
+	It("Should render signer auth token into Stacks node config from secret", func() {
+		sts := &appsv1.StatefulSet{}
+		nodeKey := types.NamespacedName{Name: node.Name, Namespace: ns.Name}
+		Expect(k8sClient.Get(context.Background(), nodeKey, sts)).To(Succeed())
+		Expect(sts.Spec.Template.Spec.InitContainers).To(ContainElement(HaveField("Name", "render-config")))
+		for _, container := range sts.Spec.Template.Spec.InitContainers {
+			if container.Name != "render-config" {
+				continue
+			}
+			Expect(container.Env).To(ContainElement(corev1.EnvVar{
+				Name: "KOTAL_AUTH_TOKEN",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: authToken.Name},
+						Key:                  "token",
+					},
+				},
+			}))
+		}
+	})
+
+	It(fmt.Sprintf("Should delete %s namespace", ns.Name), func() {
+		Expect(k8sClient.Delete(context.Background(), ns)).To(Succeed())
+	})
+})
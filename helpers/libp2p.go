@@ -0,0 +1,59 @@
+package helpers
+
+import (
+	"crypto/ed25519"
+	"math/big"
+)
+
+// base58Alphabet is bitcoin base58 alphabet used by libp2p and near
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// libp2pEd25519KeyType is libp2p protobuf key type of Ed25519 keys
+const libp2pEd25519KeyType = 1
+
+// Base58Encode encodes bytes using bitcoin base58 alphabet
+func Base58Encode(input []byte) string {
+	x := new(big.Int).SetBytes(input)
+	base := big.NewInt(58)
+	mod := new(big.Int)
+
+	output := []byte{}
+	for x.Sign() > 0 {
+		x.DivMod(x, base, mod)
+		output = append(output, base58Alphabet[mod.Int64()])
+	}
+
+	// leading zero bytes are encoded as leading 1s
+	for _, b := range input {
+		if b != 0 {
+			break
+		}
+		output = append(output, base58Alphabet[0])
+	}
+
+	// reverse
+	for i, j := 0, len(output)-1; i < j; i, j = i+1, j-1 {
+		output[i], output[j] = output[j], output[i]
+	}
+
+	return string(output)
+}
+
+// libp2pKey returns libp2p protobuf encoded key
+func libp2pKey(data []byte) []byte {
+	// field 1 (key type) varint, field 2 (key data) length delimited
+	return append([]byte{0x08, libp2pEd25519KeyType, 0x12, byte(len(data))}, data...)
+}
+
+// Libp2pPeerID returns libp2p peer ID of Ed25519 public key
+func Libp2pPeerID(publicKey ed25519.PublicKey) string {
+	key := libp2pKey(publicKey)
+	// identity multihash of the encoded public key
+	multihash := append([]byte{0x00, byte(len(key))}, key...)
+	return Base58Encode(multihash)
+}
+
+// Libp2pPrivateKey returns libp2p protobuf encoded Ed25519 private key
+func Libp2pPrivateKey(privateKey ed25519.PrivateKey) []byte {
+	return libp2pKey(privateKey)
+}
@@ -0,0 +1,66 @@
+package helpers
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"testing"
+)
+
+func TestBase58Encode(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"", ""},
+		{"61", "2g"},
+		{"626262", "a3gV"},
+		{"636363", "aPEr"},
+		{"516b6fcd0f", "ABnLTmg"},
+		{"00000000000000000000", "1111111111"},
+		{"00eb15231dfceb60925886b67d065299925915aeb172c06647", "1NS17iag9jJgTHD1VXjvLCEnZuQ3rJDE9L"},
+	}
+
+	for _, tt := range tests {
+		input, err := hex.DecodeString(tt.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := Base58Encode(input); got != tt.want {
+			t.Errorf("Base58Encode(%s) = %s, want %s", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestLibp2pPeerID(t *testing.T) {
+	// expected peer IDs are derived by go-libp2p-core peer.IDFromPublicKey
+	tests := []struct {
+		seed string
+		want string
+	}{
+		{"0000000000000000000000000000000000000000000000000000000000000000", "12D3KooWDpJ7As7BWAwRMfu1VU2WCqNjvq387JEYKDBj4kx6nXTN"},
+		{"9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60", "12D3KooWQK1wnefoLrcVHbbnf5tLzbopUd3K3bFAoJpA7YJgL5pV"},
+		{"4ccd089b28ff96da9db6c346ec114e0f5b8a319f35aba624da8cf6ed4fb8a6fb", "12D3KooWDwTirQce1RRKnasT5fPVFgzXCy6SiRgSwrwPGLC7zE91"},
+	}
+
+	for _, tt := range tests {
+		seed, err := hex.DecodeString(tt.seed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		privateKey := ed25519.NewKeyFromSeed(seed)
+		if got := Libp2pPeerID(privateKey.Public().(ed25519.PublicKey)); got != tt.want {
+			t.Errorf("Libp2pPeerID(%s) = %s, want %s", tt.seed, got, tt.want)
+		}
+	}
+}
+
+func TestLibp2pPrivateKey(t *testing.T) {
+	// expected key is encoded by go-libp2p-core crypto.MarshalPrivateKey
+	seed, _ := hex.DecodeString("9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60")
+	want, _ := hex.DecodeString("080112409d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a")
+
+	if got := Libp2pPrivateKey(ed25519.NewKeyFromSeed(seed)); !bytes.Equal(got, want) {
+		t.Errorf("Libp2pPrivateKey() = %x, want %x", got, want)
+	}
+}
@@ -50,3 +50,15 @@ func DeriveAddress(fromPrivateKey string) (addressHex string, err error) {
 	return
 
 }
+
+// GenerateNodePrivateKey generates node private key (hex without the leading 0x)
+func GenerateNodePrivateKey() (privateKeyHex string, err error) {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		return
+	}
+
+	privateKeyHex = hexutil.Encode(crypto.FromECDSA(privateKey))[2:]
+
+	return
+}
@@ -25,6 +25,16 @@ import (
 	nearv1alpha1 "github.com/kotalco/kotal/apis/near/v1alpha1"
 	polkadotv1alpha1 "github.com/kotalco/kotal/apis/polkadot/v1alpha1"
 	stacksv1alpha1 "github.com/kotalco/kotal/apis/stacks/v1alpha1"
+	aptosclients "github.com/kotalco/kotal/clients/aptos"
+	bitcoinclients "github.com/kotalco/kotal/clients/bitcoin"
+	chainlinkclients "github.com/kotalco/kotal/clients/chainlink"
+	ethereumclients "github.com/kotalco/kotal/clients/ethereum"
+	ethereum2clients "github.com/kotalco/kotal/clients/ethereum2"
+	filecoinclients "github.com/kotalco/kotal/clients/filecoin"
+	ipfsclients "github.com/kotalco/kotal/clients/ipfs"
+	nearclients "github.com/kotalco/kotal/clients/near"
+	polkadotclients "github.com/kotalco/kotal/clients/polkadot"
+	stacksclients "github.com/kotalco/kotal/clients/stacks"
 	aptoscontroller "github.com/kotalco/kotal/controllers/aptos"
 	bitcoincontroller "github.com/kotalco/kotal/controllers/bitcoin"
 	chainlinkcontroller "github.com/kotalco/kotal/controllers/chainlink"
@@ -100,29 +110,33 @@ func main() {
 
 	if err = (&filecoincontroller.NodeReconciler{
 		Reconciler: shared.Reconciler{
-			Client: mgr.GetClient(),
-			Scheme: mgr.GetScheme(),
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("filecoin-node-controller"),
 		},
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Node")
 		os.Exit(1)
 	}
 	if enableWebhooks {
-		if err = (&filecoinv1alpha1.Node{}).SetupWebhookWithManager(mgr); err != nil {
+		if err = (&filecoinv1alpha1.Node{}).SetupWebhookWithManager(mgr, filecoinclients.Args); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "Node")
 			os.Exit(1)
 		}
 	}
 
 	if err = (&ethereumcontroller.NodeReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Reconciler: shared.Reconciler{
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("ethereum-node-controller"),
+		},
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Node")
 		os.Exit(1)
 	}
 	if enableWebhooks {
-		if err = (&ethereumv1alpha1.Node{}).SetupWebhookWithManager(mgr); err != nil {
+		if err = (&ethereumv1alpha1.Node{}).SetupWebhookWithManager(mgr, ethereumclients.Args); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "Node")
 			os.Exit(1)
 		}
@@ -130,15 +144,16 @@ func main() {
 
 	if err = (&ethereum2controller.BeaconNodeReconciler{
 		Reconciler: shared.Reconciler{
-			Client: mgr.GetClient(),
-			Scheme: mgr.GetScheme(),
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("ethereum2-beacon-node-controller"),
 		},
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "BeaconNode")
 		os.Exit(1)
 	}
 	if enableWebhooks {
-		if err = (&ethereum2v1alpha1.BeaconNode{}).SetupWebhookWithManager(mgr); err != nil {
+		if err = (&ethereum2v1alpha1.BeaconNode{}).SetupWebhookWithManager(mgr, ethereum2clients.Args); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "Node")
 			os.Exit(1)
 		}
@@ -146,15 +161,16 @@ func main() {
 
 	if err = (&ethereum2controller.ValidatorReconciler{
 		Reconciler: shared.Reconciler{
-			Client: mgr.GetClient(),
-			Scheme: mgr.GetScheme(),
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("ethereum2-validator-controller"),
 		},
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Validator")
 		os.Exit(1)
 	}
 	if enableWebhooks {
-		if err = (&ethereum2v1alpha1.Validator{}).SetupWebhookWithManager(mgr); err != nil {
+		if err = (&ethereum2v1alpha1.Validator{}).SetupWebhookWithManager(mgr, ethereum2clients.Args); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "Validator")
 			os.Exit(1)
 		}
@@ -162,15 +178,16 @@ func main() {
 
 	if err = (&ipfscontroller.PeerReconciler{
 		Reconciler: shared.Reconciler{
-			Client: mgr.GetClient(),
-			Scheme: mgr.GetScheme(),
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("ipfs-peer-controller"),
 		},
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Peer")
 		os.Exit(1)
 	}
 	if enableWebhooks {
-		if err = (&ipfsv1alpha1.Peer{}).SetupWebhookWithManager(mgr); err != nil {
+		if err = (&ipfsv1alpha1.Peer{}).SetupWebhookWithManager(mgr, ipfsclients.Args); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "Peer")
 			os.Exit(1)
 		}
@@ -178,15 +195,16 @@ func main() {
 
 	if err = (&ipfscontroller.ClusterPeerReconciler{
 		Reconciler: shared.Reconciler{
-			Client: mgr.GetClient(),
-			Scheme: mgr.GetScheme(),
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("ipfs-cluster-peer-controller"),
 		},
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ClusterPeer")
 		os.Exit(1)
 	}
 	if enableWebhooks {
-		if err = (&ipfsv1alpha1.ClusterPeer{}).SetupWebhookWithManager(mgr); err != nil {
+		if err = (&ipfsv1alpha1.ClusterPeer{}).SetupWebhookWithManager(mgr, ipfsclients.Args); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "ClusterPeer")
 			os.Exit(1)
 		}
@@ -194,15 +212,16 @@ func main() {
 
 	if err = (&polkadotcontroller.NodeReconciler{
 		Reconciler: shared.Reconciler{
-			Client: mgr.GetClient(),
-			Scheme: mgr.GetScheme(),
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("polkadot-node-controller"),
 		},
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Node")
 		os.Exit(1)
 	}
 	if enableWebhooks {
-		if err = (&polkadotv1alpha1.Node{}).SetupWebhookWithManager(mgr); err != nil {
+		if err = (&polkadotv1alpha1.Node{}).SetupWebhookWithManager(mgr, polkadotclients.Args); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "Node")
 			os.Exit(1)
 		}
@@ -210,15 +229,16 @@ func main() {
 
 	if err = (&chainlinkcontroller.NodeReconciler{
 		Reconciler: shared.Reconciler{
-			Client: mgr.GetClient(),
-			Scheme: mgr.GetScheme(),
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("chainlink-node-controller"),
 		},
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Node")
 		os.Exit(1)
 	}
 	if enableWebhooks {
-		if err = (&chainlinkv1alpha1.Node{}).SetupWebhookWithManager(mgr); err != nil {
+		if err = (&chainlinkv1alpha1.Node{}).SetupWebhookWithManager(mgr, chainlinkclients.Args); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "Node")
 			os.Exit(1)
 		}
@@ -226,15 +246,16 @@ func main() {
 
 	if err = (&nearcontroller.NodeReconciler{
 		Reconciler: shared.Reconciler{
-			Client: mgr.GetClient(),
-			Scheme: mgr.GetScheme(),
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("near-node-controller"),
 		},
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Node")
 		os.Exit(1)
 	}
 	if enableWebhooks {
-		if err = (&nearv1alpha1.Node{}).SetupWebhookWithManager(mgr); err != nil {
+		if err = (&nearv1alpha1.Node{}).SetupWebhookWithManager(mgr, nearclients.Args); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "Node")
 			os.Exit(1)
 		}
@@ -242,53 +263,128 @@ func main() {
 
 	if err = (&bitcoincontroller.NodeReconciler{
 		Reconciler: shared.Reconciler{
-			Client: mgr.GetClient(),
-			Scheme: mgr.GetScheme(),
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("bitcoin-node-controller"),
 		},
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Node")
 		os.Exit(1)
 	}
 	if enableWebhooks {
-		if err = (&bitcoinv1alpha1.Node{}).SetupWebhookWithManager(mgr); err != nil {
+		if err = (&bitcoinv1alpha1.Node{}).SetupWebhookWithManager(mgr, bitcoinclients.NodeArgs); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "Node")
 			os.Exit(1)
 		}
 	}
 
+	if err = (&bitcoincontroller.LightningNodeReconciler{
+		Reconciler: shared.Reconciler{
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("bitcoin-lightning-node-controller"),
+		},
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "LightningNode")
+		os.Exit(1)
+	}
+	if enableWebhooks {
+		if err = (&bitcoinv1alpha1.LightningNode{}).SetupWebhookWithManager(mgr, bitcoinclients.LightningNodeArgs); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "LightningNode")
+			os.Exit(1)
+		}
+	}
+
+	if err = (&bitcoincontroller.ElectrumServerReconciler{
+		Reconciler: shared.Reconciler{
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("bitcoin-electrum-server-controller"),
+		},
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ElectrumServer")
+		os.Exit(1)
+	}
+	if enableWebhooks {
+		if err = (&bitcoinv1alpha1.ElectrumServer{}).SetupWebhookWithManager(mgr, bitcoinclients.ElectrumServerArgs); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ElectrumServer")
+			os.Exit(1)
+		}
+	}
+
 	if err = (&stackscontroller.NodeReconciler{
 		Reconciler: shared.Reconciler{
-			Client: mgr.GetClient(),
-			Scheme: mgr.GetScheme(),
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("stacks-node-controller"),
 		},
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Node")
 		os.Exit(1)
 	}
 	if enableWebhooks {
-		if err = (&stacksv1alpha1.Node{}).SetupWebhookWithManager(mgr); err != nil {
+		if err = (&stacksv1alpha1.Node{}).SetupWebhookWithManager(mgr, stacksclients.NodeArgs); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "Node")
 			os.Exit(1)
 		}
 	}
+
+	if err = (&stackscontroller.APIReconciler{
+		Reconciler: shared.Reconciler{
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("stacks-api-controller"),
+		},
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "API")
+		os.Exit(1)
+	}
+	if enableWebhooks {
+		if err = (&stacksv1alpha1.API{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "API")
+			os.Exit(1)
+		}
+	}
+
+	if err = (&stackscontroller.SignerReconciler{
+		Reconciler: shared.Reconciler{
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("stacks-signer-controller"),
+		},
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Signer")
+		os.Exit(1)
+	}
+	if enableWebhooks {
+		if err = (&stacksv1alpha1.Signer{}).SetupWebhookWithManager(mgr, stacksclients.SignerArgs); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Signer")
+			os.Exit(1)
+		}
+	}
+
 	if err = (&aptoscontroller.NodeReconciler{
 		Reconciler: shared.Reconciler{
-			Client: mgr.GetClient(),
-			Scheme: mgr.GetScheme(),
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("aptos-node-controller"),
 		},
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Node")
 		os.Exit(1)
 	}
 	if enableWebhooks {
-		if err = (&aptosv1alpha1.Node{}).SetupWebhookWithManager(mgr); err != nil {
+		if err = (&aptosv1alpha1.Node{}).SetupWebhookWithManager(mgr, aptosclients.Args); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "Node")
 			os.Exit(1)
 		}
 	}
 	if err = (&graphcontrollers.NodeReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Reconciler: shared.Reconciler{
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("graph-node-controller"),
+		},
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Node")
 		os.Exit(1)